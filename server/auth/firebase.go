@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"app/config"
+	"app/entity"
 
 	"firebase.google.com/go/v4/auth"
 )
@@ -45,3 +46,31 @@ func (fa *FirebaseAuth) VerifyIDToken(ctx context.Context, idToken string) (*aut
 
 	return token, nil
 }
+
+// SetRoles はロールをカスタムクレーム（roles）に設定する（既存のクレームは維持する）
+func (fa *FirebaseAuth) SetRoles(ctx context.Context, uid string, roles []entity.Role) error {
+	if fa == nil || fa.client == nil {
+		return nil
+	}
+
+	user, err := fa.client.GetUser(ctx, uid)
+	if err != nil {
+		return fmt.Errorf("failed to get firebase user: %w", err)
+	}
+
+	claims := make(map[string]interface{}, len(user.CustomClaims)+1)
+	for key, value := range user.CustomClaims {
+		claims[key] = value
+	}
+	names := make([]string, len(roles))
+	for i, role := range roles {
+		names[i] = string(role)
+	}
+	claims[entity.RoleClaimKey] = names
+
+	if err := fa.client.SetCustomUserClaims(ctx, uid, claims); err != nil {
+		return fmt.Errorf("failed to set custom claims: %w", err)
+	}
+
+	return nil
+}
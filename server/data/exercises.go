@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"log"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 	"gorm.io/gorm"
@@ -12,9 +13,35 @@ import (
 
 // InitialExercise YAMLから読み込む初期データの構造体
 type InitialExercise struct {
-	Name        string `yaml:"name"`
-	Description string `yaml:"description"`
-	Category    string `yaml:"category"`
+	Name             string   `yaml:"name"`
+	Description      string   `yaml:"description"`
+	Category         string   `yaml:"category"`
+	Equipment        string   `yaml:"equipment"`
+	TrackingType     string   `yaml:"tracking_type"` // 省略した場合は重量×レップ数
+	PrimaryMuscles   []string `yaml:"primary_muscles"`
+	SecondaryMuscles []string `yaml:"secondary_muscles"`
+	Keywords         []string `yaml:"keywords"` // 検索用の別名（漢字表記・英語名など）
+}
+
+// muscles は主働筋・協働筋から種目の部位を作成する
+func (ex InitialExercise) muscles() []entity.ExerciseMuscle {
+	primary := make([]entity.MuscleGroup, len(ex.PrimaryMuscles))
+	for i, muscle := range ex.PrimaryMuscles {
+		primary[i] = entity.MuscleGroup(muscle)
+	}
+	secondary := make([]entity.MuscleGroup, len(ex.SecondaryMuscles))
+	for i, muscle := range ex.SecondaryMuscles {
+		secondary[i] = entity.MuscleGroup(muscle)
+	}
+	return entity.NewExerciseMuscles(primary, secondary)
+}
+
+// trackingType は種目の記録方法（省略した場合は重量×レップ数）
+func (ex InitialExercise) trackingType() entity.TrackingType {
+	if ex.TrackingType == "" {
+		return entity.TrackingTypeWeightReps
+	}
+	return entity.TrackingType(ex.TrackingType)
 }
 
 // InitialExercises YAMLファイル全体の構造体
@@ -47,15 +74,19 @@ func SeedInitialExercises(db *gorm.DB, filePath string) error {
 	for _, ex := range initialData.Exercises {
 		// 既に存在するかチェック
 		var existingExercise entity.Exercise
-		result := db.Where("name = ?", ex.Name).First(&existingExercise)
+		result := db.Where("name = ? AND user_id IS NULL", ex.Name).First(&existingExercise)
 
 		if result.Error != nil {
 			if result.Error == gorm.ErrRecordNotFound {
 				// 存在しない場合は新規作成
 				exercise := &entity.Exercise{
-					Name:        ex.Name,
-					Description: ex.Description,
-					Category:    ex.Category,
+					Name:         ex.Name,
+					Description:  ex.Description,
+					Category:     ex.Category,
+					Equipment:    entity.Equipment(ex.Equipment),
+					TrackingType: ex.trackingType(),
+					Keywords:     strings.Join(ex.Keywords, ","),
+					Muscles:      ex.muscles(),
 				}
 
 				if err := db.Create(exercise).Error; err != nil {
@@ -67,7 +98,11 @@ func SeedInitialExercises(db *gorm.DB, filePath string) error {
 				return fmt.Errorf("種目 '%s' の存在確認に失敗: %w", ex.Name, result.Error)
 			}
 		} else {
-			log.Printf("ℹ️  種目 '%s' は既に存在します", ex.Name)
+			// 既存の種目は器具・部位・検索キーワードを初期データに合わせる
+			if err := updateInitialExerciseAttributes(db, &existingExercise, ex); err != nil {
+				return fmt.Errorf("種目 '%s' の更新に失敗: %w", ex.Name, err)
+			}
+			log.Printf("ℹ️  種目 '%s' は既に存在します（器具・部位・検索キーワードを更新しました）", ex.Name)
 		}
 	}
 
@@ -75,6 +110,31 @@ func SeedInitialExercises(db *gorm.DB, filePath string) error {
 	return nil
 }
 
+// updateInitialExerciseAttributes は既存の種目の器具・記録方法・部位・検索キーワードを更新する
+func updateInitialExerciseAttributes(db *gorm.DB, exercise *entity.Exercise, ex InitialExercise) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		exercise.Equipment = entity.Equipment(ex.Equipment)
+		exercise.TrackingType = ex.trackingType()
+		exercise.Keywords = strings.Join(ex.Keywords, ",")
+		if err := tx.Omit("User", "Muscles", "WorkoutExercises").Save(exercise).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Unscoped().Where("exercise_id = ?", exercise.ID).Delete(&entity.ExerciseMuscle{}).Error; err != nil {
+			return err
+		}
+
+		muscles := ex.muscles()
+		for i := range muscles {
+			muscles[i].ExerciseID = exercise.ID
+		}
+		if len(muscles) == 0 {
+			return nil
+		}
+		return tx.Create(&muscles).Error
+	})
+}
+
 // RemoveInitialExercises 初期データをデータベースから削除する
 func RemoveInitialExercises(db *gorm.DB, filePath string) error {
 	initialData, err := LoadInitialExercises(filePath)
@@ -83,8 +143,8 @@ func RemoveInitialExercises(db *gorm.DB, filePath string) error {
 	}
 
 	for _, ex := range initialData.Exercises {
-		// 初期データの種目を物理削除
-		result := db.Unscoped().Where("name = ?", ex.Name).Delete(&entity.Exercise{})
+		// 初期データの種目を物理削除（同名のユーザー作成の種目は残す）
+		result := db.Unscoped().Where("name = ? AND user_id IS NULL", ex.Name).Delete(&entity.Exercise{})
 		if result.Error != nil {
 			return fmt.Errorf("種目 '%s' の削除に失敗: %w", ex.Name, result.Error)
 		}
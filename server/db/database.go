@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"time"
 
 	"github.com/go-gormigrate/gormigrate/v2"
 	"gorm.io/driver/postgres"
@@ -364,5 +365,499 @@ func getMigrations() []*gormigrate.Migration {
 				return nil
 			},
 		},
+		{
+			ID: "202610181000_change_set_log_weight_to_decimal",
+			Migrate: func(tx *gorm.DB) error {
+				// 既存の整数値はそのままkgの小数として保持される（USING weight::decimal）
+				return tx.Migrator().AlterColumn(&entity.SetLog{}, "Weight")
+			},
+			Rollback: func(tx *gorm.DB) error {
+				return tx.Exec("ALTER TABLE set_logs ALTER COLUMN weight TYPE bigint USING ROUND(weight)").Error
+			},
+		},
+		{
+			ID: "202610181001_add_weight_unit_to_profiles",
+			Migrate: func(tx *gorm.DB) error {
+				if !tx.Migrator().HasColumn(&entity.Profile{}, "WeightUnit") {
+					return tx.Migrator().AddColumn(&entity.Profile{}, "WeightUnit")
+				}
+				return nil
+			},
+			Rollback: func(tx *gorm.DB) error {
+				if tx.Migrator().HasColumn(&entity.Profile{}, "WeightUnit") {
+					return tx.Migrator().DropColumn(&entity.Profile{}, "WeightUnit")
+				}
+				return nil
+			},
+		},
+		{
+			ID: "202610181010_create_personal_records",
+			Migrate: func(tx *gorm.DB) error {
+				if err := tx.AutoMigrate(&entity.PersonalRecord{}); err != nil {
+					return err
+				}
+
+				// 既存のセットから自己ベストを作成
+				var targets []struct {
+					UserID     uint
+					ExerciseID uint
+				}
+				if err := tx.Model(&entity.WorkoutExercise{}).
+					Select("DISTINCT workouts.user_id, workout_exercises.exercise_id").
+					Joins("inner join workouts on workouts.id = workout_exercises.workout_id").
+					Where("workouts.deleted_at IS NULL").
+					Scan(&targets).Error; err != nil {
+					return err
+				}
+
+				for _, target := range targets {
+					if err := backfillPersonalRecords(tx, target.UserID, target.ExerciseID); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+			Rollback: func(tx *gorm.DB) error {
+				return tx.Migrator().DropTable(&entity.PersonalRecord{})
+			},
+		},
+		{
+			ID: "202610181020_create_workout_templates",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&entity.WorkoutTemplate{}, &entity.WorkoutTemplateExercise{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				return tx.Migrator().DropTable(&entity.WorkoutTemplateExercise{}, &entity.WorkoutTemplate{})
+			},
+		},
+		{
+			ID: "202610181030_create_programs",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(
+					&entity.Program{},
+					&entity.ProgramDay{},
+					&entity.ProgramExercise{},
+					&entity.ProgramEnrollment{},
+					&entity.ProgramTrainingMax{},
+				)
+			},
+			Rollback: func(tx *gorm.DB) error {
+				return tx.Migrator().DropTable(
+					&entity.ProgramTrainingMax{},
+					&entity.ProgramEnrollment{},
+					&entity.ProgramExercise{},
+					&entity.ProgramDay{},
+					&entity.Program{},
+				)
+			},
+		},
+		{
+			ID: "202610181040_add_owner_to_exercises",
+			Migrate: func(tx *gorm.DB) error {
+				if err := tx.AutoMigrate(&entity.Exercise{}); err != nil {
+					return err
+				}
+				// 種目名の一意制約を作成者ごとに変更する（カタログの種目はuser_idがNULL）
+				if err := tx.Exec("ALTER TABLE exercises DROP CONSTRAINT IF EXISTS uni_exercises_name").Error; err != nil {
+					return err
+				}
+				if err := tx.Exec("ALTER TABLE exercises DROP CONSTRAINT IF EXISTS exercises_name_key").Error; err != nil {
+					return err
+				}
+				return tx.Exec("CREATE UNIQUE INDEX IF NOT EXISTS idx_exercises_owner_name ON exercises (COALESCE(user_id, 0), name) WHERE deleted_at IS NULL").Error
+			},
+			Rollback: func(tx *gorm.DB) error {
+				if err := tx.Exec("DROP INDEX IF EXISTS idx_exercises_owner_name").Error; err != nil {
+					return err
+				}
+				// ユーザーが作成した種目は削除してから一意制約を戻す
+				if err := tx.Unscoped().Where("user_id IS NOT NULL").Delete(&entity.Exercise{}).Error; err != nil {
+					return err
+				}
+				if err := tx.Exec("ALTER TABLE exercises ADD CONSTRAINT uni_exercises_name UNIQUE (name)").Error; err != nil {
+					return err
+				}
+				for _, column := range []string{"archived_at", "visibility", "user_id"} {
+					if err := tx.Migrator().DropColumn(&entity.Exercise{}, column); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		},
+		{
+			ID: "202610181050_add_search_attributes_to_exercises",
+			Migrate: func(tx *gorm.DB) error {
+				if err := tx.AutoMigrate(&entity.Exercise{}, &entity.ExerciseMuscle{}); err != nil {
+					return err
+				}
+
+				// 既存の種目の検索用テキストを作成する
+				var exercises []entity.Exercise
+				if err := tx.Find(&exercises).Error; err != nil {
+					return err
+				}
+				for _, exercise := range exercises {
+					if err := tx.Model(&exercise).UpdateColumn("search_text", exercise.BuildSearchText()).Error; err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+			Rollback: func(tx *gorm.DB) error {
+				if err := tx.Migrator().DropTable(&entity.ExerciseMuscle{}); err != nil {
+					return err
+				}
+				for _, column := range []string{"search_text", "keywords", "equipment"} {
+					if err := tx.Migrator().DropColumn(&entity.Exercise{}, column); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		},
+		{
+			ID: "202610181060_create_user_roles",
+			Migrate: func(tx *gorm.DB) error {
+				if err := tx.AutoMigrate(&entity.User{}, &entity.UserRole{}); err != nil {
+					return err
+				}
+				// 開発用の管理者ユーザーに管理者ロールを付与する（これまではUIDで判定していた）
+				if os.Getenv("ENABLE_MOCK_AUTH") == "true" {
+					var adminUser entity.User
+					if err := tx.Where("uid = ?", os.Getenv("MOCK_ADMIN_UID")).Limit(1).Find(&adminUser).Error; err != nil {
+						return err
+					}
+					if adminUser.ID != 0 {
+						return tx.Where(entity.UserRole{UserID: adminUser.ID, Role: entity.RoleAdmin}).
+							FirstOrCreate(&entity.UserRole{}).Error
+					}
+				}
+				return nil
+			},
+			Rollback: func(tx *gorm.DB) error {
+				if err := tx.Migrator().DropTable(&entity.UserRole{}); err != nil {
+					return err
+				}
+				return tx.Migrator().DropColumn(&entity.User{}, "roles_synced_at")
+			},
+		},
+		{
+			ID: "202610181070_create_workout_group_members",
+			Migrate: func(tx *gorm.DB) error {
+				if err := tx.AutoMigrate(&entity.WorkoutGroupMember{}); err != nil {
+					return err
+				}
+				// これまではグループにワークアウトを持つユーザーをメンバーとしていたため、参加中のメンバーとして移行する
+				// 最初にワークアウトを作成したユーザー（グループの作成者）をOWNERにする
+				return tx.Exec(`
+					INSERT INTO workout_group_members (created_at, updated_at, workout_group_id, user_id, role, status, joined_at)
+					SELECT NOW(), NOW(), w.workout_group_id, w.user_id,
+						CASE WHEN MIN(w.id) = (
+							SELECT MIN(earliest.id) FROM workouts earliest
+							WHERE earliest.workout_group_id = w.workout_group_id AND earliest.deleted_at IS NULL
+						) THEN ? ELSE ? END,
+						?, MIN(w.created_at)
+					FROM workouts w
+					INNER JOIN workout_groups g ON g.id = w.workout_group_id AND g.deleted_at IS NULL
+					WHERE w.deleted_at IS NULL
+					GROUP BY w.workout_group_id, w.user_id
+					ON CONFLICT DO NOTHING
+				`, entity.WorkoutGroupMemberRoleOwner, entity.WorkoutGroupMemberRoleMember, entity.WorkoutGroupMemberStatusJoined).Error
+			},
+			Rollback: func(tx *gorm.DB) error {
+				return tx.Migrator().DropTable(&entity.WorkoutGroupMember{})
+			},
+		},
+		{
+			ID: "202610181080_create_notifications",
+			Migrate: func(tx *gorm.DB) error {
+				if err := tx.AutoMigrate(&entity.Notification{}); err != nil {
+					return err
+				}
+				// 保留中の友達リクエストを未読の通知として移行する
+				return tx.Exec(`
+					INSERT INTO notifications (created_at, updated_at, user_id, actor_id, type, friendship_id)
+					SELECT f.created_at, f.created_at, f.requestee_id, f.requester_id, ?, f.id
+					FROM friendships f
+					WHERE f.status = ? AND f.deleted_at IS NULL
+				`, entity.NotificationFriendRequestReceived, string(entity.Pending)).Error
+			},
+			Rollback: func(tx *gorm.DB) error {
+				return tx.Migrator().DropTable(&entity.Notification{})
+			},
+		},
+		{
+			ID: "202610181090_create_push_notifications",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&entity.DeviceToken{}, &entity.NotificationPreference{}, &entity.PushDelivery{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				return tx.Migrator().DropTable(&entity.PushDelivery{}, &entity.NotificationPreference{}, &entity.DeviceToken{})
+			},
+		},
+		{
+			ID: "202610181100_add_friendship_lifecycle",
+			Migrate: func(tx *gorm.DB) error {
+				if err := tx.AutoMigrate(&entity.Friendship{}, &entity.UserBlock{}); err != nil {
+					return err
+				}
+				// 拒否済みのリクエストは最後に更新した日時を拒否した日時とみなす
+				return tx.Exec(`UPDATE friendships SET rejected_at = updated_at WHERE status = ? AND rejected_at IS NULL`, string(entity.Rejected)).Error
+			},
+			Rollback: func(tx *gorm.DB) error {
+				if err := tx.Migrator().DropTable(&entity.UserBlock{}); err != nil {
+					return err
+				}
+				return tx.Migrator().DropColumn(&entity.Friendship{}, "RejectedAt")
+			},
+		},
+		{
+			ID: "202610181110_unique_friendship_pair",
+			Migrate: func(tx *gorm.DB) error {
+				// 同じ2人の友達関係が複数ある場合は、承認済み・保留中・拒否済みの順に優先して新しいものを残す
+				duplicates := `
+					SELECT id, keep_id FROM (
+						SELECT id,
+							FIRST_VALUE(id) OVER pair AS keep_id,
+							ROW_NUMBER() OVER pair AS rn
+						FROM friendships
+						WHERE deleted_at IS NULL
+						WINDOW pair AS (
+							PARTITION BY LEAST(requester_id, requestee_id), GREATEST(requester_id, requestee_id)
+							ORDER BY CASE status WHEN 'accepted' THEN 0 WHEN 'pending' THEN 1 ELSE 2 END, id DESC
+						)
+					) AS ranked
+					WHERE rn > 1`
+
+				// お互いにリクエストを送り合っている場合は承認済みにする
+				if err := tx.Exec(`
+					UPDATE friendships AS kept SET status = ?, updated_at = NOW()
+					FROM (`+duplicates+`) AS duplicates
+					JOIN friendships AS duplicate ON duplicate.id = duplicates.id
+					WHERE kept.id = duplicates.keep_id
+					AND kept.status = ? AND duplicate.status = ?
+					AND duplicate.requester_id = kept.requestee_id
+				`, string(entity.Accepted), string(entity.Pending), string(entity.Pending)).Error; err != nil {
+					return err
+				}
+
+				// 重複した友達関係を削除する（関連する通知も削除される）
+				if err := tx.Exec(`DELETE FROM friendships WHERE id IN (SELECT id FROM (` + duplicates + `) AS duplicates)`).Error; err != nil {
+					return err
+				}
+
+				return tx.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS ` + entity.FriendshipPairIndex + `
+					ON friendships (LEAST(requester_id, requestee_id), GREATEST(requester_id, requestee_id))
+					WHERE deleted_at IS NULL`).Error
+			},
+			Rollback: func(tx *gorm.DB) error {
+				return tx.Exec(`DROP INDEX IF EXISTS ` + entity.FriendshipPairIndex).Error
+			},
+		},
+		{
+			ID: "202610181120_create_friend_qr_token_uses",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&entity.FriendQRTokenUse{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				return tx.Migrator().DropTable(&entity.FriendQRTokenUse{})
+			},
+		},
+		{
+			ID: "202610181130_create_recommendation_dismissals",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&entity.RecommendationDismissal{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				return tx.Migrator().DropTable(&entity.RecommendationDismissal{})
+			},
+		},
+		{
+			ID: "202610181140_add_workout_session",
+			Migrate: func(tx *gorm.DB) error {
+				// 既存のワークアウトはデフォルト値により終了済みになる
+				if err := tx.AutoMigrate(&entity.Workout{}); err != nil {
+					return err
+				}
+				return tx.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS ` + entity.WorkoutInProgressIndex + `
+					ON workouts (user_id)
+					WHERE status = 'in_progress' AND deleted_at IS NULL`).Error
+			},
+			Rollback: func(tx *gorm.DB) error {
+				if err := tx.Exec(`DROP INDEX IF EXISTS ` + entity.WorkoutInProgressIndex).Error; err != nil {
+					return err
+				}
+				for _, column := range []string{"Status", "StartedAt", "FinishedAt", "Notes", "SessionRPE"} {
+					if err := tx.Migrator().DropColumn(&entity.Workout{}, column); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		},
+		{
+			ID: "202610181150_add_set_log_metadata",
+			Migrate: func(tx *gorm.DB) error {
+				// 既存のセットは実施済みとして扱う（NOT NULLの列を追加するため先にデフォルト値付きで追加する）
+				if err := tx.Exec(`ALTER TABLE set_logs ADD COLUMN IF NOT EXISTS completed boolean NOT NULL DEFAULT true`).Error; err != nil {
+					return err
+				}
+				if err := tx.AutoMigrate(&entity.SetLog{}); err != nil {
+					return err
+				}
+				// 新しいセットは完了フラグを必ず指定して作成するためデフォルト値は外す
+				return tx.Exec(`ALTER TABLE set_logs ALTER COLUMN completed DROP DEFAULT`).Error
+			},
+			Rollback: func(tx *gorm.DB) error {
+				for _, column := range []string{"SetType", "RPE", "RIR", "Tempo", "RestSeconds", "Completed"} {
+					if err := tx.Migrator().DropColumn(&entity.SetLog{}, column); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		},
+		{
+			ID: "202610181200_add_exercise_tracking_type",
+			Migrate: func(tx *gorm.DB) error {
+				// 既存の種目はデフォルト値により重量×レップ数になる
+				return tx.AutoMigrate(&entity.Exercise{}, &entity.SetLog{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				for _, column := range []string{"DurationSeconds", "DistanceMeters", "HeartRate"} {
+					if err := tx.Migrator().DropColumn(&entity.SetLog{}, column); err != nil {
+						return err
+					}
+				}
+				return tx.Migrator().DropColumn(&entity.Exercise{}, "TrackingType")
+			},
+		},
+		{
+			ID: "202610181210_add_workout_exercise_order",
+			Migrate: func(tx *gorm.DB) error {
+				// 既存の種目は追加した順に並べる
+				if err := tx.Exec(`ALTER TABLE workout_exercises ADD COLUMN IF NOT EXISTS position bigint NOT NULL DEFAULT 0`).Error; err != nil {
+					return err
+				}
+				if err := tx.Exec(`
+					UPDATE workout_exercises
+					SET position = ordered.position
+					FROM (
+						SELECT id, ROW_NUMBER() OVER (PARTITION BY workout_id ORDER BY id) AS position
+						FROM workout_exercises
+					) AS ordered
+					WHERE workout_exercises.id = ordered.id`).Error; err != nil {
+					return err
+				}
+				if err := tx.Exec(`ALTER TABLE workout_exercises ALTER COLUMN position DROP DEFAULT`).Error; err != nil {
+					return err
+				}
+				return tx.AutoMigrate(&entity.WorkoutExercise{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				for _, column := range []string{"Position", "SupersetGroup"} {
+					if err := tx.Migrator().DropColumn(&entity.WorkoutExercise{}, column); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		},
+		{
+			ID: "202610181220_create_body_measurements",
+			Migrate: func(tx *gorm.DB) error {
+				if err := tx.AutoMigrate(&entity.BodyMeasurement{}); err != nil {
+					return err
+				}
+				// プロフィールの体重は最新の体重の記録から取得するため、既存の体重を記録に移す
+				if !tx.Migrator().HasColumn(&entity.Profile{}, "weight") {
+					return nil
+				}
+				if err := tx.Exec(`
+					INSERT INTO body_measurements (created_at, updated_at, user_id, measured_at, weight)
+					SELECT NOW(), NOW(), user_id, updated_at, weight
+					FROM profiles
+					WHERE weight IS NOT NULL AND deleted_at IS NULL`).Error; err != nil {
+					return err
+				}
+				return tx.Exec(`ALTER TABLE profiles DROP COLUMN weight`).Error
+			},
+			Rollback: func(tx *gorm.DB) error {
+				if err := tx.Exec(`ALTER TABLE profiles ADD COLUMN IF NOT EXISTS weight decimal`).Error; err != nil {
+					return err
+				}
+				if err := tx.Exec(`
+					UPDATE profiles
+					SET weight = (
+						SELECT body_measurements.weight
+						FROM body_measurements
+						WHERE body_measurements.user_id = profiles.user_id
+							AND body_measurements.weight IS NOT NULL
+							AND body_measurements.deleted_at IS NULL
+						ORDER BY body_measurements.measured_at DESC, body_measurements.id DESC
+						LIMIT 1
+					)`).Error; err != nil {
+					return err
+				}
+				return tx.Migrator().DropTable(&entity.BodyMeasurement{})
+			},
+		},
+		{
+			ID: "202610181230_link_workouts_to_program_enrollments",
+			Migrate: func(tx *gorm.DB) error {
+				// 既存のワークアウトはプログラムに紐付けない（プログラムの進行は以降に開始したワークアウトから数える）
+				// 週・日の重複はプログラムの作成時に検証しているため、既存のプログラムには重複がない
+				return tx.AutoMigrate(&entity.Workout{}, &entity.ProgramDay{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				if err := tx.Migrator().DropIndex(&entity.ProgramDay{}, "idx_program_days_program_week_day"); err != nil {
+					return err
+				}
+				return tx.Migrator().DropColumn(&entity.Workout{}, "ProgramEnrollmentID")
+			},
+		},
 	}
 }
+
+// backfillPersonalRecords は既存のセットから自己ベストを作成する
+// このマイグレーション時点のスキーマで実行するため、後から追加した列（set_logs.completed など）を参照するサービスのクエリは使わない
+func backfillPersonalRecords(tx *gorm.DB, userID, exerciseID uint) error {
+	var rows []struct {
+		ID        uint
+		WorkoutID uint
+		Weight    float64
+		RepCount  int
+		CreatedAt time.Time
+	}
+	if err := tx.Table("set_logs").
+		Select("set_logs.id, workout_exercises.workout_id, set_logs.weight, set_logs.rep_count, set_logs.created_at").
+		Joins("inner join workout_exercises on workout_exercises.id = set_logs.workout_exercise_id").
+		Joins("inner join workouts on workouts.id = workout_exercises.workout_id").
+		Where("workouts.user_id = ? AND workout_exercises.exercise_id = ?", userID, exerciseID).
+		Where("set_logs.deleted_at IS NULL AND workout_exercises.deleted_at IS NULL AND workouts.deleted_at IS NULL").
+		Order("set_logs.created_at ASC, set_logs.id ASC").
+		Scan(&rows).Error; err != nil {
+		return fmt.Errorf("failed to fetch set logs: %w", err)
+	}
+
+	sets := make([]entity.PersonalRecordSet, len(rows))
+	for i, row := range rows {
+		sets[i] = entity.PersonalRecordSet{
+			SetLogID:   row.ID,
+			WorkoutID:  row.WorkoutID,
+			Weight:     row.Weight,
+			RepCount:   row.RepCount,
+			AchievedAt: row.CreatedAt,
+		}
+	}
+
+	records := entity.ReplayPersonalRecords(userID, exerciseID, sets)
+	if len(records) == 0 {
+		return nil
+	}
+	if err := tx.Create(&records).Error; err != nil {
+		return fmt.Errorf("failed to create personal records: %w", err)
+	}
+	return nil
+}
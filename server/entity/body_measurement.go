@@ -0,0 +1,194 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"app/graph/model"
+
+	"gorm.io/gorm"
+)
+
+type BodyMeasurementMetric string
+
+const (
+	MetricWeight            BodyMeasurementMetric = "weight"
+	MetricBodyFatPercentage BodyMeasurementMetric = "body_fat_percentage"
+	MetricWaist             BodyMeasurementMetric = "waist"
+	MetricChest             BodyMeasurementMetric = "chest"
+	MetricArm               BodyMeasurementMetric = "arm"
+	MetricHip               BodyMeasurementMetric = "hip"
+	MetricThigh             BodyMeasurementMetric = "thigh"
+	MetricNeck              BodyMeasurementMetric = "neck"
+)
+
+var bodyMeasurementMetricsToGraphQL = map[BodyMeasurementMetric]model.BodyMeasurementMetric{
+	MetricWeight:            model.BodyMeasurementMetricWeight,
+	MetricBodyFatPercentage: model.BodyMeasurementMetricBodyFatPercentage,
+	MetricWaist:             model.BodyMeasurementMetricWaist,
+	MetricChest:             model.BodyMeasurementMetricChest,
+	MetricArm:               model.BodyMeasurementMetricArm,
+	MetricHip:               model.BodyMeasurementMetricHip,
+	MetricThigh:             model.BodyMeasurementMetricThigh,
+	MetricNeck:              model.BodyMeasurementMetricNeck,
+}
+
+const (
+	// DefaultMovingAverageDays は移動平均の期間（日数）のデフォルト値
+	DefaultMovingAverageDays = 7
+	MaxMovingAverageDays     = 90
+	MaxBodyMeasurementNote   = 500
+)
+
+// BodyMeasurement は体重・体脂肪率・周囲径の記録（記録日時ごとの時系列）
+type BodyMeasurement struct {
+	gorm.Model
+	UserID            uint      `gorm:"not null;index:idx_body_measurements_user_measured_at,priority:1"`
+	MeasuredAt        time.Time `gorm:"not null;index:idx_body_measurements_user_measured_at,priority:2"`
+	Weight            *float64  // kg
+	BodyFatPercentage *float64  // %
+	Waist             *float64  // 以下の周囲径はcm
+	Chest             *float64
+	Arm               *float64
+	Hip               *float64
+	Thigh             *float64
+	Neck              *float64
+	Note              string `gorm:"size:500"`
+
+	User User `gorm:"constraint:OnDelete:CASCADE;foreignKey:UserID"`
+}
+
+func (m BodyMeasurementMetric) ToGraphQL() model.BodyMeasurementMetric {
+	return bodyMeasurementMetricsToGraphQL[m]
+}
+
+// BodyMeasurementMetricFromGraphQL GraphQL enumから変換
+func BodyMeasurementMetricFromGraphQL(metric model.BodyMeasurementMetric) BodyMeasurementMetric {
+	for entityMetric, graphQLMetric := range bodyMeasurementMetricsToGraphQL {
+		if graphQLMetric == metric {
+			return entityMetric
+		}
+	}
+	return ""
+}
+
+func (b *BodyMeasurement) BeforeSave(tx *gorm.DB) error {
+	return b.Validate()
+}
+
+func (b *BodyMeasurement) BeforeCreate(tx *gorm.DB) error {
+	return b.Validate()
+}
+
+func (b *BodyMeasurement) BeforeUpdate(tx *gorm.DB) error {
+	return b.Validate()
+}
+
+func (b *BodyMeasurement) Validate() error {
+	if b.UserID == 0 {
+		return fmt.Errorf("user_id は必須です")
+	}
+	if b.MeasuredAt.IsZero() {
+		return fmt.Errorf("記録日時は必須です")
+	}
+
+	hasValue := false
+	for _, metric := range []BodyMeasurementMetric{
+		MetricWeight, MetricBodyFatPercentage, MetricWaist, MetricChest, MetricArm, MetricHip, MetricThigh, MetricNeck,
+	} {
+		if b.Value(metric) != nil {
+			hasValue = true
+			break
+		}
+	}
+	if !hasValue {
+		return fmt.Errorf("体重・体脂肪率・周囲径のいずれかを入力してください")
+	}
+
+	if b.Weight != nil && (*b.Weight < 20 || *b.Weight > 500) {
+		return fmt.Errorf("体重は20kg〜500kgの範囲で入力してください")
+	}
+	if b.BodyFatPercentage != nil && (*b.BodyFatPercentage < 2 || *b.BodyFatPercentage > 75) {
+		return fmt.Errorf("体脂肪率は2%%〜75%%の範囲で入力してください")
+	}
+	for _, circumference := range []*float64{b.Waist, b.Chest, b.Arm, b.Hip, b.Thigh, b.Neck} {
+		if circumference != nil && (*circumference < 10 || *circumference > 300) {
+			return fmt.Errorf("周囲径は10cm〜300cmの範囲で入力してください")
+		}
+	}
+	if len([]rune(b.Note)) > MaxBodyMeasurementNote {
+		return fmt.Errorf("メモは%d文字以内で入力してください", MaxBodyMeasurementNote)
+	}
+	return nil
+}
+
+// Value は指定した項目の値（記録していない場合はnil）
+func (b *BodyMeasurement) Value(metric BodyMeasurementMetric) *float64 {
+	switch metric {
+	case MetricWeight:
+		return b.Weight
+	case MetricBodyFatPercentage:
+		return b.BodyFatPercentage
+	case MetricWaist:
+		return b.Waist
+	case MetricChest:
+		return b.Chest
+	case MetricArm:
+		return b.Arm
+	case MetricHip:
+		return b.Hip
+	case MetricThigh:
+		return b.Thigh
+	case MetricNeck:
+		return b.Neck
+	default:
+		return nil
+	}
+}
+
+// BodyMeasurementPoint は推移の1点（記録した値と、それまでの移動平均）
+type BodyMeasurementPoint struct {
+	MeasuredAt    time.Time
+	Value         float64
+	MovingAverage float64
+}
+
+// NewBodyMeasurementTrend は記録日時順の記録から metric の推移を作る
+// 移動平均は各記録の直前 windowDays 日間（記録日時を含む）の平均で、from より前の記録は移動平均にのみ使う
+func NewBodyMeasurementTrend(measurements []*BodyMeasurement, metric BodyMeasurementMetric, windowDays int, from time.Time) []BodyMeasurementPoint {
+	window := time.Duration(windowDays) * 24 * time.Hour
+
+	var values []BodyMeasurementPoint
+	points := []BodyMeasurementPoint{}
+	start, sum := 0, 0.0
+	for _, measurement := range measurements {
+		value := measurement.Value(metric)
+		if value == nil {
+			continue
+		}
+		values = append(values, BodyMeasurementPoint{MeasuredAt: measurement.MeasuredAt, Value: *value})
+		sum += *value
+
+		// 期間外になった記録を移動平均から外す
+		for !values[start].MeasuredAt.After(measurement.MeasuredAt.Add(-window)) {
+			sum -= values[start].Value
+			start++
+		}
+
+		if measurement.MeasuredAt.Before(from) {
+			continue
+		}
+		points = append(points, BodyMeasurementPoint{
+			MeasuredAt:    measurement.MeasuredAt,
+			Value:         *value,
+			MovingAverage: roundTo(sum/float64(len(values)-start), 2),
+		})
+	}
+	return points
+}
+
+// CalculateBMI は身長（cm）と体重（kg）からBMIを計算する（小数第1位で丸める）
+func CalculateBMI(heightCm, weightKg float64) float64 {
+	heightM := heightCm / 100
+	return roundTo(weightKg/(heightM*heightM), 1)
+}
@@ -0,0 +1,72 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBodyMeasurement_Validate(t *testing.T) {
+	value := func(v float64) *float64 { return &v }
+	valid := func() BodyMeasurement {
+		return BodyMeasurement{UserID: 1, MeasuredAt: time.Now(), Weight: value(70)}
+	}
+
+	tests := []struct {
+		name      string
+		modify    func(b *BodyMeasurement)
+		expectErr bool
+	}{
+		{name: "Valid", modify: func(b *BodyMeasurement) {}},
+		{name: "Circumference only", modify: func(b *BodyMeasurement) { b.Weight, b.Waist = nil, value(80) }},
+		{name: "No values", modify: func(b *BodyMeasurement) { b.Weight = nil }, expectErr: true},
+		{name: "Missing measured at", modify: func(b *BodyMeasurement) { b.MeasuredAt = time.Time{} }, expectErr: true},
+		{name: "Weight out of range", modify: func(b *BodyMeasurement) { b.Weight = value(600) }, expectErr: true},
+		{name: "Body fat out of range", modify: func(b *BodyMeasurement) { b.BodyFatPercentage = value(80) }, expectErr: true},
+		{name: "Circumference out of range", modify: func(b *BodyMeasurement) { b.Neck = value(5) }, expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			measurement := valid()
+			tt.modify(&measurement)
+			err := measurement.Validate()
+			if tt.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNewBodyMeasurementTrend(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 10, d, 7, 0, 0, 0, time.UTC) }
+	weight := func(d int, v float64) *BodyMeasurement {
+		return &BodyMeasurement{MeasuredAt: day(d), Weight: &v}
+	}
+	waist := 80.0
+
+	measurements := []*BodyMeasurement{
+		weight(1, 72),
+		weight(2, 71),
+		{MeasuredAt: day(3), Waist: &waist},
+		weight(4, 70),
+		weight(6, 69),
+	}
+
+	// 3日間の移動平均（1日の記録は期間前のため移動平均にのみ使う）
+	points := NewBodyMeasurementTrend(measurements, MetricWeight, 3, day(2))
+
+	assert.Equal(t, []BodyMeasurementPoint{
+		{MeasuredAt: day(2), Value: 71, MovingAverage: 71.5},
+		{MeasuredAt: day(4), Value: 70, MovingAverage: 70.5},
+		{MeasuredAt: day(6), Value: 69, MovingAverage: 69.5},
+	}, points)
+}
+
+func TestCalculateBMI(t *testing.T) {
+	assert.Equal(t, 22.9, CalculateBMI(175, 70))
+	assert.Equal(t, 20.8, CalculateBMI(160, 53.2))
+}
@@ -0,0 +1,71 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"app/graph/model"
+
+	"gorm.io/gorm"
+)
+
+type DevicePlatform string
+
+const (
+	DevicePlatformIOS     DevicePlatform = "ios"
+	DevicePlatformAndroid DevicePlatform = "android"
+	DevicePlatformWeb     DevicePlatform = "web"
+)
+
+var devicePlatformsToGraphQL = map[DevicePlatform]model.DevicePlatform{
+	DevicePlatformIOS:     model.DevicePlatformIos,
+	DevicePlatformAndroid: model.DevicePlatformAndroid,
+	DevicePlatformWeb:     model.DevicePlatformWeb,
+}
+
+// DeviceToken はプッシュ通知を送る端末のFCM登録トークン
+// 同じ端末で別のユーザーがログインした場合はトークンの持ち主を付け替える
+type DeviceToken struct {
+	gorm.Model
+	UserID           uint           `gorm:"not null;index"`
+	Token            string         `gorm:"size:512;not null;uniqueIndex"`
+	Platform         DevicePlatform `gorm:"size:20;not null"`
+	LastRegisteredAt time.Time      `gorm:"not null"` // 最後に登録した日時（アプリの起動ごとに更新される）
+
+	User User `gorm:"constraint:OnDelete:CASCADE;foreignKey:UserID"`
+}
+
+func (d *DeviceToken) BeforeSave(tx *gorm.DB) error {
+	return d.Validate()
+}
+
+func (d *DeviceToken) Validate() error {
+	if d.UserID == 0 {
+		return fmt.Errorf("ユーザーIDは必須です")
+	}
+	if d.Token == "" {
+		return fmt.Errorf("トークンは必須です")
+	}
+	if len(d.Token) > 512 {
+		return fmt.Errorf("トークンは512文字以内で入力してください")
+	}
+	if _, ok := devicePlatformsToGraphQL[d.Platform]; !ok {
+		return fmt.Errorf("無効なプラットフォームです: %s", d.Platform)
+	}
+	return nil
+}
+
+// ToGraphQL GraphQL enumに変換
+func (p DevicePlatform) ToGraphQL() model.DevicePlatform {
+	return devicePlatformsToGraphQL[p]
+}
+
+// DevicePlatformFromGraphQL GraphQL enumから変換
+func DevicePlatformFromGraphQL(platform model.DevicePlatform) DevicePlatform {
+	for entityPlatform, modelPlatform := range devicePlatformsToGraphQL {
+		if modelPlatform == platform {
+			return entityPlatform
+		}
+	}
+	return ""
+}
@@ -2,36 +2,178 @@ package entity
 
 import (
 	"fmt"
+	"strings"
+	"time"
+
+	"app/graph/model"
 
 	"gorm.io/gorm"
 )
 
+type ExerciseVisibility string
+
+const (
+	ExerciseVisibilityPrivate ExerciseVisibility = "private" // 作成者のみ
+	ExerciseVisibilityFriends ExerciseVisibility = "friends" // 作成者とフレンド
+	ExerciseVisibilityPublic  ExerciseVisibility = "public"  // 全ユーザー
+)
+
+type Equipment string
+
+const (
+	EquipmentBarbell      Equipment = "barbell"       // バーベル
+	EquipmentDumbbell     Equipment = "dumbbell"      // ダンベル
+	EquipmentMachine      Equipment = "machine"       // マシン
+	EquipmentCable        Equipment = "cable"         // ケーブル
+	EquipmentBodyweight   Equipment = "bodyweight"    // 自重
+	EquipmentKettlebell   Equipment = "kettlebell"    // ケトルベル
+	EquipmentBand         Equipment = "band"          // チューブ
+	EquipmentSmithMachine Equipment = "smith_machine" // スミスマシン
+	EquipmentOther        Equipment = "other"         // その他
+)
+
+var equipmentToGraphQL = map[Equipment]model.Equipment{
+	EquipmentBarbell:      model.EquipmentBarbell,
+	EquipmentDumbbell:     model.EquipmentDumbbell,
+	EquipmentMachine:      model.EquipmentMachine,
+	EquipmentCable:        model.EquipmentCable,
+	EquipmentBodyweight:   model.EquipmentBodyweight,
+	EquipmentKettlebell:   model.EquipmentKettlebell,
+	EquipmentBand:         model.EquipmentBand,
+	EquipmentSmithMachine: model.EquipmentSmithMachine,
+	EquipmentOther:        model.EquipmentOther,
+}
+
+// IsValid は定義済みの器具かどうか
+func (e Equipment) IsValid() bool {
+	_, ok := equipmentToGraphQL[e]
+	return ok
+}
+
+// ToGraphQL GraphQL enumに変換
+func (e Equipment) ToGraphQL() model.Equipment {
+	return equipmentToGraphQL[e]
+}
+
+// EquipmentFromGraphQL GraphQL enumから変換
+func EquipmentFromGraphQL(equipment model.Equipment) Equipment {
+	for entityEquipment, modelEquipment := range equipmentToGraphQL {
+		if modelEquipment == equipment {
+			return entityEquipment
+		}
+	}
+	return ""
+}
+
+// TrackingType は種目の記録方法（セットに何を記録するか）
+type TrackingType string
+
+const (
+	TrackingTypeWeightReps       TrackingType = "weight_reps"       // 重量×レップ数
+	TrackingTypeRepsOnly         TrackingType = "reps_only"         // レップ数のみ（自重、重量は加重する場合のみ）
+	TrackingTypeDuration         TrackingType = "duration"          // 時間（プランクなど）
+	TrackingTypeDistanceDuration TrackingType = "distance_duration" // 距離と時間（ランニング・ローイングなど）
+	TrackingTypeAssistedWeight   TrackingType = "assisted_weight"   // 補助重量×レップ数（アシストプルアップなど）
+)
+
+var trackingTypesToGraphQL = map[TrackingType]model.ExerciseTrackingType{
+	TrackingTypeWeightReps:       model.ExerciseTrackingTypeWeightReps,
+	TrackingTypeRepsOnly:         model.ExerciseTrackingTypeRepsOnly,
+	TrackingTypeDuration:         model.ExerciseTrackingTypeDuration,
+	TrackingTypeDistanceDuration: model.ExerciseTrackingTypeDistanceDuration,
+	TrackingTypeAssistedWeight:   model.ExerciseTrackingTypeAssistedWeight,
+}
+
+// IsValid は定義済みの記録方法かどうか
+func (t TrackingType) IsValid() bool {
+	_, ok := trackingTypesToGraphQL[t]
+	return ok
+}
+
+// IsWeighted は重量×レップ数で記録する種目かどうか（自己ベスト・ボリュームの集計対象）
+func (t TrackingType) IsWeighted() bool {
+	return t == "" || t == TrackingTypeWeightReps
+}
+
+// ToGraphQL GraphQL enumに変換（未設定の場合は重量×レップ数）
+func (t TrackingType) ToGraphQL() model.ExerciseTrackingType {
+	if t == "" {
+		return model.ExerciseTrackingTypeWeightReps
+	}
+	return trackingTypesToGraphQL[t]
+}
+
+// TrackingTypeFromGraphQL GraphQL enumから変換
+func TrackingTypeFromGraphQL(trackingType model.ExerciseTrackingType) TrackingType {
+	for entityType, modelType := range trackingTypesToGraphQL {
+		if modelType == trackingType {
+			return entityType
+		}
+	}
+	return ""
+}
+
+// Exercise は種目（UserIDがnilの場合は全ユーザー共通のカタログ）
 type Exercise struct {
 	gorm.Model
-	Name        string `gorm:"size:255;not null;unique"`
-	Description string `gorm:"size:1000"`
-	Category    string `gorm:"size:100"`
+	UserID       *uint              `gorm:"index"` // 作成者（カタログの種目はnil）
+	Name         string             `gorm:"size:255;not null"`
+	Description  string             `gorm:"size:1000"`
+	Category     string             `gorm:"size:100"`
+	Visibility   ExerciseVisibility `gorm:"size:20;not null;default:public"`
+	ArchivedAt   *time.Time         // アーカイブ済みの種目は一覧に表示しない
+	Equipment    Equipment          `gorm:"size:50;index"`
+	TrackingType TrackingType       `gorm:"size:30;not null;default:weight_reps"`
+	Keywords     string             `gorm:"size:1000"` // 検索用の別名（漢字表記・英語名など、カンマ区切り）
+	SearchText   string             `gorm:"size:4000"` // 正規化した検索用テキスト（保存時に自動生成）
 
+	User             *User             `gorm:"constraint:OnDelete:CASCADE;foreignKey:UserID"`
+	Muscles          []ExerciseMuscle  `gorm:"foreignKey:ExerciseID;constraint:OnDelete:CASCADE"`
 	WorkoutExercises []WorkoutExercise `gorm:"foreignKey:ExerciseID;constraint:OnDelete:CASCADE"`
 }
 
 func (e *Exercise) BeforeSave(tx *gorm.DB) error {
+	e.SearchText = e.BuildSearchText()
 	return e.Validate()
 }
 
 func (e *Exercise) BeforeCreate(tx *gorm.DB) error {
-	var count int64
-	tx.Model(&Exercise{}).Where("name = ?", e.Name).Count(&count)
-	if count > 0 {
-		return fmt.Errorf("種目名 '%s' はすでに存在します", e.Name)
+	if e.Visibility == "" {
+		e.Visibility = ExerciseVisibilityPublic
+	}
+	if err := e.checkDuplicateName(tx); err != nil {
+		return err
 	}
 	return e.Validate()
 }
 
 func (e *Exercise) BeforeUpdate(tx *gorm.DB) error {
+	if err := e.checkDuplicateName(tx); err != nil {
+		return err
+	}
 	return e.Validate()
 }
 
+// checkDuplicateName は同じ作成者（カタログの場合はカタログ内）で種目名が重複していないか確認する
+func (e *Exercise) checkDuplicateName(tx *gorm.DB) error {
+	query := tx.Session(&gorm.Session{NewDB: true}).Model(&Exercise{}).Where("name = ?", e.Name)
+	if e.UserID == nil {
+		query = query.Where("user_id IS NULL")
+	} else {
+		query = query.Where("user_id = ?", *e.UserID)
+	}
+	if e.ID != 0 {
+		query = query.Where("id <> ?", e.ID)
+	}
+
+	var count int64
+	query.Count(&count)
+	if count > 0 {
+		return fmt.Errorf("種目名 '%s' はすでに存在します", e.Name)
+	}
+	return nil
+}
+
 func (e *Exercise) Validate() error {
 	if e.Name == "" {
 		return fmt.Errorf("種目名は必須です")
@@ -45,5 +187,108 @@ func (e *Exercise) Validate() error {
 	if len(e.Category) > 100 {
 		return fmt.Errorf("カテゴリは100文字以内で入力してください")
 	}
+	if e.Visibility != "" && !e.IsValidVisibility() {
+		return fmt.Errorf("無効な公開範囲です: %s", e.Visibility)
+	}
+	if e.Equipment != "" && !e.Equipment.IsValid() {
+		return fmt.Errorf("無効な器具です: %s", e.Equipment)
+	}
+	if e.TrackingType != "" && !e.TrackingType.IsValid() {
+		return fmt.Errorf("無効な記録方法です: %s", e.TrackingType)
+	}
+	if len(e.Keywords) > 1000 {
+		return fmt.Errorf("検索キーワードは1000文字以内で入力してください")
+	}
+	if e.UserID == nil && e.Visibility != "" && e.Visibility != ExerciseVisibilityPublic {
+		return fmt.Errorf("カタログの種目は公開範囲を変更できません")
+	}
 	return nil
 }
+
+func (e *Exercise) IsValidVisibility() bool {
+	switch e.Visibility {
+	case ExerciseVisibilityPrivate, ExerciseVisibilityFriends, ExerciseVisibilityPublic:
+		return true
+	default:
+		return false
+	}
+}
+
+// BuildSearchText は種目名・説明・カテゴリ・検索キーワードから検索用テキストを作成する
+func (e *Exercise) BuildSearchText() string {
+	return BuildSearchText(append([]string{e.Name, e.Description, e.Category}, strings.Split(e.Keywords, ",")...)...)
+}
+
+// MuscleGroups は主働筋または協働筋の部位を返す
+func (e *Exercise) MuscleGroups(primary bool) []MuscleGroup {
+	muscleGroups := []MuscleGroup{}
+	for _, muscle := range e.Muscles {
+		if muscle.IsPrimary == primary {
+			muscleGroups = append(muscleGroups, muscle.MuscleGroup)
+		}
+	}
+	return muscleGroups
+}
+
+// IsGlobal はカタログの種目かどうか
+func (e *Exercise) IsGlobal() bool {
+	return e.UserID == nil
+}
+
+// IsOwnedBy は指定ユーザーが作成した種目かどうか
+func (e *Exercise) IsOwnedBy(userID uint) bool {
+	return e.UserID != nil && *e.UserID == userID
+}
+
+// IsVisibleTo は指定ユーザーが種目を閲覧できるかどうか（フレンド公開の場合はisFriendで判定）
+func (e *Exercise) IsVisibleTo(userID uint, isFriend bool) bool {
+	if e.IsGlobal() || e.IsOwnedBy(userID) {
+		return true
+	}
+	switch e.Visibility {
+	case ExerciseVisibilityPublic:
+		return true
+	case ExerciseVisibilityFriends:
+		return isFriend
+	default:
+		return false
+	}
+}
+
+// RedactedExerciseName は閲覧できない種目の代わりに表示する名前
+const RedactedExerciseName = "非公開の種目"
+
+// Redacted は閲覧できない種目の名前・説明・部位などを伏せたもの（記録方法はセットの表示に必要なため残す）
+func (e *Exercise) Redacted() Exercise {
+	return Exercise{
+		Model:        gorm.Model{ID: e.ID},
+		UserID:       e.UserID,
+		Name:         RedactedExerciseName,
+		Visibility:   e.Visibility,
+		TrackingType: e.TrackingType,
+	}
+}
+
+// VisibilityToGraphQL GraphQL enumに変換
+func (e *Exercise) VisibilityToGraphQL() model.ExerciseVisibility {
+	switch e.Visibility {
+	case ExerciseVisibilityPrivate:
+		return model.ExerciseVisibilityPrivate
+	case ExerciseVisibilityFriends:
+		return model.ExerciseVisibilityFriends
+	default:
+		return model.ExerciseVisibilityPublic
+	}
+}
+
+// VisibilityFromGraphQL GraphQL enumから変換
+func (e *Exercise) VisibilityFromGraphQL(visibility model.ExerciseVisibility) {
+	switch visibility {
+	case model.ExerciseVisibilityPrivate:
+		e.Visibility = ExerciseVisibilityPrivate
+	case model.ExerciseVisibilityFriends:
+		e.Visibility = ExerciseVisibilityFriends
+	case model.ExerciseVisibilityPublic:
+		e.Visibility = ExerciseVisibilityPublic
+	}
+}
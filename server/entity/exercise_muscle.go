@@ -0,0 +1,110 @@
+package entity
+
+import (
+	"fmt"
+
+	"app/graph/model"
+
+	"gorm.io/gorm"
+)
+
+type MuscleGroup string
+
+const (
+	MuscleChest      MuscleGroup = "chest"      // 大胸筋
+	MuscleBack       MuscleGroup = "back"       // 背中（僧帽筋・広背筋以外）
+	MuscleLats       MuscleGroup = "lats"       // 広背筋
+	MuscleTraps      MuscleGroup = "traps"      // 僧帽筋
+	MuscleShoulders  MuscleGroup = "shoulders"  // 三角筋
+	MuscleBiceps     MuscleGroup = "biceps"     // 上腕二頭筋
+	MuscleTriceps    MuscleGroup = "triceps"    // 上腕三頭筋
+	MuscleForearms   MuscleGroup = "forearms"   // 前腕
+	MuscleAbs        MuscleGroup = "abs"        // 腹直筋
+	MuscleObliques   MuscleGroup = "obliques"   // 腹斜筋
+	MuscleLowerBack  MuscleGroup = "lower_back" // 脊柱起立筋
+	MuscleGlutes     MuscleGroup = "glutes"     // 臀筋
+	MuscleQuadriceps MuscleGroup = "quadriceps" // 大腿四頭筋
+	MuscleHamstrings MuscleGroup = "hamstrings" // ハムストリングス
+	MuscleAdductors  MuscleGroup = "adductors"  // 内転筋
+	MuscleCalves     MuscleGroup = "calves"     // 下腿三頭筋
+)
+
+var muscleGroupsToGraphQL = map[MuscleGroup]model.MuscleGroup{
+	MuscleChest:      model.MuscleGroupChest,
+	MuscleBack:       model.MuscleGroupBack,
+	MuscleLats:       model.MuscleGroupLats,
+	MuscleTraps:      model.MuscleGroupTraps,
+	MuscleShoulders:  model.MuscleGroupShoulders,
+	MuscleBiceps:     model.MuscleGroupBiceps,
+	MuscleTriceps:    model.MuscleGroupTriceps,
+	MuscleForearms:   model.MuscleGroupForearms,
+	MuscleAbs:        model.MuscleGroupAbs,
+	MuscleObliques:   model.MuscleGroupObliques,
+	MuscleLowerBack:  model.MuscleGroupLowerBack,
+	MuscleGlutes:     model.MuscleGroupGlutes,
+	MuscleQuadriceps: model.MuscleGroupQuadriceps,
+	MuscleHamstrings: model.MuscleGroupHamstrings,
+	MuscleAdductors:  model.MuscleGroupAdductors,
+	MuscleCalves:     model.MuscleGroupCalves,
+}
+
+// IsValid は定義済みの部位かどうか
+func (m MuscleGroup) IsValid() bool {
+	_, ok := muscleGroupsToGraphQL[m]
+	return ok
+}
+
+// ToGraphQL GraphQL enumに変換
+func (m MuscleGroup) ToGraphQL() model.MuscleGroup {
+	return muscleGroupsToGraphQL[m]
+}
+
+// MuscleGroupFromGraphQL GraphQL enumから変換
+func MuscleGroupFromGraphQL(muscleGroup model.MuscleGroup) MuscleGroup {
+	for entityMuscleGroup, modelMuscleGroup := range muscleGroupsToGraphQL {
+		if modelMuscleGroup == muscleGroup {
+			return entityMuscleGroup
+		}
+	}
+	return ""
+}
+
+// ExerciseMuscle は種目で鍛えられる部位（主働筋・協働筋）
+type ExerciseMuscle struct {
+	gorm.Model
+	ExerciseID  uint        `gorm:"not null;uniqueIndex:idx_exercise_muscles_exercise_muscle"`
+	MuscleGroup MuscleGroup `gorm:"size:50;not null;uniqueIndex:idx_exercise_muscles_exercise_muscle;index"`
+	IsPrimary   bool        `gorm:"not null"` // 主働筋かどうか
+
+	Exercise Exercise `gorm:"constraint:OnDelete:CASCADE;foreignKey:ExerciseID"`
+}
+
+func (m *ExerciseMuscle) BeforeSave(tx *gorm.DB) error {
+	return m.Validate()
+}
+
+func (m *ExerciseMuscle) Validate() error {
+	if !m.MuscleGroup.IsValid() {
+		return fmt.Errorf("無効な部位です: %s", m.MuscleGroup)
+	}
+	return nil
+}
+
+// NewExerciseMuscles は主働筋・協働筋から部位の一覧を作成する（両方に含まれる場合は主働筋とする）
+func NewExerciseMuscles(primary, secondary []MuscleGroup) []ExerciseMuscle {
+	muscles := make([]ExerciseMuscle, 0, len(primary)+len(secondary))
+	added := make(map[MuscleGroup]bool)
+	for _, muscleGroup := range primary {
+		if !added[muscleGroup] {
+			added[muscleGroup] = true
+			muscles = append(muscles, ExerciseMuscle{MuscleGroup: muscleGroup, IsPrimary: true})
+		}
+	}
+	for _, muscleGroup := range secondary {
+		if !added[muscleGroup] {
+			added[muscleGroup] = true
+			muscles = append(muscles, ExerciseMuscle{MuscleGroup: muscleGroup})
+		}
+	}
+	return muscles
+}
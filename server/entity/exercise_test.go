@@ -0,0 +1,73 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExercise_IsVisibleTo(t *testing.T) {
+	ownerID := uint(1)
+	otherID := uint(2)
+
+	tests := []struct {
+		name     string
+		exercise Exercise
+		userID   uint
+		isFriend bool
+		expected bool
+	}{
+		{
+			name:     "Catalog exercise is visible to everyone",
+			exercise: Exercise{Visibility: ExerciseVisibilityPublic},
+			userID:   otherID,
+			expected: true,
+		},
+		{
+			name:     "Private exercise is visible to the owner",
+			exercise: Exercise{UserID: &ownerID, Visibility: ExerciseVisibilityPrivate},
+			userID:   ownerID,
+			expected: true,
+		},
+		{
+			name:     "Private exercise is hidden from friends",
+			exercise: Exercise{UserID: &ownerID, Visibility: ExerciseVisibilityPrivate},
+			userID:   otherID,
+			isFriend: true,
+			expected: false,
+		},
+		{
+			name:     "Friends exercise is visible to friends",
+			exercise: Exercise{UserID: &ownerID, Visibility: ExerciseVisibilityFriends},
+			userID:   otherID,
+			isFriend: true,
+			expected: true,
+		},
+		{
+			name:     "Friends exercise is hidden from others",
+			exercise: Exercise{UserID: &ownerID, Visibility: ExerciseVisibilityFriends},
+			userID:   otherID,
+			expected: false,
+		},
+		{
+			name:     "Public exercise is visible to others",
+			exercise: Exercise{UserID: &ownerID, Visibility: ExerciseVisibilityPublic},
+			userID:   otherID,
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.exercise.IsVisibleTo(tt.userID, tt.isFriend))
+		})
+	}
+}
+
+func TestExercise_Validate(t *testing.T) {
+	ownerID := uint(1)
+
+	assert.NoError(t, (&Exercise{Name: "ベンチプレス", UserID: &ownerID, Visibility: ExerciseVisibilityFriends}).Validate())
+	assert.Error(t, (&Exercise{Name: "ベンチプレス", UserID: &ownerID, Visibility: "everyone"}).Validate())
+	assert.Error(t, (&Exercise{Name: "ベンチプレス", Visibility: ExerciseVisibilityPrivate}).Validate())
+}
@@ -0,0 +1,35 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// FriendQRTokenUse は1回限りのQRコードのトークンの使用記録
+// 同じトークンIDは1回しか記録できないため、2回目以降の友達追加を防ぐ
+type FriendQRTokenUse struct {
+	gorm.Model
+	TokenID   string    `gorm:"size:64;not null;uniqueIndex"`
+	IssuerID  uint      `gorm:"not null"`       // QRコードを表示したユーザー
+	UsedByID  uint      `gorm:"not null"`       // QRコードを読み取ったユーザー
+	ExpiresAt time.Time `gorm:"not null;index"` // 期限切れの記録は削除してよい
+
+	Issuer User `gorm:"constraint:OnDelete:CASCADE;foreignKey:IssuerID"`
+	UsedBy User `gorm:"constraint:OnDelete:CASCADE;foreignKey:UsedByID"`
+}
+
+func (u *FriendQRTokenUse) BeforeSave(tx *gorm.DB) error {
+	return u.Validate()
+}
+
+func (u *FriendQRTokenUse) Validate() error {
+	if u.TokenID == "" {
+		return fmt.Errorf("トークンIDは必須です")
+	}
+	if u.IssuerID == 0 || u.UsedByID == 0 {
+		return fmt.Errorf("QRコードを表示したユーザーと読み取ったユーザーは必須です")
+	}
+	return nil
+}
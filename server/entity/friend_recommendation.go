@@ -0,0 +1,119 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// RecommendationAgeRange は年齢が近いとみなす年齢差
+	RecommendationAgeRange = 5
+	// RecommendationCategoryPeriod は共通のトレーニングカテゴリを判定する期間（直近のワークアウト）
+	RecommendationCategoryPeriod = 90 * 24 * time.Hour
+)
+
+// 推奨ユーザーのスコアの重み（共通の友達・グループは上限までで頭打ちにする）
+const (
+	mutualFriendWeight      = 3.0
+	mutualFriendCap         = 10
+	sharedGroupWeight       = 4.0
+	sharedGroupCap          = 5
+	sharedCategoryWeight    = 1.5
+	sharedCategoryCap       = 4
+	sameActivityLevelWeight = 1.0
+	closeAgeWeight          = 1.0
+
+	maxReasonCategories = 3
+)
+
+// RecommendationDismissal は推奨ユーザーから非表示にしたユーザー
+type RecommendationDismissal struct {
+	gorm.Model
+	UserID          uint `gorm:"not null;uniqueIndex:idx_recommendation_dismissals_user_dismissed"`
+	DismissedUserID uint `gorm:"not null;uniqueIndex:idx_recommendation_dismissals_user_dismissed"`
+
+	User          User `gorm:"constraint:OnDelete:CASCADE;foreignKey:UserID"`
+	DismissedUser User `gorm:"constraint:OnDelete:CASCADE;foreignKey:DismissedUserID"`
+}
+
+func (d *RecommendationDismissal) BeforeSave(tx *gorm.DB) error {
+	return d.Validate()
+}
+
+func (d *RecommendationDismissal) Validate() error {
+	if d.UserID == 0 || d.DismissedUserID == 0 {
+		return fmt.Errorf("ユーザーと非表示にするユーザーは必須です")
+	}
+	if d.UserID == d.DismissedUserID {
+		return fmt.Errorf("自分自身を非表示にすることはできません")
+	}
+	return nil
+}
+
+// FriendRecommendationSignals は推奨ユーザーとの共通点
+type FriendRecommendationSignals struct {
+	MutualFriends     int      // 共通の友達の数
+	SharedGroups      int      // 一緒に参加しているワークアウトグループの数
+	SharedCategories  []string // 直近に共通して記録したトレーニングカテゴリ
+	SameActivityLevel bool     // プロフィールの活動レベルが同じ
+	AgeGap            *int     // 年齢差（どちらかの生年月日が未登録の場合はnil）
+}
+
+// CompareProfiles はプロフィールの近さ（活動レベル・年齢）を設定する
+func (s *FriendRecommendationSignals) CompareProfiles(profile, other *Profile) {
+	if profile == nil || other == nil {
+		return
+	}
+	s.SameActivityLevel = profile.ActivityLevel != "" && profile.ActivityLevel == other.ActivityLevel
+	if profile.BirthDate != nil && other.BirthDate != nil {
+		gap := int(profile.BirthDate.Sub(*other.BirthDate).Abs().Hours() / 24 / 365.25)
+		s.AgeGap = &gap
+	}
+}
+
+// IsCloseAge は年齢が近いかどうか
+func (s *FriendRecommendationSignals) IsCloseAge() bool {
+	return s.AgeGap != nil && *s.AgeGap <= RecommendationAgeRange
+}
+
+// Score は推奨の順位に使うスコア（共通点がない場合は0）
+func (s *FriendRecommendationSignals) Score() float64 {
+	score := mutualFriendWeight*float64(min(s.MutualFriends, mutualFriendCap)) +
+		sharedGroupWeight*float64(min(s.SharedGroups, sharedGroupCap)) +
+		sharedCategoryWeight*float64(min(len(s.SharedCategories), sharedCategoryCap))
+	if s.SameActivityLevel {
+		score += sameActivityLevelWeight
+	}
+	if s.IsCloseAge() {
+		score += closeAgeWeight
+	}
+	return score
+}
+
+// Reasons は推奨の理由をスコアへの影響が大きい順に返す
+func (s *FriendRecommendationSignals) Reasons() []string {
+	var reasons []string
+	if s.MutualFriends > 0 {
+		reasons = append(reasons, fmt.Sprintf("共通の友達が%d人います", s.MutualFriends))
+	}
+	if s.SharedGroups > 0 {
+		reasons = append(reasons, fmt.Sprintf("%d個のグループに一緒に参加しています", s.SharedGroups))
+	}
+	if len(s.SharedCategories) > 0 {
+		categories := s.SharedCategories
+		if len(categories) > maxReasonCategories {
+			categories = categories[:maxReasonCategories]
+		}
+		reasons = append(reasons, fmt.Sprintf("%sのトレーニングをしています", strings.Join(categories, "・")))
+	}
+	if s.SameActivityLevel {
+		reasons = append(reasons, "活動レベルが同じです")
+	}
+	if s.IsCloseAge() {
+		reasons = append(reasons, "年齢が近いです")
+	}
+	return reasons
+}
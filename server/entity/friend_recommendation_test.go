@@ -0,0 +1,85 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFriendRecommendationSignals_Score(t *testing.T) {
+	mutual := FriendRecommendationSignals{MutualFriends: 3}
+	group := FriendRecommendationSignals{SharedGroups: 1}
+	category := FriendRecommendationSignals{SharedCategories: []string{"胸"}}
+	profile := FriendRecommendationSignals{SameActivityLevel: true}
+
+	// 共通の友達 > グループ > カテゴリ > プロフィールの順に重視する
+	assert.Greater(t, mutual.Score(), group.Score())
+	assert.Greater(t, group.Score(), category.Score())
+	assert.Greater(t, category.Score(), profile.Score())
+	assert.Zero(t, (&FriendRecommendationSignals{}).Score())
+
+	// 共通の友達は上限で頭打ちにする
+	many := FriendRecommendationSignals{MutualFriends: 100}
+	capped := FriendRecommendationSignals{MutualFriends: mutualFriendCap}
+	assert.Equal(t, capped.Score(), many.Score())
+}
+
+func TestFriendRecommendationSignals_CompareProfiles(t *testing.T) {
+	date := func(year int) *time.Time {
+		d := time.Date(year, 4, 1, 0, 0, 0, 0, time.UTC)
+		return &d
+	}
+
+	tests := []struct {
+		name         string
+		profile      *Profile
+		other        *Profile
+		expectSame   bool
+		expectClose  bool
+		expectNilGap bool
+	}{
+		{
+			name:        "Close age and same activity level",
+			profile:     &Profile{BirthDate: date(1990), ActivityLevel: VeryActive},
+			other:       &Profile{BirthDate: date(1994), ActivityLevel: VeryActive},
+			expectSame:  true,
+			expectClose: true,
+		},
+		{
+			name:    "Far age",
+			profile: &Profile{BirthDate: date(1990), ActivityLevel: VeryActive},
+			other:   &Profile{BirthDate: date(2000), ActivityLevel: Sedentary},
+		},
+		{
+			name:         "Missing birth date and activity level",
+			profile:      &Profile{},
+			other:        &Profile{BirthDate: date(1990)},
+			expectNilGap: true,
+		},
+		{name: "Missing profile", profile: nil, other: &Profile{}, expectNilGap: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var signals FriendRecommendationSignals
+			signals.CompareProfiles(tt.profile, tt.other)
+			assert.Equal(t, tt.expectSame, signals.SameActivityLevel)
+			assert.Equal(t, tt.expectClose, signals.IsCloseAge())
+			assert.Equal(t, tt.expectNilGap, signals.AgeGap == nil)
+		})
+	}
+}
+
+func TestFriendRecommendationSignals_Reasons(t *testing.T) {
+	signals := FriendRecommendationSignals{
+		MutualFriends:    3,
+		SharedGroups:     1,
+		SharedCategories: []string{"胸", "背中", "脚", "肩"},
+	}
+	assert.Equal(t, []string{
+		"共通の友達が3人います",
+		"1個のグループに一緒に参加しています",
+		"胸・背中・脚のトレーニングをしています",
+	}, signals.Reasons())
+}
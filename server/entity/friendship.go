@@ -2,6 +2,7 @@ package entity
 
 import (
 	"fmt"
+	"time"
 
 	"app/graph/model"
 
@@ -16,11 +17,21 @@ const (
 	Rejected FriendshipStatus = "rejected"
 )
 
+// FriendshipRequestCooldown は友達リクエストを拒否されたユーザーが再送できるようになるまでの期間
+const FriendshipRequestCooldown = 7 * 24 * time.Hour
+
+// FriendshipPairIndex は2人のユーザーの組（申請の向きを問わない）の友達関係を1件に制限するユニークインデックス
+// 式インデックスのためマイグレーションで作成する
+const FriendshipPairIndex = "idx_friendships_pair"
+
+// Friendship は2人のユーザーの友達関係（リクエスト中・拒否済みを含む）
+// 同じ2人の間の友達関係は申請の向きに関わらず1件のみ（FriendshipPairIndex）
 type Friendship struct {
 	gorm.Model
 	RequesterID uint `gorm:"not null;index:idx_requester_requestee"`
 	RequesteeID uint `gorm:"not null;index:idx_requester_requestee"`
 	Status      string
+	RejectedAt  *time.Time // 拒否された日時（再送の制限に使う）
 
 	Requester User `gorm:"constraint:OnDelete:CASCADE;foreignKey:RequesterID"`
 	Requestee User `gorm:"constraint:OnDelete:CASCADE;foreignKey:RequesteeID"`
@@ -76,6 +87,72 @@ func (f *Friendship) GetValidStatuses() []string {
 	}
 }
 
+// OtherUserID は友達関係の相手のユーザーIDを返す
+func (f *Friendship) OtherUserID(userID uint) uint {
+	if f.RequesterID == userID {
+		return f.RequesteeID
+	}
+	return f.RequesterID
+}
+
+// Accept は友達リクエストを承認する
+func (f *Friendship) Accept() error {
+	if f.Status != string(Pending) {
+		return fmt.Errorf("保留中の友達リクエストではありません")
+	}
+	f.Status = string(Accepted)
+	return nil
+}
+
+// Reject は友達リクエストを拒否する
+func (f *Friendship) Reject(now time.Time) error {
+	if f.Status != string(Pending) {
+		return fmt.Errorf("保留中の友達リクエストではありません")
+	}
+	f.Status = string(Rejected)
+	f.RejectedAt = &now
+	return nil
+}
+
+// Resend は拒否された友達リクエストを送り直す
+// 拒否されたユーザーはFriendshipRequestCooldownが経過するまで送り直せない（拒否したユーザーからはすぐに送れる）
+func (f *Friendship) Resend(requesterID uint, now time.Time) error {
+	if requesterID == f.RequesterID {
+		if availableAt := f.ResendAvailableAt(); availableAt != nil && now.Before(*availableAt) {
+			return fmt.Errorf("友達リクエストは%s以降に送り直せます", availableAt.Format(time.RFC3339))
+		}
+	}
+
+	return f.Reopen(requesterID)
+}
+
+// Reopen は拒否された友達リクエストをrequesterIDからのリクエストとして保留中に戻す
+// QRコードのように相手の同意がある場合はFriendshipRequestCooldownを待たずに使える
+func (f *Friendship) Reopen(requesterID uint) error {
+	if f.Status != string(Rejected) {
+		return fmt.Errorf("拒否された友達リクエストではありません")
+	}
+	if requesterID != f.RequesterID && requesterID != f.RequesteeID {
+		return fmt.Errorf("友達リクエストの当事者ではありません")
+	}
+
+	if requesterID != f.RequesterID {
+		f.RequesterID, f.RequesteeID = f.RequesteeID, f.RequesterID
+	}
+	f.Status = string(Pending)
+	f.RejectedAt = nil
+	return nil
+}
+
+// ResendAvailableAt は拒否された友達リクエストを申請者が送り直せるようになる日時を返す
+func (f *Friendship) ResendAvailableAt() *time.Time {
+	if f.Status != string(Rejected) || f.RejectedAt == nil {
+		return nil
+	}
+	availableAt := f.RejectedAt.Add(FriendshipRequestCooldown)
+	return &availableAt
+}
+
 func (f *Friendship) StatusToGraphQL() *model.FriendshipStatus {
 	switch f.Status {
 	case string(Pending):
@@ -2,6 +2,7 @@ package entity
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -428,6 +429,56 @@ func TestFriendship_StatusTransitionsWithValidation(t *testing.T) {
 	})
 }
 
+func TestFriendship_Resend(t *testing.T) {
+	rejectedAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		requesterID   uint
+		now           time.Time
+		expectError   bool
+		wantRequester uint
+	}{
+		{name: "Rejected requester within cooldown", requesterID: 1, now: rejectedAt.Add(FriendshipRequestCooldown - time.Minute), expectError: true},
+		{name: "Rejected requester after cooldown", requesterID: 1, now: rejectedAt.Add(FriendshipRequestCooldown), wantRequester: 1},
+		{name: "User who rejected can send immediately", requesterID: 2, now: rejectedAt, wantRequester: 2},
+		{name: "Unrelated user", requesterID: 3, now: rejectedAt.Add(FriendshipRequestCooldown), expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			friendship := &Friendship{RequesterID: 1, RequesteeID: 2, Status: string(Pending)}
+			assert.NoError(t, friendship.Reject(rejectedAt))
+
+			err := friendship.Resend(tt.requesterID, tt.now)
+			if tt.expectError {
+				assert.Error(t, err)
+				assert.Equal(t, string(Rejected), friendship.Status)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, string(Pending), friendship.Status)
+			assert.Equal(t, tt.wantRequester, friendship.RequesterID)
+			assert.Nil(t, friendship.RejectedAt)
+		})
+	}
+}
+
+func TestFriendship_Accept(t *testing.T) {
+	friendship := &Friendship{RequesterID: 1, RequesteeID: 2, Status: string(Pending)}
+	assert.NoError(t, friendship.Accept())
+	assert.Equal(t, string(Accepted), friendship.Status)
+
+	// 承認済み・拒否済みのリクエストは承認できない
+	assert.Error(t, friendship.Accept())
+	assert.Error(t, (&Friendship{RequesterID: 1, RequesteeID: 2, Status: string(Rejected)}).Accept())
+}
+
+func TestFriendship_RejectOnlyPending(t *testing.T) {
+	friendship := &Friendship{RequesterID: 1, RequesteeID: 2, Status: string(Accepted)}
+	assert.Error(t, friendship.Reject(time.Now()))
+}
+
 // Benchmark tests for performance
 func BenchmarkFriendship_Validate(b *testing.B) {
 	friendship := &Friendship{
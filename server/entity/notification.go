@@ -0,0 +1,126 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"app/graph/model"
+
+	"gorm.io/gorm"
+)
+
+type NotificationType string
+
+const (
+	NotificationFriendRequestReceived NotificationType = "friend_request_received" // 友達リクエストを受け取った
+	NotificationFriendRequestAccepted NotificationType = "friend_request_accepted" // 送った友達リクエストが承認された
+	NotificationWorkoutGroupInvited   NotificationType = "workout_group_invited"   // ワークアウトグループに招待された
+	NotificationFriendPersonalRecord  NotificationType = "friend_personal_record"  // 友達が自己ベストを更新した
+)
+
+var notificationTypesToGraphQL = map[NotificationType]model.NotificationType{
+	NotificationFriendRequestReceived: model.NotificationTypeFriendRequestReceived,
+	NotificationFriendRequestAccepted: model.NotificationTypeFriendRequestAccepted,
+	NotificationWorkoutGroupInvited:   model.NotificationTypeWorkoutGroupInvited,
+	NotificationFriendPersonalRecord:  model.NotificationTypeFriendPersonalRecord,
+}
+
+// Notification はユーザーへのアプリ内通知
+// 通知の内容は種類ごとに参照先（友達関係・グループ・自己ベスト）を持ち、表示時に取得する
+type Notification struct {
+	gorm.Model
+	UserID           uint             `gorm:"not null;index:idx_notifications_user_read"` // 通知を受け取るユーザー
+	ActorID          *uint            // 通知のきっかけになったユーザー
+	Type             NotificationType `gorm:"size:50;not null"`
+	FriendshipID     *uint
+	WorkoutGroupID   *uint
+	PersonalRecordID *uint
+	ReadAt           *time.Time `gorm:"index:idx_notifications_user_read"`
+
+	User           User            `gorm:"constraint:OnDelete:CASCADE;foreignKey:UserID"`
+	Actor          *User           `gorm:"constraint:OnDelete:CASCADE;foreignKey:ActorID"`
+	Friendship     *Friendship     `gorm:"constraint:OnDelete:CASCADE;foreignKey:FriendshipID"`
+	WorkoutGroup   *WorkoutGroup   `gorm:"constraint:OnDelete:CASCADE;foreignKey:WorkoutGroupID"`
+	PersonalRecord *PersonalRecord `gorm:"constraint:OnDelete:SET NULL;foreignKey:PersonalRecordID"`
+}
+
+func (n *Notification) BeforeSave(tx *gorm.DB) error {
+	return n.Validate()
+}
+
+func (n *Notification) Validate() error {
+	if n.UserID == 0 {
+		return fmt.Errorf("ユーザーIDは必須です")
+	}
+	if _, ok := notificationTypesToGraphQL[n.Type]; !ok {
+		return fmt.Errorf("無効な通知の種類です: %s", n.Type)
+	}
+	if n.ActorID != nil && *n.ActorID == n.UserID {
+		return fmt.Errorf("自分の操作は通知できません")
+	}
+
+	switch n.Type {
+	case NotificationFriendRequestReceived, NotificationFriendRequestAccepted:
+		if n.FriendshipID == nil {
+			return fmt.Errorf("友達関係のIDは必須です")
+		}
+	case NotificationWorkoutGroupInvited:
+		if n.WorkoutGroupID == nil {
+			return fmt.Errorf("グループIDは必須です")
+		}
+	}
+	return nil
+}
+
+// IsRead は既読かどうかを返す
+func (n *Notification) IsRead() bool {
+	return n.ReadAt != nil
+}
+
+func (n *Notification) TypeToGraphQL() model.NotificationType {
+	return notificationTypesToGraphQL[n.Type]
+}
+
+// NewFriendRequestReceivedNotification は友達リクエストを受け取ったユーザーへの通知を作成する
+func NewFriendRequestReceivedNotification(friendship Friendship) Notification {
+	return Notification{
+		UserID:       friendship.RequesteeID,
+		ActorID:      &friendship.RequesterID,
+		Type:         NotificationFriendRequestReceived,
+		FriendshipID: &friendship.ID,
+	}
+}
+
+// NewFriendRequestAcceptedNotification は友達リクエストを送ったユーザーへの承認の通知を作成する
+func NewFriendRequestAcceptedNotification(friendship Friendship) Notification {
+	return Notification{
+		UserID:       friendship.RequesterID,
+		ActorID:      &friendship.RequesteeID,
+		Type:         NotificationFriendRequestAccepted,
+		FriendshipID: &friendship.ID,
+	}
+}
+
+// NewWorkoutGroupInvitedNotification はグループに招待されたユーザーへの通知を作成する
+func NewWorkoutGroupInvitedNotification(member WorkoutGroupMember) Notification {
+	return Notification{
+		UserID:         member.UserID,
+		ActorID:        member.InvitedByID,
+		Type:           NotificationWorkoutGroupInvited,
+		WorkoutGroupID: &member.WorkoutGroupID,
+	}
+}
+
+// NewFriendPersonalRecordNotifications は自己ベストの更新を友達に知らせる通知を作成する
+func NewFriendPersonalRecordNotifications(record PersonalRecord, friendIDs []uint) []Notification {
+	notifications := make([]Notification, 0, len(friendIDs))
+	for _, friendID := range friendIDs {
+		notifications = append(notifications, Notification{
+			UserID:           friendID,
+			ActorID:          &record.UserID,
+			Type:             NotificationFriendPersonalRecord,
+			PersonalRecordID: &record.ID,
+		})
+	}
+	return notifications
+}
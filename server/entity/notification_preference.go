@@ -0,0 +1,133 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// DefaultNotificationTimeZone はおやすみ時間の判定に使うデフォルトのタイムゾーン
+const DefaultNotificationTimeZone = "Asia/Tokyo"
+
+// NotificationPreference はユーザーごとのプッシュ通知の設定
+// 設定が保存されていないユーザーはDefaultNotificationPreferenceの設定（全て受け取る）として扱う
+type NotificationPreference struct {
+	gorm.Model
+	UserID                uint   `gorm:"not null;uniqueIndex"`
+	PushEnabled           bool   `gorm:"not null"` // falseの場合は全てのプッシュ通知を送らない
+	FriendRequestReceived bool   `gorm:"not null"`
+	FriendRequestAccepted bool   `gorm:"not null"`
+	WorkoutGroupInvited   bool   `gorm:"not null"`
+	QuietHoursStart       *int   // おやすみ時間の開始（0時からの分）
+	QuietHoursEnd         *int   // おやすみ時間の終了（0時からの分、開始より前の場合は翌日）
+	TimeZone              string `gorm:"size:64;not null"`
+
+	User User `gorm:"constraint:OnDelete:CASCADE;foreignKey:UserID"`
+}
+
+// DefaultNotificationPreference は全てのプッシュ通知を受け取る設定を作成する
+func DefaultNotificationPreference(userID uint) NotificationPreference {
+	return NotificationPreference{
+		UserID:                userID,
+		PushEnabled:           true,
+		FriendRequestReceived: true,
+		FriendRequestAccepted: true,
+		WorkoutGroupInvited:   true,
+		TimeZone:              DefaultNotificationTimeZone,
+	}
+}
+
+func (p *NotificationPreference) BeforeSave(tx *gorm.DB) error {
+	return p.Validate()
+}
+
+func (p *NotificationPreference) Validate() error {
+	if p.UserID == 0 {
+		return fmt.Errorf("ユーザーIDは必須です")
+	}
+	if (p.QuietHoursStart == nil) != (p.QuietHoursEnd == nil) {
+		return fmt.Errorf("おやすみ時間は開始と終了の両方を指定してください")
+	}
+	if p.QuietHoursStart != nil {
+		if !isValidMinuteOfDay(*p.QuietHoursStart) || !isValidMinuteOfDay(*p.QuietHoursEnd) {
+			return fmt.Errorf("おやすみ時間は00:00から23:59の間で指定してください")
+		}
+		if *p.QuietHoursStart == *p.QuietHoursEnd {
+			return fmt.Errorf("おやすみ時間の開始と終了は異なる時刻を指定してください")
+		}
+	}
+	if _, err := time.LoadLocation(p.TimeZone); err != nil || p.TimeZone == "" {
+		return fmt.Errorf("無効なタイムゾーンです: %s", p.TimeZone)
+	}
+	return nil
+}
+
+// AllowsPush は指定した種類の通知をプッシュ通知で送るかどうか
+// 自己ベストの通知はアプリ内のみでプッシュ通知は送らない
+func (p *NotificationPreference) AllowsPush(notificationType NotificationType) bool {
+	if !p.PushEnabled {
+		return false
+	}
+
+	switch notificationType {
+	case NotificationFriendRequestReceived:
+		return p.FriendRequestReceived
+	case NotificationFriendRequestAccepted:
+		return p.FriendRequestAccepted
+	case NotificationWorkoutGroupInvited:
+		return p.WorkoutGroupInvited
+	default:
+		return false
+	}
+}
+
+// QuietHoursEndAfter はnowがおやすみ時間中の場合に、おやすみ時間が終わる日時を返す
+func (p *NotificationPreference) QuietHoursEndAfter(now time.Time) (time.Time, bool) {
+	if p.QuietHoursStart == nil || p.QuietHoursEnd == nil {
+		return time.Time{}, false
+	}
+
+	location, err := time.LoadLocation(p.TimeZone)
+	if err != nil {
+		location = time.UTC
+	}
+	local := now.In(location)
+	minute := local.Hour()*60 + local.Minute()
+	start, end := *p.QuietHoursStart, *p.QuietHoursEnd
+
+	var inQuietHours bool
+	if start < end {
+		inQuietHours = minute >= start && minute < end
+	} else {
+		// 22:00〜07:00のように日付をまたぐ場合
+		inQuietHours = minute >= start || minute < end
+	}
+	if !inQuietHours {
+		return time.Time{}, false
+	}
+
+	endAt := time.Date(local.Year(), local.Month(), local.Day(), end/60, end%60, 0, 0, location)
+	if !endAt.After(local) {
+		endAt = endAt.AddDate(0, 0, 1)
+	}
+	return endAt, true
+}
+
+// ParseMinuteOfDay は"HH:MM"形式の時刻を0時からの分に変換する
+func ParseMinuteOfDay(value string) (int, error) {
+	parsed, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("時刻はHH:MM形式で指定してください: %s", value)
+	}
+	return parsed.Hour()*60 + parsed.Minute(), nil
+}
+
+// FormatMinuteOfDay は0時からの分を"HH:MM"形式に変換する
+func FormatMinuteOfDay(minute int) string {
+	return fmt.Sprintf("%02d:%02d", minute/60, minute%60)
+}
+
+func isValidMinuteOfDay(minute int) bool {
+	return minute >= 0 && minute < 24*60
+}
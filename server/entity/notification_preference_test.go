@@ -0,0 +1,115 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationPreference_Validate(t *testing.T) {
+	start, end := 22*60, 7*60
+	invalid := 24 * 60
+
+	tests := []struct {
+		name        string
+		modify      func(p *NotificationPreference)
+		expectError bool
+	}{
+		{name: "Default", modify: func(p *NotificationPreference) {}, expectError: false},
+		{name: "Quiet hours across midnight", modify: func(p *NotificationPreference) { p.QuietHoursStart, p.QuietHoursEnd = &start, &end }, expectError: false},
+		{name: "Only start of quiet hours", modify: func(p *NotificationPreference) { p.QuietHoursStart = &start }, expectError: true},
+		{name: "Quiet hours out of range", modify: func(p *NotificationPreference) { p.QuietHoursStart, p.QuietHoursEnd = &start, &invalid }, expectError: true},
+		{name: "Same start and end", modify: func(p *NotificationPreference) { p.QuietHoursStart, p.QuietHoursEnd = &start, &start }, expectError: true},
+		{name: "Unknown time zone", modify: func(p *NotificationPreference) { p.TimeZone = "Mars/Olympus" }, expectError: true},
+		{name: "Empty time zone", modify: func(p *NotificationPreference) { p.TimeZone = "" }, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			preference := DefaultNotificationPreference(1)
+			tt.modify(&preference)
+			err := preference.Validate()
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNotificationPreference_AllowsPush(t *testing.T) {
+	preference := DefaultNotificationPreference(1)
+	assert.True(t, preference.AllowsPush(NotificationFriendRequestReceived))
+	assert.False(t, preference.AllowsPush(NotificationFriendPersonalRecord))
+
+	preference.WorkoutGroupInvited = false
+	assert.False(t, preference.AllowsPush(NotificationWorkoutGroupInvited))
+	assert.True(t, preference.AllowsPush(NotificationFriendRequestAccepted))
+
+	preference.PushEnabled = false
+	assert.False(t, preference.AllowsPush(NotificationFriendRequestAccepted))
+}
+
+func TestNotificationPreference_QuietHoursEndAfter(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		start, end int
+		now        time.Time
+		expectEnd  time.Time
+		expectIn   bool
+	}{
+		{
+			name: "Before midnight", start: 22 * 60, end: 7 * 60,
+			now:       time.Date(2026, 10, 18, 23, 30, 0, 0, tokyo),
+			expectEnd: time.Date(2026, 10, 19, 7, 0, 0, 0, tokyo), expectIn: true,
+		},
+		{
+			name: "After midnight", start: 22 * 60, end: 7 * 60,
+			now:       time.Date(2026, 10, 19, 6, 59, 0, 0, tokyo),
+			expectEnd: time.Date(2026, 10, 19, 7, 0, 0, 0, tokyo), expectIn: true,
+		},
+		{
+			name: "Outside quiet hours", start: 22 * 60, end: 7 * 60,
+			now: time.Date(2026, 10, 19, 7, 0, 0, 0, tokyo), expectIn: false,
+		},
+		{
+			name: "Same day range", start: 13 * 60, end: 14 * 60,
+			now:       time.Date(2026, 10, 18, 13, 15, 0, 0, tokyo),
+			expectEnd: time.Date(2026, 10, 18, 14, 0, 0, 0, tokyo), expectIn: true,
+		},
+		{
+			name: "Converted from UTC", start: 22 * 60, end: 7 * 60,
+			now:       time.Date(2026, 10, 18, 14, 0, 0, 0, time.UTC),
+			expectEnd: time.Date(2026, 10, 19, 7, 0, 0, 0, tokyo), expectIn: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			preference := DefaultNotificationPreference(1)
+			preference.QuietHoursStart, preference.QuietHoursEnd = &tt.start, &tt.end
+
+			endAt, in := preference.QuietHoursEndAfter(tt.now)
+			assert.Equal(t, tt.expectIn, in)
+			if tt.expectIn {
+				assert.True(t, tt.expectEnd.Equal(endAt), "expected %s, got %s", tt.expectEnd, endAt)
+			}
+		})
+	}
+}
+
+func TestParseMinuteOfDay(t *testing.T) {
+	minute, err := ParseMinuteOfDay("22:30")
+	require.NoError(t, err)
+	assert.Equal(t, 22*60+30, minute)
+	assert.Equal(t, "22:30", FormatMinuteOfDay(minute))
+
+	_, err = ParseMinuteOfDay("25:00")
+	assert.Error(t, err)
+}
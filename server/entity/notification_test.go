@@ -0,0 +1,92 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotification_Validate(t *testing.T) {
+	actorID := uint(2)
+	friendshipID := uint(10)
+
+	tests := []struct {
+		name         string
+		notification Notification
+		expectError  bool
+	}{
+		{
+			name:         "Valid friend request notification",
+			notification: Notification{UserID: 1, ActorID: &actorID, Type: NotificationFriendRequestReceived, FriendshipID: &friendshipID},
+			expectError:  false,
+		},
+		{
+			name:         "Missing user",
+			notification: Notification{ActorID: &actorID, Type: NotificationFriendRequestReceived, FriendshipID: &friendshipID},
+			expectError:  true,
+		},
+		{
+			name:         "Unknown type",
+			notification: Notification{UserID: 1, Type: NotificationType("unknown")},
+			expectError:  true,
+		},
+		{
+			name:         "Friend request without friendship",
+			notification: Notification{UserID: 1, ActorID: &actorID, Type: NotificationFriendRequestAccepted},
+			expectError:  true,
+		},
+		{
+			name:         "Group invitation without group",
+			notification: Notification{UserID: 1, ActorID: &actorID, Type: NotificationWorkoutGroupInvited},
+			expectError:  true,
+		},
+		{
+			name:         "Actor is the recipient",
+			notification: Notification{UserID: 2, ActorID: &actorID, Type: NotificationFriendRequestReceived, FriendshipID: &friendshipID},
+			expectError:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.notification.Validate()
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNewFriendshipNotifications(t *testing.T) {
+	friendship := Friendship{RequesterID: 1, RequesteeID: 2}
+	friendship.ID = 10
+
+	received := NewFriendRequestReceivedNotification(friendship)
+	assert.Equal(t, uint(2), received.UserID)
+	assert.Equal(t, uint(1), *received.ActorID)
+	assert.Equal(t, uint(10), *received.FriendshipID)
+	assert.NoError(t, received.Validate())
+
+	accepted := NewFriendRequestAcceptedNotification(friendship)
+	assert.Equal(t, uint(1), accepted.UserID)
+	assert.Equal(t, uint(2), *accepted.ActorID)
+	assert.NoError(t, accepted.Validate())
+}
+
+func TestNewFriendPersonalRecordNotifications(t *testing.T) {
+	record := PersonalRecord{UserID: 1}
+	record.ID = 5
+
+	notifications := NewFriendPersonalRecordNotifications(record, []uint{2, 3})
+
+	assert.Len(t, notifications, 2)
+	for i, friendID := range []uint{2, 3} {
+		assert.Equal(t, friendID, notifications[i].UserID)
+		assert.Equal(t, uint(1), *notifications[i].ActorID)
+		assert.Equal(t, uint(5), *notifications[i].PersonalRecordID)
+		assert.False(t, notifications[i].IsRead())
+	}
+	assert.Empty(t, NewFriendPersonalRecordNotifications(record, nil))
+}
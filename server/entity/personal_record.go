@@ -0,0 +1,298 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"app/graph/model"
+
+	"gorm.io/gorm"
+)
+
+type PersonalRecordType string
+
+const (
+	HeaviestWeight     PersonalRecordType = "heaviest_weight"       // 最高重量
+	MostReps           PersonalRecordType = "most_reps"             // 同重量での最多レップ
+	EstimatedOneRepMax PersonalRecordType = "estimated_one_rep_max" // 推定1RM
+	SessionVolume      PersonalRecordType = "session_volume"        // 1ワークアウトでの最大ボリューム
+)
+
+// PersonalRecord はユーザー・種目ごとの自己ベスト（更新履歴を含む）
+type PersonalRecord struct {
+	gorm.Model
+	UserID     uint               `gorm:"not null;index:idx_personal_records_user_exercise"`
+	ExerciseID uint               `gorm:"not null;index:idx_personal_records_user_exercise"`
+	WorkoutID  uint               `gorm:"not null"`
+	SetLogID   *uint              // セッションボリュームの場合はnil
+	Type       PersonalRecordType `gorm:"size:50;not null"`
+	Value      float64            `gorm:"not null"` // 重量・推定1RM・ボリュームはkg、最多レップは回数
+	Weight     float64            // 記録時の重量（kg）
+	RepCount   int                // 記録時のレップ数
+	IsCurrent  bool               `gorm:"not null;index"` // 現在の自己ベストかどうか
+	AchievedAt time.Time          `gorm:"not null"`
+
+	User     User     `gorm:"constraint:OnDelete:CASCADE;foreignKey:UserID"`
+	Exercise Exercise `gorm:"constraint:OnDelete:CASCADE;foreignKey:ExerciseID"`
+}
+
+func (p *PersonalRecord) BeforeSave(tx *gorm.DB) error {
+	return p.Validate()
+}
+
+func (p *PersonalRecord) BeforeCreate(tx *gorm.DB) error {
+	return p.Validate()
+}
+
+func (p *PersonalRecord) BeforeUpdate(tx *gorm.DB) error {
+	return p.Validate()
+}
+
+func (p *PersonalRecord) Validate() error {
+	if p.UserID == 0 {
+		return fmt.Errorf("user_id は必須です")
+	}
+	if p.ExerciseID == 0 {
+		return fmt.Errorf("exercise_id は必須です")
+	}
+	if p.WorkoutID == 0 {
+		return fmt.Errorf("workout_id は必須です")
+	}
+	if !p.IsValidType() {
+		return fmt.Errorf("無効な記録種別です: %s", p.Type)
+	}
+	if p.Value <= 0 {
+		return fmt.Errorf("記録値は正の数である必要があります")
+	}
+	return nil
+}
+
+func (p *PersonalRecord) IsValidType() bool {
+	switch p.Type {
+	case HeaviestWeight, MostReps, EstimatedOneRepMax, SessionVolume:
+		return true
+	default:
+		return false
+	}
+}
+
+// TypeToGraphQL GraphQL enumに変換
+func (p *PersonalRecord) TypeToGraphQL() model.PersonalRecordType {
+	switch p.Type {
+	case MostReps:
+		return model.PersonalRecordTypeMostReps
+	case EstimatedOneRepMax:
+		return model.PersonalRecordTypeEstimatedOneRepMax
+	case SessionVolume:
+		return model.PersonalRecordTypeSessionVolume
+	default:
+		return model.PersonalRecordTypeHeaviestWeight
+	}
+}
+
+type OneRepMaxFormula string
+
+const (
+	Epley   OneRepMaxFormula = "epley"   // weight × (1 + reps / 30)
+	Brzycki OneRepMaxFormula = "brzycki" // weight × 36 / (37 - reps)
+)
+
+// OneRepMaxFormulaFromGraphQL GraphQL enumから変換
+func OneRepMaxFormulaFromGraphQL(formula model.OneRepMaxFormula) OneRepMaxFormula {
+	if formula == model.OneRepMaxFormulaBrzycki {
+		return Brzycki
+	}
+	return Epley
+}
+
+// EstimateOneRepMax はEpley式で推定1RMを計算する（1レップの場合は重量そのもの）
+func EstimateOneRepMax(weight float64, repCount int) float64 {
+	if repCount <= 0 {
+		return 0
+	}
+	if repCount == 1 {
+		return weight
+	}
+	return roundTo(weight*(1+float64(repCount)/30), 2)
+}
+
+// PersonalRecordSet は自己ベスト判定に使うセットの情報
+type PersonalRecordSet struct {
+	SetLogID      uint
+	WorkoutID     uint
+	Weight        float64   // kg
+	RepCount      int       // レップ数
+	SessionVolume float64   // このセットを含む、同ワークアウト内の同種目の総ボリューム（kg）
+	AchievedAt    time.Time // セットの記録日時
+}
+
+// PersonalRecordTracker はユーザー・種目ごとの現在の自己ベストを保持し、セットごとに更新を判定する
+type PersonalRecordTracker struct {
+	userID     uint
+	exerciseID uint
+	current    map[string]*PersonalRecord
+}
+
+// NewPersonalRecordTracker は現在の自己ベストからトラッカーを作成する
+func NewPersonalRecordTracker(userID, exerciseID uint, currentRecords []*PersonalRecord) *PersonalRecordTracker {
+	tracker := &PersonalRecordTracker{
+		userID:     userID,
+		exerciseID: exerciseID,
+		current:    make(map[string]*PersonalRecord),
+	}
+	for _, record := range currentRecords {
+		if record != nil && record.IsCurrent {
+			tracker.current[record.key()] = record
+		}
+	}
+	return tracker
+}
+
+// Apply はセットを評価し、新たに作成する記録と更新が必要な既存の記録を返す
+func (t *PersonalRecordTracker) Apply(set PersonalRecordSet) (created []*PersonalRecord, updated []*PersonalRecord) {
+	candidates := []*PersonalRecord{
+		t.newRecord(set, HeaviestWeight, set.Weight),
+		t.newRecord(set, MostReps, float64(set.RepCount)),
+		t.newRecord(set, EstimatedOneRepMax, EstimateOneRepMax(set.Weight, set.RepCount)),
+	}
+
+	for _, candidate := range candidates {
+		previous := t.current[candidate.key()]
+		if previous != nil && candidate.Value <= previous.Value {
+			continue
+		}
+		if previous != nil {
+			previous.IsCurrent = false
+			updated = append(updated, previous)
+		}
+		t.current[candidate.key()] = candidate
+		created = append(created, candidate)
+	}
+
+	// セッションボリュームは同じワークアウト内であれば既存の記録を更新する
+	volume := t.newRecord(set, SessionVolume, set.SessionVolume)
+	volume.SetLogID = nil
+	previous := t.current[volume.key()]
+	switch {
+	case previous != nil && volume.Value <= previous.Value:
+	case previous != nil && previous.WorkoutID == set.WorkoutID:
+		previous.Value = volume.Value
+		previous.AchievedAt = set.AchievedAt
+		updated = append(updated, previous)
+	default:
+		if previous != nil {
+			previous.IsCurrent = false
+			updated = append(updated, previous)
+		}
+		t.current[volume.key()] = volume
+		created = append(created, volume)
+	}
+
+	return created, updated
+}
+
+func (t *PersonalRecordTracker) newRecord(set PersonalRecordSet, recordType PersonalRecordType, value float64) *PersonalRecord {
+	setLogID := set.SetLogID
+	return &PersonalRecord{
+		UserID:     t.userID,
+		ExerciseID: t.exerciseID,
+		WorkoutID:  set.WorkoutID,
+		SetLogID:   &setLogID,
+		Type:       recordType,
+		Value:      value,
+		Weight:     set.Weight,
+		RepCount:   set.RepCount,
+		IsCurrent:  true,
+		AchievedAt: set.AchievedAt,
+	}
+}
+
+// ReplayPersonalRecords は完了済みのセットを記録順に評価し直した自己ベスト（履歴を含む）を返す
+// セッションボリュームは同じワークアウトのそれまでのセットから累計する
+func ReplayPersonalRecords(userID, exerciseID uint, sets []PersonalRecordSet) []*PersonalRecord {
+	tracker := NewPersonalRecordTracker(userID, exerciseID, nil)
+	sessionVolumes := make(map[uint]float64)
+	var records []*PersonalRecord
+	for _, set := range sets {
+		sessionVolumes[set.WorkoutID] += set.Weight * float64(set.RepCount)
+		set.SessionVolume = sessionVolumes[set.WorkoutID]
+
+		// 未保存の記録はその場で更新されるため、新規作成分のみ保持すればよい
+		created, _ := tracker.Apply(set)
+		records = append(records, created...)
+	}
+	return records
+}
+
+// HeadlinePersonalRecord は友達に通知する自己ベスト（最高重量、なければ推定1RM）を返す
+// 最多レップは重量ごとに記録されるため、初めて扱う重量のセットでも更新されてしまうので通知しない
+func HeadlinePersonalRecord(records []*PersonalRecord) *PersonalRecord {
+	for _, recordType := range []PersonalRecordType{HeaviestWeight, EstimatedOneRepMax} {
+		for _, record := range records {
+			if record.Type == recordType {
+				return record
+			}
+		}
+	}
+	return nil
+}
+
+// SyncPersonalRecords は再計算した記録（履歴を含む）を既存の記録に対応づけ、作成・更新・削除する記録を返す
+// 同じセット（セッションボリュームは同じワークアウト）で達成した記録は既存の行を更新し、IDと通知からの参照を維持する
+func SyncPersonalRecords(existing, rebuilt []*PersonalRecord) (created, updated, deleted []*PersonalRecord) {
+	remaining := make(map[string][]*PersonalRecord)
+	for _, record := range existing {
+		remaining[record.identity()] = append(remaining[record.identity()], record)
+	}
+
+	matched := make(map[*PersonalRecord]bool)
+	for _, record := range rebuilt {
+		candidates := remaining[record.identity()]
+		if len(candidates) == 0 {
+			created = append(created, record)
+			continue
+		}
+		previous := candidates[0]
+		remaining[record.identity()] = candidates[1:]
+		matched[previous] = true
+
+		if previous.WorkoutID == record.WorkoutID &&
+			previous.Value == record.Value &&
+			previous.Weight == record.Weight &&
+			previous.RepCount == record.RepCount &&
+			previous.IsCurrent == record.IsCurrent &&
+			previous.AchievedAt.Equal(record.AchievedAt) {
+			continue
+		}
+		previous.WorkoutID = record.WorkoutID
+		previous.Value = record.Value
+		previous.Weight = record.Weight
+		previous.RepCount = record.RepCount
+		previous.IsCurrent = record.IsCurrent
+		previous.AchievedAt = record.AchievedAt
+		updated = append(updated, previous)
+	}
+
+	for _, record := range existing {
+		if !matched[record] {
+			deleted = append(deleted, record)
+		}
+	}
+	return created, updated, deleted
+}
+
+// identity は再計算しても変わらない記録の識別子（種別と、達成したセットまたはワークアウト）
+func (p *PersonalRecord) identity() string {
+	if p.SetLogID == nil {
+		return fmt.Sprintf("%s:workout:%d", p.key(), p.WorkoutID)
+	}
+	return fmt.Sprintf("%s:set:%d", p.key(), *p.SetLogID)
+}
+
+// key は現在の自己ベストを識別するキー（最多レップは重量ごと）
+func (p *PersonalRecord) key() string {
+	if p.Type == MostReps {
+		return fmt.Sprintf("%s:%.3f", p.Type, p.Weight)
+	}
+	return string(p.Type)
+}
@@ -0,0 +1,259 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimateOneRepMax(t *testing.T) {
+	tests := []struct {
+		name     string
+		weight   float64
+		repCount int
+		expected float64
+	}{
+		{
+			name:     "Single rep returns the weight",
+			weight:   100,
+			repCount: 1,
+			expected: 100,
+		},
+		{
+			name:     "Epley formula for multiple reps",
+			weight:   100,
+			repCount: 5,
+			expected: 116.67,
+		},
+		{
+			name:     "Zero reps",
+			weight:   100,
+			repCount: 0,
+			expected: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, EstimateOneRepMax(tt.weight, tt.repCount))
+		})
+	}
+}
+
+func TestPersonalRecordTracker_Apply(t *testing.T) {
+	now := time.Now()
+
+	t.Run("First set creates every record", func(t *testing.T) {
+		tracker := NewPersonalRecordTracker(1, 2, nil)
+		created, updated := tracker.Apply(PersonalRecordSet{SetLogID: 1, WorkoutID: 1, Weight: 60, RepCount: 10, SessionVolume: 600, AchievedAt: now})
+
+		assert.Len(t, created, 4)
+		assert.Empty(t, updated)
+		for _, record := range created {
+			assert.True(t, record.IsCurrent)
+			assert.NoError(t, record.Validate())
+		}
+	})
+
+	t.Run("Heavier set supersedes the previous record", func(t *testing.T) {
+		tracker := NewPersonalRecordTracker(1, 2, nil)
+		first, _ := tracker.Apply(PersonalRecordSet{SetLogID: 1, WorkoutID: 1, Weight: 60, RepCount: 10, SessionVolume: 600, AchievedAt: now})
+		created, updated := tracker.Apply(PersonalRecordSet{SetLogID: 2, WorkoutID: 2, Weight: 80, RepCount: 3, SessionVolume: 240, AchievedAt: now})
+
+		types := make([]PersonalRecordType, 0, len(created))
+		for _, record := range created {
+			types = append(types, record.Type)
+		}
+		// 重量・推定1RM・80kgでの最多レップが更新され、ボリュームは更新されない
+		assert.ElementsMatch(t, []PersonalRecordType{HeaviestWeight, MostReps, EstimatedOneRepMax}, types)
+		assert.Len(t, updated, 2)
+		assert.False(t, first[0].IsCurrent)
+	})
+
+	t.Run("Fewer reps at the same weight is not a record", func(t *testing.T) {
+		tracker := NewPersonalRecordTracker(1, 2, nil)
+		tracker.Apply(PersonalRecordSet{SetLogID: 1, WorkoutID: 1, Weight: 60, RepCount: 10, SessionVolume: 600, AchievedAt: now})
+		created, _ := tracker.Apply(PersonalRecordSet{SetLogID: 2, WorkoutID: 1, Weight: 60, RepCount: 8, SessionVolume: 1080, AchievedAt: now})
+
+		for _, record := range created {
+			assert.NotEqual(t, MostReps, record.Type)
+		}
+	})
+
+	t.Run("Session volume is updated within the same workout", func(t *testing.T) {
+		tracker := NewPersonalRecordTracker(1, 2, nil)
+		first, _ := tracker.Apply(PersonalRecordSet{SetLogID: 1, WorkoutID: 1, Weight: 60, RepCount: 10, SessionVolume: 600, AchievedAt: now})
+		created, updated := tracker.Apply(PersonalRecordSet{SetLogID: 2, WorkoutID: 1, Weight: 50, RepCount: 10, SessionVolume: 1100, AchievedAt: now})
+
+		var volume *PersonalRecord
+		for _, record := range first {
+			if record.Type == SessionVolume {
+				volume = record
+			}
+		}
+		assert.Contains(t, updated, volume)
+		assert.Equal(t, 1100.0, volume.Value)
+		assert.True(t, volume.IsCurrent)
+		for _, record := range created {
+			assert.NotEqual(t, SessionVolume, record.Type)
+		}
+	})
+}
+
+func TestHeadlinePersonalRecord(t *testing.T) {
+	now := time.Now()
+	tracker := NewPersonalRecordTracker(1, 2, nil)
+	tracker.Apply(PersonalRecordSet{SetLogID: 1, WorkoutID: 1, Weight: 100, RepCount: 5, SessionVolume: 500, AchievedAt: now})
+
+	t.Run("Routine set at a new weight is not notified", func(t *testing.T) {
+		// 60kgは初めてのため最多レップは更新されるが、最高重量・推定1RMは更新されない
+		created, _ := tracker.Apply(PersonalRecordSet{SetLogID: 2, WorkoutID: 2, Weight: 60, RepCount: 10, SessionVolume: 600, AchievedAt: now})
+
+		assert.NotEmpty(t, created)
+		assert.Nil(t, HeadlinePersonalRecord(created))
+	})
+
+	t.Run("Heaviest weight is notified first", func(t *testing.T) {
+		created, _ := tracker.Apply(PersonalRecordSet{SetLogID: 3, WorkoutID: 3, Weight: 110, RepCount: 3, SessionVolume: 330, AchievedAt: now})
+
+		record := HeadlinePersonalRecord(created)
+		assert.NotNil(t, record)
+		assert.Equal(t, HeaviestWeight, record.Type)
+	})
+
+	t.Run("Estimated one rep max without heaviest weight", func(t *testing.T) {
+		created, _ := tracker.Apply(PersonalRecordSet{SetLogID: 4, WorkoutID: 4, Weight: 100, RepCount: 8, SessionVolume: 800, AchievedAt: now})
+
+		record := HeadlinePersonalRecord(created)
+		assert.NotNil(t, record)
+		assert.Equal(t, EstimatedOneRepMax, record.Type)
+	})
+}
+
+func TestReplayPersonalRecords(t *testing.T) {
+	now := time.Now()
+	sets := []PersonalRecordSet{
+		{SetLogID: 1, WorkoutID: 1, Weight: 100, RepCount: 5, AchievedAt: now},
+		{SetLogID: 2, WorkoutID: 2, Weight: 100, RepCount: 5, AchievedAt: now.Add(time.Hour)},
+		{SetLogID: 3, WorkoutID: 2, Weight: 110, RepCount: 3, AchievedAt: now.Add(2 * time.Hour)},
+	}
+	current := func(records []*PersonalRecord, recordType PersonalRecordType) *PersonalRecord {
+		for _, record := range records {
+			if record.Type == recordType && record.IsCurrent {
+				return record
+			}
+		}
+		return nil
+	}
+
+	tests := []struct {
+		name                  string
+		sets                  []PersonalRecordSet
+		expectedHeaviestSet   uint
+		expectedHeaviest      float64
+		expectedSessionVolume float64
+	}{
+		{
+			name:                  "Session volume accumulates within a workout",
+			sets:                  sets,
+			expectedHeaviestSet:   3,
+			expectedHeaviest:      110,
+			expectedSessionVolume: 830,
+		},
+		{
+			name: "Lowering the weight of the best set restores the previous record",
+			sets: []PersonalRecordSet{
+				sets[0],
+				sets[1],
+				{SetLogID: 3, WorkoutID: 2, Weight: 90, RepCount: 3, AchievedAt: now.Add(2 * time.Hour)},
+			},
+			expectedHeaviestSet:   1,
+			expectedHeaviest:      100,
+			expectedSessionVolume: 770,
+		},
+		{
+			name:                  "Deleting the best set restores the previous record",
+			sets:                  sets[:2],
+			expectedHeaviestSet:   1,
+			expectedHeaviest:      100,
+			expectedSessionVolume: 500,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			records := ReplayPersonalRecords(1, 2, tt.sets)
+
+			heaviest := current(records, HeaviestWeight)
+			assert.Equal(t, tt.expectedHeaviest, heaviest.Value)
+			assert.Equal(t, tt.expectedHeaviestSet, *heaviest.SetLogID)
+			assert.Equal(t, tt.expectedSessionVolume, current(records, SessionVolume).Value)
+		})
+	}
+}
+
+func TestSyncPersonalRecords(t *testing.T) {
+	now := time.Now()
+	sets := []PersonalRecordSet{
+		{SetLogID: 1, WorkoutID: 1, Weight: 60, RepCount: 10, AchievedAt: now},
+		{SetLogID: 2, WorkoutID: 2, Weight: 80, RepCount: 3, AchievedAt: now.Add(time.Hour)},
+		{SetLogID: 3, WorkoutID: 2, Weight: 80, RepCount: 5, AchievedAt: now.Add(2 * time.Hour)},
+	}
+	rebuild := func(sets []PersonalRecordSet) []*PersonalRecord {
+		return ReplayPersonalRecords(1, 2, sets)
+	}
+	saved := func() []*PersonalRecord {
+		records := rebuild(sets)
+		for i, record := range records {
+			record.ID = uint(i + 1)
+		}
+		return records
+	}
+
+	t.Run("Unchanged sets keep every record", func(t *testing.T) {
+		created, updated, deleted := SyncPersonalRecords(saved(), rebuild(sets))
+
+		assert.Empty(t, created)
+		assert.Empty(t, updated)
+		assert.Empty(t, deleted)
+	})
+
+	t.Run("Deleting a set only removes its records", func(t *testing.T) {
+		existing := saved()
+
+		// セット3を削除すると、セット3の記録とワークアウト2のボリュームのみ削除され、それまでの記録は既存の行のまま現在の自己ベストに戻る
+		created, updated, deleted := SyncPersonalRecords(existing, rebuild(sets[:2]))
+
+		assert.Empty(t, created)
+		assert.ElementsMatch(t, []string{"most_reps:80.000:set:3", "estimated_one_rep_max:set:3", "session_volume:workout:2"}, identities(deleted))
+		assert.ElementsMatch(t, []string{"most_reps:80.000:set:2", "estimated_one_rep_max:set:2", "session_volume:workout:1"}, identities(updated))
+		for _, record := range updated {
+			assert.True(t, record.IsCurrent)
+		}
+	})
+
+	t.Run("Updating a set updates its records in place", func(t *testing.T) {
+		existing := saved()
+		changed := append([]PersonalRecordSet{}, sets...)
+		changed[1].Weight = 85
+
+		created, updated, deleted := SyncPersonalRecords(existing, rebuild(changed))
+
+		// 最多レップは重量ごとのため作り直し、最高重量・推定1RM・ワークアウト2のボリュームは同じ行を更新する（セット3は推定1RMの記録ではなくなる）
+		assert.ElementsMatch(t, []string{"most_reps:85.000:set:2"}, identities(created))
+		assert.ElementsMatch(t, []string{"heaviest_weight:set:2", "estimated_one_rep_max:set:2", "session_volume:workout:2"}, identities(updated))
+		assert.ElementsMatch(t, []string{"most_reps:80.000:set:2", "estimated_one_rep_max:set:3"}, identities(deleted))
+		for _, record := range updated {
+			assert.NotZero(t, record.ID)
+		}
+	})
+}
+
+func identities(records []*PersonalRecord) []string {
+	result := make([]string, len(records))
+	for i, record := range records {
+		result[i] = record.identity()
+	}
+	return result
+}
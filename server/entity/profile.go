@@ -12,6 +12,7 @@ import (
 
 type Gender string
 type ActivityLevel string
+type WeightUnit string
 
 const (
 	Male   Gender = "male"
@@ -27,6 +28,11 @@ const (
 	ExtremelyActive  ActivityLevel = "extremely_active"
 )
 
+const (
+	WeightUnitKg WeightUnit = "kg"
+	WeightUnitLb WeightUnit = "lb"
+)
+
 type Profile struct {
 	gorm.Model
 	UserID        uint       `gorm:"not null;uniqueIndex"`
@@ -34,8 +40,9 @@ type Profile struct {
 	BirthDate     *time.Time `gorm:"type:date"`
 	Gender        Gender
 	Height        *float64
-	Weight        *float64
+	Weight        *float64 `gorm:"->;-:migration"` // 最新の体重の記録（BodyMeasurement）から取得する
 	ActivityLevel ActivityLevel
+	WeightUnit    WeightUnit `gorm:"not null;size:2;default:kg"` // 重量の表示・入力単位
 	ImageURL      string
 
 	User User `gorm:"constraint:OnDelete:CASCADE;foreignKey:UserID"`
@@ -81,6 +88,11 @@ func (p *Profile) ActivityLevelToGraphQL() *model.ActivityLevel {
 	}
 }
 
+// WeightUnitToGraphQL GraphQL enumに変換（未設定の場合はkg）
+func (p *Profile) WeightUnitToGraphQL() model.WeightUnit {
+	return p.WeightUnit.ToGraphQL()
+}
+
 // GenderFromGraphQL GraphQL enumから変換
 func (p *Profile) GenderFromGraphQL(gender *model.Gender) {
 	if gender == nil {
@@ -119,6 +131,16 @@ func (p *Profile) ActivityLevelFromGraphQL(level *model.ActivityLevel) {
 	}
 }
 
+// WeightUnitFromGraphQL GraphQL enumから変換
+func (p *Profile) WeightUnitFromGraphQL(unit *model.WeightUnit) {
+	if unit == nil {
+		p.WeightUnit = WeightUnitKg
+		return
+	}
+
+	p.WeightUnit = WeightUnitFromGraphQL(*unit)
+}
+
 // BeforeSave GORMフック - 保存前のバリデーション
 func (p *Profile) BeforeSave(tx *gorm.DB) error {
 	return p.Validate()
@@ -160,6 +182,10 @@ func (p *Profile) Validate() error {
 		}
 	}
 
+	if p.WeightUnit != "" && p.WeightUnit != WeightUnitKg && p.WeightUnit != WeightUnitLb {
+		return fmt.Errorf("重量単位はkgまたはlbで入力してください")
+	}
+
 	if p.ImageURL != "" {
 		if !strings.HasPrefix(p.ImageURL, "https://") {
 			return fmt.Errorf("画像URLはhttpsから始まる必要があります")
@@ -169,6 +195,15 @@ func (p *Profile) Validate() error {
 	return nil
 }
 
+// BMI は身長と最新の体重から計算する（どちらかが未設定の場合はnil）
+func (p *Profile) BMI() *float64 {
+	if p.Height == nil || p.Weight == nil {
+		return nil
+	}
+	bmi := CalculateBMI(*p.Height, *p.Weight)
+	return &bmi
+}
+
 // IsOnboardingCompleted オンボーディング完了判定
 func (p *Profile) IsOnboardingCompleted() bool {
 	return p.BirthDate != nil && p.Gender != "" && p.ActivityLevel != ""
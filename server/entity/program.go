@@ -0,0 +1,67 @@
+package entity
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// Program は複数週にわたるトレーニングプログラム（5/3/1、線形進行など）
+type Program struct {
+	gorm.Model
+	UserID      uint   `gorm:"not null;index"` // 作成者
+	Name        string `gorm:"size:255;not null"`
+	Description string `gorm:"size:1000"`
+
+	User User         `gorm:"constraint:OnDelete:CASCADE;foreignKey:UserID"`
+	Days []ProgramDay `gorm:"foreignKey:ProgramID;constraint:OnDelete:CASCADE"`
+}
+
+func (p *Program) BeforeSave(tx *gorm.DB) error {
+	return p.Validate()
+}
+
+func (p *Program) BeforeCreate(tx *gorm.DB) error {
+	return p.Validate()
+}
+
+func (p *Program) BeforeUpdate(tx *gorm.DB) error {
+	return p.Validate()
+}
+
+func (p *Program) Validate() error {
+	if p.UserID == 0 {
+		return fmt.Errorf("user_id は必須です")
+	}
+	if p.Name == "" {
+		return fmt.Errorf("プログラム名は必須です")
+	}
+	if len(p.Name) > 255 {
+		return fmt.Errorf("プログラム名は255文字以内で入力してください")
+	}
+	if len(p.Description) > 1000 {
+		return fmt.Errorf("説明は1000文字以内で入力してください")
+	}
+
+	// 週・日の重複チェック
+	seen := make(map[[2]int]bool, len(p.Days))
+	for _, day := range p.Days {
+		key := [2]int{day.Week, day.Day}
+		if seen[key] {
+			return fmt.Errorf("第%d週%d日目が重複しています", day.Week, day.Day)
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+// WeekCount はプログラムの週数
+func (p *Program) WeekCount() int {
+	weeks := 0
+	for _, day := range p.Days {
+		if day.Week > weeks {
+			weeks = day.Week
+		}
+	}
+	return weeks
+}
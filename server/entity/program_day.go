@@ -0,0 +1,44 @@
+package entity
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// ProgramDay はプログラムの1日分（第何週の何日目か）
+type ProgramDay struct {
+	gorm.Model
+	ProgramID uint   `gorm:"not null;index;uniqueIndex:idx_program_days_program_week_day,priority:1"`
+	Week      int    `gorm:"not null;uniqueIndex:idx_program_days_program_week_day,priority:2"`
+	Day       int    `gorm:"not null;uniqueIndex:idx_program_days_program_week_day,priority:3"`
+	Name      string `gorm:"size:255"`
+
+	Program   Program           `gorm:"constraint:OnDelete:CASCADE;foreignKey:ProgramID"`
+	Exercises []ProgramExercise `gorm:"foreignKey:ProgramDayID;constraint:OnDelete:CASCADE"`
+}
+
+func (d *ProgramDay) BeforeSave(tx *gorm.DB) error {
+	return d.Validate()
+}
+
+func (d *ProgramDay) BeforeCreate(tx *gorm.DB) error {
+	return d.Validate()
+}
+
+func (d *ProgramDay) BeforeUpdate(tx *gorm.DB) error {
+	return d.Validate()
+}
+
+func (d *ProgramDay) Validate() error {
+	if d.Week <= 0 {
+		return fmt.Errorf("週は正の整数で入力してください")
+	}
+	if d.Day <= 0 {
+		return fmt.Errorf("日は正の整数で入力してください")
+	}
+	if len(d.Name) > 255 {
+		return fmt.Errorf("名前は255文字以内で入力してください")
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ProgramEnrollment はユーザーのプログラムへの参加
+type ProgramEnrollment struct {
+	gorm.Model
+	UserID    uint       `gorm:"not null;index"`
+	ProgramID uint       `gorm:"not null;index"`
+	StartedAt time.Time  `gorm:"not null"`
+	EndedAt   *time.Time // 終了・離脱した日時（参加中はnil）
+
+	User          User                 `gorm:"constraint:OnDelete:CASCADE;foreignKey:UserID"`
+	Program       Program              `gorm:"constraint:OnDelete:CASCADE;foreignKey:ProgramID"`
+	TrainingMaxes []ProgramTrainingMax `gorm:"foreignKey:ProgramEnrollmentID;constraint:OnDelete:CASCADE"`
+}
+
+func (e *ProgramEnrollment) BeforeSave(tx *gorm.DB) error {
+	return e.Validate()
+}
+
+func (e *ProgramEnrollment) BeforeCreate(tx *gorm.DB) error {
+	return e.Validate()
+}
+
+func (e *ProgramEnrollment) BeforeUpdate(tx *gorm.DB) error {
+	return e.Validate()
+}
+
+func (e *ProgramEnrollment) Validate() error {
+	if e.UserID == 0 {
+		return fmt.Errorf("user_id は必須です")
+	}
+	if e.ProgramID == 0 {
+		return fmt.Errorf("program_id は必須です")
+	}
+	if e.StartedAt.IsZero() {
+		return fmt.Errorf("開始日時は必須です")
+	}
+	if e.EndedAt != nil && e.EndedAt.Before(e.StartedAt) {
+		return fmt.Errorf("終了日時は開始日時以降である必要があります")
+	}
+	return nil
+}
+
+// IsActive は参加中かどうか
+func (e *ProgramEnrollment) IsActive() bool {
+	return e.EndedAt == nil
+}
+
+// TrainingMaxFor は指定種目のトレーニングマックスを取得する
+func (e *ProgramEnrollment) TrainingMaxFor(exerciseID uint) (float64, bool) {
+	for _, trainingMax := range e.TrainingMaxes {
+		if trainingMax.ExerciseID == exerciseID {
+			return trainingMax.Weight, true
+		}
+	}
+	return 0, false
+}
+
+// ProgramTrainingMax は参加時点の種目ごとのトレーニングマックス（kg）
+type ProgramTrainingMax struct {
+	gorm.Model
+	ProgramEnrollmentID uint    `gorm:"not null;index"`
+	ExerciseID          uint    `gorm:"not null"`
+	Weight              float64 `gorm:"not null"`
+}
+
+func (t *ProgramTrainingMax) BeforeSave(tx *gorm.DB) error {
+	return t.Validate()
+}
+
+func (t *ProgramTrainingMax) Validate() error {
+	if t.ExerciseID == 0 {
+		return fmt.Errorf("exercise_id は必須です")
+	}
+	if t.Weight <= 0 {
+		return fmt.Errorf("トレーニングマックスは正の数で入力してください")
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package entity
+
+import (
+	"fmt"
+	"math"
+
+	"app/graph/model"
+
+	"gorm.io/gorm"
+)
+
+type ProgramTargetType string
+
+const (
+	PercentOneRepMax ProgramTargetType = "percent_one_rep_max" // トレーニングマックスに対する割合（%）
+	RPE              ProgramTargetType = "rpe"                 // 主観的運動強度（1〜10）
+)
+
+// 処方重量を丸める単位（kg）
+const plateIncrement = 2.5
+
+// ProgramExercise はプログラムの1日に含まれる種目と目標・進行ルール
+type ProgramExercise struct {
+	gorm.Model
+	ProgramDayID         uint              `gorm:"not null;index"`
+	ExerciseID           uint              `gorm:"not null;index"`
+	Position             int               `gorm:"not null"` // 何番目の種目か
+	Sets                 int               `gorm:"not null"` // セット数
+	Reps                 int               `gorm:"not null"` // レップ数
+	TargetType           ProgramTargetType `gorm:"size:50;not null"`
+	TargetValue          float64           `gorm:"not null"` // %1RM または RPE
+	ProgressionIncrement float64           `gorm:"not null"` // 全セット達成ごとにトレーニングマックスへ加算する重量（kg）
+
+	ProgramDay ProgramDay `gorm:"constraint:OnDelete:CASCADE;foreignKey:ProgramDayID"`
+	Exercise   Exercise   `gorm:"constraint:OnDelete:CASCADE;foreignKey:ExerciseID"`
+}
+
+func (e *ProgramExercise) BeforeSave(tx *gorm.DB) error {
+	return e.Validate()
+}
+
+func (e *ProgramExercise) BeforeCreate(tx *gorm.DB) error {
+	if err := e.Validate(); err != nil {
+		return err
+	}
+
+	// Exercise存在確認
+	var count int64
+	tx.Model(&Exercise{}).Where("id = ?", e.ExerciseID).Count(&count)
+	if count == 0 {
+		return fmt.Errorf("指定された exercise_id は存在しません")
+	}
+
+	return nil
+}
+
+func (e *ProgramExercise) BeforeUpdate(tx *gorm.DB) error {
+	return e.Validate()
+}
+
+func (e *ProgramExercise) Validate() error {
+	if e.ExerciseID == 0 {
+		return fmt.Errorf("exercise_id は必須です")
+	}
+	if e.Position <= 0 {
+		return fmt.Errorf("種目の順番は正の整数で入力してください")
+	}
+	if e.Sets <= 0 || e.Sets > maxTargetSets {
+		return fmt.Errorf("セット数は1〜%dの範囲で入力してください", maxTargetSets)
+	}
+	if e.Reps <= 0 {
+		return fmt.Errorf("レップ数は正の整数で入力してください")
+	}
+	switch e.TargetType {
+	case PercentOneRepMax:
+		if e.TargetValue <= 0 || e.TargetValue > 100 {
+			return fmt.Errorf("%%1RMは0より大きく100以下で入力してください")
+		}
+	case RPE:
+		if e.TargetValue < 1 || e.TargetValue > 10 {
+			return fmt.Errorf("RPEは1〜10の範囲で入力してください")
+		}
+	default:
+		return fmt.Errorf("無効な目標種別です: %s", e.TargetType)
+	}
+	if e.ProgressionIncrement < 0 {
+		return fmt.Errorf("進行重量は0以上で入力してください")
+	}
+	return nil
+}
+
+// TargetTypeToGraphQL GraphQL enumに変換
+func (e *ProgramExercise) TargetTypeToGraphQL() model.ProgramTargetType {
+	if e.TargetType == RPE {
+		return model.ProgramTargetTypeRpe
+	}
+	return model.ProgramTargetTypePercentOneRepMax
+}
+
+// TargetTypeFromGraphQL GraphQL enumから変換
+func (e *ProgramExercise) TargetTypeFromGraphQL(targetType model.ProgramTargetType) {
+	switch targetType {
+	case model.ProgramTargetTypeRpe:
+		e.TargetType = RPE
+	case model.ProgramTargetTypePercentOneRepMax:
+		e.TargetType = PercentOneRepMax
+	}
+}
+
+// ProgressedTrainingMax は全セット達成回数に応じて進行させたトレーニングマックス
+func (e *ProgramExercise) ProgressedTrainingMax(trainingMax float64, completedSessions int) float64 {
+	return trainingMax + e.ProgressionIncrement*float64(completedSessions)
+}
+
+// PrescribedWeight はトレーニングマックスから処方重量を計算する（2.5kg単位で丸める）
+func (e *ProgramExercise) PrescribedWeight(trainingMax float64) float64 {
+	var weight float64
+	switch e.TargetType {
+	case RPE:
+		// 残りレップ数（10 - RPE）を加えたレップ数でEpley式を逆算する（1レップ以下は1RMそのもの）
+		reps := float64(e.Reps) + 10 - e.TargetValue
+		weight = trainingMax
+		if reps > 1 {
+			weight = trainingMax / (1 + reps/30)
+		}
+	default:
+		weight = trainingMax * e.TargetValue / 100
+	}
+	return math.Round(weight/plateIncrement) * plateIncrement
+}
@@ -0,0 +1,54 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProgramExercisePrescribedWeight(t *testing.T) {
+	tests := []struct {
+		name        string
+		exercise    ProgramExercise
+		trainingMax float64
+		expected    float64
+	}{
+		{
+			name:        "Percent of training max",
+			exercise:    ProgramExercise{Reps: 5, TargetType: PercentOneRepMax, TargetValue: 80},
+			trainingMax: 100,
+			expected:    80,
+		},
+		{
+			name:        "Percent of training max is rounded to plate increment",
+			exercise:    ProgramExercise{Reps: 5, TargetType: PercentOneRepMax, TargetValue: 75},
+			trainingMax: 102.5,
+			expected:    77.5,
+		},
+		{
+			name:        "RPE uses reps in reserve",
+			exercise:    ProgramExercise{Reps: 5, TargetType: RPE, TargetValue: 8},
+			trainingMax: 100,
+			expected:    80,
+		},
+		{
+			name:        "RPE 10 at one rep is the training max",
+			exercise:    ProgramExercise{Reps: 1, TargetType: RPE, TargetValue: 10},
+			trainingMax: 140,
+			expected:    140,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.exercise.PrescribedWeight(tt.trainingMax))
+		})
+	}
+}
+
+func TestProgramExerciseProgressedTrainingMax(t *testing.T) {
+	exercise := ProgramExercise{ProgressionIncrement: 2.5}
+
+	assert.Equal(t, 100.0, exercise.ProgressedTrainingMax(100, 0))
+	assert.Equal(t, 107.5, exercise.ProgressedTrainingMax(100, 3))
+}
@@ -0,0 +1,30 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProgram_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		days      []ProgramDay
+		expectErr bool
+	}{
+		{name: "Unique days", days: []ProgramDay{{Week: 1, Day: 1}, {Week: 1, Day: 2}, {Week: 2, Day: 1}}},
+		{name: "Duplicate week and day", days: []ProgramDay{{Week: 1, Day: 1}, {Week: 1, Day: 1}}, expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			program := Program{UserID: 1, Name: "5/3/1", Days: tt.days}
+			err := program.Validate()
+			if tt.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type PushDeliveryStatus string
+
+const (
+	PushDeliveryStatusPending PushDeliveryStatus = "pending" // 送信待ち（おやすみ時間・再試行待ちを含む）
+	PushDeliveryStatusSent    PushDeliveryStatus = "sent"    // 送信済み
+	PushDeliveryStatusSkipped PushDeliveryStatus = "skipped" // 設定や端末がないため送らなかった
+	PushDeliveryStatusFailed  PushDeliveryStatus = "failed"  // 再試行しても送信できなかった
+)
+
+// MaxPushDeliveryAttempts はプッシュ通知の送信を試みる最大回数
+const MaxPushDeliveryAttempts = 5
+
+// PushDelivery は通知をプッシュ通知で送るためのキュー
+// 通知の作成と同時に登録し、ワーカーがNextAttemptAtを過ぎたものを送信する
+type PushDelivery struct {
+	gorm.Model
+	NotificationID uint               `gorm:"not null;uniqueIndex"`
+	UserID         uint               `gorm:"not null;index"`
+	Status         PushDeliveryStatus `gorm:"size:20;not null;default:pending;index:idx_push_deliveries_status_next_attempt"`
+	Attempts       int                `gorm:"not null;default:0"`
+	NextAttemptAt  time.Time          `gorm:"not null;index:idx_push_deliveries_status_next_attempt"`
+	SentAt         *time.Time
+	LastError      string `gorm:"size:1000"`
+
+	Notification Notification `gorm:"constraint:OnDelete:CASCADE;foreignKey:NotificationID"`
+	User         User         `gorm:"constraint:OnDelete:CASCADE;foreignKey:UserID"`
+}
+
+func (d *PushDelivery) BeforeSave(tx *gorm.DB) error {
+	return d.Validate()
+}
+
+func (d *PushDelivery) Validate() error {
+	if d.NotificationID == 0 {
+		return fmt.Errorf("通知IDは必須です")
+	}
+	if d.UserID == 0 {
+		return fmt.Errorf("ユーザーIDは必須です")
+	}
+	switch d.Status {
+	case PushDeliveryStatusPending, PushDeliveryStatusSent, PushDeliveryStatusSkipped, PushDeliveryStatusFailed:
+	default:
+		return fmt.Errorf("無効なステータスです: %s", d.Status)
+	}
+	return nil
+}
+
+// IsPushNotificationType はプッシュ通知でも送る種類の通知かどうか
+func IsPushNotificationType(notificationType NotificationType) bool {
+	switch notificationType {
+	case NotificationFriendRequestReceived, NotificationFriendRequestAccepted, NotificationWorkoutGroupInvited:
+		return true
+	default:
+		return false
+	}
+}
+
+// NewPushDeliveries はプッシュ通知で送る種類の通知の送信待ちを作成する（通知は作成済みであること）
+func NewPushDeliveries(notifications []Notification, now time.Time) []PushDelivery {
+	deliveries := make([]PushDelivery, 0, len(notifications))
+	for _, notification := range notifications {
+		if !IsPushNotificationType(notification.Type) {
+			continue
+		}
+		deliveries = append(deliveries, PushDelivery{
+			NotificationID: notification.ID,
+			UserID:         notification.UserID,
+			Status:         PushDeliveryStatusPending,
+			NextAttemptAt:  now,
+		})
+	}
+	return deliveries
+}
+
+// MarkSent は送信済みにする
+func (d *PushDelivery) MarkSent(now time.Time) {
+	d.Status = PushDeliveryStatusSent
+	d.Attempts++
+	d.SentAt = &now
+	d.LastError = ""
+}
+
+// Skip は送らずに終了する
+func (d *PushDelivery) Skip(reason string) {
+	d.Status = PushDeliveryStatusSkipped
+	d.LastError = reason
+}
+
+// Postpone は指定した日時まで送信を延期する（おやすみ時間）
+func (d *PushDelivery) Postpone(until time.Time) {
+	d.Status = PushDeliveryStatusPending
+	d.NextAttemptAt = until
+}
+
+// Fail は送信の失敗を記録し、最大回数に達するまでは間隔を空けて再試行する（1分、2分、4分…）
+func (d *PushDelivery) Fail(err error, now time.Time) {
+	d.Attempts++
+	d.LastError = truncate(err.Error(), 1000)
+	if d.Attempts >= MaxPushDeliveryAttempts {
+		d.Status = PushDeliveryStatusFailed
+		return
+	}
+	d.Status = PushDeliveryStatusPending
+	d.NextAttemptAt = now.Add(time.Minute << (d.Attempts - 1))
+}
+
+func truncate(value string, maxLength int) string {
+	runes := []rune(value)
+	if len(runes) <= maxLength {
+		return value
+	}
+	return string(runes[:maxLength])
+}
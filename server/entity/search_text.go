@@ -0,0 +1,199 @@
+package entity
+
+import (
+	"strings"
+	"unicode"
+)
+
+// 検索用テキストの正規化
+// 全角英数字を半角に、カタカナをひらがなに、長音符を直前の母音に揃え、空白や記号を取り除く。
+// ローマ字で入力された検索語はひらがなに変換してから照合する。
+
+// NormalizeSearchText は検索用にテキストを正規化する
+func NormalizeSearchText(text string) string {
+	var builder strings.Builder
+	var previous rune
+	for _, r := range foldWidth(text) {
+		r = unicode.ToLower(r)
+		// カタカナ（ァ〜ヶ）をひらがなに変換
+		if r >= 'ァ' && r <= 'ヶ' {
+			r -= 'ァ' - 'ぁ'
+		}
+		switch {
+		case r == 'ー':
+			// 長音符は直前の母音に置き換える（オーバー → おおばあ）
+			vowel, ok := hiraganaVowel(previous)
+			if !ok {
+				continue
+			}
+			r = vowel
+		case unicode.IsSpace(r), r == '-', r == '・', r == '_', r == '.':
+			continue
+		}
+		builder.WriteRune(r)
+		previous = r
+	}
+	return builder.String()
+}
+
+// SearchTextVariants は検索語の正規化候補を返す（ローマ字を含む場合はひらがなに変換したものも含む）
+func SearchTextVariants(query string) []string {
+	normalized := NormalizeSearchText(query)
+	if normalized == "" {
+		return nil
+	}
+
+	variants := []string{normalized}
+	kana := NormalizeSearchText(RomajiToHiragana(strings.ToLower(foldWidth(query))))
+	if kana != "" && kana != normalized {
+		variants = append(variants, kana)
+	}
+	return variants
+}
+
+// BuildSearchText は複数のテキストを正規化して検索用テキストにまとめる
+func BuildSearchText(texts ...string) string {
+	normalized := make([]string, 0, len(texts))
+	for _, text := range texts {
+		if n := NormalizeSearchText(text); n != "" {
+			normalized = append(normalized, n)
+		}
+	}
+	return strings.Join(normalized, "\n")
+}
+
+// foldWidth は全角英数字・記号と全角スペースを半角に変換する
+func foldWidth(text string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= '！' && r <= '～':
+			return r - ('！' - '!')
+		case r == '　':
+			return ' '
+		default:
+			return r
+		}
+	}, text)
+}
+
+// hiraganaVowel はひらがなの母音を返す
+func hiraganaVowel(r rune) (rune, bool) {
+	for _, row := range hiraganaVowelRows {
+		if strings.ContainsRune(row.kana, r) {
+			return row.vowel, true
+		}
+	}
+	return 0, false
+}
+
+var hiraganaVowelRows = []struct {
+	vowel rune
+	kana  string
+}{
+	{'あ', "あぁかがさざただなはばぱまやゃらわゎ"},
+	{'い', "いぃきぎしじちぢにひびぴみりゐ"},
+	{'う', "うぅくぐすずつづっぬふぶぷむゆゅるゔ"},
+	{'え', "えぇけげせぜてでねへべぺめれゑ"},
+	{'お', "おぉこごそぞとどのほぼぽもよょろを"},
+}
+
+// RomajiToHiragana はローマ字をひらがなに変換する（変換できない文字はそのまま残す）
+func RomajiToHiragana(text string) string {
+	var builder strings.Builder
+	runes := []rune(text)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+
+		// 促音（子音の重複、nを除く）
+		if i+1 < len(runes) && r == runes[i+1] && isRomajiConsonant(r) && r != 'n' {
+			builder.WriteRune('っ')
+			i++
+			continue
+		}
+
+		// 撥音
+		if r == 'n' {
+			if i+1 < len(runes) && (runes[i+1] == 'n' || runes[i+1] == '\'') {
+				builder.WriteRune('ん')
+				i += 2
+				continue
+			}
+			if i+1 == len(runes) || (!isRomajiVowel(runes[i+1]) && runes[i+1] != 'y') {
+				builder.WriteRune('ん')
+				i++
+				continue
+			}
+		}
+
+		if r == '-' {
+			builder.WriteRune('ー')
+			i++
+			continue
+		}
+
+		matched := false
+		for length := 4; length >= 1; length-- {
+			if i+length > len(runes) {
+				continue
+			}
+			if kana, ok := romajiTable[string(runes[i:i+length])]; ok {
+				builder.WriteString(kana)
+				i += length
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			builder.WriteRune(r)
+			i++
+		}
+	}
+	return builder.String()
+}
+
+func isRomajiVowel(r rune) bool {
+	return strings.ContainsRune("aiueo", r)
+}
+
+func isRomajiConsonant(r rune) bool {
+	return r >= 'a' && r <= 'z' && !isRomajiVowel(r)
+}
+
+// romajiTable はヘボン式・訓令式のローマ字とひらがなの対応表
+var romajiTable = map[string]string{
+	"a": "あ", "i": "い", "u": "う", "e": "え", "o": "お",
+	"ka": "か", "ki": "き", "ku": "く", "ke": "け", "ko": "こ",
+	"ga": "が", "gi": "ぎ", "gu": "ぐ", "ge": "げ", "go": "ご",
+	"sa": "さ", "si": "し", "shi": "し", "su": "す", "se": "せ", "so": "そ",
+	"za": "ざ", "zi": "じ", "ji": "じ", "zu": "ず", "ze": "ぜ", "zo": "ぞ",
+	"ta": "た", "ti": "ち", "chi": "ち", "tu": "つ", "tsu": "つ", "te": "て", "to": "と",
+	"da": "だ", "di": "ぢ", "du": "づ", "de": "で", "do": "ど",
+	"na": "な", "ni": "に", "nu": "ぬ", "ne": "ね", "no": "の",
+	"ha": "は", "hi": "ひ", "hu": "ふ", "fu": "ふ", "he": "へ", "ho": "ほ",
+	"ba": "ば", "bi": "び", "bu": "ぶ", "be": "べ", "bo": "ぼ",
+	"pa": "ぱ", "pi": "ぴ", "pu": "ぷ", "pe": "ぺ", "po": "ぽ",
+	"ma": "ま", "mi": "み", "mu": "む", "me": "め", "mo": "も",
+	"ya": "や", "yu": "ゆ", "yo": "よ",
+	"ra": "ら", "ri": "り", "ru": "る", "re": "れ", "ro": "ろ",
+	"wa": "わ", "wi": "うぃ", "we": "うぇ", "wo": "を",
+	"kya": "きゃ", "kyu": "きゅ", "kyo": "きょ",
+	"gya": "ぎゃ", "gyu": "ぎゅ", "gyo": "ぎょ",
+	"sya": "しゃ", "syu": "しゅ", "syo": "しょ", "sha": "しゃ", "shu": "しゅ", "sho": "しょ", "she": "しぇ",
+	"zya": "じゃ", "zyu": "じゅ", "zyo": "じょ", "ja": "じゃ", "ju": "じゅ", "jo": "じょ", "je": "じぇ",
+	"jya": "じゃ", "jyu": "じゅ", "jyo": "じょ",
+	"tya": "ちゃ", "tyu": "ちゅ", "tyo": "ちょ", "cha": "ちゃ", "chu": "ちゅ", "cho": "ちょ", "che": "ちぇ",
+	"cya": "ちゃ", "cyu": "ちゅ", "cyo": "ちょ",
+	"nya": "にゃ", "nyu": "にゅ", "nyo": "にょ",
+	"hya": "ひゃ", "hyu": "ひゅ", "hyo": "ひょ",
+	"bya": "びゃ", "byu": "びゅ", "byo": "びょ",
+	"pya": "ぴゃ", "pyu": "ぴゅ", "pyo": "ぴょ",
+	"mya": "みゃ", "myu": "みゅ", "myo": "みょ",
+	"rya": "りゃ", "ryu": "りゅ", "ryo": "りょ",
+	"fa": "ふぁ", "fi": "ふぃ", "fe": "ふぇ", "fo": "ふぉ",
+	"va": "ゔぁ", "vi": "ゔぃ", "vu": "ゔ", "ve": "ゔぇ", "vo": "ゔぉ",
+	"thi": "てぃ", "dhi": "でぃ", "twu": "とぅ", "dwu": "どぅ",
+	"tsa": "つぁ", "tsi": "つぃ", "tse": "つぇ", "tso": "つぉ",
+	"xa": "ぁ", "xi": "ぃ", "xu": "ぅ", "xe": "ぇ", "xo": "ぉ",
+	"xtu": "っ", "ltu": "っ", "xtsu": "っ", "ltsu": "っ",
+	"xya": "ゃ", "xyu": "ゅ", "xyo": "ょ",
+}
@@ -0,0 +1,89 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeSearchText(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{
+			name:     "Katakana is converted to hiragana",
+			text:     "ベンチプレス",
+			expected: "べんちぷれす",
+		},
+		{
+			name:     "Long vowel mark is replaced with the preceding vowel",
+			text:     "オーバーヘッドプレス",
+			expected: "おおばあへっどぷれす",
+		},
+		{
+			name:     "Full-width alphabets are folded and lowercased",
+			text:     "ＢＥＮＣＨ Press",
+			expected: "benchpress",
+		},
+		{
+			name:     "Kanji is kept as is",
+			text:     "懸垂",
+			expected: "懸垂",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, NormalizeSearchText(tt.text))
+		})
+	}
+}
+
+func TestSearchTextVariants(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		contains string
+	}{
+		{
+			name:     "Romaji matches katakana name",
+			query:    "benchipuresu",
+			contains: NormalizeSearchText("ベンチプレス"),
+		},
+		{
+			name:     "Double consonant becomes small tsu",
+			query:    "deddorifuto",
+			contains: NormalizeSearchText("デッドリフト"),
+		},
+		{
+			name:     "Hyphen is treated as a long vowel mark",
+			query:    "o-ba-heddo",
+			contains: NormalizeSearchText("オーバーヘッド"),
+		},
+		{
+			name:     "Syllabic n before a consonant",
+			query:    "puranku",
+			contains: NormalizeSearchText("プランク"),
+		},
+		{
+			name:     "Hiragana query matches katakana name",
+			query:    "すくわっと",
+			contains: NormalizeSearchText("スクワット"),
+		},
+		{
+			name:     "English query is kept",
+			query:    "Bench",
+			contains: "bench",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Contains(t, SearchTextVariants(tt.query), tt.contains)
+		})
+	}
+
+	assert.Empty(t, SearchTextVariants("  "))
+}
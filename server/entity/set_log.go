@@ -2,22 +2,73 @@ package entity
 
 import (
 	"fmt"
+	"math"
+	"regexp"
+
+	"app/graph/model"
 
 	"gorm.io/gorm"
 )
 
+type SetType string
+
+const (
+	SetTypeWarmup  SetType = "warmup"  // ウォームアップ
+	SetTypeWorking SetType = "working" // メインセット
+	SetTypeDropSet SetType = "drop_set"
+	SetTypeFailure SetType = "failure" // 限界まで
+	SetTypeAMRAP   SetType = "amrap"   // できるだけ多く（As Many Reps As Possible）
+)
+
+var setTypesToGraphQL = map[SetType]model.SetType{
+	SetTypeWarmup:  model.SetTypeWarmup,
+	SetTypeWorking: model.SetTypeWorking,
+	SetTypeDropSet: model.SetTypeDropSet,
+	SetTypeFailure: model.SetTypeFailure,
+	SetTypeAMRAP:   model.SetTypeAmrap,
+}
+
+const (
+	MinSetRPE      = 1.0
+	MaxSetRPE      = 10.0
+	MaxRIR         = 10
+	MaxRestSeconds = 60 * 60
+	MinHeartRate   = 30
+	MaxHeartRate   = 250
+)
+
+// tempoPattern はテンポ（下ろす・ボトム・上げる・トップの秒数、Xは爆発的に）
+// 「3-1-X-0」または「31X0」の形式
+var tempoPattern = regexp.MustCompile(`^([0-9]{1,2}|X)(-([0-9]{1,2}|X)){3}$|^[0-9X]{4}$`)
+
 type SetLog struct {
 	gorm.Model
-	WorkoutExerciseID uint `gorm:"not null;index"`
-	Weight            int  `gorm:"not null"` // 何キロでやったか
-	RepCount          int  `gorm:"not null"` // 何レップやったか
-	SetNumber         int  `gorm:"not null"` // 何セット目か
+	WorkoutExerciseID uint     `gorm:"not null;index"`
+	Weight            float64  `gorm:"not null"` // 何キロでやったか（kgで保存）
+	RepCount          int      `gorm:"not null"` // 何レップやったか
+	SetNumber         int      `gorm:"not null"` // 何セット目か
+	SetType           SetType  `gorm:"size:20;not null;default:working"`
+	RPE               *float64 // 主観的運動強度（1〜10、0.5刻み）
+	RIR               *int     // 余力のレップ数（Reps In Reserve）
+	Tempo             string   `gorm:"size:20"`
+	RestSeconds       *int     // このセットの前の休憩時間（秒）
+	Completed         bool     `gorm:"not null"` // falseの場合は予定のセット（自己ベスト・統計には含めない）
+	DurationSeconds   *int     // 時間（秒）
+	DistanceMeters    *float64 // 距離（m）
+	HeartRate         *int     // 平均心拍数（bpm）
 
 	WorkoutExercise WorkoutExercise `gorm:"constraint:OnDelete:CASCADE;foreignKey:WorkoutExerciseID"`
 }
 
 func (s *SetLog) BeforeSave(tx *gorm.DB) error {
-	return s.Validate()
+	if err := s.Validate(); err != nil {
+		return err
+	}
+	trackingType, err := s.trackingType(tx)
+	if err != nil {
+		return err
+	}
+	return s.ValidateTracking(trackingType)
 }
 
 func (s *SetLog) BeforeCreate(tx *gorm.DB) error {
@@ -36,18 +87,116 @@ func (s *SetLog) Validate() error {
 	if s.WorkoutExerciseID == 0 {
 		return fmt.Errorf("workout_exercise_id は必須です")
 	}
-	if s.Weight <= 0 {
-		return fmt.Errorf("重量は正の整数で入力してください")
+	if s.Weight < 0 {
+		return fmt.Errorf("重量は0以上で入力してください")
 	}
-	if s.RepCount <= 0 {
-		return fmt.Errorf("レップ数は正の整数で入力してください")
+	if s.RepCount < 0 {
+		return fmt.Errorf("レップ数は0以上で入力してください")
 	}
 	if s.SetNumber <= 0 {
 		return fmt.Errorf("セット番号は正の整数で入力してください")
 	}
+	if _, ok := setTypesToGraphQL[s.SetType]; s.SetType != "" && !ok {
+		return fmt.Errorf("無効なセットの種類です: %s", s.SetType)
+	}
+	if s.RPE != nil {
+		if *s.RPE < MinSetRPE || *s.RPE > MaxSetRPE {
+			return fmt.Errorf("RPEは1〜10の範囲で入力してください")
+		}
+		if *s.RPE*2 != math.Trunc(*s.RPE*2) {
+			return fmt.Errorf("RPEは0.5刻みで入力してください")
+		}
+	}
+	if s.RIR != nil && (*s.RIR < 0 || *s.RIR > MaxRIR) {
+		return fmt.Errorf("RIRは0〜%dの範囲で入力してください", MaxRIR)
+	}
+	if s.Tempo != "" && !tempoPattern.MatchString(s.Tempo) {
+		return fmt.Errorf("テンポは「3-1-X-0」または「31X0」の形式で入力してください")
+	}
+	if s.RestSeconds != nil && (*s.RestSeconds < 0 || *s.RestSeconds > MaxRestSeconds) {
+		return fmt.Errorf("休憩時間は0〜%d秒の範囲で入力してください", MaxRestSeconds)
+	}
+	if s.DurationSeconds != nil && *s.DurationSeconds <= 0 {
+		return fmt.Errorf("時間は正の整数で入力してください")
+	}
+	if s.DistanceMeters != nil && *s.DistanceMeters <= 0 {
+		return fmt.Errorf("距離は正の数で入力してください")
+	}
+	if s.HeartRate != nil && (*s.HeartRate < MinHeartRate || *s.HeartRate > MaxHeartRate) {
+		return fmt.Errorf("心拍数は%d〜%dの範囲で入力してください", MinHeartRate, MaxHeartRate)
+	}
+	return nil
+}
+
+// ValidateTracking は種目の記録方法に必要な項目が入力されているか確認する
+func (s *SetLog) ValidateTracking(trackingType TrackingType) error {
+	switch trackingType {
+	case TrackingTypeWeightReps, "":
+		if s.Weight <= 0 {
+			return fmt.Errorf("重量は正の数で入力してください")
+		}
+		if s.RepCount <= 0 {
+			return fmt.Errorf("レップ数は正の整数で入力してください")
+		}
+	case TrackingTypeAssistedWeight:
+		if s.Weight <= 0 {
+			return fmt.Errorf("補助重量は正の数で入力してください")
+		}
+		if s.RepCount <= 0 {
+			return fmt.Errorf("レップ数は正の整数で入力してください")
+		}
+	case TrackingTypeRepsOnly:
+		if s.RepCount <= 0 {
+			return fmt.Errorf("レップ数は正の整数で入力してください")
+		}
+	case TrackingTypeDuration:
+		if s.DurationSeconds == nil {
+			return fmt.Errorf("時間は必須です")
+		}
+	case TrackingTypeDistanceDuration:
+		if s.DistanceMeters == nil {
+			return fmt.Errorf("距離は必須です")
+		}
+		if s.DurationSeconds == nil {
+			return fmt.Errorf("時間は必須です")
+		}
+	default:
+		return fmt.Errorf("無効な記録方法です: %s", trackingType)
+	}
 	return nil
 }
 
+// trackingType はセットを記録する種目の記録方法を取得する
+func (s *SetLog) trackingType(tx *gorm.DB) (TrackingType, error) {
+	var trackingTypes []TrackingType
+	if err := tx.Session(&gorm.Session{NewDB: true}).Unscoped().Model(&Exercise{}).
+		Joins("inner join workout_exercises on workout_exercises.exercise_id = exercises.id").
+		Where("workout_exercises.id = ?", s.WorkoutExerciseID).
+		Limit(1).
+		Pluck("exercises.tracking_type", &trackingTypes).Error; err != nil {
+		return "", fmt.Errorf("種目の記録方法を取得できませんでした: %w", err)
+	}
+	if len(trackingTypes) == 0 {
+		return "", nil
+	}
+	return trackingTypes[0], nil
+}
+
+// ToGraphQL GraphQL enumに変換
+func (t SetType) ToGraphQL() model.SetType {
+	return setTypesToGraphQL[t]
+}
+
+// SetTypeFromGraphQL GraphQL enumから変換
+func SetTypeFromGraphQL(t model.SetType) SetType {
+	for setType, graphQLType := range setTypesToGraphQL {
+		if graphQLType == t {
+			return setType
+		}
+	}
+	return ""
+}
+
 // checkForeignKeys は WorkoutLog, WorkoutType の存在チェックを行う
 func (s *SetLog) checkForeignKeys(tx *gorm.DB) error {
 	var count int64
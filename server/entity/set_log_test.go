@@ -0,0 +1,83 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetLog_Validate(t *testing.T) {
+	rpe := func(v float64) *float64 { return &v }
+	intPtr := func(v int) *int { return &v }
+	valid := func() SetLog {
+		return SetLog{WorkoutExerciseID: 1, Weight: 100, RepCount: 5, SetNumber: 1, SetType: SetTypeWorking, Completed: true}
+	}
+
+	tests := []struct {
+		name      string
+		modify    func(s *SetLog)
+		expectErr bool
+	}{
+		{name: "Valid", modify: func(s *SetLog) {}},
+		{name: "Valid metadata", modify: func(s *SetLog) {
+			s.SetType, s.RPE, s.RIR, s.Tempo, s.RestSeconds = SetTypeAMRAP, rpe(8.5), intPtr(2), "3-1-X-0", intPtr(180)
+		}},
+		{name: "Compact tempo", modify: func(s *SetLog) { s.Tempo = "31X0" }},
+		{name: "Invalid set type", modify: func(s *SetLog) { s.SetType = "cluster" }, expectErr: true},
+		{name: "RPE out of range", modify: func(s *SetLog) { s.RPE = rpe(10.5) }, expectErr: true},
+		{name: "RPE not in half steps", modify: func(s *SetLog) { s.RPE = rpe(7.3) }, expectErr: true},
+		{name: "Negative RIR", modify: func(s *SetLog) { s.RIR = intPtr(-1) }, expectErr: true},
+		{name: "Invalid tempo", modify: func(s *SetLog) { s.Tempo = "3-1-2" }, expectErr: true},
+		{name: "Negative rest", modify: func(s *SetLog) { s.RestSeconds = intPtr(-30) }, expectErr: true},
+		{name: "Heart rate out of range", modify: func(s *SetLog) { s.HeartRate = intPtr(300) }, expectErr: true},
+		{name: "Zero duration", modify: func(s *SetLog) { s.DurationSeconds = intPtr(0) }, expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setLog := valid()
+			tt.modify(&setLog)
+			err := setLog.Validate()
+			if tt.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSetLog_ValidateTracking(t *testing.T) {
+	seconds := func(v int) *int { return &v }
+	meters := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		name         string
+		trackingType TrackingType
+		setLog       SetLog
+		expectErr    bool
+	}{
+		{name: "Weight and reps", trackingType: TrackingTypeWeightReps, setLog: SetLog{Weight: 60, RepCount: 10}},
+		{name: "Weight and reps without weight", trackingType: TrackingTypeWeightReps, setLog: SetLog{RepCount: 10}, expectErr: true},
+		{name: "Reps only", trackingType: TrackingTypeRepsOnly, setLog: SetLog{RepCount: 12}},
+		{name: "Reps only with added weight", trackingType: TrackingTypeRepsOnly, setLog: SetLog{Weight: 10, RepCount: 8}},
+		{name: "Reps only without reps", trackingType: TrackingTypeRepsOnly, setLog: SetLog{}, expectErr: true},
+		{name: "Duration", trackingType: TrackingTypeDuration, setLog: SetLog{DurationSeconds: seconds(60)}},
+		{name: "Duration without duration", trackingType: TrackingTypeDuration, setLog: SetLog{RepCount: 1}, expectErr: true},
+		{name: "Distance and duration", trackingType: TrackingTypeDistanceDuration, setLog: SetLog{DistanceMeters: meters(5000), DurationSeconds: seconds(1500)}},
+		{name: "Distance without duration", trackingType: TrackingTypeDistanceDuration, setLog: SetLog{DistanceMeters: meters(5000)}, expectErr: true},
+		{name: "Assisted weight", trackingType: TrackingTypeAssistedWeight, setLog: SetLog{Weight: 20, RepCount: 8}},
+		{name: "Assisted weight without assistance", trackingType: TrackingTypeAssistedWeight, setLog: SetLog{RepCount: 8}, expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.setLog.ValidateTracking(tt.trackingType)
+			if tt.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
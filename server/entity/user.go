@@ -2,15 +2,18 @@ package entity
 
 import (
 	"fmt"
-	"os"
+	"sort"
+	"time"
 
 	"gorm.io/gorm"
 )
 
 type User struct {
 	gorm.Model
-	UID      string    `gorm:"unique;not null"`
-	Workouts []Workout `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	UID           string     `gorm:"unique;not null"`
+	RolesSyncedAt *time.Time // ロールを最後に変更した日時（これより前に発行されたトークンのクレームは反映しない）
+	Workouts      []Workout  `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	Roles         []UserRole `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
 }
 
 // BeforeSave GORMフック - 保存前のバリデーション
@@ -39,8 +42,58 @@ func (u *User) Validate() error {
 	return nil
 }
 
+// IsAdmin は管理者ロールを持つかどうか（Rolesを読み込んでおく必要がある）
 func (u *User) IsAdmin() bool {
-	return u.UID == os.Getenv("MOCK_ADMIN_UID")
+	return u.HasRole(RoleAdmin)
+}
+
+// HasRole は指定したロールを持つかどうか（一般ユーザーは全員が持つ）
+func (u *User) HasRole(role Role) bool {
+	if role == RoleUser {
+		return true
+	}
+	for _, userRole := range u.Roles {
+		if userRole.Role == role {
+			return true
+		}
+	}
+	return false
+}
+
+// RoleList は一般ユーザーを含むロールの一覧を返す
+func (u *User) RoleList() []Role {
+	roles := []Role{RoleUser}
+	for _, userRole := range u.Roles {
+		roles = append(roles, userRole.Role)
+	}
+	return roles
+}
+
+// HasSameRoles は付与されたロールが指定したロールと一致するかどうか（一般ユーザーは除く）
+func (u *User) HasSameRoles(roles []Role) bool {
+	current := make([]string, 0, len(u.Roles))
+	for _, userRole := range u.Roles {
+		current = append(current, string(userRole.Role))
+	}
+	expected := make([]string, 0, len(roles))
+	seen := make(map[Role]bool)
+	for _, role := range roles {
+		if role != RoleUser && !seen[role] {
+			seen[role] = true
+			expected = append(expected, string(role))
+		}
+	}
+	if len(current) != len(expected) {
+		return false
+	}
+	sort.Strings(current)
+	sort.Strings(expected)
+	for i := range current {
+		if current[i] != expected[i] {
+			return false
+		}
+	}
+	return true
 }
 
 func (u *User) GetFriendshipRequest(db *gorm.DB, friendshipID string) *Friendship {
@@ -92,6 +145,25 @@ func (u *User) GetRecommendedUsers(db *gorm.DB) []User {
 		return nil
 	}
 
+	// ブロックした・されたユーザーを除外リストに追加
+	var blockedIDs []uint
+	if err := db.Model(&UserBlock{}).
+		Select("CASE WHEN blocker_id = ? THEN blocked_id ELSE blocker_id END", u.ID).
+		Where("blocker_id = ? OR blocked_id = ?", u.ID, u.ID).
+		Scan(&blockedIDs).Error; err != nil {
+		return nil
+	}
+	excludeIDs = append(excludeIDs, blockedIDs...)
+
+	// 推奨から非表示にしたユーザーを除外リストに追加
+	var dismissedIDs []uint
+	if err := db.Model(&RecommendationDismissal{}).
+		Where("user_id = ?", u.ID).
+		Pluck("dismissed_user_id", &dismissedIDs).Error; err != nil {
+		return nil
+	}
+	excludeIDs = append(excludeIDs, dismissedIDs...)
+
 	// 現在のユーザーも除外リストに追加
 	excludeIDs = append(excludeIDs, u.ID)
 
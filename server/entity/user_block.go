@@ -0,0 +1,32 @@
+package entity
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// UserBlock はユーザーのブロック
+// ブロックしたユーザーとされたユーザーの間では友達リクエスト・推奨ユーザー・グループのメンバー表示を相互に行わない
+type UserBlock struct {
+	gorm.Model
+	BlockerID uint `gorm:"not null;uniqueIndex:idx_user_blocks_blocker_blocked"` // ブロックしたユーザー
+	BlockedID uint `gorm:"not null;uniqueIndex:idx_user_blocks_blocker_blocked;index"`
+
+	Blocker User `gorm:"constraint:OnDelete:CASCADE;foreignKey:BlockerID"`
+	Blocked User `gorm:"constraint:OnDelete:CASCADE;foreignKey:BlockedID"`
+}
+
+func (b *UserBlock) BeforeSave(tx *gorm.DB) error {
+	return b.Validate()
+}
+
+func (b *UserBlock) Validate() error {
+	if b.BlockerID == 0 || b.BlockedID == 0 {
+		return fmt.Errorf("ブロックするユーザーとされるユーザーは必須です")
+	}
+	if b.BlockerID == b.BlockedID {
+		return fmt.Errorf("自分自身をブロックすることはできません")
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package entity
+
+import (
+	"fmt"
+
+	"app/graph/model"
+
+	"gorm.io/gorm"
+)
+
+type Role string
+
+const (
+	RoleUser      Role = "user"      // 一般ユーザー（全ユーザーが暗黙的に持つ）
+	RoleCoach     Role = "coach"     // コーチ
+	RoleModerator Role = "moderator" // モデレーター
+	RoleAdmin     Role = "admin"     // 管理者
+)
+
+// RoleClaimKey はFirebaseのカスタムクレームでロールを保持するキー
+const RoleClaimKey = "roles"
+
+var rolesToGraphQL = map[Role]model.Role{
+	RoleUser:      model.RoleUser,
+	RoleCoach:     model.RoleCoach,
+	RoleModerator: model.RoleModerator,
+	RoleAdmin:     model.RoleAdmin,
+}
+
+// IsValid は定義済みのロールかどうか
+func (r Role) IsValid() bool {
+	_, ok := rolesToGraphQL[r]
+	return ok
+}
+
+// ToGraphQL GraphQL enumに変換
+func (r Role) ToGraphQL() model.Role {
+	return rolesToGraphQL[r]
+}
+
+// RoleFromGraphQL GraphQL enumから変換
+func RoleFromGraphQL(role model.Role) Role {
+	for entityRole, modelRole := range rolesToGraphQL {
+		if modelRole == role {
+			return entityRole
+		}
+	}
+	return ""
+}
+
+// RolesFromClaims はカスタムクレームからロールを取得する（クレームがない場合はfalse）
+func RolesFromClaims(claims map[string]interface{}) ([]Role, bool) {
+	value, exists := claims[RoleClaimKey]
+	if !exists {
+		return nil, false
+	}
+
+	values, ok := value.([]interface{})
+	if !ok {
+		return nil, false
+	}
+
+	roles := []Role{}
+	for _, v := range values {
+		name, ok := v.(string)
+		if !ok {
+			continue
+		}
+		role := Role(name)
+		// 一般ユーザーは暗黙的に付与されるため保存しない
+		if role.IsValid() && role != RoleUser {
+			roles = append(roles, role)
+		}
+	}
+	return roles, true
+}
+
+// UserRole はユーザーに付与されたロール
+type UserRole struct {
+	gorm.Model
+	UserID uint `gorm:"not null;uniqueIndex:idx_user_roles_user_role"`
+	Role   Role `gorm:"size:50;not null;uniqueIndex:idx_user_roles_user_role"`
+
+	User User `gorm:"constraint:OnDelete:CASCADE;foreignKey:UserID"`
+}
+
+func (r *UserRole) BeforeSave(tx *gorm.DB) error {
+	return r.Validate()
+}
+
+func (r *UserRole) Validate() error {
+	if r.UserID == 0 {
+		return fmt.Errorf("user_id は必須です")
+	}
+	if !r.Role.IsValid() {
+		return fmt.Errorf("無効なロールです: %s", r.Role)
+	}
+	if r.Role == RoleUser {
+		return fmt.Errorf("一般ユーザーのロールは付与できません")
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRolesFromClaims(t *testing.T) {
+	tests := []struct {
+		name     string
+		claims   map[string]interface{}
+		expected []Role
+		ok       bool
+	}{
+		{
+			name:   "Missing claim is not synced",
+			claims: map[string]interface{}{},
+			ok:     false,
+		},
+		{
+			name:     "Known roles are returned",
+			claims:   map[string]interface{}{"roles": []interface{}{"coach", "admin"}},
+			expected: []Role{RoleCoach, RoleAdmin},
+			ok:       true,
+		},
+		{
+			name:     "Implicit user role and unknown roles are ignored",
+			claims:   map[string]interface{}{"roles": []interface{}{"user", "superuser", 1, "moderator"}},
+			expected: []Role{RoleModerator},
+			ok:       true,
+		},
+		{
+			name:     "Empty claim revokes all roles",
+			claims:   map[string]interface{}{"roles": []interface{}{}},
+			expected: []Role{},
+			ok:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			roles, ok := RolesFromClaims(tt.claims)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, roles)
+		})
+	}
+}
+
+func TestUser_Roles(t *testing.T) {
+	user := User{Roles: []UserRole{{Role: RoleModerator}, {Role: RoleCoach}}}
+
+	assert.True(t, user.HasRole(RoleUser))
+	assert.True(t, user.HasRole(RoleModerator))
+	assert.False(t, user.IsAdmin())
+	assert.Equal(t, []Role{RoleUser, RoleModerator, RoleCoach}, user.RoleList())
+
+	assert.True(t, user.HasSameRoles([]Role{RoleCoach, RoleModerator}))
+	assert.True(t, user.HasSameRoles([]Role{RoleUser, RoleCoach, RoleModerator, RoleCoach}))
+	assert.False(t, user.HasSameRoles([]Role{RoleCoach}))
+	assert.False(t, user.HasSameRoles([]Role{RoleCoach, RoleAdmin}))
+}
@@ -0,0 +1,47 @@
+package entity
+
+import (
+	"math"
+
+	"app/graph/model"
+)
+
+// 1ポンドあたりのキログラム（国際ポンド）
+const kilogramsPerPound = 0.45359237
+
+// ToGraphQL GraphQL enumに変換（未設定の場合はkg）
+func (u WeightUnit) ToGraphQL() model.WeightUnit {
+	if u == WeightUnitLb {
+		return model.WeightUnitLb
+	}
+	return model.WeightUnitKg
+}
+
+// WeightUnitFromGraphQL GraphQL enumから変換
+func WeightUnitFromGraphQL(unit model.WeightUnit) WeightUnit {
+	if unit == model.WeightUnitLb {
+		return WeightUnitLb
+	}
+	return WeightUnitKg
+}
+
+// ToKilograms 指定単位の重量をkgに変換（小数第3位で丸める）
+func ToKilograms(weight float64, unit WeightUnit) float64 {
+	if unit == WeightUnitLb {
+		weight = weight * kilogramsPerPound
+	}
+	return roundTo(weight, 3)
+}
+
+// FromKilograms kgの重量を指定単位に変換（小数第2位で丸める）
+func FromKilograms(weightKg float64, unit WeightUnit) float64 {
+	if unit == WeightUnitLb {
+		weightKg = weightKg / kilogramsPerPound
+	}
+	return roundTo(weightKg, 2)
+}
+
+func roundTo(value float64, digits int) float64 {
+	pow := math.Pow(10, float64(digits))
+	return math.Round(value*pow) / pow
+}
@@ -0,0 +1,81 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToKilograms(t *testing.T) {
+	tests := []struct {
+		name     string
+		weight   float64
+		unit     WeightUnit
+		expected float64
+	}{
+		{
+			name:     "Kilograms are kept as is",
+			weight:   62.5,
+			unit:     WeightUnitKg,
+			expected: 62.5,
+		},
+		{
+			name:     "Micro plates are kept as is",
+			weight:   2.25,
+			unit:     WeightUnitKg,
+			expected: 2.25,
+		},
+		{
+			name:     "Pounds are converted to kilograms",
+			weight:   135,
+			unit:     WeightUnitLb,
+			expected: 61.235,
+		},
+		{
+			name:     "Empty unit is treated as kilograms",
+			weight:   100,
+			unit:     "",
+			expected: 100,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ToKilograms(tt.weight, tt.unit))
+		})
+	}
+}
+
+func TestFromKilograms(t *testing.T) {
+	tests := []struct {
+		name     string
+		weightKg float64
+		unit     WeightUnit
+		expected float64
+	}{
+		{
+			name:     "Kilograms are kept as is",
+			weightKg: 62.5,
+			unit:     WeightUnitKg,
+			expected: 62.5,
+		},
+		{
+			name:     "Kilograms are converted to pounds",
+			weightKg: 100,
+			unit:     WeightUnitLb,
+			expected: 220.46,
+		},
+		{
+			name:     "Pounds round trip",
+			weightKg: ToKilograms(135, WeightUnitLb),
+			unit:     WeightUnitLb,
+			expected: 135,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, FromKilograms(tt.weightKg, tt.unit))
+		})
+	}
+}
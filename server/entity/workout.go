@@ -3,19 +3,58 @@ package entity
 import (
 	"fmt"
 	"time"
+	"unicode/utf8"
+
+	"app/graph/model"
 
 	"gorm.io/gorm"
 )
 
+type WorkoutStatus string
+
+const (
+	WorkoutStatusPlanned    WorkoutStatus = "planned"     // 開始前（グループに参加した時に作成されたワークアウトなど）
+	WorkoutStatusInProgress WorkoutStatus = "in_progress" // 実施中（ユーザーごとに1件まで）
+	WorkoutStatusCompleted  WorkoutStatus = "completed"   // 終了済み
+	WorkoutStatusAbandoned  WorkoutStatus = "abandoned"   // 終了せずに別のワークアウトを開始した
+)
+
+var workoutStatusesToGraphQL = map[WorkoutStatus]model.WorkoutStatus{
+	WorkoutStatusPlanned:    model.WorkoutStatusPlanned,
+	WorkoutStatusInProgress: model.WorkoutStatusInProgress,
+	WorkoutStatusCompleted:  model.WorkoutStatusCompleted,
+	WorkoutStatusAbandoned:  model.WorkoutStatusAbandoned,
+}
+
+const (
+	// WorkoutInProgressIndex はユーザーの実施中のワークアウトを1件に制限するユニークインデックス
+	// 部分インデックスのためマイグレーションで作成する
+	WorkoutInProgressIndex = "idx_workouts_user_in_progress"
+
+	MinSessionRPE         = 1
+	MaxSessionRPE         = 10
+	MaxWorkoutNotesLength = 2000
+)
+
+// Workout はワークアウトのセッション
+// 開始（StartedAt）から終了（FinishedAt）までを記録し、以前から記録されていたワークアウトは終了済みとして扱う
 type Workout struct {
 	gorm.Model
 	Date           *time.Time
-	UserID         uint  `gorm:"not null;index"`
-	WorkoutGroupID *uint `gorm:"index"`
+	UserID         uint          `gorm:"not null;index"`
+	WorkoutGroupID *uint         `gorm:"index"`
+	Status         WorkoutStatus `gorm:"size:20;not null;default:completed"`
+	StartedAt      *time.Time
+	FinishedAt     *time.Time
+	Notes          string `gorm:"type:text"`
+	SessionRPE     *int   // セッション全体の主観的運動強度（1〜10）
+	// プログラムの処方で開始したワークアウトの参加（プログラムの進行はこのワークアウトのみで数える）
+	ProgramEnrollmentID *uint `gorm:"index"`
 
-	User             User              `gorm:"constraint:OnDelete:CASCADE;foreignKey:UserID"`
-	WorkoutGroup     *WorkoutGroup     `gorm:"constraint:OnDelete:SET NULL;foreignKey:WorkoutGroupID"`
-	WorkoutExercises []WorkoutExercise `gorm:"foreignKey:WorkoutID;constraint:OnDelete:CASCADE"`
+	User              User               `gorm:"constraint:OnDelete:CASCADE;foreignKey:UserID"`
+	WorkoutGroup      *WorkoutGroup      `gorm:"constraint:OnDelete:SET NULL;foreignKey:WorkoutGroupID"`
+	ProgramEnrollment *ProgramEnrollment `gorm:"constraint:OnDelete:SET NULL;foreignKey:ProgramEnrollmentID"`
+	WorkoutExercises  []WorkoutExercise  `gorm:"foreignKey:WorkoutID;constraint:OnDelete:CASCADE"`
 }
 
 func (w *Workout) BeforeSave(tx *gorm.DB) error {
@@ -39,5 +78,77 @@ func (wl *Workout) Validate() error {
 	if wl.UserID == 0 {
 		return fmt.Errorf("ユーザーIDは必須です")
 	}
+	if _, ok := workoutStatusesToGraphQL[wl.Status]; wl.Status != "" && !ok {
+		return fmt.Errorf("無効なステータスです: %s", wl.Status)
+	}
+	if wl.SessionRPE != nil && (*wl.SessionRPE < MinSessionRPE || *wl.SessionRPE > MaxSessionRPE) {
+		return fmt.Errorf("セッションRPEは%dから%dの間で入力してください", MinSessionRPE, MaxSessionRPE)
+	}
+	if utf8.RuneCountInString(wl.Notes) > MaxWorkoutNotesLength {
+		return fmt.Errorf("メモは%d文字以内で入力してください", MaxWorkoutNotesLength)
+	}
+	if wl.StartedAt != nil && wl.FinishedAt != nil && wl.FinishedAt.Before(*wl.StartedAt) {
+		return fmt.Errorf("終了日時は開始日時より後にしてください")
+	}
 	return nil
 }
+
+// Start は開始前のワークアウトを開始する
+func (wl *Workout) Start(now time.Time) error {
+	if wl.Status != WorkoutStatusPlanned {
+		return fmt.Errorf("開始前のワークアウトではありません")
+	}
+	wl.Status = WorkoutStatusInProgress
+	wl.StartedAt = &now
+	return nil
+}
+
+// Finish は実施中のワークアウトを終了する
+func (wl *Workout) Finish(now time.Time) error {
+	if wl.Status != WorkoutStatusInProgress {
+		return fmt.Errorf("実施中のワークアウトではありません")
+	}
+	wl.Status = WorkoutStatusCompleted
+	wl.FinishedAt = &now
+	return nil
+}
+
+// Resume は終了済み・中断したワークアウトを再開する（開始前の場合は開始する）
+// 開始日時は最初に開始した日時のまま残す
+func (wl *Workout) Resume(now time.Time) error {
+	switch wl.Status {
+	case WorkoutStatusPlanned:
+		return wl.Start(now)
+	case WorkoutStatusCompleted, WorkoutStatusAbandoned:
+		wl.Status = WorkoutStatusInProgress
+		wl.FinishedAt = nil
+		if wl.StartedAt == nil {
+			wl.StartedAt = &now
+		}
+		return nil
+	default:
+		return fmt.Errorf("すでに実施中のワークアウトです")
+	}
+}
+
+// Duration は開始から終了までの時間（終了していない場合はnil）
+func (wl *Workout) Duration() *time.Duration {
+	if wl.StartedAt == nil || wl.FinishedAt == nil {
+		return nil
+	}
+	duration := wl.FinishedAt.Sub(*wl.StartedAt)
+	return &duration
+}
+
+// AbandonInProgressWorkouts はユーザーの実施中のワークアウトを中断済みにする（exceptIDのワークアウトは除く）
+// 新しいワークアウトを開始・再開する前に同じトランザクションで呼ぶ
+func AbandonInProgressWorkouts(tx *gorm.DB, userID, exceptID uint) error {
+	return tx.Model(&Workout{}).
+		Where("user_id = ? AND status = ? AND id <> ?", userID, WorkoutStatusInProgress, exceptID).
+		Update("status", WorkoutStatusAbandoned).Error
+}
+
+// ToGraphQL GraphQL enumに変換
+func (s WorkoutStatus) ToGraphQL() model.WorkoutStatus {
+	return workoutStatusesToGraphQL[s]
+}
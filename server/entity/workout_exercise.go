@@ -2,14 +2,20 @@ package entity
 
 import (
 	"fmt"
+	"sort"
 
 	"gorm.io/gorm"
 )
 
+// minSupersetSize はスーパーセットにできる種目の最小数（3種目以上はサーキット）
+const minSupersetSize = 2
+
 type WorkoutExercise struct {
 	gorm.Model
-	WorkoutID  uint `gorm:"not null;index"`
-	ExerciseID uint `gorm:"not null;index"`
+	WorkoutID     uint `gorm:"not null;index"`
+	ExerciseID    uint `gorm:"not null;index"`
+	Position      int  `gorm:"not null"` // 何番目の種目か
+	SupersetGroup *int // 同じ値の種目を続けて行う（スーパーセット・サーキット）
 
 	Workout  Workout  `gorm:"constraint:OnDelete:CASCADE;foreignKey:WorkoutID"`
 	Exercise Exercise `gorm:"constraint:OnDelete:CASCADE;foreignKey:ExerciseID"`
@@ -35,5 +41,228 @@ func (w *WorkoutExercise) Validate() error {
 	if w.ExerciseID == 0 {
 		return fmt.Errorf("exercise_id は必須です")
 	}
+	if w.Position <= 0 {
+		return fmt.Errorf("種目の順番は正の整数で入力してください")
+	}
+	if w.SupersetGroup != nil && *w.SupersetGroup <= 0 {
+		return fmt.Errorf("スーパーセットのグループは正の整数で入力してください")
+	}
+	return nil
+}
+
+// WorkoutExerciseOrder は1つのワークアウトの種目の並び（順番・スーパーセット）を扱う
+// スーパーセットの種目は連続して並び、2種目未満になったグループは解除する
+type WorkoutExerciseOrder []*WorkoutExercise
+
+// NewWorkoutExerciseOrder は種目を現在の順番で並べる
+func NewWorkoutExerciseOrder(workoutExercises []*WorkoutExercise) WorkoutExerciseOrder {
+	order := make(WorkoutExerciseOrder, len(workoutExercises))
+	copy(order, workoutExercises)
+	sort.SliceStable(order, func(i, j int) bool {
+		if order[i].Position != order[j].Position {
+			return order[i].Position < order[j].Position
+		}
+		return order[i].ID < order[j].ID
+	})
+	return order
+}
+
+// Reorder は orderedIDs の順に並べ替える（ワークアウトの全ての種目を指定する）
+func (o *WorkoutExerciseOrder) Reorder(orderedIDs []uint) error {
+	if len(orderedIDs) != len(*o) {
+		return fmt.Errorf("ワークアウトの全ての種目を指定してください")
+	}
+
+	byID := o.byID()
+	reordered := make(WorkoutExerciseOrder, 0, len(orderedIDs))
+	for _, id := range orderedIDs {
+		workoutExercise, ok := byID[id]
+		if !ok {
+			return fmt.Errorf("ワークアウトに含まれない種目です（ID: %d）", id)
+		}
+		delete(byID, id)
+		reordered = append(reordered, workoutExercise)
+	}
+
+	if err := reordered.validateSupersets(); err != nil {
+		return err
+	}
+	*o = reordered
+	o.renumber()
 	return nil
 }
+
+// GroupIntoSuperset は指定した種目を新しいスーパーセットにまとめる
+// 種目は指定した順に、最初に並んでいた種目の位置へ連続して並べる（他のスーパーセットからは外れる）
+func (o *WorkoutExerciseOrder) GroupIntoSuperset(memberIDs []uint) error {
+	if len(memberIDs) < minSupersetSize {
+		return fmt.Errorf("スーパーセットには%d種目以上を指定してください", minSupersetSize)
+	}
+
+	byID := o.byID()
+	members := make([]*WorkoutExercise, 0, len(memberIDs))
+	isMember := make(map[uint]bool, len(memberIDs))
+	for _, id := range memberIDs {
+		workoutExercise, ok := byID[id]
+		if !ok {
+			return fmt.Errorf("ワークアウトに含まれない種目です（ID: %d）", id)
+		}
+		if isMember[id] {
+			return fmt.Errorf("同じ種目が複数指定されています（ID: %d）", id)
+		}
+		isMember[id] = true
+		members = append(members, workoutExercise)
+	}
+
+	group := o.nextSupersetGroup()
+	first := (*o)[o.indexOfFirst(isMember)]
+	grouped := make(WorkoutExerciseOrder, 0, len(*o))
+	for _, workoutExercise := range *o {
+		if !isMember[workoutExercise.ID] {
+			grouped = append(grouped, workoutExercise)
+			continue
+		}
+		if workoutExercise == first {
+			for _, member := range members {
+				member.SupersetGroup = &group
+				grouped = append(grouped, member)
+			}
+		}
+	}
+
+	*o = grouped
+	o.dissolveSmallGroups()
+	o.renumber()
+	return nil
+}
+
+// RemoveFromSuperset は種目をスーパーセットから外し、グループの直後に移動する
+func (o *WorkoutExerciseOrder) RemoveFromSuperset(id uint) error {
+	target, ok := o.byID()[id]
+	if !ok {
+		return fmt.Errorf("ワークアウトに含まれない種目です（ID: %d）", id)
+	}
+	if target.SupersetGroup == nil {
+		return fmt.Errorf("スーパーセットに含まれていない種目です")
+	}
+
+	group := *target.SupersetGroup
+	target.SupersetGroup = nil
+
+	// 残りのメンバーが連続するように、グループの最後の種目の後ろに移動する
+	removed := make(WorkoutExerciseOrder, 0, len(*o))
+	insertAt := -1
+	for _, workoutExercise := range *o {
+		if workoutExercise == target {
+			continue
+		}
+		removed = append(removed, workoutExercise)
+		if workoutExercise.SupersetGroup != nil && *workoutExercise.SupersetGroup == group {
+			insertAt = len(removed)
+		}
+	}
+	if insertAt < 0 {
+		insertAt = len(removed)
+	}
+	removed = append(removed[:insertAt], append(WorkoutExerciseOrder{target}, removed[insertAt:]...)...)
+
+	*o = removed
+	o.dissolveSmallGroups()
+	o.renumber()
+	return nil
+}
+
+// Remove はワークアウトから種目を取り除く（削除する種目を返す）
+func (o *WorkoutExerciseOrder) Remove(id uint) (*WorkoutExercise, error) {
+	remaining := make(WorkoutExerciseOrder, 0, len(*o))
+	var removed *WorkoutExercise
+	for _, workoutExercise := range *o {
+		if workoutExercise.ID == id {
+			removed = workoutExercise
+			continue
+		}
+		remaining = append(remaining, workoutExercise)
+	}
+	if removed == nil {
+		return nil, fmt.Errorf("ワークアウトに含まれない種目です（ID: %d）", id)
+	}
+
+	*o = remaining
+	o.dissolveSmallGroups()
+	o.renumber()
+	return removed, nil
+}
+
+// NextPosition は末尾に追加する種目の順番
+func (o WorkoutExerciseOrder) NextPosition() int {
+	if len(o) == 0 {
+		return 1
+	}
+	return o[len(o)-1].Position + 1
+}
+
+func (o WorkoutExerciseOrder) byID() map[uint]*WorkoutExercise {
+	byID := make(map[uint]*WorkoutExercise, len(o))
+	for _, workoutExercise := range o {
+		byID[workoutExercise.ID] = workoutExercise
+	}
+	return byID
+}
+
+func (o WorkoutExerciseOrder) indexOfFirst(isMember map[uint]bool) int {
+	for i, workoutExercise := range o {
+		if isMember[workoutExercise.ID] {
+			return i
+		}
+	}
+	return -1
+}
+
+func (o WorkoutExerciseOrder) nextSupersetGroup() int {
+	next := 1
+	for _, workoutExercise := range o {
+		if workoutExercise.SupersetGroup != nil && *workoutExercise.SupersetGroup >= next {
+			next = *workoutExercise.SupersetGroup + 1
+		}
+	}
+	return next
+}
+
+// validateSupersets はスーパーセットの種目が連続して並んでいるか確認する
+func (o WorkoutExerciseOrder) validateSupersets() error {
+	seen := make(map[int]bool)
+	for i, workoutExercise := range o {
+		if workoutExercise.SupersetGroup == nil {
+			continue
+		}
+		group := *workoutExercise.SupersetGroup
+		continues := i > 0 && o[i-1].SupersetGroup != nil && *o[i-1].SupersetGroup == group
+		if seen[group] && !continues {
+			return fmt.Errorf("スーパーセットの種目は連続して並べてください")
+		}
+		seen[group] = true
+	}
+	return nil
+}
+
+// dissolveSmallGroups は2種目未満になったスーパーセットを解除する
+func (o WorkoutExerciseOrder) dissolveSmallGroups() {
+	counts := make(map[int]int)
+	for _, workoutExercise := range o {
+		if workoutExercise.SupersetGroup != nil {
+			counts[*workoutExercise.SupersetGroup]++
+		}
+	}
+	for _, workoutExercise := range o {
+		if workoutExercise.SupersetGroup != nil && counts[*workoutExercise.SupersetGroup] < minSupersetSize {
+			workoutExercise.SupersetGroup = nil
+		}
+	}
+}
+
+// renumber は並び順に順番を1から振り直す
+func (o WorkoutExerciseOrder) renumber() {
+	for i, workoutExercise := range o {
+		workoutExercise.Position = i + 1
+	}
+}
@@ -0,0 +1,125 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// newTestOrder は ID 1..n の種目を順に並べる（groups は ID ごとのスーパーセット）
+func newTestOrder(n int, groups map[uint]int) WorkoutExerciseOrder {
+	workoutExercises := make([]*WorkoutExercise, n)
+	for i := range workoutExercises {
+		id := uint(i + 1)
+		workoutExercises[i] = &WorkoutExercise{Model: gorm.Model{ID: id}, WorkoutID: 1, ExerciseID: id, Position: i + 1}
+		if group, ok := groups[id]; ok {
+			workoutExercises[i].SupersetGroup = &group
+		}
+	}
+	return NewWorkoutExerciseOrder(workoutExercises)
+}
+
+// describe は並び順のIDとスーパーセットを返す
+func describe(order WorkoutExerciseOrder) ([]uint, []int) {
+	ids := make([]uint, len(order))
+	groups := make([]int, len(order))
+	for i, workoutExercise := range order {
+		ids[i] = workoutExercise.ID
+		if workoutExercise.SupersetGroup != nil {
+			groups[i] = *workoutExercise.SupersetGroup
+		}
+	}
+	return ids, groups
+}
+
+func TestWorkoutExerciseOrder(t *testing.T) {
+	tests := []struct {
+		name           string
+		order          WorkoutExerciseOrder
+		apply          func(o *WorkoutExerciseOrder) error
+		expectedIDs    []uint
+		expectedGroups []int
+		expectErr      bool
+	}{
+		{
+			name:           "Reorder",
+			order:          newTestOrder(3, nil),
+			apply:          func(o *WorkoutExerciseOrder) error { return o.Reorder([]uint{3, 1, 2}) },
+			expectedIDs:    []uint{3, 1, 2},
+			expectedGroups: []int{0, 0, 0},
+		},
+		{
+			name:      "Reorder missing exercise",
+			order:     newTestOrder(3, nil),
+			apply:     func(o *WorkoutExerciseOrder) error { return o.Reorder([]uint{3, 1}) },
+			expectErr: true,
+		},
+		{
+			name:      "Reorder splits superset",
+			order:     newTestOrder(3, map[uint]int{1: 1, 2: 1}),
+			apply:     func(o *WorkoutExerciseOrder) error { return o.Reorder([]uint{1, 3, 2}) },
+			expectErr: true,
+		},
+		{
+			name:           "Group into superset",
+			order:          newTestOrder(4, nil),
+			apply:          func(o *WorkoutExerciseOrder) error { return o.GroupIntoSuperset([]uint{4, 2}) },
+			expectedIDs:    []uint{1, 4, 2, 3},
+			expectedGroups: []int{0, 1, 1, 0},
+		},
+		{
+			name:           "Group dissolves leftover superset",
+			order:          newTestOrder(4, map[uint]int{1: 1, 2: 1}),
+			apply:          func(o *WorkoutExerciseOrder) error { return o.GroupIntoSuperset([]uint{2, 3, 4}) },
+			expectedIDs:    []uint{1, 2, 3, 4},
+			expectedGroups: []int{0, 2, 2, 2},
+		},
+		{
+			name:      "Group single exercise",
+			order:     newTestOrder(2, nil),
+			apply:     func(o *WorkoutExerciseOrder) error { return o.GroupIntoSuperset([]uint{1}) },
+			expectErr: true,
+		},
+		{
+			name:           "Remove from circuit",
+			order:          newTestOrder(4, map[uint]int{1: 1, 2: 1, 3: 1}),
+			apply:          func(o *WorkoutExerciseOrder) error { return o.RemoveFromSuperset(1) },
+			expectedIDs:    []uint{2, 3, 1, 4},
+			expectedGroups: []int{1, 1, 0, 0},
+		},
+		{
+			name:      "Remove exercise not in superset",
+			order:     newTestOrder(2, nil),
+			apply:     func(o *WorkoutExerciseOrder) error { return o.RemoveFromSuperset(1) },
+			expectErr: true,
+		},
+		{
+			name:  "Remove dissolves superset",
+			order: newTestOrder(3, map[uint]int{1: 1, 2: 1}),
+			apply: func(o *WorkoutExerciseOrder) error {
+				_, err := o.Remove(2)
+				return err
+			},
+			expectedIDs:    []uint{1, 3},
+			expectedGroups: []int{0, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.apply(&tt.order)
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			for i, workoutExercise := range tt.order {
+				assert.Equal(t, i+1, workoutExercise.Position)
+			}
+			ids, groups := describe(tt.order)
+			assert.Equal(t, tt.expectedIDs, ids)
+			assert.Equal(t, tt.expectedGroups, groups)
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"app/graph/model"
+
+	"gorm.io/gorm"
+)
+
+type WorkoutGroupMemberRole string
+
+const (
+	WorkoutGroupMemberRoleOwner  WorkoutGroupMemberRole = "owner"  // 作成者（グループの削除・ロールの変更ができる）
+	WorkoutGroupMemberRoleAdmin  WorkoutGroupMemberRole = "admin"  // 管理者（グループの編集・メンバーの招待・削除ができる）
+	WorkoutGroupMemberRoleMember WorkoutGroupMemberRole = "member" // メンバー
+)
+
+type WorkoutGroupMemberStatus string
+
+const (
+	WorkoutGroupMemberStatusInvited  WorkoutGroupMemberStatus = "invited"  // 招待中
+	WorkoutGroupMemberStatusJoined   WorkoutGroupMemberStatus = "joined"   // 参加中
+	WorkoutGroupMemberStatusDeclined WorkoutGroupMemberStatus = "declined" // 招待を辞退
+	WorkoutGroupMemberStatusLeft     WorkoutGroupMemberStatus = "left"     // 退出（削除された場合を含む）
+)
+
+var workoutGroupMemberRolesToGraphQL = map[WorkoutGroupMemberRole]model.WorkoutGroupMemberRole{
+	WorkoutGroupMemberRoleOwner:  model.WorkoutGroupMemberRoleOwner,
+	WorkoutGroupMemberRoleAdmin:  model.WorkoutGroupMemberRoleAdmin,
+	WorkoutGroupMemberRoleMember: model.WorkoutGroupMemberRoleMember,
+}
+
+var workoutGroupMemberStatusesToGraphQL = map[WorkoutGroupMemberStatus]model.WorkoutGroupMemberStatus{
+	WorkoutGroupMemberStatusInvited:  model.WorkoutGroupMemberStatusInvited,
+	WorkoutGroupMemberStatusJoined:   model.WorkoutGroupMemberStatusJoined,
+	WorkoutGroupMemberStatusDeclined: model.WorkoutGroupMemberStatusDeclined,
+	WorkoutGroupMemberStatusLeft:     model.WorkoutGroupMemberStatusLeft,
+}
+
+// workoutGroupMemberRoleRanks はロールの強さ（大きいほど強い）
+var workoutGroupMemberRoleRanks = map[WorkoutGroupMemberRole]int{
+	WorkoutGroupMemberRoleMember: 1,
+	WorkoutGroupMemberRoleAdmin:  2,
+	WorkoutGroupMemberRoleOwner:  3,
+}
+
+// WorkoutGroupMember はワークアウトグループのメンバー（招待中・退出済みを含む）
+type WorkoutGroupMember struct {
+	gorm.Model
+	WorkoutGroupID uint                     `gorm:"not null;uniqueIndex:idx_workout_group_members_group_user"`
+	UserID         uint                     `gorm:"not null;uniqueIndex:idx_workout_group_members_group_user;index"`
+	Role           WorkoutGroupMemberRole   `gorm:"size:20;not null;default:member"`
+	Status         WorkoutGroupMemberStatus `gorm:"size:20;not null;default:invited"`
+	InvitedByID    *uint
+	JoinedAt       *time.Time
+
+	WorkoutGroup WorkoutGroup `gorm:"constraint:OnDelete:CASCADE;foreignKey:WorkoutGroupID"`
+	User         User         `gorm:"constraint:OnDelete:CASCADE;foreignKey:UserID"`
+	InvitedBy    *User        `gorm:"constraint:OnDelete:SET NULL;foreignKey:InvitedByID"`
+}
+
+func (m *WorkoutGroupMember) BeforeSave(tx *gorm.DB) error {
+	return m.Validate()
+}
+
+func (m *WorkoutGroupMember) Validate() error {
+	if m.WorkoutGroupID == 0 {
+		return fmt.Errorf("グループIDは必須です")
+	}
+	if m.UserID == 0 {
+		return fmt.Errorf("ユーザーIDは必須です")
+	}
+	if _, ok := workoutGroupMemberRolesToGraphQL[m.Role]; !ok {
+		return fmt.Errorf("無効なロールです: %s", m.Role)
+	}
+	if _, ok := workoutGroupMemberStatusesToGraphQL[m.Status]; !ok {
+		return fmt.Errorf("無効なステータスです: %s", m.Status)
+	}
+	if m.InvitedByID != nil && *m.InvitedByID == m.UserID {
+		return fmt.Errorf("自分自身を招待することはできません")
+	}
+	return nil
+}
+
+// IsJoined は参加中かどうか
+func (m *WorkoutGroupMember) IsJoined() bool {
+	return m.Status == WorkoutGroupMemberStatusJoined
+}
+
+// CanManageMembers はメンバーの招待・削除とグループの編集ができるかどうか（参加中の作成者・管理者）
+func (m *WorkoutGroupMember) CanManageMembers() bool {
+	return m.IsJoined() && m.Role.Outranks(WorkoutGroupMemberRoleMember)
+}
+
+// CanKick は指定したメンバーをグループから削除できるかどうか（自分より弱いロールのメンバーのみ）
+func (m *WorkoutGroupMember) CanKick(target *WorkoutGroupMember) bool {
+	if !m.CanManageMembers() || m.UserID == target.UserID {
+		return false
+	}
+	return m.Role.Outranks(target.Role)
+}
+
+// Invite は招待中にする（辞退・退出したユーザーは再度招待できる）
+func (m *WorkoutGroupMember) Invite(invitedByID uint) error {
+	if m.Status == WorkoutGroupMemberStatusInvited || m.Status == WorkoutGroupMemberStatusJoined {
+		return fmt.Errorf("すでに招待中または参加中のユーザーです")
+	}
+	m.Status = WorkoutGroupMemberStatusInvited
+	m.Role = WorkoutGroupMemberRoleMember
+	m.InvitedByID = &invitedByID
+	m.JoinedAt = nil
+	return nil
+}
+
+// Accept は招待を承認して参加する
+func (m *WorkoutGroupMember) Accept(now time.Time) error {
+	if m.Status != WorkoutGroupMemberStatusInvited {
+		return fmt.Errorf("招待されていません")
+	}
+	m.Status = WorkoutGroupMemberStatusJoined
+	m.JoinedAt = &now
+	return nil
+}
+
+// Decline は招待を辞退する
+func (m *WorkoutGroupMember) Decline() error {
+	if m.Status != WorkoutGroupMemberStatusInvited {
+		return fmt.Errorf("招待されていません")
+	}
+	m.Status = WorkoutGroupMemberStatusDeclined
+	return nil
+}
+
+// Leave はグループから退出する（作成者は退出できない）
+func (m *WorkoutGroupMember) Leave() error {
+	if !m.IsJoined() {
+		return fmt.Errorf("グループに参加していません")
+	}
+	if m.Role == WorkoutGroupMemberRoleOwner {
+		return fmt.Errorf("作成者はグループから退出できません")
+	}
+	m.Status = WorkoutGroupMemberStatusLeft
+	m.Role = WorkoutGroupMemberRoleMember
+	return nil
+}
+
+// Outranks は指定したロールより強いかどうか
+func (r WorkoutGroupMemberRole) Outranks(other WorkoutGroupMemberRole) bool {
+	return workoutGroupMemberRoleRanks[r] > workoutGroupMemberRoleRanks[other]
+}
+
+// ToGraphQL GraphQL enumに変換
+func (r WorkoutGroupMemberRole) ToGraphQL() model.WorkoutGroupMemberRole {
+	return workoutGroupMemberRolesToGraphQL[r]
+}
+
+// WorkoutGroupMemberRoleFromGraphQL GraphQL enumから変換
+func WorkoutGroupMemberRoleFromGraphQL(role model.WorkoutGroupMemberRole) WorkoutGroupMemberRole {
+	for entityRole, modelRole := range workoutGroupMemberRolesToGraphQL {
+		if modelRole == role {
+			return entityRole
+		}
+	}
+	return ""
+}
+
+// ToGraphQL GraphQL enumに変換
+func (s WorkoutGroupMemberStatus) ToGraphQL() model.WorkoutGroupMemberStatus {
+	return workoutGroupMemberStatusesToGraphQL[s]
+}
+
+// WorkoutGroupMemberStatusFromGraphQL GraphQL enumから変換
+func WorkoutGroupMemberStatusFromGraphQL(status model.WorkoutGroupMemberStatus) WorkoutGroupMemberStatus {
+	for entityStatus, modelStatus := range workoutGroupMemberStatusesToGraphQL {
+		if modelStatus == status {
+			return entityStatus
+		}
+	}
+	return ""
+}
@@ -0,0 +1,73 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkoutGroupMember_Lifecycle(t *testing.T) {
+	member := WorkoutGroupMember{
+		WorkoutGroupID: 1,
+		UserID:         2,
+		Role:           WorkoutGroupMemberRoleMember,
+		Status:         WorkoutGroupMemberStatusInvited,
+	}
+
+	// 招待中は再招待できない
+	assert.Error(t, member.Invite(3))
+
+	assert.NoError(t, member.Accept(time.Now()))
+	assert.True(t, member.IsJoined())
+	assert.NotNil(t, member.JoinedAt)
+	assert.Error(t, member.Decline())
+
+	assert.NoError(t, member.Leave())
+	assert.Equal(t, WorkoutGroupMemberStatusLeft, member.Status)
+
+	// 退出したユーザーは再度招待できる
+	assert.NoError(t, member.Invite(3))
+	assert.Equal(t, WorkoutGroupMemberStatusInvited, member.Status)
+	assert.Nil(t, member.JoinedAt)
+
+	assert.NoError(t, member.Decline())
+	assert.Equal(t, WorkoutGroupMemberStatusDeclined, member.Status)
+	assert.Error(t, member.Accept(time.Now()))
+}
+
+func TestWorkoutGroupMember_OwnerCannotLeave(t *testing.T) {
+	owner := WorkoutGroupMember{Role: WorkoutGroupMemberRoleOwner, Status: WorkoutGroupMemberStatusJoined}
+
+	assert.Error(t, owner.Leave())
+	assert.True(t, owner.IsJoined())
+}
+
+func TestWorkoutGroupMember_CanKick(t *testing.T) {
+	owner := &WorkoutGroupMember{UserID: 1, Role: WorkoutGroupMemberRoleOwner, Status: WorkoutGroupMemberStatusJoined}
+	admin := &WorkoutGroupMember{UserID: 2, Role: WorkoutGroupMemberRoleAdmin, Status: WorkoutGroupMemberStatusJoined}
+	otherAdmin := &WorkoutGroupMember{UserID: 3, Role: WorkoutGroupMemberRoleAdmin, Status: WorkoutGroupMemberStatusJoined}
+	member := &WorkoutGroupMember{UserID: 4, Role: WorkoutGroupMemberRoleMember, Status: WorkoutGroupMemberStatusJoined}
+	invitedAdmin := &WorkoutGroupMember{UserID: 5, Role: WorkoutGroupMemberRoleAdmin, Status: WorkoutGroupMemberStatusInvited}
+
+	tests := []struct {
+		name     string
+		actor    *WorkoutGroupMember
+		target   *WorkoutGroupMember
+		expected bool
+	}{
+		{name: "Owner can kick an admin", actor: owner, target: admin, expected: true},
+		{name: "Admin can kick a member", actor: admin, target: member, expected: true},
+		{name: "Admin cannot kick another admin", actor: admin, target: otherAdmin, expected: false},
+		{name: "Admin cannot kick the owner", actor: admin, target: owner, expected: false},
+		{name: "Member cannot kick anyone", actor: member, target: member, expected: false},
+		{name: "Invited admin cannot kick", actor: invitedAdmin, target: member, expected: false},
+		{name: "Owner cannot kick themselves", actor: owner, target: owner, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.actor.CanKick(tt.target))
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package entity
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// WorkoutTemplate は繰り返し行うワークアウトのテンプレート（ルーティン）
+type WorkoutTemplate struct {
+	gorm.Model
+	UserID      uint   `gorm:"not null;index"`
+	Name        string `gorm:"size:255;not null"`
+	Description string `gorm:"size:1000"`
+
+	User      User                      `gorm:"constraint:OnDelete:CASCADE;foreignKey:UserID"`
+	Exercises []WorkoutTemplateExercise `gorm:"foreignKey:WorkoutTemplateID;constraint:OnDelete:CASCADE"`
+}
+
+func (w *WorkoutTemplate) BeforeSave(tx *gorm.DB) error {
+	return w.Validate()
+}
+
+func (w *WorkoutTemplate) BeforeCreate(tx *gorm.DB) error {
+	return w.Validate()
+}
+
+func (w *WorkoutTemplate) BeforeUpdate(tx *gorm.DB) error {
+	return w.Validate()
+}
+
+func (w *WorkoutTemplate) Validate() error {
+	if w.UserID == 0 {
+		return fmt.Errorf("user_id は必須です")
+	}
+	if w.Name == "" {
+		return fmt.Errorf("テンプレート名は必須です")
+	}
+	if len(w.Name) > 255 {
+		return fmt.Errorf("テンプレート名は255文字以内で入力してください")
+	}
+	if len(w.Description) > 1000 {
+		return fmt.Errorf("説明は1000文字以内で入力してください")
+	}
+	return nil
+}
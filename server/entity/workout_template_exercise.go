@@ -0,0 +1,98 @@
+package entity
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// セット数の上限
+const maxTargetSets = 20
+
+// WorkoutTemplateExercise はテンプレートに含まれる種目と目標（順序付き）
+type WorkoutTemplateExercise struct {
+	gorm.Model
+	WorkoutTemplateID uint     `gorm:"not null;index"`
+	ExerciseID        uint     `gorm:"not null;index"`
+	Position          int      `gorm:"not null"` // 何番目の種目か
+	TargetSets        int      `gorm:"not null"` // 目標セット数
+	TargetReps        int      `gorm:"not null"` // 目標レップ数
+	TargetWeight      *float64 // 目標重量（kg）
+
+	WorkoutTemplate WorkoutTemplate `gorm:"constraint:OnDelete:CASCADE;foreignKey:WorkoutTemplateID"`
+	Exercise        Exercise        `gorm:"constraint:OnDelete:CASCADE;foreignKey:ExerciseID"`
+}
+
+func (w *WorkoutTemplateExercise) BeforeSave(tx *gorm.DB) error {
+	return w.Validate()
+}
+
+func (w *WorkoutTemplateExercise) BeforeCreate(tx *gorm.DB) error {
+	if err := w.Validate(); err != nil {
+		return err
+	}
+
+	// Exercise存在確認
+	var count int64
+	tx.Model(&Exercise{}).Where("id = ?", w.ExerciseID).Count(&count)
+	if count == 0 {
+		return fmt.Errorf("指定された exercise_id は存在しません")
+	}
+
+	return nil
+}
+
+func (w *WorkoutTemplateExercise) BeforeUpdate(tx *gorm.DB) error {
+	return w.Validate()
+}
+
+func (w *WorkoutTemplateExercise) Validate() error {
+	if w.ExerciseID == 0 {
+		return fmt.Errorf("exercise_id は必須です")
+	}
+	if w.Position <= 0 {
+		return fmt.Errorf("種目の順番は正の整数で入力してください")
+	}
+	if w.TargetSets <= 0 || w.TargetSets > maxTargetSets {
+		return fmt.Errorf("目標セット数は1〜%dの範囲で入力してください", maxTargetSets)
+	}
+	if w.TargetReps <= 0 {
+		return fmt.Errorf("目標レップ数は正の整数で入力してください")
+	}
+	if w.TargetWeight != nil && *w.TargetWeight <= 0 {
+		return fmt.Errorf("目標重量は正の数で入力してください")
+	}
+	return nil
+}
+
+// PlaceholderSetLogs は目標からプレースホルダーのセットを予定（未完了）として作成する
+// 種目の記録方法で必要な目標が揃わない場合は作成しない（重量で記録する種目は目標重量が未設定の場合、時間・距離で記録する種目は常に作成しない）
+func (w *WorkoutTemplateExercise) PlaceholderSetLogs(workoutExerciseID uint, trackingType TrackingType) []SetLog {
+	var weight float64
+	switch trackingType {
+	case TrackingTypeWeightReps, TrackingTypeAssistedWeight, "":
+		if w.TargetWeight == nil {
+			return nil
+		}
+		weight = *w.TargetWeight
+	case TrackingTypeRepsOnly:
+		// 自重の種目は加重する場合のみ目標重量を使う
+		if w.TargetWeight != nil {
+			weight = *w.TargetWeight
+		}
+	default:
+		return nil
+	}
+
+	setLogs := make([]SetLog, w.TargetSets)
+	for i := range setLogs {
+		setLogs[i] = SetLog{
+			WorkoutExerciseID: workoutExerciseID,
+			Weight:            weight,
+			RepCount:          w.TargetReps,
+			SetNumber:         i + 1,
+			Completed:         false,
+		}
+	}
+	return setLogs
+}
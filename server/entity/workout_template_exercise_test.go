@@ -0,0 +1,44 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkoutTemplateExercise_PlaceholderSetLogs(t *testing.T) {
+	weight := 60.0
+
+	tests := []struct {
+		name           string
+		trackingType   TrackingType
+		targetWeight   *float64
+		expectedSets   int
+		expectedWeight float64
+	}{
+		{name: "Weight and reps", trackingType: TrackingTypeWeightReps, targetWeight: &weight, expectedSets: 3, expectedWeight: 60},
+		{name: "Weight and reps without target weight", trackingType: TrackingTypeWeightReps},
+		{name: "Assisted weight", trackingType: TrackingTypeAssistedWeight, targetWeight: &weight, expectedSets: 3, expectedWeight: 60},
+		{name: "Reps only without target weight", trackingType: TrackingTypeRepsOnly, expectedSets: 3},
+		{name: "Reps only with added weight", trackingType: TrackingTypeRepsOnly, targetWeight: &weight, expectedSets: 3, expectedWeight: 60},
+		{name: "Duration", trackingType: TrackingTypeDuration, targetWeight: &weight},
+		{name: "Distance and duration", trackingType: TrackingTypeDistanceDuration, targetWeight: &weight},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			templateExercise := WorkoutTemplateExercise{ExerciseID: 1, Position: 1, TargetSets: 3, TargetReps: 10, TargetWeight: tt.targetWeight}
+
+			setLogs := templateExercise.PlaceholderSetLogs(100, tt.trackingType)
+			assert.Len(t, setLogs, tt.expectedSets)
+			for i, setLog := range setLogs {
+				assert.Equal(t, i+1, setLog.SetNumber)
+				assert.Equal(t, tt.expectedWeight, setLog.Weight)
+				assert.False(t, setLog.Completed)
+				// 保存時の検証を通るセットのみ作成する
+				assert.NoError(t, setLog.Validate())
+				assert.NoError(t, setLog.ValidateTracking(tt.trackingType))
+			}
+		})
+	}
+}
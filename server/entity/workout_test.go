@@ -0,0 +1,63 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkout_Lifecycle(t *testing.T) {
+	startedAt := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	workout := Workout{UserID: 1, Status: WorkoutStatusPlanned}
+
+	// 開始前のワークアウトは終了できない
+	assert.Error(t, workout.Finish(startedAt))
+	assert.Nil(t, workout.Duration())
+
+	assert.NoError(t, workout.Start(startedAt))
+	assert.Equal(t, WorkoutStatusInProgress, workout.Status)
+	assert.Error(t, workout.Start(startedAt))
+	assert.Error(t, workout.Resume(startedAt))
+
+	assert.NoError(t, workout.Finish(startedAt.Add(45*time.Minute)))
+	assert.Equal(t, WorkoutStatusCompleted, workout.Status)
+	assert.Equal(t, 45*time.Minute, *workout.Duration())
+
+	// 再開しても開始日時は変わらない
+	assert.NoError(t, workout.Resume(startedAt.Add(time.Hour)))
+	assert.Equal(t, WorkoutStatusInProgress, workout.Status)
+	assert.Equal(t, startedAt, *workout.StartedAt)
+	assert.Nil(t, workout.FinishedAt)
+}
+
+func TestWorkout_Validate(t *testing.T) {
+	startedAt := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	finishedAt := startedAt.Add(-time.Minute)
+	rpe := func(v int) *int { return &v }
+
+	tests := []struct {
+		name      string
+		workout   Workout
+		expectErr bool
+	}{
+		{name: "Valid", workout: Workout{UserID: 1, Status: WorkoutStatusCompleted, SessionRPE: rpe(8), Notes: "脚の日"}},
+		{name: "Missing user", workout: Workout{}, expectErr: true},
+		{name: "Invalid status", workout: Workout{UserID: 1, Status: "paused"}, expectErr: true},
+		{name: "Session RPE out of range", workout: Workout{UserID: 1, SessionRPE: rpe(11)}, expectErr: true},
+		{name: "Notes too long", workout: Workout{UserID: 1, Notes: strings.Repeat("あ", MaxWorkoutNotesLength+1)}, expectErr: true},
+		{name: "Finished before started", workout: Workout{UserID: 1, StartedAt: &startedAt, FinishedAt: &finishedAt}, expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.workout.Validate()
+			if tt.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
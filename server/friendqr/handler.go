@@ -0,0 +1,81 @@
+package friendqr
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+const (
+	// PNGPath はPNG画像のエンドポイントのパス
+	PNGPath = "/friend-qr.png"
+	// SVGPath はSVG画像のエンドポイントのパス
+	SVGPath = "/friend-qr.svg"
+)
+
+// ImageURL はトークンのQRコード画像の相対URLを返す
+func ImageURL(path, token string) string {
+	return path + "?token=" + url.QueryEscape(token)
+}
+
+// Handler はQRコードの画像を返す
+// GET /friend-qr.png?token=<トークン>&size=<ピクセル数> と GET /friend-qr.svg?token=<トークン>
+// 署名が正しく有効期限内のトークンのみ画像にする（任意の文字列のQRコードは作らない）
+type Handler struct {
+	signer *Signer
+	format string
+}
+
+// NewPNGHandler はPNG画像を返すHandlerを作成する
+func NewPNGHandler(signer *Signer) *Handler {
+	return &Handler{signer: signer, format: "png"}
+}
+
+// NewSVGHandler はSVG画像を返すHandlerを作成する
+func NewSVGHandler(signer *Signer) *Handler {
+	return &Handler{signer: signer, format: "svg"}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	token := r.URL.Query().Get("token")
+	if _, err := h.signer.Verify(token); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var (
+		image       []byte
+		contentType string
+		err         error
+	)
+	switch h.format {
+	case "svg":
+		image, err = SVG(token)
+		contentType = "image/svg+xml"
+	default:
+		size := DefaultPNGSize
+		if value := r.URL.Query().Get("size"); value != "" {
+			size, err = strconv.Atoi(value)
+			if err != nil || size <= 0 || size > MaxPNGSize {
+				http.Error(w, "size must be between 1 and "+strconv.Itoa(MaxPNGSize), http.StatusBadRequest)
+				return
+			}
+		}
+		image, err = PNG(token, size)
+		contentType = "image/png"
+	}
+	if err != nil {
+		http.Error(w, "failed to render QR code", http.StatusInternalServerError)
+		return
+	}
+
+	// トークンは短時間で失効するためキャッシュさせない
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Cache-Control", "no-store")
+	w.Write(image)
+}
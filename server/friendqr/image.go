@@ -0,0 +1,46 @@
+package friendqr
+
+import (
+	"bytes"
+	"fmt"
+
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+const (
+	// DefaultPNGSize はPNGのデフォルトの一辺のピクセル数
+	DefaultPNGSize = 256
+	// MaxPNGSize はPNGの一辺の最大ピクセル数
+	MaxPNGSize = 1024
+)
+
+// PNG はトークンのQRコードをPNG画像にする
+func PNG(token string, size int) ([]byte, error) {
+	code, err := qrcode.New(token, qrcode.Medium)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode QR code: %w", err)
+	}
+	return code.PNG(size)
+}
+
+// SVG はトークンのQRコードをSVG画像にする（1モジュールを1単位とし、表示サイズはクライアントで指定する）
+func SVG(token string) ([]byte, error) {
+	code, err := qrcode.New(token, qrcode.Medium)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode QR code: %w", err)
+	}
+
+	bitmap := code.Bitmap()
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %[1]d %[1]d" shape-rendering="crispEdges">`, len(bitmap))
+	fmt.Fprintf(&buf, `<rect width="%[1]d" height="%[1]d" fill="#fff"/><path fill="#000" d="`, len(bitmap))
+	for y, row := range bitmap {
+		for x, black := range row {
+			if black {
+				fmt.Fprintf(&buf, "M%d %dh1v1h-1z", x, y)
+			}
+		}
+	}
+	buf.WriteString(`"/></svg>`)
+	return buf.Bytes(), nil
+}
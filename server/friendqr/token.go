@@ -0,0 +1,134 @@
+package friendqr
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+)
+
+// TokenTTL はQRコードのトークンの有効期間
+const TokenTTL = 5 * time.Minute
+
+var (
+	// ErrInvalidToken はトークンの形式または署名が不正
+	ErrInvalidToken = errors.New("invalid QR code token")
+	// ErrExpiredToken はトークンの有効期限が切れている
+	ErrExpiredToken = errors.New("QR code token has expired")
+)
+
+// Claims はトークンに含まれる情報
+type Claims struct {
+	UserID    uint      // QRコードを表示したユーザー
+	TokenID   string    // 1回限りのトークンの使用済みの記録に使う
+	SingleUse bool      // trueの場合は1回だけ友達追加に使える
+	ExpiresAt time.Time // 有効期限
+}
+
+// payload はトークンに署名して埋め込むJSON
+type payload struct {
+	UserID    uint   `json:"sub"`
+	TokenID   string `json:"jti"`
+	SingleUse bool   `json:"once,omitempty"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// Signer はサーバーの秘密鍵（HMAC-SHA256）でQRコードのトークンを発行・検証する
+// トークンは"<base64urlのペイロード>.<base64urlの署名>"の形式
+type Signer struct {
+	secret []byte
+	ttl    time.Duration
+	now    func() time.Time
+}
+
+func NewSigner(secret []byte) *Signer {
+	return &Signer{secret: secret, ttl: TokenTTL, now: time.Now}
+}
+
+// NewSignerFromEnv は環境変数FRIEND_QR_SECRETを秘密鍵にしたSignerを作成する
+// 未設定の場合はランダムな秘密鍵を使う（再起動や別のインスタンスでは発行済みのトークンを検証できない）
+func NewSignerFromEnv() (*Signer, error) {
+	if secret := os.Getenv("FRIEND_QR_SECRET"); secret != "" {
+		return NewSigner([]byte(secret)), nil
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, fmt.Errorf("failed to generate QR code secret: %w", err)
+	}
+	log.Println("⚠️ FRIEND_QR_SECRETが未設定のため、ランダムな秘密鍵でQRコードに署名します")
+	return NewSigner(secret), nil
+}
+
+// Issue はユーザーのQRコードのトークンを発行する
+func (s *Signer) Issue(userID uint, singleUse bool) (string, Claims, error) {
+	tokenID := make([]byte, 16)
+	if _, err := rand.Read(tokenID); err != nil {
+		return "", Claims{}, fmt.Errorf("failed to generate token ID: %w", err)
+	}
+
+	claims := Claims{
+		UserID:    userID,
+		TokenID:   hex.EncodeToString(tokenID),
+		SingleUse: singleUse,
+		ExpiresAt: s.now().Add(s.ttl).Truncate(time.Second),
+	}
+	body, err := json.Marshal(payload{
+		UserID:    claims.UserID,
+		TokenID:   claims.TokenID,
+		SingleUse: claims.SingleUse,
+		ExpiresAt: claims.ExpiresAt.Unix(),
+	})
+	if err != nil {
+		return "", Claims{}, fmt.Errorf("failed to encode token: %w", err)
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(body)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(s.sign(encoded)), claims, nil
+}
+
+// Verify はトークンの署名と有効期限を検証する
+func (s *Signer) Verify(token string) (*Claims, error) {
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+	decodedSignature, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(decodedSignature, s.sign(encoded)) {
+		return nil, ErrInvalidToken
+	}
+
+	body, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	var p payload
+	if err := json.Unmarshal(body, &p); err != nil || p.UserID == 0 || p.TokenID == "" {
+		return nil, ErrInvalidToken
+	}
+
+	claims := &Claims{
+		UserID:    p.UserID,
+		TokenID:   p.TokenID,
+		SingleUse: p.SingleUse,
+		ExpiresAt: time.Unix(p.ExpiresAt, 0),
+	}
+	if !s.now().Before(claims.ExpiresAt) {
+		return nil, ErrExpiredToken
+	}
+	return claims, nil
+}
+
+func (s *Signer) sign(encoded string) []byte {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(encoded))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,92 @@
+package friendqr
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSigner(now time.Time) *Signer {
+	signer := NewSigner([]byte("test-secret"))
+	signer.now = func() time.Time { return now }
+	return signer
+}
+
+func TestSigner_IssueAndVerify(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	signer := newTestSigner(now)
+
+	token, issued, err := signer.Issue(42, true)
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(TokenTTL), issued.ExpiresAt)
+
+	claims, err := signer.Verify(token)
+	require.NoError(t, err)
+	assert.Equal(t, uint(42), claims.UserID)
+	assert.Equal(t, issued.TokenID, claims.TokenID)
+	assert.True(t, claims.SingleUse)
+
+	// 毎回異なるトークンを発行する
+	other, _, err := signer.Issue(42, true)
+	require.NoError(t, err)
+	assert.NotEqual(t, token, other)
+}
+
+func TestSigner_VerifyRejects(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	token, _, err := newTestSigner(now).Issue(42, false)
+	require.NoError(t, err)
+	encoded, signature, _ := strings.Cut(token, ".")
+
+	// 他のユーザーIDのペイロードに差し替えたトークン
+	forged, _, err := newTestSigner(now).Issue(7, false)
+	require.NoError(t, err)
+	forgedPayload, _, _ := strings.Cut(forged, ".")
+
+	tests := []struct {
+		name        string
+		signer      *Signer
+		token       string
+		expectError error
+	}{
+		{name: "Expired", signer: newTestSigner(now.Add(TokenTTL)), token: token, expectError: ErrExpiredToken},
+		{name: "Swapped payload", signer: newTestSigner(now), token: forgedPayload + "." + signature, expectError: ErrInvalidToken},
+		{name: "Other secret", signer: &Signer{secret: []byte("other"), now: func() time.Time { return now }}, token: token, expectError: ErrInvalidToken},
+		{name: "Missing signature", signer: newTestSigner(now), token: encoded, expectError: ErrInvalidToken},
+		{name: "Raw user ID", signer: newTestSigner(now), token: "42", expectError: ErrInvalidToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.signer.Verify(tt.token)
+			assert.ErrorIs(t, err, tt.expectError)
+		})
+	}
+}
+
+func TestHandler(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	signer := newTestSigner(now)
+	token, _, err := signer.Issue(42, false)
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	NewPNGHandler(signer).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/friend-qr.png?size=128&token="+token, nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "image/png", rec.Header().Get("Content-Type"))
+	assert.True(t, strings.HasPrefix(rec.Body.String(), "\x89PNG"))
+
+	rec = httptest.NewRecorder()
+	NewSVGHandler(signer).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/friend-qr.svg?token="+token, nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.True(t, strings.HasPrefix(rec.Body.String(), "<svg"))
+
+	rec = httptest.NewRecorder()
+	NewPNGHandler(signer).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/friend-qr.png?token=hello", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
@@ -0,0 +1,39 @@
+package graph
+
+import (
+	"app/graph/model"
+	"app/graph/services"
+	"context"
+)
+
+// ================================
+// Query
+// ================================
+
+// BodyMeasurements is the resolver for the bodyMeasurements field.
+func (r *queryResolver) BodyMeasurements(ctx context.Context, from *string, to *string) ([]*model.BodyMeasurement, error) {
+	bodyMeasurementService := services.NewBodyMeasurementServiceWithSeparation(r.DB)
+	return bodyMeasurementService.GetBodyMeasurements(ctx, from, to)
+}
+
+// BodyMeasurementTrend is the resolver for the bodyMeasurementTrend field.
+func (r *queryResolver) BodyMeasurementTrend(ctx context.Context, metric model.BodyMeasurementMetric, from string, to string, windowDays *int32) (*model.BodyMeasurementTrend, error) {
+	bodyMeasurementService := services.NewBodyMeasurementServiceWithSeparation(r.DB)
+	return bodyMeasurementService.GetBodyMeasurementTrend(ctx, metric, from, to, windowDays)
+}
+
+// ================================
+// Mutation
+// ================================
+
+// LogBodyMeasurement is the resolver for the logBodyMeasurement field.
+func (r *mutationResolver) LogBodyMeasurement(ctx context.Context, input model.LogBodyMeasurement) (*model.BodyMeasurement, error) {
+	bodyMeasurementService := services.NewBodyMeasurementServiceWithSeparation(r.DB)
+	return bodyMeasurementService.LogBodyMeasurement(ctx, input)
+}
+
+// DeleteBodyMeasurement is the resolver for the deleteBodyMeasurement field.
+func (r *mutationResolver) DeleteBodyMeasurement(ctx context.Context, input model.DeleteBodyMeasurement) (bool, error) {
+	bodyMeasurementService := services.NewBodyMeasurementServiceWithSeparation(r.DB)
+	return bodyMeasurementService.DeleteBodyMeasurement(ctx, input)
+}
@@ -0,0 +1,43 @@
+package graph
+
+import (
+	"app/graph/errcode"
+	"app/graph/services/common"
+	"app/middleware"
+	"context"
+
+	"github.com/99designs/gqlgen/graphql"
+	"gorm.io/gorm"
+)
+
+// NewDirectives はスキーマで宣言したディレクティブの実装を作成する
+func NewDirectives(db *gorm.DB) DirectiveRoot {
+	return DirectiveRoot{
+		Auth:  authDirective,
+		Admin: adminDirective(db),
+	}
+}
+
+// authDirective はログインしていない場合にUNAUTHENTICATEDエラーを返す
+func authDirective(ctx context.Context, obj interface{}, next graphql.Resolver) (interface{}, error) {
+	if _, err := middleware.GetUserFromContext(ctx); err != nil {
+		return nil, errcode.NewUnauthenticated(ctx, "authentication required")
+	}
+	return next(ctx)
+}
+
+// adminDirective は管理者ロールを持たない場合にFORBIDDENエラーを返す
+func adminDirective(db *gorm.DB) func(ctx context.Context, obj interface{}, next graphql.Resolver) (interface{}, error) {
+	return func(ctx context.Context, obj interface{}, next graphql.Resolver) (interface{}, error) {
+		if _, err := middleware.GetUserFromContext(ctx); err != nil {
+			return nil, errcode.NewUnauthenticated(ctx, "authentication required")
+		}
+
+		user, err := common.NewCommonRepository(db).GetCurrentUser(ctx)
+		if err != nil || !user.IsAdmin() {
+			return nil, errcode.NewForbidden(ctx, "admin role required")
+		}
+
+		return next(ctx)
+	}
+}
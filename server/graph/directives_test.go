@@ -0,0 +1,36 @@
+package graph
+
+import (
+	"app/graph/errcode"
+	"app/middleware"
+	"context"
+	"testing"
+
+	firebaseAuth "firebase.google.com/go/v4/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+func TestAuthDirective(t *testing.T) {
+	next := func(ctx context.Context) (interface{}, error) {
+		return "ok", nil
+	}
+
+	t.Run("Anonymous request is rejected with UNAUTHENTICATED", func(t *testing.T) {
+		res, err := authDirective(context.Background(), nil, next)
+
+		assert.Nil(t, res)
+		assert.True(t, errcode.Is(err, errcode.Unauthenticated))
+		gqlErr, ok := err.(*gqlerror.Error)
+		assert.True(t, ok)
+		assert.Equal(t, "UNAUTHENTICATED", gqlErr.Extensions["code"])
+	})
+
+	t.Run("Authenticated request is resolved", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), middleware.UserContextKey, &firebaseAuth.Token{UID: "user-1"})
+		res, err := authDirective(ctx, nil, next)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "ok", res)
+	})
+}
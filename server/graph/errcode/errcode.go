@@ -0,0 +1,50 @@
+package errcode
+
+import (
+	"context"
+	"errors"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// Code はクライアントが処理を分岐するためのエラーコード（extensions.code）
+type Code string
+
+const (
+	Unauthenticated Code = "UNAUTHENTICATED" // 未ログイン・トークンが無効
+	Forbidden       Code = "FORBIDDEN"       // 権限がない
+)
+
+// New はエラーコード付きのGraphQLエラーを作成する（サービス層でラップされてもコードは維持される）
+func New(ctx context.Context, code Code, message string) error {
+	err := &gqlerror.Error{
+		Message: message,
+		Extensions: map[string]interface{}{
+			"code": string(code),
+		},
+	}
+	if graphql.GetFieldContext(ctx) != nil {
+		err.Path = graphql.GetPath(ctx)
+	}
+	return err
+}
+
+// NewUnauthenticated は未認証エラーを作成する
+func NewUnauthenticated(ctx context.Context, message string) error {
+	return New(ctx, Unauthenticated, message)
+}
+
+// NewForbidden は権限エラーを作成する
+func NewForbidden(ctx context.Context, message string) error {
+	return New(ctx, Forbidden, message)
+}
+
+// Is はエラー（ラップされたものを含む）が指定したコードを持つかどうか
+func Is(err error, code Code) bool {
+	var gqlErr *gqlerror.Error
+	if !errors.As(err, &gqlErr) {
+		return false
+	}
+	return gqlErr.Extensions["code"] == string(code)
+}
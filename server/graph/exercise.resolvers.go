@@ -6,11 +6,63 @@ import (
 	"context"
 )
 
+// ================================
+// Model
+// ================================
+
+// Exercise returns ExerciseResolver implementation.
+func (r *Resolver) Exercise() ExerciseResolver { return &exerciseResolver{r} }
+
+type exerciseResolver struct{ *Resolver }
+
+// Owner is the resolver for the owner field.
+func (r *exerciseResolver) Owner(ctx context.Context, obj *model.Exercise) (*model.User, error) {
+	if obj.OwnerID == nil {
+		return nil, nil
+	}
+	userService := services.NewUserServiceWithSeparation(r.DB)
+	return userService.GetUserByIDWithDataLoader(ctx, *obj.OwnerID)
+}
+
+// MyRecords is the resolver for the myRecords field.
+func (r *exerciseResolver) MyRecords(ctx context.Context, obj *model.Exercise, history *bool) ([]*model.PersonalRecord, error) {
+	personalRecordService := services.NewPersonalRecordServiceWithSeparation(r.DB)
+	return personalRecordService.GetMyRecordsByExerciseID(ctx, obj.ID, history != nil && *history)
+}
+
 // ================================
 // Query
 // ================================
 // Exercises is the resolver for the exercises field.
-func (r *queryResolver) Exercises(ctx context.Context) ([]*model.Exercise, error) {
+func (r *queryResolver) Exercises(ctx context.Context, filter *model.ExerciseFilter, orderBy *model.ExerciseOrderBy) ([]*model.Exercise, error) {
+	exerciseService := services.NewExerciseServiceWithSeparation(r.DB)
+	return exerciseService.GetExercises(ctx, filter, orderBy)
+}
+
+// ================================
+// Mutation
+// ================================
+
+// CreateExercise is the resolver for the createExercise field.
+func (r *mutationResolver) CreateExercise(ctx context.Context, input model.CreateExercise) (*model.Exercise, error) {
+	exerciseService := services.NewExerciseServiceWithSeparation(r.DB)
+	return exerciseService.CreateExercise(ctx, input)
+}
+
+// UpdateExercise is the resolver for the updateExercise field.
+func (r *mutationResolver) UpdateExercise(ctx context.Context, input model.UpdateExercise) (*model.Exercise, error) {
+	exerciseService := services.NewExerciseServiceWithSeparation(r.DB)
+	return exerciseService.UpdateExercise(ctx, input)
+}
+
+// ArchiveExercise is the resolver for the archiveExercise field.
+func (r *mutationResolver) ArchiveExercise(ctx context.Context, input model.ArchiveExercise) (*model.Exercise, error) {
+	exerciseService := services.NewExerciseServiceWithSeparation(r.DB)
+	return exerciseService.ArchiveExercise(ctx, input)
+}
+
+// PromoteExercise is the resolver for the promoteExercise field.
+func (r *mutationResolver) PromoteExercise(ctx context.Context, input model.PromoteExercise) (*model.Exercise, error) {
 	exerciseService := services.NewExerciseServiceWithSeparation(r.DB)
-	return exerciseService.GetExercises(ctx)
+	return exerciseService.PromoteExercise(ctx, input)
 }
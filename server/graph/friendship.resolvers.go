@@ -27,30 +27,92 @@ func (r *friendshipResolver) Requestee(ctx context.Context, obj *model.Friendshi
 	return userService.GetUserByIDWithDataLoader(ctx, obj.RequesteeID)
 }
 
+// ================================
+// Query
+// ================================
+
+// BlockedUsers is the resolver for the blockedUsers field.
+func (r *queryResolver) BlockedUsers(ctx context.Context) ([]*model.User, error) {
+	friendshipService := services.NewFriendshipServiceWithSeparation(r.DB, r.PubSub, r.FriendQR)
+	return friendshipService.GetBlockedUsers(ctx)
+}
+
+// GenerateFriendQRToken is the resolver for the generateFriendQRToken field.
+func (r *queryResolver) GenerateFriendQRToken(ctx context.Context, singleUse *bool) (*model.FriendQRToken, error) {
+	friendshipService := services.NewFriendshipServiceWithSeparation(r.DB, r.PubSub, r.FriendQR)
+	return friendshipService.GenerateFriendQRToken(ctx, singleUse != nil && *singleUse)
+}
+
+// FriendRecommendations is the resolver for the friendRecommendations field.
+func (r *queryResolver) FriendRecommendations(ctx context.Context, first *int32) ([]*model.FriendRecommendation, error) {
+	recommendationService := services.NewRecommendationServiceWithSeparation(r.DB)
+	return recommendationService.GetFriendRecommendations(ctx, first)
+}
+
 // ================================
 // Mutation
 // ================================
 
 // SendFriendshipRequest is the resolver for the sendFriendshipRequest field.
 func (r *mutationResolver) SendFriendshipRequest(ctx context.Context, input model.SendFriendshipRequest) (*model.Friendship, error) {
-	friendshipService := services.NewFriendshipServiceWithSeparation(r.DB)
+	friendshipService := services.NewFriendshipServiceWithSeparation(r.DB, r.PubSub, r.FriendQR)
 	return friendshipService.SendFriendshipRequest(ctx, input)
 }
 
 // AcceptFriendshipRequest is the resolver for the acceptFriendshipRequest field.
 func (r *mutationResolver) AcceptFriendshipRequest(ctx context.Context, input model.AcceptFriendshipRequest) (*model.Friendship, error) {
-	friendshipService := services.NewFriendshipServiceWithSeparation(r.DB)
+	friendshipService := services.NewFriendshipServiceWithSeparation(r.DB, r.PubSub, r.FriendQR)
 	return friendshipService.AcceptFriendshipRequest(ctx, input)
 }
 
 // RejectFriendshipRequest is the resolver for the rejectFriendshipRequest field.
 func (r *mutationResolver) RejectFriendshipRequest(ctx context.Context, input model.RejectFriendshipRequest) (*model.Friendship, error) {
-	friendshipService := services.NewFriendshipServiceWithSeparation(r.DB)
+	friendshipService := services.NewFriendshipServiceWithSeparation(r.DB, r.PubSub, r.FriendQR)
 	return friendshipService.RejectFriendshipRequest(ctx, input)
 }
 
 // AddFriendByQRCode is the resolver for the addFriendByQRCode field.
 func (r *mutationResolver) AddFriendByQRCode(ctx context.Context, input model.AddFriendByQRCode) (*model.Friendship, error) {
-	friendshipService := services.NewFriendshipServiceWithSeparation(r.DB)
+	friendshipService := services.NewFriendshipServiceWithSeparation(r.DB, r.PubSub, r.FriendQR)
 	return friendshipService.AddFriendByQRCode(ctx, input)
 }
+
+// CancelFriendshipRequest is the resolver for the cancelFriendshipRequest field.
+func (r *mutationResolver) CancelFriendshipRequest(ctx context.Context, input model.CancelFriendshipRequest) (bool, error) {
+	friendshipService := services.NewFriendshipServiceWithSeparation(r.DB, r.PubSub, r.FriendQR)
+	return friendshipService.CancelFriendshipRequest(ctx, input)
+}
+
+// RemoveFriend is the resolver for the removeFriend field.
+func (r *mutationResolver) RemoveFriend(ctx context.Context, input model.RemoveFriend) (bool, error) {
+	friendshipService := services.NewFriendshipServiceWithSeparation(r.DB, r.PubSub, r.FriendQR)
+	return friendshipService.RemoveFriend(ctx, input)
+}
+
+// BlockUser is the resolver for the blockUser field.
+func (r *mutationResolver) BlockUser(ctx context.Context, input model.BlockUser) (bool, error) {
+	friendshipService := services.NewFriendshipServiceWithSeparation(r.DB, r.PubSub, r.FriendQR)
+	return friendshipService.BlockUser(ctx, input)
+}
+
+// UnblockUser is the resolver for the unblockUser field.
+func (r *mutationResolver) UnblockUser(ctx context.Context, input model.UnblockUser) (bool, error) {
+	friendshipService := services.NewFriendshipServiceWithSeparation(r.DB, r.PubSub, r.FriendQR)
+	return friendshipService.UnblockUser(ctx, input)
+}
+
+// DismissRecommendation is the resolver for the dismissRecommendation field.
+func (r *mutationResolver) DismissRecommendation(ctx context.Context, input model.DismissRecommendation) (bool, error) {
+	recommendationService := services.NewRecommendationServiceWithSeparation(r.DB)
+	return recommendationService.DismissRecommendation(ctx, input)
+}
+
+// ================================
+// Subscription
+// ================================
+
+// FriendshipRequestReceived is the resolver for the friendshipRequestReceived field.
+func (r *subscriptionResolver) FriendshipRequestReceived(ctx context.Context) (<-chan *model.Friendship, error) {
+	friendshipService := services.NewFriendshipServiceWithSeparation(r.DB, r.PubSub, r.FriendQR)
+	return friendshipService.SubscribeFriendshipRequestReceived(ctx)
+}
@@ -154,3 +154,968 @@ func (f *FriendshipStatus) UnmarshalJSON(b []byte) error {
 	}
 	return nil
 }
+
+// WeightUnit enum
+type WeightUnit int
+
+const (
+	WeightUnitKg WeightUnit = iota
+	WeightUnitLb
+)
+
+func (w WeightUnit) String() string {
+	switch w {
+	case WeightUnitKg:
+		return "KG"
+	case WeightUnitLb:
+		return "LB"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+func (w WeightUnit) MarshalJSON() ([]byte, error) {
+	return []byte(fmt.Sprintf(`"%s"`, w.String())), nil
+}
+
+func (w *WeightUnit) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	switch s {
+	case "KG":
+		*w = WeightUnitKg
+	case "LB":
+		*w = WeightUnitLb
+	default:
+		return fmt.Errorf("unexpected weight unit value %q", s)
+	}
+	return nil
+}
+
+// PersonalRecordType enum
+type PersonalRecordType int
+
+const (
+	PersonalRecordTypeHeaviestWeight PersonalRecordType = iota
+	PersonalRecordTypeMostReps
+	PersonalRecordTypeEstimatedOneRepMax
+	PersonalRecordTypeSessionVolume
+)
+
+func (p PersonalRecordType) String() string {
+	switch p {
+	case PersonalRecordTypeHeaviestWeight:
+		return "HEAVIEST_WEIGHT"
+	case PersonalRecordTypeMostReps:
+		return "MOST_REPS"
+	case PersonalRecordTypeEstimatedOneRepMax:
+		return "ESTIMATED_ONE_REP_MAX"
+	case PersonalRecordTypeSessionVolume:
+		return "SESSION_VOLUME"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+func (p PersonalRecordType) MarshalJSON() ([]byte, error) {
+	return []byte(fmt.Sprintf(`"%s"`, p.String())), nil
+}
+
+func (p *PersonalRecordType) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	switch s {
+	case "HEAVIEST_WEIGHT":
+		*p = PersonalRecordTypeHeaviestWeight
+	case "MOST_REPS":
+		*p = PersonalRecordTypeMostReps
+	case "ESTIMATED_ONE_REP_MAX":
+		*p = PersonalRecordTypeEstimatedOneRepMax
+	case "SESSION_VOLUME":
+		*p = PersonalRecordTypeSessionVolume
+	default:
+		return fmt.Errorf("unexpected personal record type value %q", s)
+	}
+	return nil
+}
+
+// OneRepMaxFormula enum
+type OneRepMaxFormula int
+
+const (
+	OneRepMaxFormulaEpley OneRepMaxFormula = iota
+	OneRepMaxFormulaBrzycki
+)
+
+func (o OneRepMaxFormula) String() string {
+	switch o {
+	case OneRepMaxFormulaEpley:
+		return "EPLEY"
+	case OneRepMaxFormulaBrzycki:
+		return "BRZYCKI"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+func (o OneRepMaxFormula) MarshalJSON() ([]byte, error) {
+	return []byte(fmt.Sprintf(`"%s"`, o.String())), nil
+}
+
+func (o *OneRepMaxFormula) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	switch s {
+	case "EPLEY":
+		*o = OneRepMaxFormulaEpley
+	case "BRZYCKI":
+		*o = OneRepMaxFormulaBrzycki
+	default:
+		return fmt.Errorf("unexpected one rep max formula value %q", s)
+	}
+	return nil
+}
+
+// ProgramTargetType enum
+type ProgramTargetType int
+
+const (
+	ProgramTargetTypePercentOneRepMax ProgramTargetType = iota
+	ProgramTargetTypeRpe
+)
+
+func (p ProgramTargetType) String() string {
+	switch p {
+	case ProgramTargetTypePercentOneRepMax:
+		return "PERCENT_ONE_REP_MAX"
+	case ProgramTargetTypeRpe:
+		return "RPE"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+func (p ProgramTargetType) MarshalJSON() ([]byte, error) {
+	return []byte(fmt.Sprintf(`"%s"`, p.String())), nil
+}
+
+func (p *ProgramTargetType) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	switch s {
+	case "PERCENT_ONE_REP_MAX":
+		*p = ProgramTargetTypePercentOneRepMax
+	case "RPE":
+		*p = ProgramTargetTypeRpe
+	default:
+		return fmt.Errorf("unexpected program target type value %q", s)
+	}
+	return nil
+}
+
+// ExerciseVisibility enum
+type ExerciseVisibility int
+
+const (
+	ExerciseVisibilityPrivate ExerciseVisibility = iota
+	ExerciseVisibilityFriends
+	ExerciseVisibilityPublic
+)
+
+func (e ExerciseVisibility) String() string {
+	switch e {
+	case ExerciseVisibilityPrivate:
+		return "PRIVATE"
+	case ExerciseVisibilityFriends:
+		return "FRIENDS"
+	case ExerciseVisibilityPublic:
+		return "PUBLIC"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+func (e ExerciseVisibility) MarshalJSON() ([]byte, error) {
+	return []byte(fmt.Sprintf(`"%s"`, e.String())), nil
+}
+
+func (e *ExerciseVisibility) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	switch s {
+	case "PRIVATE":
+		*e = ExerciseVisibilityPrivate
+	case "FRIENDS":
+		*e = ExerciseVisibilityFriends
+	case "PUBLIC":
+		*e = ExerciseVisibilityPublic
+	default:
+		return fmt.Errorf("unexpected exercise visibility value %q", s)
+	}
+	return nil
+}
+
+// MuscleGroup enum
+type MuscleGroup int
+
+const (
+	MuscleGroupChest MuscleGroup = iota
+	MuscleGroupBack
+	MuscleGroupLats
+	MuscleGroupTraps
+	MuscleGroupShoulders
+	MuscleGroupBiceps
+	MuscleGroupTriceps
+	MuscleGroupForearms
+	MuscleGroupAbs
+	MuscleGroupObliques
+	MuscleGroupLowerBack
+	MuscleGroupGlutes
+	MuscleGroupQuadriceps
+	MuscleGroupHamstrings
+	MuscleGroupAdductors
+	MuscleGroupCalves
+)
+
+func (m MuscleGroup) String() string {
+	switch m {
+	case MuscleGroupChest:
+		return "CHEST"
+	case MuscleGroupBack:
+		return "BACK"
+	case MuscleGroupLats:
+		return "LATS"
+	case MuscleGroupTraps:
+		return "TRAPS"
+	case MuscleGroupShoulders:
+		return "SHOULDERS"
+	case MuscleGroupBiceps:
+		return "BICEPS"
+	case MuscleGroupTriceps:
+		return "TRICEPS"
+	case MuscleGroupForearms:
+		return "FOREARMS"
+	case MuscleGroupAbs:
+		return "ABS"
+	case MuscleGroupObliques:
+		return "OBLIQUES"
+	case MuscleGroupLowerBack:
+		return "LOWER_BACK"
+	case MuscleGroupGlutes:
+		return "GLUTES"
+	case MuscleGroupQuadriceps:
+		return "QUADRICEPS"
+	case MuscleGroupHamstrings:
+		return "HAMSTRINGS"
+	case MuscleGroupAdductors:
+		return "ADDUCTORS"
+	case MuscleGroupCalves:
+		return "CALVES"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+func (m MuscleGroup) MarshalJSON() ([]byte, error) {
+	return []byte(fmt.Sprintf(`"%s"`, m.String())), nil
+}
+
+func (m *MuscleGroup) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	switch s {
+	case "CHEST":
+		*m = MuscleGroupChest
+	case "BACK":
+		*m = MuscleGroupBack
+	case "LATS":
+		*m = MuscleGroupLats
+	case "TRAPS":
+		*m = MuscleGroupTraps
+	case "SHOULDERS":
+		*m = MuscleGroupShoulders
+	case "BICEPS":
+		*m = MuscleGroupBiceps
+	case "TRICEPS":
+		*m = MuscleGroupTriceps
+	case "FOREARMS":
+		*m = MuscleGroupForearms
+	case "ABS":
+		*m = MuscleGroupAbs
+	case "OBLIQUES":
+		*m = MuscleGroupObliques
+	case "LOWER_BACK":
+		*m = MuscleGroupLowerBack
+	case "GLUTES":
+		*m = MuscleGroupGlutes
+	case "QUADRICEPS":
+		*m = MuscleGroupQuadriceps
+	case "HAMSTRINGS":
+		*m = MuscleGroupHamstrings
+	case "ADDUCTORS":
+		*m = MuscleGroupAdductors
+	case "CALVES":
+		*m = MuscleGroupCalves
+	default:
+		return fmt.Errorf("unexpected muscle group value %q", s)
+	}
+	return nil
+}
+
+// Equipment enum
+type Equipment int
+
+const (
+	EquipmentBarbell Equipment = iota
+	EquipmentDumbbell
+	EquipmentMachine
+	EquipmentCable
+	EquipmentBodyweight
+	EquipmentKettlebell
+	EquipmentBand
+	EquipmentSmithMachine
+	EquipmentOther
+)
+
+func (e Equipment) String() string {
+	switch e {
+	case EquipmentBarbell:
+		return "BARBELL"
+	case EquipmentDumbbell:
+		return "DUMBBELL"
+	case EquipmentMachine:
+		return "MACHINE"
+	case EquipmentCable:
+		return "CABLE"
+	case EquipmentBodyweight:
+		return "BODYWEIGHT"
+	case EquipmentKettlebell:
+		return "KETTLEBELL"
+	case EquipmentBand:
+		return "BAND"
+	case EquipmentSmithMachine:
+		return "SMITH_MACHINE"
+	case EquipmentOther:
+		return "OTHER"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+func (e Equipment) MarshalJSON() ([]byte, error) {
+	return []byte(fmt.Sprintf(`"%s"`, e.String())), nil
+}
+
+func (e *Equipment) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	switch s {
+	case "BARBELL":
+		*e = EquipmentBarbell
+	case "DUMBBELL":
+		*e = EquipmentDumbbell
+	case "MACHINE":
+		*e = EquipmentMachine
+	case "CABLE":
+		*e = EquipmentCable
+	case "BODYWEIGHT":
+		*e = EquipmentBodyweight
+	case "KETTLEBELL":
+		*e = EquipmentKettlebell
+	case "BAND":
+		*e = EquipmentBand
+	case "SMITH_MACHINE":
+		*e = EquipmentSmithMachine
+	case "OTHER":
+		*e = EquipmentOther
+	default:
+		return fmt.Errorf("unexpected equipment value %q", s)
+	}
+	return nil
+}
+
+// ExerciseOrderBy enum
+type ExerciseOrderBy int
+
+const (
+	ExerciseOrderByNameAsc ExerciseOrderBy = iota
+	ExerciseOrderByNameDesc
+	ExerciseOrderByCategoryAsc
+	ExerciseOrderByCreatedAtDesc
+)
+
+func (e ExerciseOrderBy) String() string {
+	switch e {
+	case ExerciseOrderByNameAsc:
+		return "NAME_ASC"
+	case ExerciseOrderByNameDesc:
+		return "NAME_DESC"
+	case ExerciseOrderByCategoryAsc:
+		return "CATEGORY_ASC"
+	case ExerciseOrderByCreatedAtDesc:
+		return "CREATED_AT_DESC"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+func (e ExerciseOrderBy) MarshalJSON() ([]byte, error) {
+	return []byte(fmt.Sprintf(`"%s"`, e.String())), nil
+}
+
+func (e *ExerciseOrderBy) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	switch s {
+	case "NAME_ASC":
+		*e = ExerciseOrderByNameAsc
+	case "NAME_DESC":
+		*e = ExerciseOrderByNameDesc
+	case "CATEGORY_ASC":
+		*e = ExerciseOrderByCategoryAsc
+	case "CREATED_AT_DESC":
+		*e = ExerciseOrderByCreatedAtDesc
+	default:
+		return fmt.Errorf("unexpected exercise order by value %q", s)
+	}
+	return nil
+}
+
+// Role enum
+type Role int
+
+const (
+	RoleUser Role = iota
+	RoleCoach
+	RoleModerator
+	RoleAdmin
+)
+
+func (r Role) String() string {
+	switch r {
+	case RoleUser:
+		return "USER"
+	case RoleCoach:
+		return "COACH"
+	case RoleModerator:
+		return "MODERATOR"
+	case RoleAdmin:
+		return "ADMIN"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+func (r Role) MarshalJSON() ([]byte, error) {
+	return []byte(fmt.Sprintf(`"%s"`, r.String())), nil
+}
+
+func (r *Role) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	switch s {
+	case "USER":
+		*r = RoleUser
+	case "COACH":
+		*r = RoleCoach
+	case "MODERATOR":
+		*r = RoleModerator
+	case "ADMIN":
+		*r = RoleAdmin
+	default:
+		return fmt.Errorf("unexpected role value %q", s)
+	}
+	return nil
+}
+
+// WorkoutGroupMemberRole enum
+type WorkoutGroupMemberRole int
+
+const (
+	WorkoutGroupMemberRoleOwner WorkoutGroupMemberRole = iota
+	WorkoutGroupMemberRoleAdmin
+	WorkoutGroupMemberRoleMember
+)
+
+func (r WorkoutGroupMemberRole) String() string {
+	switch r {
+	case WorkoutGroupMemberRoleOwner:
+		return "OWNER"
+	case WorkoutGroupMemberRoleAdmin:
+		return "ADMIN"
+	case WorkoutGroupMemberRoleMember:
+		return "MEMBER"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+func (r WorkoutGroupMemberRole) MarshalJSON() ([]byte, error) {
+	return []byte(fmt.Sprintf(`"%s"`, r.String())), nil
+}
+
+func (r *WorkoutGroupMemberRole) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	switch s {
+	case "OWNER":
+		*r = WorkoutGroupMemberRoleOwner
+	case "ADMIN":
+		*r = WorkoutGroupMemberRoleAdmin
+	case "MEMBER":
+		*r = WorkoutGroupMemberRoleMember
+	default:
+		return fmt.Errorf("unexpected workout group member role value %q", s)
+	}
+	return nil
+}
+
+// WorkoutGroupMemberStatus enum
+type WorkoutGroupMemberStatus int
+
+const (
+	WorkoutGroupMemberStatusInvited WorkoutGroupMemberStatus = iota
+	WorkoutGroupMemberStatusJoined
+	WorkoutGroupMemberStatusDeclined
+	WorkoutGroupMemberStatusLeft
+)
+
+func (s WorkoutGroupMemberStatus) String() string {
+	switch s {
+	case WorkoutGroupMemberStatusInvited:
+		return "INVITED"
+	case WorkoutGroupMemberStatusJoined:
+		return "JOINED"
+	case WorkoutGroupMemberStatusDeclined:
+		return "DECLINED"
+	case WorkoutGroupMemberStatusLeft:
+		return "LEFT"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+func (s WorkoutGroupMemberStatus) MarshalJSON() ([]byte, error) {
+	return []byte(fmt.Sprintf(`"%s"`, s.String())), nil
+}
+
+func (s *WorkoutGroupMemberStatus) UnmarshalJSON(b []byte) error {
+	var str string
+	if err := json.Unmarshal(b, &str); err != nil {
+		return err
+	}
+
+	switch str {
+	case "INVITED":
+		*s = WorkoutGroupMemberStatusInvited
+	case "JOINED":
+		*s = WorkoutGroupMemberStatusJoined
+	case "DECLINED":
+		*s = WorkoutGroupMemberStatusDeclined
+	case "LEFT":
+		*s = WorkoutGroupMemberStatusLeft
+	default:
+		return fmt.Errorf("unexpected workout group member status value %q", str)
+	}
+	return nil
+}
+
+// NotificationType enum
+type NotificationType int
+
+const (
+	NotificationTypeFriendRequestReceived NotificationType = iota
+	NotificationTypeFriendRequestAccepted
+	NotificationTypeWorkoutGroupInvited
+	NotificationTypeFriendPersonalRecord
+)
+
+func (t NotificationType) String() string {
+	switch t {
+	case NotificationTypeFriendRequestReceived:
+		return "FRIEND_REQUEST_RECEIVED"
+	case NotificationTypeFriendRequestAccepted:
+		return "FRIEND_REQUEST_ACCEPTED"
+	case NotificationTypeWorkoutGroupInvited:
+		return "WORKOUT_GROUP_INVITED"
+	case NotificationTypeFriendPersonalRecord:
+		return "FRIEND_PERSONAL_RECORD"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+func (t NotificationType) MarshalJSON() ([]byte, error) {
+	return []byte(fmt.Sprintf(`"%s"`, t.String())), nil
+}
+
+func (t *NotificationType) UnmarshalJSON(b []byte) error {
+	var str string
+	if err := json.Unmarshal(b, &str); err != nil {
+		return err
+	}
+
+	switch str {
+	case "FRIEND_REQUEST_RECEIVED":
+		*t = NotificationTypeFriendRequestReceived
+	case "FRIEND_REQUEST_ACCEPTED":
+		*t = NotificationTypeFriendRequestAccepted
+	case "WORKOUT_GROUP_INVITED":
+		*t = NotificationTypeWorkoutGroupInvited
+	case "FRIEND_PERSONAL_RECORD":
+		*t = NotificationTypeFriendPersonalRecord
+	default:
+		return fmt.Errorf("unexpected notification type value %q", str)
+	}
+	return nil
+}
+
+// WorkoutGroupActivityType enum
+type WorkoutGroupActivityType int
+
+const (
+	WorkoutGroupActivityTypeSetLogged WorkoutGroupActivityType = iota
+	WorkoutGroupActivityTypeMemberJoined
+	WorkoutGroupActivityTypeWorkoutFinished
+)
+
+func (t WorkoutGroupActivityType) String() string {
+	switch t {
+	case WorkoutGroupActivityTypeSetLogged:
+		return "SET_LOGGED"
+	case WorkoutGroupActivityTypeMemberJoined:
+		return "MEMBER_JOINED"
+	case WorkoutGroupActivityTypeWorkoutFinished:
+		return "WORKOUT_FINISHED"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+func (t WorkoutGroupActivityType) MarshalJSON() ([]byte, error) {
+	return []byte(fmt.Sprintf(`"%s"`, t.String())), nil
+}
+
+func (t *WorkoutGroupActivityType) UnmarshalJSON(b []byte) error {
+	var str string
+	if err := json.Unmarshal(b, &str); err != nil {
+		return err
+	}
+
+	switch str {
+	case "SET_LOGGED":
+		*t = WorkoutGroupActivityTypeSetLogged
+	case "MEMBER_JOINED":
+		*t = WorkoutGroupActivityTypeMemberJoined
+	case "WORKOUT_FINISHED":
+		*t = WorkoutGroupActivityTypeWorkoutFinished
+	default:
+		return fmt.Errorf("unexpected workout group activity type value %q", str)
+	}
+	return nil
+}
+
+// DevicePlatform enum
+type DevicePlatform int
+
+const (
+	DevicePlatformIos DevicePlatform = iota
+	DevicePlatformAndroid
+	DevicePlatformWeb
+)
+
+func (p DevicePlatform) String() string {
+	switch p {
+	case DevicePlatformIos:
+		return "IOS"
+	case DevicePlatformAndroid:
+		return "ANDROID"
+	case DevicePlatformWeb:
+		return "WEB"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+func (p DevicePlatform) MarshalJSON() ([]byte, error) {
+	return []byte(fmt.Sprintf(`"%s"`, p.String())), nil
+}
+
+func (p *DevicePlatform) UnmarshalJSON(b []byte) error {
+	var str string
+	if err := json.Unmarshal(b, &str); err != nil {
+		return err
+	}
+
+	switch str {
+	case "IOS":
+		*p = DevicePlatformIos
+	case "ANDROID":
+		*p = DevicePlatformAndroid
+	case "WEB":
+		*p = DevicePlatformWeb
+	default:
+		return fmt.Errorf("unexpected device platform value %q", str)
+	}
+	return nil
+}
+
+// WorkoutStatus enum
+type WorkoutStatus int
+
+const (
+	WorkoutStatusPlanned WorkoutStatus = iota
+	WorkoutStatusInProgress
+	WorkoutStatusCompleted
+	WorkoutStatusAbandoned
+)
+
+func (s WorkoutStatus) String() string {
+	switch s {
+	case WorkoutStatusPlanned:
+		return "PLANNED"
+	case WorkoutStatusInProgress:
+		return "IN_PROGRESS"
+	case WorkoutStatusCompleted:
+		return "COMPLETED"
+	case WorkoutStatusAbandoned:
+		return "ABANDONED"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+func (s WorkoutStatus) MarshalJSON() ([]byte, error) {
+	return []byte(fmt.Sprintf(`"%s"`, s.String())), nil
+}
+
+func (s *WorkoutStatus) UnmarshalJSON(b []byte) error {
+	var str string
+	if err := json.Unmarshal(b, &str); err != nil {
+		return err
+	}
+
+	switch str {
+	case "PLANNED":
+		*s = WorkoutStatusPlanned
+	case "IN_PROGRESS":
+		*s = WorkoutStatusInProgress
+	case "COMPLETED":
+		*s = WorkoutStatusCompleted
+	case "ABANDONED":
+		*s = WorkoutStatusAbandoned
+	default:
+		return fmt.Errorf("unexpected workout status value %q", str)
+	}
+	return nil
+}
+
+// SetType enum
+type SetType int
+
+const (
+	SetTypeWarmup SetType = iota
+	SetTypeWorking
+	SetTypeDropSet
+	SetTypeFailure
+	SetTypeAmrap
+)
+
+func (t SetType) String() string {
+	switch t {
+	case SetTypeWarmup:
+		return "WARMUP"
+	case SetTypeWorking:
+		return "WORKING"
+	case SetTypeDropSet:
+		return "DROP_SET"
+	case SetTypeFailure:
+		return "FAILURE"
+	case SetTypeAmrap:
+		return "AMRAP"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+func (t SetType) MarshalJSON() ([]byte, error) {
+	return []byte(fmt.Sprintf(`"%s"`, t.String())), nil
+}
+
+func (t *SetType) UnmarshalJSON(b []byte) error {
+	var str string
+	if err := json.Unmarshal(b, &str); err != nil {
+		return err
+	}
+
+	switch str {
+	case "WARMUP":
+		*t = SetTypeWarmup
+	case "WORKING":
+		*t = SetTypeWorking
+	case "DROP_SET":
+		*t = SetTypeDropSet
+	case "FAILURE":
+		*t = SetTypeFailure
+	case "AMRAP":
+		*t = SetTypeAmrap
+	default:
+		return fmt.Errorf("unexpected set type value %q", str)
+	}
+	return nil
+}
+
+// ExerciseTrackingType enum
+type ExerciseTrackingType int
+
+const (
+	ExerciseTrackingTypeWeightReps ExerciseTrackingType = iota
+	ExerciseTrackingTypeRepsOnly
+	ExerciseTrackingTypeDuration
+	ExerciseTrackingTypeDistanceDuration
+	ExerciseTrackingTypeAssistedWeight
+)
+
+func (t ExerciseTrackingType) String() string {
+	switch t {
+	case ExerciseTrackingTypeWeightReps:
+		return "WEIGHT_REPS"
+	case ExerciseTrackingTypeRepsOnly:
+		return "REPS_ONLY"
+	case ExerciseTrackingTypeDuration:
+		return "DURATION"
+	case ExerciseTrackingTypeDistanceDuration:
+		return "DISTANCE_DURATION"
+	case ExerciseTrackingTypeAssistedWeight:
+		return "ASSISTED_WEIGHT"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+func (t ExerciseTrackingType) MarshalJSON() ([]byte, error) {
+	return []byte(fmt.Sprintf(`"%s"`, t.String())), nil
+}
+
+func (t *ExerciseTrackingType) UnmarshalJSON(b []byte) error {
+	var str string
+	if err := json.Unmarshal(b, &str); err != nil {
+		return err
+	}
+
+	switch str {
+	case "WEIGHT_REPS":
+		*t = ExerciseTrackingTypeWeightReps
+	case "REPS_ONLY":
+		*t = ExerciseTrackingTypeRepsOnly
+	case "DURATION":
+		*t = ExerciseTrackingTypeDuration
+	case "DISTANCE_DURATION":
+		*t = ExerciseTrackingTypeDistanceDuration
+	case "ASSISTED_WEIGHT":
+		*t = ExerciseTrackingTypeAssistedWeight
+	default:
+		return fmt.Errorf("unexpected exercise tracking type value %q", str)
+	}
+	return nil
+}
+
+// BodyMeasurementMetric enum
+type BodyMeasurementMetric int
+
+const (
+	BodyMeasurementMetricWeight BodyMeasurementMetric = iota
+	BodyMeasurementMetricBodyFatPercentage
+	BodyMeasurementMetricWaist
+	BodyMeasurementMetricChest
+	BodyMeasurementMetricArm
+	BodyMeasurementMetricHip
+	BodyMeasurementMetricThigh
+	BodyMeasurementMetricNeck
+)
+
+func (m BodyMeasurementMetric) String() string {
+	switch m {
+	case BodyMeasurementMetricWeight:
+		return "WEIGHT"
+	case BodyMeasurementMetricBodyFatPercentage:
+		return "BODY_FAT_PERCENTAGE"
+	case BodyMeasurementMetricWaist:
+		return "WAIST"
+	case BodyMeasurementMetricChest:
+		return "CHEST"
+	case BodyMeasurementMetricArm:
+		return "ARM"
+	case BodyMeasurementMetricHip:
+		return "HIP"
+	case BodyMeasurementMetricThigh:
+		return "THIGH"
+	case BodyMeasurementMetricNeck:
+		return "NECK"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+func (m BodyMeasurementMetric) MarshalJSON() ([]byte, error) {
+	return []byte(fmt.Sprintf(`"%s"`, m.String())), nil
+}
+
+func (m *BodyMeasurementMetric) UnmarshalJSON(b []byte) error {
+	var str string
+	if err := json.Unmarshal(b, &str); err != nil {
+		return err
+	}
+
+	switch str {
+	case "WEIGHT":
+		*m = BodyMeasurementMetricWeight
+	case "BODY_FAT_PERCENTAGE":
+		*m = BodyMeasurementMetricBodyFatPercentage
+	case "WAIST":
+		*m = BodyMeasurementMetricWaist
+	case "CHEST":
+		*m = BodyMeasurementMetricChest
+	case "ARM":
+		*m = BodyMeasurementMetricArm
+	case "HIP":
+		*m = BodyMeasurementMetricHip
+	case "THIGH":
+		*m = BodyMeasurementMetricThigh
+	case "NECK":
+		*m = BodyMeasurementMetricNeck
+	default:
+		return fmt.Errorf("unexpected body measurement metric value %q", str)
+	}
+	return nil
+}
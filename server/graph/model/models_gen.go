@@ -6,8 +6,12 @@ type AcceptFriendshipRequest struct {
 	FriendshipID string `json:"friendshipID"`
 }
 
+type AcceptWorkoutGroupInvitation struct {
+	WorkoutGroupID string `json:"workoutGroupID"`
+}
+
 type AddFriendByQRCode struct {
-	TargetUserID string `json:"targetUserID"`
+	Token string `json:"token"`
 }
 
 type AddWorkoutGroupMember struct {
@@ -15,21 +19,97 @@ type AddWorkoutGroupMember struct {
 	UserID         string `json:"userID"`
 }
 
+type ArchiveExercise struct {
+	ID string `json:"id"`
+}
+
+type BlockUser struct {
+	UserID string `json:"userID"`
+}
+
+type BodyMeasurement struct {
+	ID                string   `json:"id"`
+	MeasuredAt        string   `json:"measuredAt"`
+	WeightKg          *float64 `json:"weightKg,omitempty"`
+	BodyFatPercentage *float64 `json:"bodyFatPercentage,omitempty"`
+	Waist             *float64 `json:"waist,omitempty"`
+	Chest             *float64 `json:"chest,omitempty"`
+	Arm               *float64 `json:"arm,omitempty"`
+	Hip               *float64 `json:"hip,omitempty"`
+	Thigh             *float64 `json:"thigh,omitempty"`
+	Neck              *float64 `json:"neck,omitempty"`
+	Note              *string  `json:"note,omitempty"`
+	CreatedAt         string   `json:"createdAt"`
+}
+
+type BodyMeasurementPoint struct {
+	MeasuredAt    string  `json:"measuredAt"`
+	Value         float64 `json:"value"`
+	MovingAverage float64 `json:"movingAverage"`
+}
+
+type BodyMeasurementTrend struct {
+	Metric     BodyMeasurementMetric   `json:"metric"`
+	From       string                  `json:"from"`
+	To         string                  `json:"to"`
+	WindowDays int32                   `json:"windowDays"`
+	Points     []*BodyMeasurementPoint `json:"points"`
+	Change     *float64                `json:"change,omitempty"`
+}
+
+type CancelFriendshipRequest struct {
+	FriendshipID string `json:"friendshipID"`
+}
+
+type CategoryVolumeStat struct {
+	Category string  `json:"category"`
+	Volume   float64 `json:"volume"`
+}
+
+type CreateExercise struct {
+	Name             string                `json:"name"`
+	Description      *string               `json:"description,omitempty"`
+	Category         *string               `json:"category,omitempty"`
+	PrimaryMuscles   []MuscleGroup         `json:"primaryMuscles,omitempty"`
+	SecondaryMuscles []MuscleGroup         `json:"secondaryMuscles,omitempty"`
+	Equipment        *Equipment            `json:"equipment,omitempty"`
+	TrackingType     *ExerciseTrackingType `json:"trackingType,omitempty"`
+	Visibility       *ExerciseVisibility   `json:"visibility,omitempty"`
+}
+
 type CreateProfile struct {
 	Name          string         `json:"name"`
 	BirthDate     string         `json:"birthDate"`
 	Gender        Gender         `json:"gender"`
 	Height        *float64       `json:"height,omitempty"`
 	Weight        *float64       `json:"weight,omitempty"`
+	WeightUnit    *WeightUnit    `json:"weightUnit,omitempty"`
 	ActivityLevel *ActivityLevel `json:"activityLevel,omitempty"`
 	ImageURL      *string        `json:"imageURL,omitempty"`
 }
 
+type CreateProgram struct {
+	Name        string             `json:"name"`
+	Description *string            `json:"description,omitempty"`
+	WeightUnit  *WeightUnit        `json:"weightUnit,omitempty"`
+	Days        []*ProgramDayInput `json:"days"`
+}
+
 type CreateSetLog struct {
-	WorkoutExerciseID string   `json:"workoutExerciseID"`
-	SetNumber         int32    `json:"setNumber"`
-	Weight            *float64 `json:"weight,omitempty"`
-	RepCount          *int32   `json:"repCount,omitempty"`
+	WorkoutExerciseID string      `json:"workoutExerciseID"`
+	SetNumber         int32       `json:"setNumber"`
+	Weight            *float64    `json:"weight,omitempty"`
+	WeightUnit        *WeightUnit `json:"weightUnit,omitempty"`
+	RepCount          *int32      `json:"repCount,omitempty"`
+	SetType           *SetType    `json:"setType,omitempty"`
+	Rpe               *float64    `json:"rpe,omitempty"`
+	Rir               *int32      `json:"rir,omitempty"`
+	Tempo             *string     `json:"tempo,omitempty"`
+	RestSeconds       *int32      `json:"restSeconds,omitempty"`
+	Completed         *bool       `json:"completed,omitempty"`
+	DurationSeconds   *int32      `json:"durationSeconds,omitempty"`
+	DistanceMeters    *float64    `json:"distanceMeters,omitempty"`
+	HeartRate         *int32      `json:"heartRate,omitempty"`
 }
 
 type CreateWorkoutExercise struct {
@@ -43,6 +123,25 @@ type CreateWorkoutGroup struct {
 	ImageURL *string `json:"imageURL,omitempty"`
 }
 
+type CreateWorkoutTemplate struct {
+	Name        string                          `json:"name"`
+	Description *string                         `json:"description,omitempty"`
+	WeightUnit  *WeightUnit                     `json:"weightUnit,omitempty"`
+	Exercises   []*WorkoutTemplateExerciseInput `json:"exercises"`
+}
+
+type DeclineWorkoutGroupInvitation struct {
+	WorkoutGroupID string `json:"workoutGroupID"`
+}
+
+type DeleteBodyMeasurement struct {
+	ID string `json:"id"`
+}
+
+type DeleteProgram struct {
+	ID string `json:"id"`
+}
+
 type DeleteSetLog struct {
 	SetLogID string `json:"setLogID"`
 }
@@ -55,15 +154,73 @@ type DeleteWorkout struct {
 	ID string `json:"id"`
 }
 
+type DeleteWorkoutExercise struct {
+	ID string `json:"id"`
+}
+
 type DeleteWorkoutGroup struct {
 	ID string `json:"id"`
 }
 
+type DeleteWorkoutTemplate struct {
+	ID string `json:"id"`
+}
+
+type DismissRecommendation struct {
+	UserID string `json:"userID"`
+}
+
+type EnrollProgram struct {
+	ProgramID     string              `json:"programID"`
+	WeightUnit    *WeightUnit         `json:"weightUnit,omitempty"`
+	TrainingMaxes []*TrainingMaxInput `json:"trainingMaxes,omitempty"`
+}
+
 type Exercise struct {
-	ID          string  `json:"id"`
-	Name        string  `json:"name"`
-	Description *string `json:"description,omitempty"`
-	Category    *string `json:"category,omitempty"`
+	ID               string               `json:"id"`
+	Name             string               `json:"name"`
+	Description      *string              `json:"description,omitempty"`
+	Category         *string              `json:"category,omitempty"`
+	Owner            *User                `json:"owner,omitempty"`
+	OwnerID          *string              `json:"ownerID,omitempty"`
+	PrimaryMuscles   []MuscleGroup        `json:"primaryMuscles"`
+	SecondaryMuscles []MuscleGroup        `json:"secondaryMuscles"`
+	Equipment        *Equipment           `json:"equipment,omitempty"`
+	TrackingType     ExerciseTrackingType `json:"trackingType"`
+	Visibility       ExerciseVisibility   `json:"visibility"`
+	IsGlobal         bool                 `json:"isGlobal"`
+	ArchivedAt       *string              `json:"archivedAt,omitempty"`
+	MyRecords        []*PersonalRecord    `json:"myRecords"`
+}
+
+type ExerciseFilter struct {
+	Category     *string       `json:"category,omitempty"`
+	MuscleGroups []MuscleGroup `json:"muscleGroups,omitempty"`
+	Equipment    []Equipment   `json:"equipment,omitempty"`
+	Text         *string       `json:"text,omitempty"`
+}
+
+type FinishWorkout struct {
+	ID         string  `json:"id"`
+	Notes      *string `json:"notes,omitempty"`
+	SessionRpe *int32  `json:"sessionRPE,omitempty"`
+}
+
+type FriendQRToken struct {
+	Token     string `json:"token"`
+	ExpiresAt string `json:"expiresAt"`
+	SingleUse bool   `json:"singleUse"`
+	PngURL    string `json:"pngURL"`
+	SVGURL    string `json:"svgURL"`
+}
+
+type FriendRecommendation struct {
+	User              *User    `json:"user"`
+	Score             float64  `json:"score"`
+	MutualFriendCount int32    `json:"mutualFriendCount"`
+	SharedGroupCount  int32    `json:"sharedGroupCount"`
+	SharedCategories  []string `json:"sharedCategories"`
+	Reasons           []string `json:"reasons"`
 }
 
 type Friendship struct {
@@ -75,6 +232,48 @@ type Friendship struct {
 	Status      FriendshipStatus `json:"status"`
 }
 
+type GrantRole struct {
+	UserID string `json:"userID"`
+	Role   Role   `json:"role"`
+}
+
+type GroupIntoSuperset struct {
+	WorkoutID          string   `json:"workoutID"`
+	WorkoutExerciseIDs []string `json:"workoutExerciseIDs"`
+}
+
+type InviteWorkoutGroupMember struct {
+	WorkoutGroupID string `json:"workoutGroupID"`
+	UserID         string `json:"userID"`
+}
+
+type KickWorkoutGroupMember struct {
+	WorkoutGroupID string `json:"workoutGroupID"`
+	UserID         string `json:"userID"`
+}
+
+type LeaveWorkoutGroup struct {
+	WorkoutGroupID string `json:"workoutGroupID"`
+}
+
+type LogBodyMeasurement struct {
+	MeasuredAt        *string     `json:"measuredAt,omitempty"`
+	Weight            *float64    `json:"weight,omitempty"`
+	WeightUnit        *WeightUnit `json:"weightUnit,omitempty"`
+	BodyFatPercentage *float64    `json:"bodyFatPercentage,omitempty"`
+	Waist             *float64    `json:"waist,omitempty"`
+	Chest             *float64    `json:"chest,omitempty"`
+	Arm               *float64    `json:"arm,omitempty"`
+	Hip               *float64    `json:"hip,omitempty"`
+	Thigh             *float64    `json:"thigh,omitempty"`
+	Neck              *float64    `json:"neck,omitempty"`
+	Note              *string     `json:"note,omitempty"`
+}
+
+type MarkNotificationsRead struct {
+	Ids []string `json:"ids,omitempty"`
+}
+
 type Mutation struct {
 }
 
@@ -82,6 +281,93 @@ type NewUser struct {
 	Name string `json:"name"`
 }
 
+type Notification struct {
+	ID               string           `json:"id"`
+	Type             NotificationType `json:"type"`
+	ActorID          *string          `json:"actorID,omitempty"`
+	Actor            *User            `json:"actor,omitempty"`
+	FriendshipID     *string          `json:"friendshipID,omitempty"`
+	Friendship       *Friendship      `json:"friendship,omitempty"`
+	WorkoutGroupID   *string          `json:"workoutGroupID,omitempty"`
+	WorkoutGroup     *WorkoutGroup    `json:"workoutGroup,omitempty"`
+	PersonalRecordID *string          `json:"personalRecordID,omitempty"`
+	PersonalRecord   *PersonalRecord  `json:"personalRecord,omitempty"`
+	Read             bool             `json:"read"`
+	ReadAt           *string          `json:"readAt,omitempty"`
+	CreatedAt        string           `json:"createdAt"`
+}
+
+type NotificationConnection struct {
+	Edges      []*NotificationEdge `json:"edges"`
+	PageInfo   *PageInfo           `json:"pageInfo"`
+	TotalCount int32               `json:"totalCount"`
+}
+
+type NotificationEdge struct {
+	Cursor string        `json:"cursor"`
+	Node   *Notification `json:"node"`
+}
+
+type NotificationPreferences struct {
+	PushEnabled           bool    `json:"pushEnabled"`
+	FriendRequestReceived bool    `json:"friendRequestReceived"`
+	FriendRequestAccepted bool    `json:"friendRequestAccepted"`
+	WorkoutGroupInvited   bool    `json:"workoutGroupInvited"`
+	QuietHoursStart       *string `json:"quietHoursStart,omitempty"`
+	QuietHoursEnd         *string `json:"quietHoursEnd,omitempty"`
+	TimeZone              string  `json:"timeZone"`
+}
+
+type OneRepMaxPoint struct {
+	Date               string  `json:"date"`
+	EstimatedOneRepMax float64 `json:"estimatedOneRepMax"`
+}
+
+type OneRepMaxTrend struct {
+	Exercise   *Exercise         `json:"exercise"`
+	ExerciseID string            `json:"exerciseID"`
+	Points     []*OneRepMaxPoint `json:"points"`
+}
+
+type PageInfo struct {
+	HasNextPage     bool    `json:"hasNextPage"`
+	HasPreviousPage bool    `json:"hasPreviousPage"`
+	StartCursor     *string `json:"startCursor,omitempty"`
+	EndCursor       *string `json:"endCursor,omitempty"`
+}
+
+type PersonalRecord struct {
+	ID         string             `json:"id"`
+	Type       PersonalRecordType `json:"type"`
+	Exercise   *Exercise          `json:"exercise"`
+	ExerciseID string             `json:"exerciseID"`
+	WorkoutID  string             `json:"workoutID"`
+	SetLogID   *string            `json:"setLogID,omitempty"`
+	Value      float64            `json:"value"`
+	WeightKg   float64            `json:"weightKg"`
+	RepCount   int32              `json:"repCount"`
+	IsCurrent  bool               `json:"isCurrent"`
+	AchievedAt string             `json:"achievedAt"`
+}
+
+type PrescribedExercise struct {
+	Exercise      *Exercise         `json:"exercise"`
+	ExerciseID    string            `json:"exerciseID"`
+	Sets          int32             `json:"sets"`
+	Reps          int32             `json:"reps"`
+	TargetType    ProgramTargetType `json:"targetType"`
+	TargetValue   float64           `json:"targetValue"`
+	TrainingMaxKg *float64          `json:"trainingMaxKg,omitempty"`
+	WeightKg      *float64          `json:"weightKg,omitempty"`
+}
+
+type PrescribedWorkout struct {
+	ProgramDay *ProgramDay           `json:"programDay"`
+	Week       int32                 `json:"week"`
+	Day        int32                 `json:"day"`
+	Exercises  []*PrescribedExercise `json:"exercises"`
+}
+
 type Profile struct {
 	ID            string         `json:"id"`
 	User          *User          `json:"user"`
@@ -90,45 +376,238 @@ type Profile struct {
 	Gender        *Gender        `json:"gender,omitempty"`
 	Height        *float64       `json:"height,omitempty"`
 	Weight        *float64       `json:"weight,omitempty"`
+	Bmi           *float64       `json:"bmi,omitempty"`
+	WeightUnit    WeightUnit     `json:"weightUnit"`
 	ActivityLevel *ActivityLevel `json:"activityLevel,omitempty"`
 	ImageURL      *string        `json:"imageURL,omitempty"`
 	CreatedAt     string         `json:"createdAt"`
 	UpdatedAt     string         `json:"updatedAt"`
 }
 
+type Program struct {
+	ID          string        `json:"id"`
+	Name        string        `json:"name"`
+	Description *string       `json:"description,omitempty"`
+	User        *User         `json:"user"`
+	UserID      string        `json:"userID"`
+	WeekCount   int32         `json:"weekCount"`
+	Days        []*ProgramDay `json:"days"`
+	CreatedAt   string        `json:"createdAt"`
+	UpdatedAt   string        `json:"updatedAt"`
+}
+
+type ProgramDay struct {
+	ID        string             `json:"id"`
+	Week      int32              `json:"week"`
+	Day       int32              `json:"day"`
+	Name      *string            `json:"name,omitempty"`
+	Exercises []*ProgramExercise `json:"exercises"`
+}
+
+type ProgramDayInput struct {
+	Week      int32                   `json:"week"`
+	Day       int32                   `json:"day"`
+	Name      *string                 `json:"name,omitempty"`
+	Exercises []*ProgramExerciseInput `json:"exercises"`
+}
+
+type ProgramEnrollment struct {
+	ID            string             `json:"id"`
+	Program       *Program           `json:"program"`
+	ProgramID     string             `json:"programID"`
+	StartedAt     string             `json:"startedAt"`
+	EndedAt       *string            `json:"endedAt,omitempty"`
+	IsActive      bool               `json:"isActive"`
+	TodaysWorkout *PrescribedWorkout `json:"todaysWorkout,omitempty"`
+}
+
+type ProgramExercise struct {
+	ID                     string            `json:"id"`
+	Exercise               *Exercise         `json:"exercise"`
+	ExerciseID             string            `json:"exerciseID"`
+	Position               int32             `json:"position"`
+	Sets                   int32             `json:"sets"`
+	Reps                   int32             `json:"reps"`
+	TargetType             ProgramTargetType `json:"targetType"`
+	TargetValue            float64           `json:"targetValue"`
+	ProgressionIncrementKg float64           `json:"progressionIncrementKg"`
+}
+
+type ProgramExerciseInput struct {
+	ExerciseID           string            `json:"exerciseID"`
+	Sets                 int32             `json:"sets"`
+	Reps                 int32             `json:"reps"`
+	TargetType           ProgramTargetType `json:"targetType"`
+	TargetValue          float64           `json:"targetValue"`
+	ProgressionIncrement *float64          `json:"progressionIncrement,omitempty"`
+}
+
+type PromoteExercise struct {
+	ID string `json:"id"`
+}
+
 type Query struct {
 }
 
+type RegisterDevice struct {
+	Token    string         `json:"token"`
+	Platform DevicePlatform `json:"platform"`
+}
+
 type RejectFriendshipRequest struct {
 	FriendshipID string `json:"friendshipID"`
 }
 
+type RemoveFriend struct {
+	UserID string `json:"userID"`
+}
+
+type RemoveFromSuperset struct {
+	WorkoutExerciseID string `json:"workoutExerciseID"`
+}
+
+type ReorderSetLogs struct {
+	WorkoutExerciseID string   `json:"workoutExerciseID"`
+	OrderedIDs        []string `json:"orderedIDs"`
+}
+
+type ReorderWorkoutExercises struct {
+	WorkoutID  string   `json:"workoutID"`
+	OrderedIDs []string `json:"orderedIDs"`
+}
+
+type ReplaceExercise struct {
+	WorkoutExerciseID string `json:"workoutExerciseID"`
+	ExerciseID        string `json:"exerciseID"`
+}
+
+type ResumeWorkout struct {
+	ID string `json:"id"`
+}
+
+type RevokeRole struct {
+	UserID string `json:"userID"`
+	Role   Role   `json:"role"`
+}
+
 type SendFriendshipRequest struct {
 	RequesteeID string `json:"requesteeID"`
 }
 
 type SetLog struct {
-	ID        string `json:"id"`
-	Weight    int32  `json:"weight"`
-	RepCount  int32  `json:"repCount"`
-	SetNumber int32  `json:"setNumber"`
+	ID              string   `json:"id"`
+	WeightKg        float64  `json:"weightKg"`
+	Weight          float64  `json:"weight"`
+	RepCount        int32    `json:"repCount"`
+	SetNumber       int32    `json:"setNumber"`
+	SetType         SetType  `json:"setType"`
+	Rpe             *float64 `json:"rpe,omitempty"`
+	Rir             *int32   `json:"rir,omitempty"`
+	Tempo           *string  `json:"tempo,omitempty"`
+	RestSeconds     *int32   `json:"restSeconds,omitempty"`
+	Completed       bool     `json:"completed"`
+	DurationSeconds *int32   `json:"durationSeconds,omitempty"`
+	DistanceMeters  *float64 `json:"distanceMeters,omitempty"`
+	HeartRate       *int32   `json:"heartRate,omitempty"`
 }
 
 type StartWorkout struct {
+	Date                *string `json:"date,omitempty"`
+	WorkoutGroupID      *string `json:"workoutGroupID,omitempty"`
+	ProgramEnrollmentID *string `json:"programEnrollmentID,omitempty"`
+}
+
+type StartWorkoutFromTemplate struct {
+	TemplateID     string  `json:"templateID"`
 	Date           *string `json:"date,omitempty"`
 	WorkoutGroupID *string `json:"workoutGroupID,omitempty"`
 }
 
+type Stats struct {
+	From            string                `json:"from"`
+	To              string                `json:"to"`
+	TotalVolume     float64               `json:"totalVolume"`
+	DailyVolume     []*VolumeStat         `json:"dailyVolume"`
+	WeeklyVolume    []*VolumeStat         `json:"weeklyVolume"`
+	MonthlyVolume   []*VolumeStat         `json:"monthlyVolume"`
+	CategoryVolume  []*CategoryVolumeStat `json:"categoryVolume"`
+	OneRepMaxTrends []*OneRepMaxTrend     `json:"oneRepMaxTrends"`
+}
+
+type Subscription struct {
+}
+
+type TrainingMaxInput struct {
+	ExerciseID string  `json:"exerciseID"`
+	Weight     float64 `json:"weight"`
+}
+
+type UnblockUser struct {
+	UserID string `json:"userID"`
+}
+
+type UnregisterDevice struct {
+	Token string `json:"token"`
+}
+
+type UpdateExercise struct {
+	ID               string                `json:"id"`
+	Name             *string               `json:"name,omitempty"`
+	Description      *string               `json:"description,omitempty"`
+	Category         *string               `json:"category,omitempty"`
+	PrimaryMuscles   []MuscleGroup         `json:"primaryMuscles,omitempty"`
+	SecondaryMuscles []MuscleGroup         `json:"secondaryMuscles,omitempty"`
+	Equipment        *Equipment            `json:"equipment,omitempty"`
+	TrackingType     *ExerciseTrackingType `json:"trackingType,omitempty"`
+	Visibility       *ExerciseVisibility   `json:"visibility,omitempty"`
+}
+
+type UpdateNotificationPreferences struct {
+	PushEnabled           *bool   `json:"pushEnabled,omitempty"`
+	FriendRequestReceived *bool   `json:"friendRequestReceived,omitempty"`
+	FriendRequestAccepted *bool   `json:"friendRequestAccepted,omitempty"`
+	WorkoutGroupInvited   *bool   `json:"workoutGroupInvited,omitempty"`
+	QuietHoursStart       *string `json:"quietHoursStart,omitempty"`
+	QuietHoursEnd         *string `json:"quietHoursEnd,omitempty"`
+	ClearQuietHours       *bool   `json:"clearQuietHours,omitempty"`
+	TimeZone              *string `json:"timeZone,omitempty"`
+}
+
 type UpdateProfile struct {
 	Name          *string        `json:"name,omitempty"`
 	BirthDate     *string        `json:"birthDate,omitempty"`
 	Gender        *Gender        `json:"gender,omitempty"`
 	Height        *float64       `json:"height,omitempty"`
 	Weight        *float64       `json:"weight,omitempty"`
+	WeightUnit    *WeightUnit    `json:"weightUnit,omitempty"`
 	ActivityLevel *ActivityLevel `json:"activityLevel,omitempty"`
 	ImageURL      *string        `json:"imageURL,omitempty"`
 }
 
+type UpdateSetLog struct {
+	SetLogID        string      `json:"setLogID"`
+	Weight          *float64    `json:"weight,omitempty"`
+	WeightUnit      *WeightUnit `json:"weightUnit,omitempty"`
+	RepCount        *int32      `json:"repCount,omitempty"`
+	SetType         *SetType    `json:"setType,omitempty"`
+	Rpe             *float64    `json:"rpe,omitempty"`
+	Rir             *int32      `json:"rir,omitempty"`
+	Tempo           *string     `json:"tempo,omitempty"`
+	RestSeconds     *int32      `json:"restSeconds,omitempty"`
+	Completed       *bool       `json:"completed,omitempty"`
+	DurationSeconds *int32      `json:"durationSeconds,omitempty"`
+	DistanceMeters  *float64    `json:"distanceMeters,omitempty"`
+	HeartRate       *int32      `json:"heartRate,omitempty"`
+}
+
+type UpdateWorkout struct {
+	ID              string  `json:"id"`
+	Date            *string `json:"date,omitempty"`
+	Notes           *string `json:"notes,omitempty"`
+	SessionRpe      *int32  `json:"sessionRPE,omitempty"`
+	ClearSessionRpe *bool   `json:"clearSessionRPE,omitempty"`
+}
+
 type UpdateWorkoutGroup struct {
 	ID       string  `json:"id"`
 	Title    *string `json:"title,omitempty"`
@@ -136,43 +615,153 @@ type UpdateWorkoutGroup struct {
 	ImageURL *string `json:"imageURL,omitempty"`
 }
 
+type UpdateWorkoutGroupMemberRole struct {
+	WorkoutGroupID string                 `json:"workoutGroupID"`
+	UserID         string                 `json:"userID"`
+	Role           WorkoutGroupMemberRole `json:"role"`
+}
+
+type UpdateWorkoutTemplate struct {
+	ID          string                          `json:"id"`
+	Name        *string                         `json:"name,omitempty"`
+	Description *string                         `json:"description,omitempty"`
+	WeightUnit  *WeightUnit                     `json:"weightUnit,omitempty"`
+	Exercises   []*WorkoutTemplateExerciseInput `json:"exercises,omitempty"`
+}
+
 type User struct {
-	ID                 string        `json:"id"`
-	UID                string        `json:"uid"`
-	CreatedAt          string        `json:"createdAt"`
-	UpdatedAt          string        `json:"updatedAt"`
-	Profile            *Profile      `json:"profile,omitempty"`
-	Workouts           []*Workout    `json:"workouts"`
-	Friends            []*User       `json:"friends"`
-	FriendshipRequests []*Friendship `json:"friendshipRequests"`
-	RecommendedUsers   []*User       `json:"recommendedUsers"`
+	ID                 string             `json:"id"`
+	UID                string             `json:"uid"`
+	CreatedAt          string             `json:"createdAt"`
+	UpdatedAt          string             `json:"updatedAt"`
+	Roles              []Role             `json:"roles"`
+	Profile            *Profile           `json:"profile,omitempty"`
+	Workouts           *WorkoutConnection `json:"workouts"`
+	Friends            *UserConnection    `json:"friends"`
+	FriendshipRequests []*Friendship      `json:"friendshipRequests"`
+	RecommendedUsers   *UserConnection    `json:"recommendedUsers"`
+	PersonalRecords    []*PersonalRecord  `json:"personalRecords"`
+}
+
+type UserConnection struct {
+	Edges      []*UserEdge `json:"edges"`
+	PageInfo   *PageInfo   `json:"pageInfo"`
+	TotalCount int32       `json:"totalCount"`
+}
+
+type UserEdge struct {
+	Cursor string `json:"cursor"`
+	Node   *User  `json:"node"`
+}
+
+type VolumeStat struct {
+	Date   string  `json:"date"`
+	Volume float64 `json:"volume"`
 }
 
 type Workout struct {
-	ID               string             `json:"id"`
-	Date             *string            `json:"date,omitempty"`
-	CreatedAt        string             `json:"createdAt"`
-	UpdatedAt        string             `json:"updatedAt"`
-	User             *User              `json:"user"`
-	UserID           string             `json:"userID"`
-	WorkoutExercises []*WorkoutExercise `json:"workoutExercises"`
-	WorkoutGroup     *WorkoutGroup      `json:"workoutGroup,omitempty"`
-	WorkoutGroupID   *string            `json:"workoutGroupID,omitempty"`
+	ID                  string             `json:"id"`
+	Date                *string            `json:"date,omitempty"`
+	CreatedAt           string             `json:"createdAt"`
+	UpdatedAt           string             `json:"updatedAt"`
+	User                *User              `json:"user"`
+	UserID              string             `json:"userID"`
+	WorkoutExercises    []*WorkoutExercise `json:"workoutExercises"`
+	WorkoutGroup        *WorkoutGroup      `json:"workoutGroup,omitempty"`
+	WorkoutGroupID      *string            `json:"workoutGroupID,omitempty"`
+	Status              WorkoutStatus      `json:"status"`
+	StartedAt           *string            `json:"startedAt,omitempty"`
+	FinishedAt          *string            `json:"finishedAt,omitempty"`
+	DurationSeconds     *int32             `json:"durationSeconds,omitempty"`
+	Notes               string             `json:"notes"`
+	SessionRpe          *int32             `json:"sessionRPE,omitempty"`
+	ProgramEnrollmentID *string            `json:"programEnrollmentID,omitempty"`
+}
+
+type WorkoutConnection struct {
+	Edges      []*WorkoutEdge `json:"edges"`
+	PageInfo   *PageInfo      `json:"pageInfo"`
+	TotalCount int32          `json:"totalCount"`
+}
+
+type WorkoutEdge struct {
+	Cursor string   `json:"cursor"`
+	Node   *Workout `json:"node"`
 }
 
 type WorkoutExercise struct {
-	ID       string    `json:"id"`
-	Workout  *Workout  `json:"workout"`
-	Exercise *Exercise `json:"exercise"`
-	SetLogs  []*SetLog `json:"setLogs"`
+	ID            string    `json:"id"`
+	Workout       *Workout  `json:"workout"`
+	Exercise      *Exercise `json:"exercise"`
+	Position      int32     `json:"position"`
+	SupersetGroup *int32    `json:"supersetGroup,omitempty"`
+	SetLogs       []*SetLog `json:"setLogs"`
 }
 
 type WorkoutGroup struct {
-	ID        string     `json:"id"`
-	Title     string     `json:"title"`
-	Date      *string    `json:"date,omitempty"`
-	ImageURL  *string    `json:"imageURL,omitempty"`
-	CreatedAt string     `json:"createdAt"`
-	UpdatedAt string     `json:"updatedAt"`
-	Workouts  []*Workout `json:"workouts"`
+	ID        string                `json:"id"`
+	Title     string                `json:"title"`
+	Date      *string               `json:"date,omitempty"`
+	ImageURL  *string               `json:"imageURL,omitempty"`
+	CreatedAt string                `json:"createdAt"`
+	UpdatedAt string                `json:"updatedAt"`
+	Workouts  []*Workout            `json:"workouts"`
+	Members   []*WorkoutGroupMember `json:"members"`
+}
+
+type WorkoutGroupActivity struct {
+	Type           WorkoutGroupActivityType `json:"type"`
+	WorkoutGroupID string                   `json:"workoutGroupID"`
+	WorkoutGroup   *WorkoutGroup            `json:"workoutGroup"`
+	UserID         string                   `json:"userID"`
+	User           *User                    `json:"user"`
+	WorkoutID      *string                  `json:"workoutID,omitempty"`
+	Workout        *Workout                 `json:"workout,omitempty"`
+	SetLogID       *string                  `json:"setLogID,omitempty"`
+	SetLog         *SetLog                  `json:"setLog,omitempty"`
+	OccurredAt     string                   `json:"occurredAt"`
+}
+
+type WorkoutGroupMember struct {
+	ID             string                   `json:"id"`
+	WorkoutGroupID string                   `json:"workoutGroupID"`
+	WorkoutGroup   *WorkoutGroup            `json:"workoutGroup"`
+	UserID         string                   `json:"userID"`
+	User           *User                    `json:"user"`
+	Role           WorkoutGroupMemberRole   `json:"role"`
+	Status         WorkoutGroupMemberStatus `json:"status"`
+	InvitedByID    *string                  `json:"invitedByID,omitempty"`
+	InvitedBy      *User                    `json:"invitedBy,omitempty"`
+	JoinedAt       *string                  `json:"joinedAt,omitempty"`
+	CreatedAt      string                   `json:"createdAt"`
+	UpdatedAt      string                   `json:"updatedAt"`
+}
+
+type WorkoutTemplate struct {
+	ID          string                     `json:"id"`
+	Name        string                     `json:"name"`
+	Description *string                    `json:"description,omitempty"`
+	User        *User                      `json:"user"`
+	UserID      string                     `json:"userID"`
+	Exercises   []*WorkoutTemplateExercise `json:"exercises"`
+	CreatedAt   string                     `json:"createdAt"`
+	UpdatedAt   string                     `json:"updatedAt"`
+}
+
+type WorkoutTemplateExercise struct {
+	ID             string    `json:"id"`
+	Exercise       *Exercise `json:"exercise"`
+	ExerciseID     string    `json:"exerciseID"`
+	Position       int32     `json:"position"`
+	TargetSets     int32     `json:"targetSets"`
+	TargetReps     int32     `json:"targetReps"`
+	TargetWeightKg *float64  `json:"targetWeightKg,omitempty"`
+	TargetWeight   *float64  `json:"targetWeight,omitempty"`
+}
+
+type WorkoutTemplateExerciseInput struct {
+	ExerciseID   string   `json:"exerciseID"`
+	TargetSets   int32    `json:"targetSets"`
+	TargetReps   int32    `json:"targetReps"`
+	TargetWeight *float64 `json:"targetWeight,omitempty"`
 }
@@ -0,0 +1,102 @@
+package graph
+
+import (
+	"app/graph/model"
+	"app/graph/services"
+	"context"
+)
+
+// ================================
+// Model
+// ================================
+
+// Notification returns NotificationResolver implementation.
+func (r *Resolver) Notification() NotificationResolver { return &notificationResolver{r} }
+
+type notificationResolver struct{ *Resolver }
+
+// Actor is the resolver for the actor field.
+func (r *notificationResolver) Actor(ctx context.Context, obj *model.Notification) (*model.User, error) {
+	if obj.ActorID == nil {
+		return nil, nil
+	}
+	userService := services.NewUserServiceWithSeparation(r.DB)
+	return userService.GetUserByIDWithDataLoader(ctx, *obj.ActorID)
+}
+
+// Friendship is the resolver for the friendship field.
+func (r *notificationResolver) Friendship(ctx context.Context, obj *model.Notification) (*model.Friendship, error) {
+	if obj.FriendshipID == nil {
+		return nil, nil
+	}
+	friendshipService := services.NewFriendshipServiceWithSeparation(r.DB, r.PubSub, r.FriendQR)
+	return friendshipService.GetFriendshipByID(ctx, *obj.FriendshipID)
+}
+
+// WorkoutGroup is the resolver for the workoutGroup field.
+func (r *notificationResolver) WorkoutGroup(ctx context.Context, obj *model.Notification) (*model.WorkoutGroup, error) {
+	if obj.WorkoutGroupID == nil {
+		return nil, nil
+	}
+	workoutGroupService := services.NewWorkoutGroupServiceWithSeparation(r.DB, r.PubSub)
+	return workoutGroupService.GetWorkoutGroupWithDataLoader(ctx, *obj.WorkoutGroupID)
+}
+
+// PersonalRecord is the resolver for the personalRecord field.
+func (r *notificationResolver) PersonalRecord(ctx context.Context, obj *model.Notification) (*model.PersonalRecord, error) {
+	if obj.PersonalRecordID == nil {
+		return nil, nil
+	}
+	personalRecordService := services.NewPersonalRecordServiceWithSeparation(r.DB)
+	return personalRecordService.GetPersonalRecordByID(ctx, *obj.PersonalRecordID)
+}
+
+// ================================
+// Query
+// ================================
+
+// Notifications is the resolver for the notifications field.
+func (r *queryResolver) Notifications(ctx context.Context, unreadOnly *bool, first *int32, after *string) (*model.NotificationConnection, error) {
+	notificationService := services.NewNotificationServiceWithSeparation(r.DB)
+	return notificationService.GetNotifications(ctx, unreadOnly != nil && *unreadOnly, first, after)
+}
+
+// UnreadNotificationCount is the resolver for the unreadNotificationCount field.
+func (r *queryResolver) UnreadNotificationCount(ctx context.Context) (int32, error) {
+	notificationService := services.NewNotificationServiceWithSeparation(r.DB)
+	return notificationService.GetUnreadCount(ctx)
+}
+
+// NotificationPreferences is the resolver for the notificationPreferences field.
+func (r *queryResolver) NotificationPreferences(ctx context.Context) (*model.NotificationPreferences, error) {
+	notificationService := services.NewNotificationServiceWithSeparation(r.DB)
+	return notificationService.GetPreferences(ctx)
+}
+
+// ================================
+// Mutation
+// ================================
+
+// MarkNotificationsRead is the resolver for the markNotificationsRead field.
+func (r *mutationResolver) MarkNotificationsRead(ctx context.Context, input model.MarkNotificationsRead) (int32, error) {
+	notificationService := services.NewNotificationServiceWithSeparation(r.DB)
+	return notificationService.MarkNotificationsRead(ctx, input)
+}
+
+// UpdateNotificationPreferences is the resolver for the updateNotificationPreferences field.
+func (r *mutationResolver) UpdateNotificationPreferences(ctx context.Context, input model.UpdateNotificationPreferences) (*model.NotificationPreferences, error) {
+	notificationService := services.NewNotificationServiceWithSeparation(r.DB)
+	return notificationService.UpdatePreferences(ctx, input)
+}
+
+// RegisterDevice is the resolver for the registerDevice field.
+func (r *mutationResolver) RegisterDevice(ctx context.Context, input model.RegisterDevice) (bool, error) {
+	deviceService := services.NewDeviceServiceWithSeparation(r.DB)
+	return deviceService.RegisterDevice(ctx, input)
+}
+
+// UnregisterDevice is the resolver for the unregisterDevice field.
+func (r *mutationResolver) UnregisterDevice(ctx context.Context, input model.UnregisterDevice) (bool, error) {
+	deviceService := services.NewDeviceServiceWithSeparation(r.DB)
+	return deviceService.UnregisterDevice(ctx, input)
+}
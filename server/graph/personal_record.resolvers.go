@@ -0,0 +1,22 @@
+package graph
+
+import (
+	"app/graph/model"
+	"app/graph/services"
+	"context"
+)
+
+// ================================
+// Model
+// ================================
+
+// PersonalRecord returns PersonalRecordResolver implementation.
+func (r *Resolver) PersonalRecord() PersonalRecordResolver { return &personalRecordResolver{r} }
+
+type personalRecordResolver struct{ *Resolver }
+
+// Exercise is the resolver for the exercise field.
+func (r *personalRecordResolver) Exercise(ctx context.Context, obj *model.PersonalRecord) (*model.Exercise, error) {
+	exerciseService := services.NewExerciseServiceWithSeparation(r.DB)
+	return exerciseService.GetExerciseWithDataLoader(ctx, obj.ExerciseID)
+}
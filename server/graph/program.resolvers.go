@@ -0,0 +1,110 @@
+package graph
+
+import (
+	"app/graph/model"
+	"app/graph/services"
+	"context"
+)
+
+// ================================
+// Model
+// ================================
+
+// Program returns ProgramResolver implementation.
+func (r *Resolver) Program() ProgramResolver { return &programResolver{r} }
+
+type programResolver struct{ *Resolver }
+
+func (r *programResolver) User(ctx context.Context, obj *model.Program) (*model.User, error) {
+	userService := services.NewUserServiceWithSeparation(r.DB)
+	return userService.GetUserByIDWithDataLoader(ctx, obj.UserID)
+}
+
+// ProgramExercise returns ProgramExerciseResolver implementation.
+func (r *Resolver) ProgramExercise() ProgramExerciseResolver { return &programExerciseResolver{r} }
+
+type programExerciseResolver struct{ *Resolver }
+
+func (r *programExerciseResolver) Exercise(ctx context.Context, obj *model.ProgramExercise) (*model.Exercise, error) {
+	exerciseService := services.NewExerciseServiceWithSeparation(r.DB)
+	return exerciseService.GetExerciseWithDataLoader(ctx, obj.ExerciseID)
+}
+
+// ProgramEnrollment returns ProgramEnrollmentResolver implementation.
+func (r *Resolver) ProgramEnrollment() ProgramEnrollmentResolver {
+	return &programEnrollmentResolver{r}
+}
+
+type programEnrollmentResolver struct{ *Resolver }
+
+func (r *programEnrollmentResolver) Program(ctx context.Context, obj *model.ProgramEnrollment) (*model.Program, error) {
+	programService := services.NewProgramServiceWithSeparation(r.DB)
+	return programService.GetProgram(ctx, obj.ProgramID)
+}
+
+func (r *programEnrollmentResolver) TodaysWorkout(ctx context.Context, obj *model.ProgramEnrollment) (*model.PrescribedWorkout, error) {
+	programService := services.NewProgramServiceWithSeparation(r.DB)
+	return programService.GetTodaysWorkout(ctx, obj.ID)
+}
+
+// PrescribedExercise returns PrescribedExerciseResolver implementation.
+func (r *Resolver) PrescribedExercise() PrescribedExerciseResolver {
+	return &prescribedExerciseResolver{r}
+}
+
+type prescribedExerciseResolver struct{ *Resolver }
+
+func (r *prescribedExerciseResolver) Exercise(ctx context.Context, obj *model.PrescribedExercise) (*model.Exercise, error) {
+	exerciseService := services.NewExerciseServiceWithSeparation(r.DB)
+	return exerciseService.GetExerciseWithDataLoader(ctx, obj.ExerciseID)
+}
+
+// ================================
+// Query
+// ================================
+
+// Programs is the resolver for the programs field.
+func (r *queryResolver) Programs(ctx context.Context) ([]*model.Program, error) {
+	programService := services.NewProgramServiceWithSeparation(r.DB)
+	return programService.GetPrograms(ctx)
+}
+
+// Program is the resolver for the program field.
+func (r *queryResolver) Program(ctx context.Context, id string) (*model.Program, error) {
+	programService := services.NewProgramServiceWithSeparation(r.DB)
+	return programService.GetProgram(ctx, id)
+}
+
+// MyProgramEnrollment is the resolver for the myProgramEnrollment field.
+func (r *queryResolver) MyProgramEnrollment(ctx context.Context) (*model.ProgramEnrollment, error) {
+	programService := services.NewProgramServiceWithSeparation(r.DB)
+	return programService.GetMyProgramEnrollment(ctx)
+}
+
+// ================================
+// Mutation
+// ================================
+
+// CreateProgram is the resolver for the createProgram field.
+func (r *mutationResolver) CreateProgram(ctx context.Context, input model.CreateProgram) (*model.Program, error) {
+	programService := services.NewProgramServiceWithSeparation(r.DB)
+	return programService.CreateProgram(ctx, input)
+}
+
+// DeleteProgram is the resolver for the deleteProgram field.
+func (r *mutationResolver) DeleteProgram(ctx context.Context, input model.DeleteProgram) (bool, error) {
+	programService := services.NewProgramServiceWithSeparation(r.DB)
+	return programService.DeleteProgram(ctx, input)
+}
+
+// EnrollProgram is the resolver for the enrollProgram field.
+func (r *mutationResolver) EnrollProgram(ctx context.Context, input model.EnrollProgram) (*model.ProgramEnrollment, error) {
+	programService := services.NewProgramServiceWithSeparation(r.DB)
+	return programService.EnrollProgram(ctx, input)
+}
+
+// LeaveProgram is the resolver for the leaveProgram field.
+func (r *mutationResolver) LeaveProgram(ctx context.Context) (bool, error) {
+	programService := services.NewProgramServiceWithSeparation(r.DB)
+	return programService.LeaveProgram(ctx)
+}
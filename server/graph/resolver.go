@@ -2,7 +2,9 @@ package graph
 
 import (
 	"app/auth"
+	"app/friendqr"
 	"app/middleware"
+	"app/pubsub"
 
 	"gorm.io/gorm"
 )
@@ -16,4 +18,6 @@ type Resolver struct {
 	FirebaseAuth   *auth.FirebaseAuth
 	AuthMiddleware *middleware.AuthMiddleware
 	DataLoaders    *DataLoaders
+	PubSub         pubsub.PubSub
+	FriendQR       *friendqr.Signer
 }
@@ -0,0 +1,57 @@
+package analytics
+
+import (
+	"app/graph/model"
+	"app/graph/services/common"
+	"fmt"
+)
+
+type AnalyticsConverter struct{}
+
+func NewAnalyticsConverter() *AnalyticsConverter {
+	return &AnalyticsConverter{}
+}
+
+func (c *AnalyticsConverter) ToModelVolumeStats(volumes []*PeriodVolume) []*model.VolumeStat {
+	result := make([]*model.VolumeStat, len(volumes))
+	for i, volume := range volumes {
+		result[i] = &model.VolumeStat{
+			Date:   volume.Period.Format(common.DateFormat),
+			Volume: volume.Volume,
+		}
+	}
+	return result
+}
+
+func (c *AnalyticsConverter) ToModelCategoryVolumeStats(volumes []*CategoryVolume) []*model.CategoryVolumeStat {
+	result := make([]*model.CategoryVolumeStat, len(volumes))
+	for i, volume := range volumes {
+		result[i] = &model.CategoryVolumeStat{
+			Category: volume.Category,
+			Volume:   volume.Volume,
+		}
+	}
+	return result
+}
+
+// ToModelOneRepMaxTrends は種目順に並んだ推定1RMを種目ごとのトレンドにまとめる
+func (c *AnalyticsConverter) ToModelOneRepMaxTrends(points []*OneRepMaxPoint) []*model.OneRepMaxTrend {
+	result := []*model.OneRepMaxTrend{}
+	trendMap := make(map[uint]*model.OneRepMaxTrend)
+	for _, point := range points {
+		trend, exists := trendMap[point.ExerciseID]
+		if !exists {
+			trend = &model.OneRepMaxTrend{
+				ExerciseID: fmt.Sprintf("%d", point.ExerciseID),
+				Points:     []*model.OneRepMaxPoint{},
+			}
+			trendMap[point.ExerciseID] = trend
+			result = append(result, trend)
+		}
+		trend.Points = append(trend.Points, &model.OneRepMaxPoint{
+			Date:               point.Day.Format(common.DateFormat),
+			EstimatedOneRepMax: point.EstimatedOneRepMax,
+		})
+	}
+	return result
+}
@@ -0,0 +1,131 @@
+package analytics
+
+import (
+	"app/entity"
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type AnalyticsRepository interface {
+	GetVolumeByPeriod(ctx context.Context, userID uint, from, to time.Time, period Period) ([]*PeriodVolume, error)
+	GetVolumeByCategory(ctx context.Context, userID uint, from, to time.Time) ([]*CategoryVolume, error)
+	GetOneRepMaxTrends(ctx context.Context, userID uint, from, to time.Time, formula entity.OneRepMaxFormula, exerciseIDs []uint) ([]*OneRepMaxPoint, error)
+}
+
+// Period は集計単位（PostgreSQLの date_trunc に渡す値）
+type Period string
+
+const (
+	PeriodDay   Period = "day"
+	PeriodWeek  Period = "week"
+	PeriodMonth Period = "month"
+)
+
+// PeriodVolume は期間ごとの総ボリューム
+type PeriodVolume struct {
+	Period time.Time
+	Volume float64
+}
+
+// CategoryVolume はカテゴリごとの総ボリューム
+type CategoryVolume struct {
+	Category string
+	Volume   float64
+}
+
+// OneRepMaxPoint は種目・日ごとの最大推定1RM
+type OneRepMaxPoint struct {
+	ExerciseID         uint
+	Day                time.Time
+	EstimatedOneRepMax float64
+}
+
+const (
+	// ワークアウトの日付（未設定の場合は作成日時）
+	workoutDateExpression = "COALESCE(workouts.date, workouts.created_at)"
+	volumeExpression      = "SUM(set_logs.weight * set_logs.rep_count)"
+)
+
+// 推定1RMの計算式（1レップの場合は重量そのもの）
+var oneRepMaxExpressions = map[entity.OneRepMaxFormula]string{
+	entity.Epley:   "MAX(CASE WHEN set_logs.rep_count = 1 THEN set_logs.weight ELSE set_logs.weight * (1 + set_logs.rep_count / 30.0) END)",
+	entity.Brzycki: "MAX(CASE WHEN set_logs.rep_count = 1 THEN set_logs.weight ELSE set_logs.weight * 36.0 / (37 - set_logs.rep_count) END)",
+}
+
+type analyticsRepository struct {
+	db *gorm.DB
+}
+
+func NewAnalyticsRepository(db *gorm.DB) AnalyticsRepository {
+	return &analyticsRepository{db: db}
+}
+
+func (r *analyticsRepository) GetVolumeByPeriod(ctx context.Context, userID uint, from, to time.Time, period Period) ([]*PeriodVolume, error) {
+	var volumes []*PeriodVolume
+	if err := r.setLogsQuery(userID, from, to).
+		Select(fmt.Sprintf("DATE_TRUNC('%s', %s) AS period, %s AS volume", period, workoutDateExpression, volumeExpression)).
+		Group("period").
+		Order("period ASC").
+		Scan(&volumes).Error; err != nil {
+		return nil, fmt.Errorf("failed to aggregate volume by %s: %w", period, err)
+	}
+
+	return volumes, nil
+}
+
+func (r *analyticsRepository) GetVolumeByCategory(ctx context.Context, userID uint, from, to time.Time) ([]*CategoryVolume, error) {
+	var volumes []*CategoryVolume
+	if err := r.setLogsQuery(userID, from, to).
+		Joins("inner join exercises on exercises.id = workout_exercises.exercise_id").
+		Select(fmt.Sprintf("COALESCE(exercises.category, '') AS category, %s AS volume", volumeExpression)).
+		Group("COALESCE(exercises.category, '')").
+		Order("volume DESC").
+		Scan(&volumes).Error; err != nil {
+		return nil, fmt.Errorf("failed to aggregate volume by category: %w", err)
+	}
+
+	return volumes, nil
+}
+
+func (r *analyticsRepository) GetOneRepMaxTrends(ctx context.Context, userID uint, from, to time.Time, formula entity.OneRepMaxFormula, exerciseIDs []uint) ([]*OneRepMaxPoint, error) {
+	expression, exists := oneRepMaxExpressions[formula]
+	if !exists {
+		return nil, fmt.Errorf("unsupported one rep max formula: %s", formula)
+	}
+
+	query := r.setLogsQuery(userID, from, to).
+		Select(fmt.Sprintf("workout_exercises.exercise_id, DATE_TRUNC('day', %s) AS day, %s AS estimated_one_rep_max", workoutDateExpression, expression))
+	if formula == entity.Brzycki {
+		// Brzycki式は37レップ以上では計算できない
+		query = query.Where("set_logs.rep_count < 37")
+	}
+	if len(exerciseIDs) > 0 {
+		query = query.Where("workout_exercises.exercise_id IN ?", exerciseIDs)
+	}
+
+	var points []*OneRepMaxPoint
+	if err := query.
+		Group("workout_exercises.exercise_id, day").
+		Order("workout_exercises.exercise_id ASC, day ASC").
+		Scan(&points).Error; err != nil {
+		return nil, fmt.Errorf("failed to aggregate one rep max trends: %w", err)
+	}
+
+	return points, nil
+}
+
+// setLogsQuery は指定ユーザー・期間（from以上to未満）の完了済みのセットを対象とするクエリを作成する
+// ボリューム・推定1RMは重量×レップ数で記録する種目のみ集計する
+func (r *analyticsRepository) setLogsQuery(userID uint, from, to time.Time) *gorm.DB {
+	return r.db.Model(&entity.SetLog{}).
+		Joins("inner join workout_exercises on workout_exercises.id = set_logs.workout_exercise_id AND workout_exercises.deleted_at IS NULL").
+		Joins("inner join workouts on workouts.id = workout_exercises.workout_id AND workouts.deleted_at IS NULL").
+		Where("workouts.user_id = ? AND set_logs.completed = ?", userID, true).
+		Where("workout_exercises.exercise_id IN (?)", r.db.Model(&entity.Exercise{}).Unscoped().
+			Select("id").
+			Where("tracking_type = ?", entity.TrackingTypeWeightReps)).
+		Where(workoutDateExpression+" >= ? AND "+workoutDateExpression+" < ?", from, to)
+}
@@ -0,0 +1,108 @@
+package analytics
+
+import (
+	"app/entity"
+	"app/graph/model"
+	"app/graph/services/common"
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// 集計できる最大期間（日数）
+const maxStatsDays = 366 * 5
+
+type AnalyticsService interface {
+	GetStats(ctx context.Context, from, to string, formula *model.OneRepMaxFormula, exerciseIDs []string) (*model.Stats, error)
+}
+
+type analyticsService struct {
+	repo      AnalyticsRepository
+	converter *AnalyticsConverter
+	common    common.CommonRepository
+}
+
+func NewAnalyticsService(repo AnalyticsRepository, converter *AnalyticsConverter) AnalyticsService {
+	return &analyticsService{
+		repo:      repo,
+		converter: converter,
+		common:    common.NewCommonRepository(repo.(*analyticsRepository).db),
+	}
+}
+
+// GetStats は現在のユーザーの from〜to（両端を含む）のトレーニングを集計する
+func (s *analyticsService) GetStats(ctx context.Context, from, to string, formula *model.OneRepMaxFormula, exerciseIDs []string) (*model.Stats, error) {
+	currentUser, err := s.common.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	fromDate, err := time.Parse(common.DateFormat, from)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse from date: %w", err)
+	}
+	toDate, err := time.Parse(common.DateFormat, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse to date: %w", err)
+	}
+	if toDate.Before(fromDate) {
+		return nil, fmt.Errorf("to must be on or after from")
+	}
+	if toDate.Sub(fromDate) > maxStatsDays*24*time.Hour {
+		return nil, fmt.Errorf("date range must be within %d days", maxStatsDays)
+	}
+	// 終了日を含めるため翌日の0時までを対象とする
+	toDate = toDate.AddDate(0, 0, 1)
+
+	ids := make([]uint, len(exerciseIDs))
+	for i, exerciseID := range exerciseIDs {
+		id, err := strconv.ParseUint(exerciseID, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exercise ID: %s", exerciseID)
+		}
+		ids[i] = uint(id)
+	}
+
+	oneRepMaxFormula := entity.Epley
+	if formula != nil {
+		oneRepMaxFormula = entity.OneRepMaxFormulaFromGraphQL(*formula)
+	}
+
+	daily, err := s.repo.GetVolumeByPeriod(ctx, currentUser.ID, fromDate, toDate, PeriodDay)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get daily volume: %w", err)
+	}
+	weekly, err := s.repo.GetVolumeByPeriod(ctx, currentUser.ID, fromDate, toDate, PeriodWeek)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get weekly volume: %w", err)
+	}
+	monthly, err := s.repo.GetVolumeByPeriod(ctx, currentUser.ID, fromDate, toDate, PeriodMonth)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get monthly volume: %w", err)
+	}
+	categories, err := s.repo.GetVolumeByCategory(ctx, currentUser.ID, fromDate, toDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get category volume: %w", err)
+	}
+	oneRepMaxPoints, err := s.repo.GetOneRepMaxTrends(ctx, currentUser.ID, fromDate, toDate, oneRepMaxFormula, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get one rep max trends: %w", err)
+	}
+
+	var totalVolume float64
+	for _, volume := range daily {
+		totalVolume += volume.Volume
+	}
+
+	return &model.Stats{
+		From:            from,
+		To:              to,
+		TotalVolume:     totalVolume,
+		DailyVolume:     s.converter.ToModelVolumeStats(daily),
+		WeeklyVolume:    s.converter.ToModelVolumeStats(weekly),
+		MonthlyVolume:   s.converter.ToModelVolumeStats(monthly),
+		CategoryVolume:  s.converter.ToModelCategoryVolumeStats(categories),
+		OneRepMaxTrends: s.converter.ToModelOneRepMaxTrends(oneRepMaxPoints),
+	}, nil
+}
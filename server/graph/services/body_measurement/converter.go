@@ -0,0 +1,55 @@
+package body_measurement
+
+import (
+	"app/entity"
+	"app/graph/model"
+	"app/graph/services/common"
+	"fmt"
+)
+
+type BodyMeasurementConverter struct{}
+
+func NewBodyMeasurementConverter() *BodyMeasurementConverter {
+	return &BodyMeasurementConverter{}
+}
+
+func (c *BodyMeasurementConverter) ToModelBodyMeasurement(measurement entity.BodyMeasurement) *model.BodyMeasurement {
+	modelMeasurement := &model.BodyMeasurement{
+		ID:                fmt.Sprintf("%d", measurement.ID),
+		MeasuredAt:        measurement.MeasuredAt.Format(common.TimeFormat),
+		WeightKg:          measurement.Weight,
+		BodyFatPercentage: measurement.BodyFatPercentage,
+		Waist:             measurement.Waist,
+		Chest:             measurement.Chest,
+		Arm:               measurement.Arm,
+		Hip:               measurement.Hip,
+		Thigh:             measurement.Thigh,
+		Neck:              measurement.Neck,
+		CreatedAt:         measurement.CreatedAt.Format(common.TimeFormat),
+	}
+	if measurement.Note != "" {
+		modelMeasurement.Note = &measurement.Note
+	}
+	return modelMeasurement
+}
+
+// ToModelBodyMeasurementsNewestFirst は古い順の記録を新しい順に変換する
+func (c *BodyMeasurementConverter) ToModelBodyMeasurementsNewestFirst(measurements []*entity.BodyMeasurement) []*model.BodyMeasurement {
+	result := make([]*model.BodyMeasurement, len(measurements))
+	for i, measurement := range measurements {
+		result[len(measurements)-1-i] = c.ToModelBodyMeasurement(*measurement)
+	}
+	return result
+}
+
+func (c *BodyMeasurementConverter) ToModelBodyMeasurementPoints(points []entity.BodyMeasurementPoint) []*model.BodyMeasurementPoint {
+	result := make([]*model.BodyMeasurementPoint, len(points))
+	for i, point := range points {
+		result[i] = &model.BodyMeasurementPoint{
+			MeasuredAt:    point.MeasuredAt.Format(common.TimeFormat),
+			Value:         point.Value,
+			MovingAverage: point.MovingAverage,
+		}
+	}
+	return result
+}
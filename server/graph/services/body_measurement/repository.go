@@ -0,0 +1,64 @@
+package body_measurement
+
+import (
+	"app/entity"
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type BodyMeasurementRepository interface {
+	GetBodyMeasurementByID(ctx context.Context, id uint) (*entity.BodyMeasurement, error)
+	GetBodyMeasurements(ctx context.Context, userID uint, from, to *time.Time) ([]*entity.BodyMeasurement, error)
+	CreateBodyMeasurement(ctx context.Context, measurement *entity.BodyMeasurement) error
+	DeleteBodyMeasurement(ctx context.Context, id uint) error
+}
+
+type bodyMeasurementRepository struct {
+	db *gorm.DB
+}
+
+func NewBodyMeasurementRepository(db *gorm.DB) BodyMeasurementRepository {
+	return &bodyMeasurementRepository{db: db}
+}
+
+func (r *bodyMeasurementRepository) GetBodyMeasurementByID(ctx context.Context, id uint) (*entity.BodyMeasurement, error) {
+	var measurement entity.BodyMeasurement
+	if err := r.db.Where("id = ?", id).First(&measurement).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch body measurement: %w", err)
+	}
+	return &measurement, nil
+}
+
+// GetBodyMeasurements はユーザーの記録を記録日時の古い順に取得する（from以上・to未満、nilの場合は制限しない）
+func (r *bodyMeasurementRepository) GetBodyMeasurements(ctx context.Context, userID uint, from, to *time.Time) ([]*entity.BodyMeasurement, error) {
+	query := r.db.Where("user_id = ?", userID)
+	if from != nil {
+		query = query.Where("measured_at >= ?", *from)
+	}
+	if to != nil {
+		query = query.Where("measured_at < ?", *to)
+	}
+
+	var measurements []*entity.BodyMeasurement
+	if err := query.Order("measured_at ASC, id ASC").Find(&measurements).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch body measurements: %w", err)
+	}
+	return measurements, nil
+}
+
+func (r *bodyMeasurementRepository) CreateBodyMeasurement(ctx context.Context, measurement *entity.BodyMeasurement) error {
+	if err := r.db.Create(measurement).Error; err != nil {
+		return fmt.Errorf("failed to create body measurement: %w", err)
+	}
+	return nil
+}
+
+func (r *bodyMeasurementRepository) DeleteBodyMeasurement(ctx context.Context, id uint) error {
+	if err := r.db.Unscoped().Delete(&entity.BodyMeasurement{}, id).Error; err != nil {
+		return fmt.Errorf("failed to delete body measurement: %w", err)
+	}
+	return nil
+}
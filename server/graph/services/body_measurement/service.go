@@ -0,0 +1,206 @@
+package body_measurement
+
+import (
+	"app/entity"
+	"app/graph/model"
+	"app/graph/services/common"
+	"app/graph/services/policy"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 推移を取得できる最大期間（日数）
+const maxTrendDays = 366 * 5
+
+type BodyMeasurementService interface {
+	GetBodyMeasurements(ctx context.Context, from, to *string) ([]*model.BodyMeasurement, error)
+	GetBodyMeasurementTrend(ctx context.Context, metric model.BodyMeasurementMetric, from, to string, windowDays *int32) (*model.BodyMeasurementTrend, error)
+	LogBodyMeasurement(ctx context.Context, input model.LogBodyMeasurement) (*model.BodyMeasurement, error)
+	DeleteBodyMeasurement(ctx context.Context, input model.DeleteBodyMeasurement) (bool, error)
+}
+
+type bodyMeasurementService struct {
+	repo      BodyMeasurementRepository
+	converter *BodyMeasurementConverter
+	common    common.CommonRepository
+	ownership *policy.OwnershipPolicy
+}
+
+func NewBodyMeasurementService(repo BodyMeasurementRepository, converter *BodyMeasurementConverter) BodyMeasurementService {
+	return &bodyMeasurementService{
+		repo:      repo,
+		converter: converter,
+		common:    common.NewCommonRepository(repo.(*bodyMeasurementRepository).db),
+		ownership: policy.NewOwnershipPolicy(policy.NewOwnershipRepository(repo.(*bodyMeasurementRepository).db)),
+	}
+}
+
+// GetBodyMeasurements は現在のユーザーの from〜to（両端を含む）の記録を新しい順に取得する
+func (s *bodyMeasurementService) GetBodyMeasurements(ctx context.Context, from, to *string) ([]*model.BodyMeasurement, error) {
+	currentUser, err := s.common.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	var fromDate, toDate *time.Time
+	if from != nil {
+		parsed, err := time.Parse(common.DateFormat, *from)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse from date: %w", err)
+		}
+		fromDate = &parsed
+	}
+	if to != nil {
+		parsed, err := time.Parse(common.DateFormat, *to)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse to date: %w", err)
+		}
+		// 終了日を含めるため翌日の0時までを対象とする
+		parsed = parsed.AddDate(0, 0, 1)
+		toDate = &parsed
+	}
+
+	measurements, err := s.repo.GetBodyMeasurements(ctx, currentUser.ID, fromDate, toDate)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.converter.ToModelBodyMeasurementsNewestFirst(measurements), nil
+}
+
+// GetBodyMeasurementTrend は現在のユーザーの from〜to（両端を含む）の metric の推移と移動平均を取得する
+func (s *bodyMeasurementService) GetBodyMeasurementTrend(ctx context.Context, metric model.BodyMeasurementMetric, from, to string, windowDays *int32) (*model.BodyMeasurementTrend, error) {
+	currentUser, err := s.common.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	fromDate, err := time.Parse(common.DateFormat, from)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse from date: %w", err)
+	}
+	toDate, err := time.Parse(common.DateFormat, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse to date: %w", err)
+	}
+	if toDate.Before(fromDate) {
+		return nil, fmt.Errorf("to must be on or after from")
+	}
+	if toDate.Sub(fromDate) > maxTrendDays*24*time.Hour {
+		return nil, fmt.Errorf("date range must be within %d days", maxTrendDays)
+	}
+	// 終了日を含めるため翌日の0時までを対象とする
+	toDate = toDate.AddDate(0, 0, 1)
+
+	window := entity.DefaultMovingAverageDays
+	if windowDays != nil {
+		window = int(*windowDays)
+	}
+	if window < 1 || window > entity.MaxMovingAverageDays {
+		return nil, fmt.Errorf("window days must be between 1 and %d", entity.MaxMovingAverageDays)
+	}
+
+	// 期間の最初の記録の移動平均を計算するため、移動平均の期間分さかのぼって取得する
+	since := fromDate.AddDate(0, 0, -window)
+	measurements, err := s.repo.GetBodyMeasurements(ctx, currentUser.ID, &since, &toDate)
+	if err != nil {
+		return nil, err
+	}
+
+	entityMetric := entity.BodyMeasurementMetricFromGraphQL(metric)
+	points := entity.NewBodyMeasurementTrend(measurements, entityMetric, window, fromDate)
+
+	trend := &model.BodyMeasurementTrend{
+		Metric:     metric,
+		From:       from,
+		To:         to,
+		WindowDays: int32(window),
+		Points:     s.converter.ToModelBodyMeasurementPoints(points),
+	}
+	if len(points) > 0 {
+		change := points[len(points)-1].Value - points[0].Value
+		trend.Change = &change
+	}
+	return trend, nil
+}
+
+// LogBodyMeasurement は現在のユーザーの体重・体脂肪率・周囲径を記録する
+func (s *bodyMeasurementService) LogBodyMeasurement(ctx context.Context, input model.LogBodyMeasurement) (*model.BodyMeasurement, error) {
+	currentUser, err := s.common.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	measuredAt := time.Now()
+	if input.MeasuredAt != nil {
+		measuredAt, err = time.Parse(common.TimeFormat, *input.MeasuredAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse measured at: %w", err)
+		}
+	}
+
+	measurement := &entity.BodyMeasurement{
+		UserID:            currentUser.ID,
+		MeasuredAt:        measuredAt,
+		BodyFatPercentage: input.BodyFatPercentage,
+		Waist:             input.Waist,
+		Chest:             input.Chest,
+		Arm:               input.Arm,
+		Hip:               input.Hip,
+		Thigh:             input.Thigh,
+		Neck:              input.Neck,
+	}
+
+	if input.Weight != nil {
+		unit := entity.WeightUnitKg
+		if input.WeightUnit != nil {
+			unit = entity.WeightUnitFromGraphQL(*input.WeightUnit)
+		} else {
+			unit, err = s.common.GetPreferredWeightUnit(ctx, currentUser.ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get preferred weight unit: %w", err)
+			}
+		}
+		weight := entity.ToKilograms(*input.Weight, unit)
+		measurement.Weight = &weight
+	}
+
+	if input.Note != nil {
+		measurement.Note = strings.TrimSpace(*input.Note)
+	}
+
+	if err := s.repo.CreateBodyMeasurement(ctx, measurement); err != nil {
+		return nil, err
+	}
+
+	return s.converter.ToModelBodyMeasurement(*measurement), nil
+}
+
+// DeleteBodyMeasurement は現在のユーザーの記録を削除する
+func (s *bodyMeasurementService) DeleteBodyMeasurement(ctx context.Context, input model.DeleteBodyMeasurement) (bool, error) {
+	currentUser, err := s.common.GetCurrentUser(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	id, err := strconv.ParseUint(input.ID, 10, 32)
+	if err != nil {
+		return false, fmt.Errorf("invalid body measurement ID: %s", input.ID)
+	}
+
+	measurement, err := s.repo.GetBodyMeasurementByID(ctx, uint(id))
+	if err != nil {
+		return false, err
+	}
+	if err := s.ownership.AuthorizeUser(ctx, currentUser, measurement.UserID); err != nil {
+		return false, err
+	}
+
+	if err := s.repo.DeleteBodyMeasurement(ctx, measurement.ID); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,134 @@
+package base
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/graph-gophers/dataloader/v7"
+	"gorm.io/gorm"
+)
+
+// PageArgs はカーソルベースのページネーション引数です
+type PageArgs struct {
+	First int  // 取得件数
+	After uint // このIDより後ろから取得する（0の場合は先頭から）
+}
+
+// Page はページネーション結果を表します
+type Page[T any] struct {
+	Items       []*T
+	HasNextPage bool
+	TotalCount  int64
+}
+
+// Append は first 件までアイテムを追加し、それ以降のアイテムがあれば次ページありとします
+// （first+1 件を取得して次ページの有無を判定するために使用）
+func (p *Page[T]) Append(item *T, first int) {
+	if len(p.Items) < first {
+		p.Items = append(p.Items, item)
+	} else {
+		p.HasNextPage = true
+	}
+}
+
+// BasePageLoader はページネーション付き配列エンティティ用の共通ローダー構造体です
+type BasePageLoader[T any] struct {
+	db     *gorm.DB
+	loader *dataloader.Loader[StringKey, *Page[T]]
+
+	// バッチ処理用の関数
+	fetchFunc func([]uint, PageArgs) (map[uint]*Page[T], error) // 親ID別にページを取得する関数
+}
+
+// DB はデータベースインスタンスを取得します
+func (b *BasePageLoader[T]) DB() *gorm.DB {
+	return b.db
+}
+
+// NewBasePageLoader は新しいページネーション用ローダーを作成します
+func NewBasePageLoader[T any](
+	db *gorm.DB,
+	fetchFunc func([]uint, PageArgs) (map[uint]*Page[T], error),
+) *BasePageLoader[T] {
+	loader := &BasePageLoader[T]{
+		db:        db,
+		fetchFunc: fetchFunc,
+	}
+	loader.loader = dataloader.NewBatchedLoader(loader.batchLoad, dataloader.WithCache(&dataloader.NoCache[StringKey, *Page[T]]{}))
+	return loader
+}
+
+// batchLoad はページネーション用バッチ処理の共通実装です
+// 同じページ引数を持つキーごとにまとめて取得します
+func (b *BasePageLoader[T]) batchLoad(ctx context.Context, keys []StringKey) []*dataloader.Result[*Page[T]] {
+	keyStrings := convertKeysToStrings(keys)
+
+	// キーをパースしてページ引数ごとにグループ化
+	groups := make(map[PageArgs][]uint)
+	for _, key := range keyStrings {
+		id, args, err := ParsePageKey(key)
+		if err != nil {
+			return CreateErrorResults[*Page[T]](keyStrings, fmt.Errorf("failed to parse keys"))
+		}
+		groups[args] = append(groups[args], id)
+	}
+
+	// データを取得
+	pages := make(map[PageArgs]map[uint]*Page[T], len(groups))
+	for args, ids := range groups {
+		data, err := b.fetchFunc(ids, args)
+		if err != nil {
+			return CreateErrorResults[*Page[T]](keyStrings, fmt.Errorf("failed to fetch data: %w", err))
+		}
+		pages[args] = data
+	}
+
+	// 結果を作成
+	results := make([]*dataloader.Result[*Page[T]], len(keyStrings))
+	for i, key := range keyStrings {
+		id, args, _ := ParsePageKey(key)
+		if page, exists := pages[args][id]; exists && page != nil {
+			results[i] = &dataloader.Result[*Page[T]]{Data: page}
+		} else {
+			// データが存在しない場合、空ページを返す
+			results[i] = &dataloader.Result[*Page[T]]{Data: &Page[T]{Items: []*T{}}}
+		}
+	}
+
+	return results
+}
+
+// Load は指定された親IDとページ引数でページを取得します
+func (b *BasePageLoader[T]) Load(ctx context.Context, id string, args PageArgs) (*Page[T], error) {
+	return LoadGeneric(ctx, b.loader, StringKey(NewPageKey(id, args)))
+}
+
+// NewPageKey は親IDとページ引数からDataLoader用のキーを作成します
+func NewPageKey(id string, args PageArgs) string {
+	return fmt.Sprintf("%s:%d:%d", id, args.First, args.After)
+}
+
+// ParsePageKey はDataLoader用のキーを親IDとページ引数に変換します
+func ParsePageKey(key string) (uint, PageArgs, error) {
+	parts := strings.Split(key, ":")
+	if len(parts) != 3 {
+		return 0, PageArgs{}, fmt.Errorf(ErrInvalidKey, key)
+	}
+
+	id, err := ParseUintKey(parts[0])
+	if err != nil {
+		return 0, PageArgs{}, err
+	}
+	first, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, PageArgs{}, fmt.Errorf(ErrInvalidKey, key)
+	}
+	after, err := ParseUintKey(parts[2])
+	if err != nil {
+		return 0, PageArgs{}, err
+	}
+
+	return id, PageArgs{First: first, After: after}, nil
+}
@@ -0,0 +1,53 @@
+package base
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPage_Append(t *testing.T) {
+	tests := []struct {
+		name            string
+		fetched         int // first+1 件を上限に取得した件数
+		first           int
+		expectedItems   int
+		expectedHasNext bool
+	}{
+		{name: "Fewer items than the page size", fetched: 2, first: 3, expectedItems: 2},
+		{name: "Exactly the page size", fetched: 3, first: 3, expectedItems: 3},
+		{name: "One more item than the page size", fetched: 4, first: 3, expectedItems: 3, expectedHasNext: true},
+		{name: "No items", fetched: 0, first: 3, expectedItems: 0},
+		{name: "Zero page size with remaining items", fetched: 1, first: 0, expectedItems: 0, expectedHasNext: true},
+		{name: "Zero page size without items", fetched: 0, first: 0, expectedItems: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := &Page[int]{Items: []*int{}}
+			for i := 0; i < tt.fetched; i++ {
+				item := i
+				page.Append(&item, tt.first)
+			}
+			assert.Len(t, page.Items, tt.expectedItems)
+			assert.Equal(t, tt.expectedHasNext, page.HasNextPage)
+			for i, item := range page.Items {
+				assert.Equal(t, i, *item)
+			}
+		})
+	}
+}
+
+func TestParsePageKey(t *testing.T) {
+	args := PageArgs{First: 20, After: 42}
+
+	id, parsed, err := ParsePageKey(NewPageKey("7", args))
+	assert.NoError(t, err)
+	assert.Equal(t, uint(7), id)
+	assert.Equal(t, args, parsed)
+
+	for _, key := range []string{"7:20", "7:x:42", "x:20:42"} {
+		_, _, err := ParsePageKey(key)
+		assert.Error(t, err, key)
+	}
+}
@@ -3,6 +3,7 @@ package common
 import (
 	"app/entity"
 	"app/graph/model"
+	"app/graph/services/common/base"
 	"fmt"
 	"time"
 )
@@ -39,3 +40,26 @@ func (c *CommonConverter) ToModelUsersFromPointers(users []*entity.User) []*mode
 	}
 	return result
 }
+
+// ToModelUserConnection はUserのページをRelay形式のConnectionに変換します
+func (c *CommonConverter) ToModelUserConnection(page *base.Page[entity.User], args base.PageArgs) *model.UserConnection {
+	edges := make([]*model.UserEdge, 0, len(page.Items))
+	cursors := make([]string, 0, len(page.Items))
+	for _, user := range page.Items {
+		if user == nil {
+			continue
+		}
+		cursor := EncodeCursor(user.ID)
+		edges = append(edges, &model.UserEdge{
+			Cursor: cursor,
+			Node:   c.ToModelUser(*user),
+		})
+		cursors = append(cursors, cursor)
+	}
+
+	return &model.UserConnection{
+		Edges:      edges,
+		PageInfo:   ToModelPageInfo(cursors, page.HasNextPage, args),
+		TotalCount: int32(page.TotalCount),
+	}
+}
@@ -0,0 +1,49 @@
+package common
+
+import (
+	"app/entity"
+	"app/graph/services/common/base"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommonConverter_ToModelUserConnection(t *testing.T) {
+	converter := NewCommonConverter()
+
+	t.Run("Page of users", func(t *testing.T) {
+		page := &base.Page[entity.User]{
+			Items:       []*entity.User{newUser(1), newUser(2)},
+			HasNextPage: true,
+			TotalCount:  5,
+		}
+
+		connection := converter.ToModelUserConnection(page, base.PageArgs{First: 2})
+		assert.Len(t, connection.Edges, 2)
+		assert.Equal(t, EncodeCursor(1), *connection.PageInfo.StartCursor)
+		assert.Equal(t, EncodeCursor(2), *connection.PageInfo.EndCursor)
+		assert.True(t, connection.PageInfo.HasNextPage)
+		assert.Equal(t, int32(5), connection.TotalCount)
+	})
+
+	t.Run("Zero page size returns only the total count", func(t *testing.T) {
+		page := &base.Page[entity.User]{
+			Items:       []*entity.User{},
+			HasNextPage: true,
+			TotalCount:  5,
+		}
+
+		connection := converter.ToModelUserConnection(page, base.PageArgs{First: 0})
+		assert.Empty(t, connection.Edges)
+		assert.Nil(t, connection.PageInfo.StartCursor)
+		assert.Nil(t, connection.PageInfo.EndCursor)
+		assert.True(t, connection.PageInfo.HasNextPage)
+		assert.Equal(t, int32(5), connection.TotalCount)
+	})
+}
+
+func newUser(id uint) *entity.User {
+	user := &entity.User{}
+	user.ID = id
+	return user
+}
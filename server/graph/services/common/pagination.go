@@ -0,0 +1,81 @@
+package common
+
+import (
+	"app/graph/model"
+	"app/graph/services/common/base"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+
+	cursorPrefix = "cursor:"
+)
+
+// NewPageArgs はGraphQLの first/after 引数をページ引数に変換します
+func NewPageArgs(first *int32, after *string) (base.PageArgs, error) {
+	args := base.PageArgs{First: DefaultPageSize}
+
+	if first != nil {
+		// 0件の場合は件数（totalCount）のみを返す
+		if *first < 0 {
+			return base.PageArgs{}, fmt.Errorf("first must be a non-negative integer")
+		}
+		args.First = int(*first)
+		if args.First > MaxPageSize {
+			args.First = MaxPageSize
+		}
+	}
+
+	if after != nil && *after != "" {
+		id, err := DecodeCursor(*after)
+		if err != nil {
+			return base.PageArgs{}, err
+		}
+		args.After = id
+	}
+
+	return args, nil
+}
+
+// EncodeCursor はIDを不透明なカーソル文字列に変換します
+func EncodeCursor(id uint) string {
+	return base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s%d", cursorPrefix, id)))
+}
+
+// DecodeCursor はカーソル文字列をIDに変換します
+func DecodeCursor(cursor string) (uint, error) {
+	decoded, err := base64.StdEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, fmt.Errorf("invalid cursor: %s", cursor)
+	}
+
+	idString, found := strings.CutPrefix(string(decoded), cursorPrefix)
+	if !found {
+		return 0, fmt.Errorf("invalid cursor: %s", cursor)
+	}
+
+	id, err := strconv.ParseUint(idString, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid cursor: %s", cursor)
+	}
+
+	return uint(id), nil
+}
+
+// ToModelPageInfo はカーソル一覧からPageInfoを作成します
+func ToModelPageInfo(cursors []string, hasNextPage bool, args base.PageArgs) *model.PageInfo {
+	pageInfo := &model.PageInfo{
+		HasNextPage:     hasNextPage,
+		HasPreviousPage: args.After != 0,
+	}
+	if len(cursors) > 0 {
+		pageInfo.StartCursor = &cursors[0]
+		pageInfo.EndCursor = &cursors[len(cursors)-1]
+	}
+	return pageInfo
+}
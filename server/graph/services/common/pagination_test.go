@@ -0,0 +1,78 @@
+package common
+
+import (
+	"app/graph/services/common/base"
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPageArgs(t *testing.T) {
+	cursor := EncodeCursor(42)
+
+	tests := []struct {
+		name      string
+		first     *int32
+		after     *string
+		expected  base.PageArgs
+		expectErr bool
+	}{
+		{name: "Default page size", expected: base.PageArgs{First: DefaultPageSize}},
+		{name: "Page size and cursor", first: int32Ptr(5), after: &cursor, expected: base.PageArgs{First: 5, After: 42}},
+		{name: "Page size is capped", first: int32Ptr(MaxPageSize + 1), expected: base.PageArgs{First: MaxPageSize}},
+		{name: "Empty cursor starts from the beginning", first: int32Ptr(5), after: stringPtr(""), expected: base.PageArgs{First: 5}},
+		{name: "Zero page size returns only the total count", first: int32Ptr(0), expected: base.PageArgs{First: 0}},
+		{name: "Negative page size is rejected", first: int32Ptr(-1), expectErr: true},
+		{name: "Invalid cursor is rejected", after: stringPtr("invalid"), expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args, err := NewPageArgs(tt.first, tt.after)
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, args)
+		})
+	}
+}
+
+func TestDecodeCursor(t *testing.T) {
+	encode := func(s string) string { return base64.StdEncoding.EncodeToString([]byte(s)) }
+
+	tests := []struct {
+		name      string
+		cursor    string
+		expected  uint
+		expectErr bool
+	}{
+		{name: "Round trip", cursor: EncodeCursor(123), expected: 123},
+		{name: "Not base64", cursor: "!!!", expectErr: true},
+		{name: "Missing prefix", cursor: encode("123"), expectErr: true},
+		{name: "Non-numeric ID", cursor: encode("cursor:abc"), expectErr: true},
+		{name: "Negative ID", cursor: encode("cursor:-1"), expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := DecodeCursor(tt.cursor)
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, id)
+		})
+	}
+}
+
+func int32Ptr(v int32) *int32 {
+	return &v
+}
+
+func stringPtr(v string) *string {
+	return &v
+}
@@ -2,15 +2,24 @@ package common
 
 import (
 	"app/entity"
+	"app/graph/errcode"
+	"app/graph/services/policy"
 	"app/middleware"
 	"context"
 	"fmt"
+	"time"
 
 	"gorm.io/gorm"
 )
 
 type CommonRepository interface {
 	GetCurrentUser(ctx context.Context) (*entity.User, error)
+	Authorize(ctx context.Context, permission policy.Permission) (*entity.User, error)
+	GetPreferredWeightUnit(ctx context.Context, userID uint) (entity.WeightUnit, error)
+	// リクエスト内で1度だけ取得するログイン中のユーザーの情報（匿名でもエラーにしない）
+	GetViewerID(ctx context.Context) (uint, error)
+	GetViewerWeightUnit(ctx context.Context) (entity.WeightUnit, error)
+	GetViewerFriendIDs(ctx context.Context) (map[uint]bool, error)
 }
 
 type commonRepository struct {
@@ -21,16 +30,104 @@ func NewCommonRepository(db *gorm.DB) CommonRepository {
 	return &commonRepository{db: db}
 }
 
+// GetCurrentUser はログイン中のユーザーをロール付きで取得する
+// トークンにロールのクレームがある場合はDBのロールに反映する
 func (r *commonRepository) GetCurrentUser(ctx context.Context) (*entity.User, error) {
 	uid, err := middleware.GetUserUIDFromContext(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("unauthorized: %w", err)
+		return nil, errcode.NewUnauthenticated(ctx, "authentication required")
 	}
 
 	var user entity.User
-	if err := r.db.Where("uid = ?", uid).First(&user).Error; err != nil {
+	if err := r.db.Preload("Roles").Where("uid = ?", uid).First(&user).Error; err != nil {
 		return nil, fmt.Errorf("failed to fetch user: %w", err)
 	}
 
+	if err := r.syncRoleClaims(ctx, &user); err != nil {
+		return nil, fmt.Errorf("failed to sync role claims: %w", err)
+	}
+
 	return &user, nil
 }
+
+// Authorize はログイン中のユーザーを取得し、操作が許可されていない場合はFORBIDDENエラーを返す
+func (r *commonRepository) Authorize(ctx context.Context, permission policy.Permission) (*entity.User, error) {
+	user, err := r.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := policy.Authorize(ctx, user, permission); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// GetPreferredWeightUnit はプロフィールに設定された重量単位を取得（プロフィール未作成の場合はkg）
+func (r *commonRepository) GetPreferredWeightUnit(ctx context.Context, userID uint) (entity.WeightUnit, error) {
+	var profiles []entity.Profile
+	if err := r.db.Where("user_id = ?", userID).Limit(1).Find(&profiles).Error; err != nil {
+		return "", fmt.Errorf("failed to fetch profile: %w", err)
+	}
+
+	if len(profiles) == 0 || profiles[0].WeightUnit == "" {
+		return entity.WeightUnitKg, nil
+	}
+
+	return profiles[0].WeightUnit, nil
+}
+
+// syncRoleClaims はFirebaseのカスタムクレーム（roles）をDBのロールに反映する
+// 最後にロールを変更した日時より前に発行されたトークンは古いクレームを持つため反映しない
+func (r *commonRepository) syncRoleClaims(ctx context.Context, user *entity.User) error {
+	token, err := middleware.GetUserFromContext(ctx)
+	if err != nil {
+		return nil
+	}
+
+	roles, ok := entity.RolesFromClaims(token.Claims)
+	if !ok || user.HasSameRoles(roles) {
+		return nil
+	}
+
+	issuedAt := time.Unix(token.IssuedAt, 0)
+	if user.RolesSyncedAt != nil && !issuedAt.After(*user.RolesSyncedAt) {
+		return nil
+	}
+
+	userRoles := make([]entity.UserRole, 0, len(roles))
+	for _, role := range roles {
+		if !containsRole(userRoles, role) {
+			userRoles = append(userRoles, entity.UserRole{UserID: user.ID, Role: role})
+		}
+	}
+
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("user_id = ?", user.ID).Delete(&entity.UserRole{}).Error; err != nil {
+			return err
+		}
+		if len(userRoles) > 0 {
+			if err := tx.Create(&userRoles).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Model(user).Update("roles_synced_at", issuedAt).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	user.Roles = userRoles
+	user.RolesSyncedAt = &issuedAt
+	return nil
+}
+
+func containsRole(userRoles []entity.UserRole, role entity.Role) bool {
+	for _, userRole := range userRoles {
+		if userRole.Role == role {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,115 @@
+package common
+
+import (
+	"app/entity"
+	"app/middleware"
+	"context"
+	"fmt"
+	"sync"
+)
+
+type viewerContextKey struct{}
+
+// viewerCache はリクエスト内でログイン中のユーザーの情報を使い回すためのキャッシュ
+// リストの各要素のフィールドから参照されるため、リクエストごとに1度だけ問い合わせる
+type viewerCache struct {
+	mu      sync.Mutex
+	entries map[string]*viewerEntry
+}
+
+type viewerEntry struct {
+	once  sync.Once
+	value any
+	err   error
+}
+
+// WithViewerCache はリクエストごとのキャッシュをcontextに設定する
+func WithViewerCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, viewerContextKey{}, &viewerCache{entries: make(map[string]*viewerEntry)})
+}
+
+// loadViewerValue はキャッシュがあれば load を1度だけ実行して結果を使い回す（キャッシュがない場合は毎回実行する）
+func loadViewerValue[T any](ctx context.Context, key string, load func() (T, error)) (T, error) {
+	cache, ok := ctx.Value(viewerContextKey{}).(*viewerCache)
+	if !ok {
+		return load()
+	}
+
+	cache.mu.Lock()
+	entry, exists := cache.entries[key]
+	if !exists {
+		entry = &viewerEntry{}
+		cache.entries[key] = entry
+	}
+	cache.mu.Unlock()
+
+	entry.once.Do(func() {
+		entry.value, entry.err = load()
+	})
+	if entry.err != nil {
+		var zero T
+		return zero, entry.err
+	}
+	return entry.value.(T), nil
+}
+
+// GetViewerID はログイン中のユーザーのIDを取得する（匿名・未登録の場合は0）
+// ロールの同期を行わない読み取り専用の取得のため、一覧の各要素の表示に使用する
+func (r *commonRepository) GetViewerID(ctx context.Context) (uint, error) {
+	return loadViewerValue(ctx, "id", func() (uint, error) {
+		uid, err := middleware.GetUserUIDFromContext(ctx)
+		if err != nil {
+			return 0, nil
+		}
+
+		var userIDs []uint
+		if err := r.db.Model(&entity.User{}).Where("uid = ?", uid).Limit(1).Pluck("id", &userIDs).Error; err != nil {
+			return 0, fmt.Errorf("failed to fetch user: %w", err)
+		}
+		if len(userIDs) == 0 {
+			return 0, nil
+		}
+		return userIDs[0], nil
+	})
+}
+
+// GetViewerWeightUnit はログイン中のユーザーのプロフィールの重量単位を取得する（匿名の場合はkg）
+func (r *commonRepository) GetViewerWeightUnit(ctx context.Context) (entity.WeightUnit, error) {
+	return loadViewerValue(ctx, "weight_unit", func() (entity.WeightUnit, error) {
+		userID, err := r.GetViewerID(ctx)
+		if err != nil {
+			return "", err
+		}
+		if userID == 0 {
+			return entity.WeightUnitKg, nil
+		}
+		return r.GetPreferredWeightUnit(ctx, userID)
+	})
+}
+
+// GetViewerFriendIDs はログイン中のユーザーのフレンドのIDを取得する（匿名の場合は空）
+func (r *commonRepository) GetViewerFriendIDs(ctx context.Context) (map[uint]bool, error) {
+	return loadViewerValue(ctx, "friend_ids", func() (map[uint]bool, error) {
+		userID, err := r.GetViewerID(ctx)
+		if err != nil {
+			return nil, err
+		}
+		friendIDs := make(map[uint]bool)
+		if userID == 0 {
+			return friendIDs, nil
+		}
+
+		var userIDs []uint
+		if err := r.db.Model(&entity.Friendship{}).
+			Select("CASE WHEN requester_id = ? THEN requestee_id ELSE requester_id END AS user_id", userID).
+			Where("status = ?", entity.Accepted).
+			Where("requester_id = ? OR requestee_id = ?", userID, userID).
+			Pluck("user_id", &userIDs).Error; err != nil {
+			return nil, fmt.Errorf("failed to fetch friends: %w", err)
+		}
+		for _, id := range userIDs {
+			friendIDs[id] = true
+		}
+		return friendIDs, nil
+	})
+}
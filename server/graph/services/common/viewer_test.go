@@ -0,0 +1,63 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadViewerValue(t *testing.T) {
+	t.Run("Loaded once per request", func(t *testing.T) {
+		ctx := WithViewerCache(context.Background())
+		calls := 0
+		load := func() (uint, error) {
+			calls++
+			return 7, nil
+		}
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				id, err := loadViewerValue(ctx, "id", load)
+				assert.NoError(t, err)
+				assert.Equal(t, uint(7), id)
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, 1, calls)
+
+		// 別のキーは別に取得する
+		_, err := loadViewerValue(ctx, "other", load)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("Error is returned to every caller", func(t *testing.T) {
+		ctx := WithViewerCache(context.Background())
+		load := func() (uint, error) { return 0, errors.New("failed") }
+
+		for i := 0; i < 2; i++ {
+			_, err := loadViewerValue(ctx, "id", load)
+			assert.Error(t, err)
+		}
+	})
+
+	t.Run("Loaded every time without a cache", func(t *testing.T) {
+		calls := 0
+		load := func() (uint, error) {
+			calls++
+			return 7, nil
+		}
+
+		for i := 0; i < 2; i++ {
+			_, err := loadViewerValue(context.Background(), "id", load)
+			assert.NoError(t, err)
+		}
+		assert.Equal(t, 2, calls)
+	})
+}
@@ -0,0 +1,45 @@
+package device
+
+import (
+	"app/entity"
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type DeviceRepository interface {
+	UpsertDeviceToken(ctx context.Context, deviceToken *entity.DeviceToken) error
+	DeleteDeviceToken(ctx context.Context, userID uint, token string) (bool, error)
+}
+
+type deviceRepository struct {
+	db *gorm.DB
+}
+
+func NewDeviceRepository(db *gorm.DB) DeviceRepository {
+	return &deviceRepository{db: db}
+}
+
+// UpsertDeviceToken はトークンを登録する（登録済みのトークンは現在のユーザーに付け替える）
+func (r *deviceRepository) UpsertDeviceToken(ctx context.Context, deviceToken *entity.DeviceToken) error {
+	if err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "token"}},
+		DoUpdates: clause.AssignmentColumns([]string{"user_id", "platform", "last_registered_at", "updated_at", "deleted_at"}),
+	}).Create(deviceToken).Error; err != nil {
+		return fmt.Errorf("failed to register device token: %w", err)
+	}
+	return nil
+}
+
+// DeleteDeviceToken はユーザーのトークンを削除する（トークンはユニークなため論理削除しない）
+func (r *deviceRepository) DeleteDeviceToken(ctx context.Context, userID uint, token string) (bool, error) {
+	result := r.db.WithContext(ctx).Unscoped().
+		Where("user_id = ? AND token = ?", userID, token).
+		Delete(&entity.DeviceToken{})
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to unregister device token: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
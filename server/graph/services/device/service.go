@@ -0,0 +1,58 @@
+package device
+
+import (
+	"app/entity"
+	"app/graph/model"
+	"app/graph/services/common"
+	"context"
+	"fmt"
+	"time"
+)
+
+type DeviceService interface {
+	RegisterDevice(ctx context.Context, input model.RegisterDevice) (bool, error)
+	UnregisterDevice(ctx context.Context, input model.UnregisterDevice) (bool, error)
+}
+
+type deviceService struct {
+	repo   DeviceRepository
+	common common.CommonRepository
+}
+
+func NewDeviceService(repo DeviceRepository) DeviceService {
+	return &deviceService{
+		repo:   repo,
+		common: common.NewCommonRepository(repo.(*deviceRepository).db),
+	}
+}
+
+// RegisterDevice は現在のユーザーの端末をプッシュ通知の送信先に登録する
+// アプリの起動時やトークンの更新時に毎回呼ばれることを想定している
+func (s *deviceService) RegisterDevice(ctx context.Context, input model.RegisterDevice) (bool, error) {
+	currentUser, err := s.common.GetCurrentUser(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	deviceToken := &entity.DeviceToken{
+		UserID:           currentUser.ID,
+		Token:            input.Token,
+		Platform:         entity.DevicePlatformFromGraphQL(input.Platform),
+		LastRegisteredAt: time.Now(),
+	}
+	if err := s.repo.UpsertDeviceToken(ctx, deviceToken); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// UnregisterDevice は現在のユーザーの端末の登録を削除する（ログアウト時など）
+// 登録されていないトークンの場合はfalseを返す
+func (s *deviceService) UnregisterDevice(ctx context.Context, input model.UnregisterDevice) (bool, error) {
+	currentUser, err := s.common.GetCurrentUser(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	return s.repo.DeleteDeviceToken(ctx, currentUser.ID, input.Token)
+}
@@ -0,0 +1,121 @@
+package event
+
+import (
+	"app/pubsub"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+)
+
+// WorkoutGroupActivityType はワークアウトグループで起きた出来事の種類
+type WorkoutGroupActivityType string
+
+const (
+	WorkoutGroupActivitySetLogged       WorkoutGroupActivityType = "set_logged"
+	WorkoutGroupActivityMemberJoined    WorkoutGroupActivityType = "member_joined"
+	WorkoutGroupActivityWorkoutFinished WorkoutGroupActivityType = "workout_finished"
+)
+
+// WorkoutGroupActivity はワークアウトグループのメンバーに配信する出来事
+// ペイロードを小さく保つためIDのみを持ち、詳細は購読側で取得する
+type WorkoutGroupActivity struct {
+	Type           WorkoutGroupActivityType `json:"type"`
+	WorkoutGroupID uint                     `json:"workoutGroupId"`
+	UserID         uint                     `json:"userId"`
+	WorkoutID      *uint                    `json:"workoutId,omitempty"`
+	SetLogID       *uint                    `json:"setLogId,omitempty"`
+	OccurredAt     time.Time                `json:"occurredAt"`
+}
+
+// FriendshipRequested は友達リクエストを受け取ったユーザーに配信する出来事
+type FriendshipRequested struct {
+	FriendshipID uint `json:"friendshipId"`
+	RequesterID  uint `json:"requesterId"`
+	RequesteeID  uint `json:"requesteeId"`
+}
+
+// Bus はサービス間で出来事をやり取りする（PubSubのトピック名とペイロードの形式をまとめる）
+type Bus struct {
+	pubsub pubsub.PubSub
+}
+
+func NewBus(ps pubsub.PubSub) *Bus {
+	return &Bus{pubsub: ps}
+}
+
+// PublishWorkoutGroupActivity はグループの出来事を配信する
+// 配信の失敗で書き込み自体を失敗させないようにログに残すだけにする
+func (b *Bus) PublishWorkoutGroupActivity(ctx context.Context, activity WorkoutGroupActivity) {
+	b.publish(ctx, workoutGroupTopic(activity.WorkoutGroupID), activity)
+}
+
+// SubscribeWorkoutGroupActivity はグループの出来事を購読する
+func (b *Bus) SubscribeWorkoutGroupActivity(ctx context.Context, workoutGroupID uint) (<-chan WorkoutGroupActivity, error) {
+	return subscribe[WorkoutGroupActivity](ctx, b, workoutGroupTopic(workoutGroupID))
+}
+
+// PublishFriendshipRequested は友達リクエストを受け取ったユーザーに配信する
+func (b *Bus) PublishFriendshipRequested(ctx context.Context, requested FriendshipRequested) {
+	b.publish(ctx, friendshipRequestTopic(requested.RequesteeID), requested)
+}
+
+// SubscribeFriendshipRequested はユーザーが受け取った友達リクエストを購読する
+func (b *Bus) SubscribeFriendshipRequested(ctx context.Context, userID uint) (<-chan FriendshipRequested, error) {
+	return subscribe[FriendshipRequested](ctx, b, friendshipRequestTopic(userID))
+}
+
+func (b *Bus) publish(ctx context.Context, topic string, message any) {
+	if b == nil || b.pubsub == nil {
+		return
+	}
+
+	payload, err := json.Marshal(message)
+	if err != nil {
+		log.Printf("failed to encode event for %s: %v", topic, err)
+		return
+	}
+	if err := b.pubsub.Publish(ctx, topic, payload); err != nil {
+		log.Printf("failed to publish event for %s: %v", topic, err)
+	}
+}
+
+// subscribe はトピックを購読し、受け取ったペイロードをデコードして流す
+func subscribe[T any](ctx context.Context, b *Bus, topic string) (<-chan T, error) {
+	if b == nil || b.pubsub == nil {
+		return nil, fmt.Errorf("subscriptions are not available")
+	}
+
+	payloads, err := b.pubsub.Subscribe(ctx, topic)
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe to %s: %w", topic, err)
+	}
+
+	events := make(chan T)
+	go func() {
+		defer close(events)
+		for payload := range payloads {
+			var event T
+			if err := json.Unmarshal(payload, &event); err != nil {
+				log.Printf("ignored malformed event for %s: %v", topic, err)
+				continue
+			}
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+func workoutGroupTopic(workoutGroupID uint) string {
+	return fmt.Sprintf("workout_group:%d", workoutGroupID)
+}
+
+func friendshipRequestTopic(userID uint) string {
+	return fmt.Sprintf("user:%d:friendship_requests", userID)
+}
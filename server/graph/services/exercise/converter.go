@@ -3,6 +3,7 @@ package exercise
 import (
 	"app/entity"
 	"app/graph/model"
+	"app/graph/services/common"
 	"fmt"
 )
 
@@ -13,12 +14,43 @@ func NewExerciseConverter() *ExerciseConverter {
 }
 
 func (c *ExerciseConverter) ToModelExercise(exercise entity.Exercise) *model.Exercise {
-	return &model.Exercise{
-		ID:          fmt.Sprintf("%d", exercise.ID),
-		Name:        exercise.Name,
-		Description: &exercise.Description,
-		Category:    &exercise.Category,
+	modelExercise := &model.Exercise{
+		ID:           fmt.Sprintf("%d", exercise.ID),
+		Name:         exercise.Name,
+		Description:  &exercise.Description,
+		Category:     &exercise.Category,
+		Visibility:   exercise.VisibilityToGraphQL(),
+		TrackingType: exercise.TrackingType.ToGraphQL(),
+		IsGlobal:     exercise.IsGlobal(),
 	}
+
+	modelExercise.PrimaryMuscles = c.toModelMuscleGroups(exercise.MuscleGroups(true))
+	modelExercise.SecondaryMuscles = c.toModelMuscleGroups(exercise.MuscleGroups(false))
+
+	if exercise.Equipment != "" {
+		equipment := exercise.Equipment.ToGraphQL()
+		modelExercise.Equipment = &equipment
+	}
+
+	if exercise.UserID != nil {
+		ownerID := fmt.Sprintf("%d", *exercise.UserID)
+		modelExercise.OwnerID = &ownerID
+	}
+
+	if exercise.ArchivedAt != nil {
+		archivedAt := exercise.ArchivedAt.Format(common.TimeFormat)
+		modelExercise.ArchivedAt = &archivedAt
+	}
+
+	return modelExercise
+}
+
+func (c *ExerciseConverter) toModelMuscleGroups(muscleGroups []entity.MuscleGroup) []model.MuscleGroup {
+	result := make([]model.MuscleGroup, len(muscleGroups))
+	for i, muscleGroup := range muscleGroups {
+		result[i] = muscleGroup.ToGraphQL()
+	}
+	return result
 }
 
 func (c *ExerciseConverter) ToModelExercises(exercises []entity.Exercise) []*model.Exercise {
@@ -2,16 +2,41 @@ package exercise
 
 import (
 	"app/entity"
+	"app/graph/services/policy"
 	"context"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"gorm.io/gorm"
 )
 
+// ExerciseOrder は種目一覧の並び順
+type ExerciseOrder string
+
+const (
+	OrderByID            ExerciseOrder = "exercises.id ASC"
+	OrderByNameAsc       ExerciseOrder = "exercises.name ASC, exercises.id ASC"
+	OrderByNameDesc      ExerciseOrder = "exercises.name DESC, exercises.id DESC"
+	OrderByCategoryAsc   ExerciseOrder = "exercises.category ASC, exercises.name ASC, exercises.id ASC"
+	OrderByCreatedAtDesc ExerciseOrder = "exercises.created_at DESC, exercises.id DESC"
+)
+
+// ExerciseFilter は種目一覧の絞り込み条件（空の条件は無視する）
+type ExerciseFilter struct {
+	Category     string
+	MuscleGroups []entity.MuscleGroup // いずれかの部位を主働筋または協働筋に含む
+	Equipment    []entity.Equipment   // いずれかの器具を使う
+	TextVariants []string             // 正規化済みの検索語（いずれかに部分一致）
+	OrderBy      ExerciseOrder
+}
+
 type ExerciseRepository interface {
-	GetExercises(ctx context.Context) ([]entity.Exercise, error)
+	GetExercises(ctx context.Context, userID uint, filter ExerciseFilter) ([]entity.Exercise, error)
 	GetExerciseByID(ctx context.Context, id string) (*entity.Exercise, error)
+	CreateExercise(ctx context.Context, exercise *entity.Exercise) error
+	UpdateExercise(ctx context.Context, exercise *entity.Exercise, muscles []entity.ExerciseMuscle) error
+	HasSetLogs(ctx context.Context, exerciseID uint) (bool, error)
 	GetExercisesByIDs(exerciseIDs []uint) ([]*entity.Exercise, error)
 }
 
@@ -23,14 +48,48 @@ func NewExerciseRepository(db *gorm.DB) ExerciseRepository {
 	return &exerciseRepository{db: db}
 }
 
-func (r *exerciseRepository) GetExercises(ctx context.Context) ([]entity.Exercise, error) {
+// GetExercises は指定ユーザーが閲覧できる、アーカイブされていない種目を絞り込んで取得する
+func (r *exerciseRepository) GetExercises(ctx context.Context, userID uint, filter ExerciseFilter) ([]entity.Exercise, error) {
+	query := r.db.Preload("Muscles").
+		Scopes(policy.VisibleExercises(userID)).
+		Where("exercises.archived_at IS NULL")
+
+	if filter.Category != "" {
+		query = query.Where("exercises.category = ?", filter.Category)
+	}
+	if len(filter.MuscleGroups) > 0 {
+		query = query.Where("EXISTS (?)", r.db.Model(&entity.ExerciseMuscle{}).
+			Select("1").
+			Where("exercise_muscles.exercise_id = exercises.id AND exercise_muscles.muscle_group IN ?", filter.MuscleGroups))
+	}
+	if len(filter.Equipment) > 0 {
+		query = query.Where("exercises.equipment IN ?", filter.Equipment)
+	}
+	if len(filter.TextVariants) > 0 {
+		conditions := r.db.Session(&gorm.Session{NewDB: true})
+		for _, variant := range filter.TextVariants {
+			conditions = conditions.Or("exercises.search_text LIKE ?", "%"+escapeLike(variant)+"%")
+		}
+		query = query.Where(conditions)
+	}
+
+	orderBy := filter.OrderBy
+	if orderBy == "" {
+		orderBy = OrderByID
+	}
+
 	var exercises []entity.Exercise
-	if err := r.db.Find(&exercises).Error; err != nil {
+	if err := query.Order(string(orderBy)).Find(&exercises).Error; err != nil {
 		return nil, fmt.Errorf("failed to fetch exercises: %w", err)
 	}
 	return exercises, nil
 }
 
+// escapeLike はLIKE検索のワイルドカードをエスケープする
+func escapeLike(text string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(text)
+}
+
 func (r *exerciseRepository) GetExerciseByID(ctx context.Context, id string) (*entity.Exercise, error) {
 	exerciseID, err := strconv.ParseUint(id, 10, 32)
 	if err != nil {
@@ -38,20 +97,68 @@ func (r *exerciseRepository) GetExerciseByID(ctx context.Context, id string) (*e
 	}
 
 	var exercise entity.Exercise
-	if err := r.db.Where("id = ?", uint(exerciseID)).First(&exercise).Error; err != nil {
+	if err := r.db.Preload("Muscles").Where("id = ?", uint(exerciseID)).First(&exercise).Error; err != nil {
 		return nil, fmt.Errorf("failed to fetch exercise: %w", err)
 	}
 
 	return &exercise, nil
 }
 
+func (r *exerciseRepository) CreateExercise(ctx context.Context, exercise *entity.Exercise) error {
+	return r.db.Create(exercise).Error
+}
+
+// UpdateExercise は種目を更新する（musclesがnilでない場合は部位を置き換える）
+func (r *exerciseRepository) UpdateExercise(ctx context.Context, exercise *entity.Exercise, muscles []entity.ExerciseMuscle) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("User", "Muscles", "WorkoutExercises").Save(exercise).Error; err != nil {
+			return err
+		}
+
+		if muscles == nil {
+			return nil
+		}
+
+		if err := tx.Unscoped().
+			Where("exercise_id = ?", exercise.ID).
+			Delete(&entity.ExerciseMuscle{}).Error; err != nil {
+			return fmt.Errorf("failed to delete exercise muscles: %w", err)
+		}
+
+		for i := range muscles {
+			muscles[i].ExerciseID = exercise.ID
+		}
+		if len(muscles) > 0 {
+			if err := tx.Create(&muscles).Error; err != nil {
+				return err
+			}
+		}
+		exercise.Muscles = muscles
+
+		return nil
+	})
+}
+
+// HasSetLogs は種目のセットが記録されているかどうか（全ユーザーのワークアウトが対象）
+func (r *exerciseRepository) HasSetLogs(ctx context.Context, exerciseID uint) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&entity.SetLog{}).
+		Joins("inner join workout_exercises on workout_exercises.id = set_logs.workout_exercise_id AND workout_exercises.deleted_at IS NULL").
+		Where("workout_exercises.exercise_id = ?", exerciseID).
+		Limit(1).
+		Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check set logs: %w", err)
+	}
+	return count > 0, nil
+}
+
 func (r *exerciseRepository) GetExercisesByIDs(exerciseIDs []uint) ([]*entity.Exercise, error) {
 	if len(exerciseIDs) == 0 {
 		return []*entity.Exercise{}, nil
 	}
 
 	var exercises []entity.Exercise
-	if err := r.db.Where("id IN ?", exerciseIDs).Find(&exercises).Error; err != nil {
+	if err := r.db.Preload("Muscles").Where("id IN ?", exerciseIDs).Find(&exercises).Error; err != nil {
 		return nil, fmt.Errorf("failed to fetch exercises by IDs: %w", err)
 	}
 
@@ -1,14 +1,26 @@
 package exercise
 
 import (
+	"app/entity"
+	"app/graph/errcode"
 	"app/graph/model"
+	"app/graph/services/common"
+	"app/graph/services/policy"
 	"context"
+	"errors"
 	"fmt"
+	"time"
+
+	"gorm.io/gorm"
 )
 
 type ExerciseService interface {
-	GetExercises(ctx context.Context) ([]*model.Exercise, error)
+	GetExercises(ctx context.Context, filter *model.ExerciseFilter, orderBy *model.ExerciseOrderBy) ([]*model.Exercise, error)
 	GetExercise(ctx context.Context, id string) (*model.Exercise, error)
+	CreateExercise(ctx context.Context, input model.CreateExercise) (*model.Exercise, error)
+	UpdateExercise(ctx context.Context, input model.UpdateExercise) (*model.Exercise, error)
+	ArchiveExercise(ctx context.Context, input model.ArchiveExercise) (*model.Exercise, error)
+	PromoteExercise(ctx context.Context, input model.PromoteExercise) (*model.Exercise, error)
 	// DataLoader使用メソッド
 	GetExerciseWithDataLoader(ctx context.Context, id string) (*model.Exercise, error)
 }
@@ -16,6 +28,7 @@ type ExerciseService interface {
 type exerciseService struct {
 	repo       ExerciseRepository
 	converter  *ExerciseConverter
+	common     common.CommonRepository
 	dataLoader *ExerciseDataLoader // DataLoaderを統合
 }
 
@@ -23,12 +36,33 @@ func NewExerciseService(repo ExerciseRepository, converter *ExerciseConverter, d
 	return &exerciseService{
 		repo:       repo,
 		converter:  converter,
+		common:     common.NewCommonRepository(repo.(*exerciseRepository).db),
 		dataLoader: dataLoader,
 	}
 }
 
-func (s *exerciseService) GetExercises(ctx context.Context) ([]*model.Exercise, error) {
-	exercises, err := s.repo.GetExercises(ctx)
+// GetExercises はカタログと閲覧可能なユーザー作成の種目を絞り込んで取得する
+func (s *exerciseService) GetExercises(ctx context.Context, filter *model.ExerciseFilter, orderBy *model.ExerciseOrderBy) ([]*model.Exercise, error) {
+	currentUser, err := s.common.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	exerciseFilter := ExerciseFilter{OrderBy: toExerciseOrder(orderBy)}
+	if filter != nil {
+		if filter.Category != nil {
+			exerciseFilter.Category = *filter.Category
+		}
+		exerciseFilter.MuscleGroups = toEntityMuscleGroups(filter.MuscleGroups)
+		for _, equipment := range filter.Equipment {
+			exerciseFilter.Equipment = append(exerciseFilter.Equipment, entity.EquipmentFromGraphQL(equipment))
+		}
+		if filter.Text != nil {
+			exerciseFilter.TextVariants = entity.SearchTextVariants(*filter.Text)
+		}
+	}
+
+	exercises, err := s.repo.GetExercises(ctx, currentUser.ID, exerciseFilter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get exercises: %w", err)
 	}
@@ -36,7 +70,15 @@ func (s *exerciseService) GetExercises(ctx context.Context) ([]*model.Exercise,
 }
 
 func (s *exerciseService) GetExercise(ctx context.Context, id string) (*model.Exercise, error) {
+	currentUser, err := s.common.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+
 	exercise, err := s.repo.GetExerciseByID(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get exercise %s: %w", id, err)
 	}
@@ -45,11 +87,233 @@ func (s *exerciseService) GetExercise(ctx context.Context, id string) (*model.Ex
 		return nil, nil
 	}
 
+	// 閲覧できない種目は存在しない種目と同じく扱い、非公開の種目があることを明かさない
+	visible, err := s.isVisibleTo(ctx, currentUser.ID, exercise)
+	if err != nil {
+		return nil, err
+	}
+	if !visible {
+		return nil, nil
+	}
+
+	return s.converter.ToModelExercise(*exercise), nil
+}
+
+func (s *exerciseService) CreateExercise(ctx context.Context, input model.CreateExercise) (*model.Exercise, error) {
+	currentUser, err := s.common.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	exercise := entity.Exercise{
+		UserID:     &currentUser.ID,
+		Name:       input.Name,
+		Visibility: entity.ExerciseVisibilityPrivate,
+	}
+	if input.Description != nil {
+		exercise.Description = *input.Description
+	}
+	if input.Category != nil {
+		exercise.Category = *input.Category
+	}
+	if input.Visibility != nil {
+		exercise.VisibilityFromGraphQL(*input.Visibility)
+	}
+	if input.Equipment != nil {
+		exercise.Equipment = entity.EquipmentFromGraphQL(*input.Equipment)
+	}
+	if input.TrackingType != nil {
+		exercise.TrackingType = entity.TrackingTypeFromGraphQL(*input.TrackingType)
+	}
+	exercise.Muscles = entity.NewExerciseMuscles(toEntityMuscleGroups(input.PrimaryMuscles), toEntityMuscleGroups(input.SecondaryMuscles))
+
+	if err := s.repo.CreateExercise(ctx, &exercise); err != nil {
+		return nil, fmt.Errorf("failed to create exercise: %w", err)
+	}
+
+	return s.converter.ToModelExercise(exercise), nil
+}
+
+func (s *exerciseService) UpdateExercise(ctx context.Context, input model.UpdateExercise) (*model.Exercise, error) {
+	exercise, err := s.getOwnedExercise(ctx, input.ID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// 更新可能なフィールドのみ更新
+	if input.Name != nil {
+		exercise.Name = *input.Name
+	}
+	if input.Description != nil {
+		exercise.Description = *input.Description
+	}
+	if input.Category != nil {
+		exercise.Category = *input.Category
+	}
+	if input.Visibility != nil {
+		exercise.VisibilityFromGraphQL(*input.Visibility)
+	}
+	if input.Equipment != nil {
+		exercise.Equipment = entity.EquipmentFromGraphQL(*input.Equipment)
+	}
+	if input.TrackingType != nil {
+		trackingType := entity.TrackingTypeFromGraphQL(*input.TrackingType)
+		if trackingType != exercise.TrackingType {
+			// 記録済みのセットが新しい記録方法の必須項目を満たさなくなるため変更できない
+			hasSetLogs, err := s.repo.HasSetLogs(ctx, exercise.ID)
+			if err != nil {
+				return nil, err
+			}
+			if hasSetLogs {
+				return nil, fmt.Errorf("cannot change the tracking type of an exercise that has set logs")
+			}
+			exercise.TrackingType = trackingType
+		}
+	}
+
+	// 部位が指定された場合は置き換える（指定されなかった側は現在の値を引き継ぐ）
+	var muscles []entity.ExerciseMuscle
+	if input.PrimaryMuscles != nil || input.SecondaryMuscles != nil {
+		primary := exercise.MuscleGroups(true)
+		if input.PrimaryMuscles != nil {
+			primary = toEntityMuscleGroups(input.PrimaryMuscles)
+		}
+		secondary := exercise.MuscleGroups(false)
+		if input.SecondaryMuscles != nil {
+			secondary = toEntityMuscleGroups(input.SecondaryMuscles)
+		}
+		muscles = entity.NewExerciseMuscles(primary, secondary)
+	}
+
+	if err := s.repo.UpdateExercise(ctx, exercise, muscles); err != nil {
+		return nil, fmt.Errorf("failed to update exercise: %w", err)
+	}
+
+	return s.converter.ToModelExercise(*exercise), nil
+}
+
+// ArchiveExercise は種目を一覧から外す（記録済みのワークアウトからは引き続き参照できる）
+// モデレーターは他のユーザーが作成した種目もアーカイブできる
+func (s *exerciseService) ArchiveExercise(ctx context.Context, input model.ArchiveExercise) (*model.Exercise, error) {
+	exercise, err := s.getOwnedExercise(ctx, input.ID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if exercise.ArchivedAt == nil {
+		now := time.Now()
+		exercise.ArchivedAt = &now
+		if err := s.repo.UpdateExercise(ctx, exercise, nil); err != nil {
+			return nil, fmt.Errorf("failed to archive exercise: %w", err)
+		}
+	}
+
+	return s.converter.ToModelExercise(*exercise), nil
+}
+
+// PromoteExercise はユーザーが作成した種目をカタログに昇格する（モデレーター・管理者のみ）
+func (s *exerciseService) PromoteExercise(ctx context.Context, input model.PromoteExercise) (*model.Exercise, error) {
+	if _, err := s.common.Authorize(ctx, policy.ManageExerciseCatalog); err != nil {
+		return nil, err
+	}
+
+	exercise, err := s.repo.GetExerciseByID(ctx, input.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get exercise: %w", err)
+	}
+
+	if exercise.IsGlobal() {
+		return nil, fmt.Errorf("exercise is already in the catalog")
+	}
+	if exercise.ArchivedAt != nil {
+		return nil, fmt.Errorf("archived exercise cannot be promoted")
+	}
+
+	exercise.UserID = nil
+	exercise.Visibility = entity.ExerciseVisibilityPublic
+	if err := s.repo.UpdateExercise(ctx, exercise, nil); err != nil {
+		return nil, fmt.Errorf("failed to promote exercise: %w", err)
+	}
+
 	return s.converter.ToModelExercise(*exercise), nil
 }
 
+// ヘルパー関数
+// getOwnedExercise は現在のユーザーが作成した種目を取得する（カタログの種目は編集できない）
+// moderateがtrueの場合、コンテンツを管理できるユーザーは他のユーザーの種目も取得できる
+func (s *exerciseService) getOwnedExercise(ctx context.Context, id string, moderate bool) (*entity.Exercise, error) {
+	currentUser, err := s.common.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	exercise, err := s.repo.GetExerciseByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get exercise: %w", err)
+	}
+
+	if exercise.IsOwnedBy(currentUser.ID) {
+		return exercise, nil
+	}
+	if moderate && !exercise.IsGlobal() {
+		if err := policy.Authorize(ctx, currentUser, policy.ModerateContent); err != nil {
+			return nil, err
+		}
+		return exercise, nil
+	}
+
+	return nil, errcode.NewForbidden(ctx, "exercise is not owned by the current user")
+}
+
+// isVisibleTo はログイン中のユーザー（viewerID）が種目を閲覧できるかどうか
+// フレンド公開の場合のみ、リクエスト内で1度だけ取得したフレンドの一覧で確認する
+func (s *exerciseService) isVisibleTo(ctx context.Context, viewerID uint, exercise *entity.Exercise) (bool, error) {
+	isFriend := false
+	if exercise.Visibility == entity.ExerciseVisibilityFriends && exercise.UserID != nil && !exercise.IsOwnedBy(viewerID) {
+		friendIDs, err := s.common.GetViewerFriendIDs(ctx)
+		if err != nil {
+			return false, fmt.Errorf("failed to get friends: %w", err)
+		}
+		isFriend = friendIDs[*exercise.UserID]
+	}
+	return exercise.IsVisibleTo(viewerID, isFriend), nil
+}
+
+// toEntityMuscleGroups はGraphQLの部位をエンティティの部位に変換する
+func toEntityMuscleGroups(muscleGroups []model.MuscleGroup) []entity.MuscleGroup {
+	result := make([]entity.MuscleGroup, len(muscleGroups))
+	for i, muscleGroup := range muscleGroups {
+		result[i] = entity.MuscleGroupFromGraphQL(muscleGroup)
+	}
+	return result
+}
+
+// toExerciseOrder はGraphQLの並び順をリポジトリの並び順に変換する（未指定の場合はID順）
+func toExerciseOrder(orderBy *model.ExerciseOrderBy) ExerciseOrder {
+	if orderBy == nil {
+		return OrderByID
+	}
+	switch *orderBy {
+	case model.ExerciseOrderByNameDesc:
+		return OrderByNameDesc
+	case model.ExerciseOrderByCategoryAsc:
+		return OrderByCategoryAsc
+	case model.ExerciseOrderByCreatedAtDesc:
+		return OrderByCreatedAtDesc
+	default:
+		return OrderByNameAsc
+	}
+}
+
 // DataLoader使用メソッド
+// ワークアウトや自己ベストから参照される種目のため、閲覧できない種目はエラーにせず名前などを伏せて返す
+// 一覧の各要素から参照されるため、ログイン中のユーザーとフレンドはリクエスト内で1度だけ取得する
 func (s *exerciseService) GetExerciseWithDataLoader(ctx context.Context, id string) (*model.Exercise, error) {
+	viewerID, err := s.common.GetViewerID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+
 	// 既存のDataLoaderを使用
 	entityExercise, err := s.dataLoader.LoadByID(ctx, id)
 	if err != nil {
@@ -58,5 +322,13 @@ func (s *exerciseService) GetExerciseWithDataLoader(ctx context.Context, id stri
 	if entityExercise == nil {
 		return nil, nil
 	}
+
+	visible, err := s.isVisibleTo(ctx, viewerID, entityExercise)
+	if err != nil {
+		return nil, err
+	}
+	if !visible {
+		return s.converter.ToModelExercise(entityExercise.Redacted()), nil
+	}
 	return s.converter.ToModelExercise(*entityExercise), nil
 }
@@ -0,0 +1,168 @@
+package exercise
+
+import (
+	"app/entity"
+	"app/graph/errcode"
+	"app/graph/model"
+	"app/graph/services/common"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// fakeCommonRepository はログイン中のユーザーとフレンドを固定で返す
+type fakeCommonRepository struct {
+	common.CommonRepository
+	user    *entity.User
+	friends map[uint]bool
+}
+
+func (r *fakeCommonRepository) GetCurrentUser(ctx context.Context) (*entity.User, error) {
+	return r.user, nil
+}
+
+func (r *fakeCommonRepository) GetViewerID(ctx context.Context) (uint, error) {
+	return r.user.ID, nil
+}
+
+func (r *fakeCommonRepository) GetViewerFriendIDs(ctx context.Context) (map[uint]bool, error) {
+	return r.friends, nil
+}
+
+// fakeExerciseRepository は保持している種目を返す
+type fakeExerciseRepository struct {
+	ExerciseRepository
+	exercises map[uint]*entity.Exercise
+}
+
+func (r *fakeExerciseRepository) GetExerciseByID(ctx context.Context, id string) (*entity.Exercise, error) {
+	for exerciseID, exercise := range r.exercises {
+		if fmt.Sprint(exerciseID) == id {
+			return exercise, nil
+		}
+	}
+	return nil, fmt.Errorf("failed to fetch exercise: %w", gorm.ErrRecordNotFound)
+}
+
+func (r *fakeExerciseRepository) GetExercisesByIDs(exerciseIDs []uint) ([]*entity.Exercise, error) {
+	var result []*entity.Exercise
+	for _, id := range exerciseIDs {
+		if exercise, ok := r.exercises[id]; ok {
+			result = append(result, exercise)
+		}
+	}
+	return result, nil
+}
+
+func TestExerciseService_GetExerciseWithDataLoader(t *testing.T) {
+	ownerID := uint(2)
+	exercise := func(id uint, visibility entity.ExerciseVisibility) *entity.Exercise {
+		return &entity.Exercise{
+			Model:        gorm.Model{ID: id},
+			UserID:       &ownerID,
+			Name:         "オリジナル種目",
+			Description:  "メモ",
+			Visibility:   visibility,
+			TrackingType: entity.TrackingTypeDuration,
+		}
+	}
+
+	tests := []struct {
+		name         string
+		visibility   entity.ExerciseVisibility
+		isFriend     bool
+		expectedName string
+	}{
+		{name: "Public exercise", visibility: entity.ExerciseVisibilityPublic, expectedName: "オリジナル種目"},
+		{name: "Friends exercise of a friend", visibility: entity.ExerciseVisibilityFriends, isFriend: true, expectedName: "オリジナル種目"},
+		{name: "Friends exercise of a non-friend is redacted", visibility: entity.ExerciseVisibilityFriends, expectedName: entity.RedactedExerciseName},
+		{name: "Private exercise is redacted", visibility: entity.ExerciseVisibilityPrivate, isFriend: true, expectedName: entity.RedactedExerciseName},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeExerciseRepository{
+				exercises: map[uint]*entity.Exercise{10: exercise(10, tt.visibility)},
+			}
+			service := &exerciseService{
+				repo:       repo,
+				converter:  NewExerciseConverter(),
+				common:     &fakeCommonRepository{user: &entity.User{Model: gorm.Model{ID: 1}}, friends: map[uint]bool{ownerID: tt.isFriend}},
+				dataLoader: NewExerciseDataLoader(repo),
+			}
+
+			result, err := service.GetExerciseWithDataLoader(context.Background(), "10")
+			assert.NoError(t, err)
+			assert.Equal(t, "10", result.ID)
+			assert.Equal(t, tt.expectedName, result.Name)
+			assert.Equal(t, entity.TrackingTypeDuration.ToGraphQL(), result.TrackingType)
+			if tt.expectedName == entity.RedactedExerciseName {
+				assert.Empty(t, *result.Description)
+			}
+		})
+	}
+}
+
+func TestExerciseService_GetExercise(t *testing.T) {
+	ownerID := uint(2)
+	repo := &fakeExerciseRepository{
+		exercises: map[uint]*entity.Exercise{
+			10: {Model: gorm.Model{ID: 10}, UserID: &ownerID, Name: "公開種目", Visibility: entity.ExerciseVisibilityPublic},
+			11: {Model: gorm.Model{ID: 11}, UserID: &ownerID, Name: "非公開種目", Visibility: entity.ExerciseVisibilityPrivate},
+		},
+	}
+	service := &exerciseService{
+		repo:      repo,
+		converter: NewExerciseConverter(),
+		common:    &fakeCommonRepository{user: &entity.User{Model: gorm.Model{ID: 1}}},
+	}
+
+	tests := []struct {
+		name         string
+		id           string
+		expectedName string
+	}{
+		{name: "Visible exercise", id: "10", expectedName: "公開種目"},
+		// 他のユーザーの非公開の種目は、存在しない種目と区別できないようにする
+		{name: "Exercise not visible is not found", id: "11"},
+		{name: "Missing exercise is not found", id: "12"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := service.GetExercise(context.Background(), tt.id)
+			assert.NoError(t, err)
+			if tt.expectedName == "" {
+				assert.Nil(t, result)
+				return
+			}
+			assert.Equal(t, tt.expectedName, result.Name)
+		})
+	}
+}
+
+func TestExerciseService_RejectsExercisesNotOwned(t *testing.T) {
+	ownerID := uint(2)
+	repo := &fakeExerciseRepository{
+		exercises: map[uint]*entity.Exercise{
+			10: {Model: gorm.Model{ID: 10}, UserID: &ownerID, Name: "他のユーザーの種目", Visibility: entity.ExerciseVisibilityPublic},
+		},
+	}
+	service := &exerciseService{
+		repo:      repo,
+		converter: NewExerciseConverter(),
+		common:    &fakeCommonRepository{user: &entity.User{Model: gorm.Model{ID: 1}}},
+	}
+	name := "変更後"
+
+	_, err := service.UpdateExercise(context.Background(), model.UpdateExercise{ID: "10", Name: &name})
+	assert.True(t, errcode.Is(err, errcode.Forbidden), err)
+
+	// モデレーターでないユーザーは他のユーザーの種目をアーカイブできない
+	_, err = service.ArchiveExercise(context.Background(), model.ArchiveExercise{ID: "10"})
+	assert.True(t, errcode.Is(err, errcode.Forbidden), err)
+	assert.Nil(t, repo.exercises[10].ArchivedAt)
+}
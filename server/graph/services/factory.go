@@ -1,15 +1,28 @@
 package services
 
 import (
+	"app/friendqr"
+	"app/graph/services/analytics"
+	"app/graph/services/body_measurement"
 	"app/graph/services/common"
+	"app/graph/services/device"
+	"app/graph/services/event"
 	"app/graph/services/exercise"
 	"app/graph/services/friendship"
+	"app/graph/services/notification"
+	"app/graph/services/personal_record"
 	"app/graph/services/profile"
+	"app/graph/services/program"
+	"app/graph/services/recommendation"
+	"app/graph/services/role"
 	"app/graph/services/set_log"
 	"app/graph/services/user"
 	"app/graph/services/workout"
 	"app/graph/services/workout_exercise"
 	"app/graph/services/workout_group"
+	"app/graph/services/workout_group_member"
+	"app/graph/services/workout_template"
+	"app/pubsub"
 
 	"gorm.io/gorm"
 )
@@ -17,10 +30,11 @@ import (
 // 新しい分離されたサービス構造のファクトリー関数
 
 // NewFriendshipServiceWithSeparation は分離されたFriendshipServiceを作成します
-func NewFriendshipServiceWithSeparation(db *gorm.DB) friendship.FriendshipService {
+func NewFriendshipServiceWithSeparation(db *gorm.DB, ps pubsub.PubSub, qrSigner *friendqr.Signer) friendship.FriendshipService {
 	repo := friendship.NewFriendshipRepository(db)
 	converter := friendship.NewFriendshipConverter()
-	return friendship.NewFriendshipService(repo, converter)
+	dataLoader := friendship.NewFriendshipDataLoader(repo)
+	return friendship.NewFriendshipService(repo, converter, dataLoader, event.NewBus(ps), qrSigner)
 }
 
 // NewUserServiceWithSeparation は分離されたUserServiceを作成します
@@ -48,19 +62,27 @@ func NewExerciseServiceWithSeparation(db *gorm.DB) exercise.ExerciseService {
 }
 
 // NewWorkoutServiceWithSeparation は分離されたWorkoutServiceを作成します
-func NewWorkoutServiceWithSeparation(db *gorm.DB) workout.WorkoutService {
+func NewWorkoutServiceWithSeparation(db *gorm.DB, ps pubsub.PubSub) workout.WorkoutService {
 	repo := workout.NewWorkoutRepository(db)
 	converter := workout.NewWorkoutConverter()
 	dataLoader := workout.NewWorkoutDataLoader(repo)
-	return workout.NewWorkoutService(repo, converter, dataLoader)
+	return workout.NewWorkoutService(repo, converter, dataLoader, event.NewBus(ps))
 }
 
 // NewWorkoutGroupServiceWithSeparation は分離されたWorkoutGroupServiceを作成します
-func NewWorkoutGroupServiceWithSeparation(db *gorm.DB) workout_group.WorkoutGroupService {
+func NewWorkoutGroupServiceWithSeparation(db *gorm.DB, ps pubsub.PubSub) workout_group.WorkoutGroupService {
 	repo := workout_group.NewWorkoutGroupRepository(db)
 	converter := workout_group.NewWorkoutGroupConverter()
 	dataLoader := workout_group.NewWorkoutGroupDataLoader(repo)
-	return workout_group.NewWorkoutGroupService(repo, converter, dataLoader)
+	return workout_group.NewWorkoutGroupService(repo, converter, dataLoader, event.NewBus(ps))
+}
+
+// NewWorkoutGroupMemberServiceWithSeparation は分離されたWorkoutGroupMemberServiceを作成します
+func NewWorkoutGroupMemberServiceWithSeparation(db *gorm.DB, ps pubsub.PubSub) workout_group_member.WorkoutGroupMemberService {
+	repo := workout_group_member.NewWorkoutGroupMemberRepository(db)
+	converter := workout_group_member.NewWorkoutGroupMemberConverter()
+	dataLoader := workout_group_member.NewWorkoutGroupMemberDataLoader(repo)
+	return workout_group_member.NewWorkoutGroupMemberService(repo, converter, dataLoader, event.NewBus(ps))
 }
 
 // NewWorkoutExerciseServiceWithSeparation は分離されたWorkoutExerciseServiceを作成します
@@ -72,11 +94,76 @@ func NewWorkoutExerciseServiceWithSeparation(db *gorm.DB) workout_exercise.Worko
 }
 
 // NewSetLogServiceWithSeparation は分離されたSetLogServiceを作成します
-func NewSetLogServiceWithSeparation(db *gorm.DB) set_log.SetLogService {
+func NewSetLogServiceWithSeparation(db *gorm.DB, ps pubsub.PubSub) set_log.SetLogService {
 	repo := set_log.NewSetLogRepository(db)
 	converter := set_log.NewSetLogConverter()
 	dataLoader := set_log.NewSetLogDataLoader(repo)
-	return set_log.NewSetLogService(repo, converter, dataLoader)
+	return set_log.NewSetLogService(repo, converter, dataLoader, event.NewBus(ps))
+}
+
+// NewWorkoutTemplateServiceWithSeparation は分離されたWorkoutTemplateServiceを作成します
+func NewWorkoutTemplateServiceWithSeparation(db *gorm.DB) workout_template.WorkoutTemplateService {
+	repo := workout_template.NewWorkoutTemplateRepository(db)
+	converter := workout_template.NewWorkoutTemplateConverter()
+	dataLoader := workout_template.NewWorkoutTemplateDataLoader(repo)
+	return workout_template.NewWorkoutTemplateService(repo, converter, dataLoader)
+}
+
+// NewPersonalRecordServiceWithSeparation は分離されたPersonalRecordServiceを作成します
+func NewPersonalRecordServiceWithSeparation(db *gorm.DB) personal_record.PersonalRecordService {
+	repo := personal_record.NewPersonalRecordRepository(db)
+	converter := personal_record.NewPersonalRecordConverter()
+	dataLoader := personal_record.NewPersonalRecordDataLoader(repo)
+	return personal_record.NewPersonalRecordService(repo, converter, dataLoader)
+}
+
+// NewAnalyticsServiceWithSeparation は分離されたAnalyticsServiceを作成します
+func NewAnalyticsServiceWithSeparation(db *gorm.DB) analytics.AnalyticsService {
+	repo := analytics.NewAnalyticsRepository(db)
+	converter := analytics.NewAnalyticsConverter()
+	return analytics.NewAnalyticsService(repo, converter)
+}
+
+// NewBodyMeasurementServiceWithSeparation は分離されたBodyMeasurementServiceを作成します
+func NewBodyMeasurementServiceWithSeparation(db *gorm.DB) body_measurement.BodyMeasurementService {
+	repo := body_measurement.NewBodyMeasurementRepository(db)
+	converter := body_measurement.NewBodyMeasurementConverter()
+	return body_measurement.NewBodyMeasurementService(repo, converter)
+}
+
+// NewProgramServiceWithSeparation は分離されたProgramServiceを作成します
+func NewProgramServiceWithSeparation(db *gorm.DB) program.ProgramService {
+	repo := program.NewProgramRepository(db)
+	converter := program.NewProgramConverter()
+	return program.NewProgramService(repo, converter)
+}
+
+// NewNotificationServiceWithSeparation は分離されたNotificationServiceを作成します
+func NewNotificationServiceWithSeparation(db *gorm.DB) notification.NotificationService {
+	repo := notification.NewNotificationRepository(db)
+	converter := notification.NewNotificationConverter()
+	return notification.NewNotificationService(repo, converter)
+}
+
+// NewDeviceServiceWithSeparation は分離されたDeviceServiceを作成します
+func NewDeviceServiceWithSeparation(db *gorm.DB) device.DeviceService {
+	repo := device.NewDeviceRepository(db)
+	return device.NewDeviceService(repo)
+}
+
+// NewRoleServiceWithSeparation は分離されたRoleServiceを作成します
+func NewRoleServiceWithSeparation(db *gorm.DB, publisher role.ClaimsPublisher) role.RoleService {
+	repo := role.NewRoleRepository(db)
+	converter := role.NewRoleConverter()
+	dataLoader := role.NewRoleDataLoader(repo)
+	return role.NewRoleService(repo, converter, dataLoader, publisher)
+}
+
+// NewRecommendationServiceWithSeparation は分離されたRecommendationServiceを作成します
+func NewRecommendationServiceWithSeparation(db *gorm.DB) recommendation.RecommendationService {
+	repo := recommendation.NewRecommendationRepository(db)
+	converter := recommendation.NewRecommendationConverter()
+	return recommendation.NewRecommendationService(repo, converter)
 }
 
 // 共通のConverterを取得する関数
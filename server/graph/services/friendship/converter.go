@@ -2,8 +2,10 @@ package friendship
 
 import (
 	"app/entity"
+	"app/friendqr"
 	"app/graph/model"
 	"app/graph/services/common"
+	"app/graph/services/common/base"
 	"fmt"
 )
 
@@ -26,6 +28,16 @@ func (c *FriendshipConverter) ToModelFriendship(friendship entity.Friendship) *m
 	}
 }
 
+func (c *FriendshipConverter) ToModelFriendQRToken(token string, claims friendqr.Claims) *model.FriendQRToken {
+	return &model.FriendQRToken{
+		Token:     token,
+		ExpiresAt: claims.ExpiresAt.Format(common.TimeFormat),
+		SingleUse: claims.SingleUse,
+		PngURL:    friendqr.ImageURL(friendqr.PNGPath, token),
+		SVGURL:    friendqr.ImageURL(friendqr.SVGPath, token),
+	}
+}
+
 func (c *FriendshipConverter) ToModelFriendships(friendships []entity.Friendship) []*model.Friendship {
 	result := make([]*model.Friendship, len(friendships))
 	for i, friendship := range friendships {
@@ -56,3 +68,7 @@ func (c *FriendshipConverter) ToModelUsers(users []entity.User) []*model.User {
 func (c *FriendshipConverter) ToModelUsersFromPointers(users []*entity.User) []*model.User {
 	return c.common.ToModelUsersFromPointers(users)
 }
+
+func (c *FriendshipConverter) ToModelUserConnection(page *base.Page[entity.User], args base.PageArgs) *model.UserConnection {
+	return c.common.ToModelUserConnection(page, args)
+}
@@ -10,9 +10,9 @@ import (
 type FriendshipDataLoader struct {
 	repository                FriendshipRepository
 	byIDLoader                *base.BaseLoader[*entity.Friendship]
-	friendshipsByUserIDLoader *base.BaseArrayLoader[entity.Friendship]
 	requestsByUserIDLoader    *base.BaseArrayLoader[entity.Friendship]
-	recommendedByUserIDLoader *base.BaseArrayLoader[entity.User]
+	friendPageByUserIDLoader  *base.BasePageLoader[entity.User]
+	recommendedByUserIDLoader *base.BasePageLoader[entity.User]
 }
 
 // NewFriendshipDataLoader は新しいDataLoaderを作成
@@ -29,14 +29,6 @@ func NewFriendshipDataLoader(repository FriendshipRepository) *FriendshipDataLoa
 		base.ParseUintKey,
 	)
 
-	// FriendshipsByUserID用のローダー
-	loader.friendshipsByUserIDLoader = base.NewBaseArrayLoader(
-		nil, // dbは不要（repositoryを使用）
-		loader.fetchFriendshipsByUserIDs,
-		loader.createFriendshipsByUserIDMap,
-		base.ParseUintKey,
-	)
-
 	// RequestsByUserID用のローダー
 	loader.requestsByUserIDLoader = base.NewBaseArrayLoader(
 		nil, // dbは不要（repositoryを使用）
@@ -45,12 +37,16 @@ func NewFriendshipDataLoader(repository FriendshipRepository) *FriendshipDataLoa
 		base.ParseUintKey,
 	)
 
+	// FriendPageByUserID用のローダー
+	loader.friendPageByUserIDLoader = base.NewBasePageLoader(
+		nil, // dbは不要（repositoryを使用）
+		loader.fetchFriendPagesByUserIDs,
+	)
+
 	// RecommendedByUserID用のローダー
-	loader.recommendedByUserIDLoader = base.NewBaseArrayLoader(
+	loader.recommendedByUserIDLoader = base.NewBasePageLoader(
 		nil, // dbは不要（repositoryを使用）
 		loader.fetchRecommendedByUserIDs,
-		loader.createRecommendedUserIDMap,
-		base.ParseUintKey,
 	)
 
 	return loader
@@ -61,25 +57,9 @@ func (l *FriendshipDataLoader) LoadByID(ctx context.Context, friendshipID string
 	return l.byIDLoader.Load(ctx, friendshipID)
 }
 
-// LoadFriendsByUserID は指定されたUserIDの友達を取得
-func (l *FriendshipDataLoader) LoadFriendsByUserID(ctx context.Context, userID string) ([]*entity.User, error) {
-	friendships, err := l.friendshipsByUserIDLoader.Load(ctx, userID)
-	if err != nil {
-		return nil, err
-	}
-
-	result := make([]*entity.User, 0, len(friendships))
-	userIDUint, _ := base.ParseUintKey(userID)
-
-	for _, friendship := range friendships {
-		if friendship.RequesterID == userIDUint && friendship.Requestee.ID != 0 {
-			result = append(result, &friendship.Requestee)
-		} else if friendship.RequesteeID == userIDUint && friendship.Requester.ID != 0 {
-			result = append(result, &friendship.Requester)
-		}
-	}
-
-	return result, nil
+// LoadFriendPageByUserID は指定されたUserIDの友達をページ単位で取得
+func (l *FriendshipDataLoader) LoadFriendPageByUserID(ctx context.Context, userID string, args base.PageArgs) (*base.Page[entity.User], error) {
+	return l.friendPageByUserIDLoader.Load(ctx, userID, args)
 }
 
 // LoadRequestsByUserID は指定されたUserIDの友達リクエストを取得
@@ -87,9 +67,9 @@ func (l *FriendshipDataLoader) LoadRequestsByUserID(ctx context.Context, userID
 	return l.requestsByUserIDLoader.Load(ctx, userID)
 }
 
-// LoadRecommendedByUserID は指定されたUserIDの推奨ユーザーを取得
-func (l *FriendshipDataLoader) LoadRecommendedByUserID(ctx context.Context, userID string) ([]*entity.User, error) {
-	return l.recommendedByUserIDLoader.Load(ctx, userID)
+// LoadRecommendedByUserID は指定されたUserIDの推奨ユーザーをページ単位で取得
+func (l *FriendshipDataLoader) LoadRecommendedByUserID(ctx context.Context, userID string, args base.PageArgs) (*base.Page[entity.User], error) {
+	return l.recommendedByUserIDLoader.Load(ctx, userID, args)
 }
 
 // fetchByIDs はRepository経由でFriendshipID別にデータを取得
@@ -97,19 +77,19 @@ func (l *FriendshipDataLoader) fetchByIDs(friendshipIDs []uint) ([]*entity.Frien
 	return l.repository.GetFriendshipsByIDs(friendshipIDs)
 }
 
-// fetchFriendshipsByUserIDs はRepository経由でUserID別に友達関係データを取得
-func (l *FriendshipDataLoader) fetchFriendshipsByUserIDs(userIDs []uint) ([]*entity.Friendship, error) {
-	return l.repository.GetFriendshipsByUserIDs(userIDs)
-}
-
 // fetchRequestsByUserIDs はRepository経由でUserID別に友達リクエストデータを取得
 func (l *FriendshipDataLoader) fetchRequestsByUserIDs(userIDs []uint) ([]*entity.Friendship, error) {
 	return l.repository.GetFriendshipRequestsByUserIDs(userIDs)
 }
 
-// fetchRecommendedByUserIDs はRepository経由でUserID別に推奨ユーザーデータを取得
-func (l *FriendshipDataLoader) fetchRecommendedByUserIDs(userIDs []uint) ([]*entity.User, error) {
-	return l.repository.GetRecommendedUsersByUserIDs(userIDs)
+// fetchFriendPagesByUserIDs はRepository経由でUserID別に友達のページを取得
+func (l *FriendshipDataLoader) fetchFriendPagesByUserIDs(userIDs []uint, args base.PageArgs) (map[uint]*base.Page[entity.User], error) {
+	return l.repository.GetFriendPagesByUserIDs(userIDs, args)
+}
+
+// fetchRecommendedByUserIDs はRepository経由でUserID別に推奨ユーザーのページを取得
+func (l *FriendshipDataLoader) fetchRecommendedByUserIDs(userIDs []uint, args base.PageArgs) (map[uint]*base.Page[entity.User], error) {
+	return l.repository.GetRecommendedUserPagesByUserIDs(userIDs, args)
 }
 
 // createIDMap はFriendshipID別にデータをマップ化
@@ -123,21 +103,6 @@ func (l *FriendshipDataLoader) createIDMap(friendships []*entity.Friendship) map
 	return result
 }
 
-// createFriendshipsByUserIDMap は友達関係データをUserID別にマップ化
-func (l *FriendshipDataLoader) createFriendshipsByUserIDMap(friendships []*entity.Friendship) map[uint][]*entity.Friendship {
-	result := make(map[uint][]*entity.Friendship)
-
-	for _, friendship := range friendships {
-		if friendship != nil {
-			// 友達関係の両方のユーザーに対してマッピング
-			result[friendship.RequesterID] = append(result[friendship.RequesterID], friendship)
-			result[friendship.RequesteeID] = append(result[friendship.RequesteeID], friendship)
-		}
-	}
-
-	return result
-}
-
 // createRequestsUserIDMap はUserID別に友達リクエストデータをマップ化
 func (l *FriendshipDataLoader) createRequestsUserIDMap(friendships []*entity.Friendship) map[uint][]*entity.Friendship {
 	result := make(map[uint][]*entity.Friendship)
@@ -148,13 +113,3 @@ func (l *FriendshipDataLoader) createRequestsUserIDMap(friendships []*entity.Fri
 	}
 	return result
 }
-
-// createRecommendedUserIDMap はUserID別に推奨ユーザーデータをマップ化
-func (l *FriendshipDataLoader) createRecommendedUserIDMap(users []*entity.User) map[uint][]*entity.User {
-	result := make(map[uint][]*entity.User)
-
-	// 注意: 現在のRepository実装では全ユーザーに対して同じ推奨リストを返すため、
-	// 適切なDataLoaderマッピングができない。各ユーザー固有の推奨ロジックが必要。
-
-	return result
-}
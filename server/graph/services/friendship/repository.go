@@ -2,12 +2,17 @@ package friendship
 
 import (
 	"app/entity"
+	"app/graph/errcode"
+	"app/graph/services/common/base"
 	"app/middleware"
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 
+	"github.com/jackc/pgx/v5/pgconn"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type FriendshipRepository interface {
@@ -17,15 +22,30 @@ type FriendshipRepository interface {
 	GetRecommendedUsersByUserID(ctx context.Context, userID string) ([]*entity.User, error)
 	CreateFriendship(ctx context.Context, friendship *entity.Friendship) error
 	UpdateFriendship(ctx context.Context, friendship *entity.Friendship) error
+	GetFriendshipBetween(ctx context.Context, userID, otherUserID uint) (*entity.Friendship, error)
+	DeleteFriendship(ctx context.Context, friendship *entity.Friendship) error
+	IsBlockedBetween(ctx context.Context, userID, otherUserID uint) (bool, error)
+	BlockUser(ctx context.Context, block *entity.UserBlock) error
+	UnblockUser(ctx context.Context, blockerID, blockedID uint) (bool, error)
+	GetBlockedUsers(ctx context.Context, blockerID uint) ([]*entity.User, error)
+	AddFriendByQRCode(ctx context.Context, friendship *entity.Friendship, use *entity.FriendQRTokenUse) error
 	GetDB() *gorm.DB
 	// Batch methods for DataLoader
 	GetFriendshipsByIDs(friendshipIDs []uint) ([]*entity.Friendship, error)
-	GetFriendsByUserIDs(userIDs []uint) ([]*entity.User, error)
-	GetFriendshipsByUserIDs(userIDs []uint) ([]*entity.Friendship, error)
 	GetFriendshipRequestsByUserIDs(userIDs []uint) ([]*entity.Friendship, error)
-	GetRecommendedUsersByUserIDs(userIDs []uint) ([]*entity.User, error)
+	GetFriendPagesByUserIDs(userIDs []uint, args base.PageArgs) (map[uint]*base.Page[entity.User], error)
+	GetRecommendedUserPagesByUserIDs(userIDs []uint, args base.PageArgs) (map[uint]*base.Page[entity.User], error)
 }
 
+// ErrFriendshipExists は同じ2人の友達関係がすでに存在する
+var ErrFriendshipExists = errors.New("friendship already exists")
+
+// ErrFriendQRTokenUsed は1回限りのQRコードのトークンがすでに使用されている
+var ErrFriendQRTokenUsed = errors.New("friend QR code token already used")
+
+// uniqueViolationCode はPostgresの一意制約違反のエラーコード
+const uniqueViolationCode = "23505"
+
 type friendshipRepository struct {
 	db *gorm.DB
 }
@@ -52,7 +72,7 @@ func (r *friendshipRepository) GetFriendsByUserID(ctx context.Context, userID st
 	// 現在のユーザーを取得
 	uid, err := getUserUIDFromContext(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("unauthorized: %w", err)
+		return nil, errcode.NewUnauthenticated(ctx, "authentication required")
 	}
 
 	var currentUser entity.User
@@ -74,7 +94,7 @@ func (r *friendshipRepository) GetFriendshipRequestsByUserID(ctx context.Context
 	// 現在のユーザーを取得
 	uid, err := getUserUIDFromContext(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("unauthorized: %w", err)
+		return nil, errcode.NewUnauthenticated(ctx, "authentication required")
 	}
 
 	var currentUser entity.User
@@ -96,7 +116,7 @@ func (r *friendshipRepository) GetRecommendedUsersByUserID(ctx context.Context,
 	// 現在のユーザーを取得
 	uid, err := getUserUIDFromContext(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("unauthorized: %w", err)
+		return nil, errcode.NewUnauthenticated(ctx, "authentication required")
 	}
 
 	var currentUser entity.User
@@ -114,89 +134,148 @@ func (r *friendshipRepository) GetRecommendedUsersByUserID(ctx context.Context,
 	return userPointers, nil
 }
 
+// CreateFriendship は友達関係を作成する（同じ2人の友達関係がすでにある場合はErrFriendshipExists）
 func (r *friendshipRepository) CreateFriendship(ctx context.Context, friendship *entity.Friendship) error {
-	return r.db.Create(friendship).Error
+	return createFriendship(r.db, friendship)
+}
+
+func createFriendship(db *gorm.DB, friendship *entity.Friendship) error {
+	err := db.Create(friendship).Error
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode && pgErr.ConstraintName == entity.FriendshipPairIndex {
+		return ErrFriendshipExists
+	}
+	return err
 }
 
 func (r *friendshipRepository) UpdateFriendship(ctx context.Context, friendship *entity.Friendship) error {
 	return r.db.Save(friendship).Error
 }
 
-func (r *friendshipRepository) GetDB() *gorm.DB {
-	return r.db
+// GetFriendshipBetween は2人のユーザーの友達関係を取得（どちらが申請したかは問わない、存在しない場合はnil）
+func (r *friendshipRepository) GetFriendshipBetween(ctx context.Context, userID, otherUserID uint) (*entity.Friendship, error) {
+	var friendships []entity.Friendship
+	if err := r.db.WithContext(ctx).
+		Where("(requester_id = ? AND requestee_id = ?) OR (requester_id = ? AND requestee_id = ?)", userID, otherUserID, otherUserID, userID).
+		Order("id DESC").
+		Limit(1).
+		Find(&friendships).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch friendship: %w", err)
+	}
+	if len(friendships) == 0 {
+		return nil, nil
+	}
+	return &friendships[0], nil
 }
 
-// Batch methods for DataLoader
-func (r *friendshipRepository) GetFriendshipsByIDs(friendshipIDs []uint) ([]*entity.Friendship, error) {
-	if len(friendshipIDs) == 0 {
-		return []*entity.Friendship{}, nil
+// DeleteFriendship は友達関係を削除する（関連する通知も削除される）
+func (r *friendshipRepository) DeleteFriendship(ctx context.Context, friendship *entity.Friendship) error {
+	if err := r.db.WithContext(ctx).Unscoped().Delete(friendship).Error; err != nil {
+		return fmt.Errorf("failed to delete friendship: %w", err)
 	}
+	return nil
+}
 
-	var friendships []entity.Friendship
-	if err := r.db.Where("id IN ?", friendshipIDs).Find(&friendships).Error; err != nil {
-		return nil, fmt.Errorf("failed to fetch friendships by IDs: %w", err)
+// IsBlockedBetween はどちらかのユーザーがもう一方をブロックしているかどうか
+func (r *friendshipRepository) IsBlockedBetween(ctx context.Context, userID, otherUserID uint) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&entity.UserBlock{}).
+		Where("(blocker_id = ? AND blocked_id = ?) OR (blocker_id = ? AND blocked_id = ?)", userID, otherUserID, otherUserID, userID).
+		Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check user block: %w", err)
 	}
+	return count > 0, nil
+}
 
-	// 値のスライスをポインタのスライスに変換
-	result := make([]*entity.Friendship, len(friendships))
-	for i := range friendships {
-		result[i] = &friendships[i]
-	}
+// BlockUser はユーザーをブロックし、2人の間の友達関係と保留中のグループへの招待を取り消す
+func (r *friendshipRepository) BlockUser(ctx context.Context, block *entity.UserBlock) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(block).Error; err != nil {
+			return fmt.Errorf("failed to block user: %w", err)
+		}
 
-	return result, nil
-}
+		if err := tx.Unscoped().
+			Where("(requester_id = ? AND requestee_id = ?) OR (requester_id = ? AND requestee_id = ?)",
+				block.BlockerID, block.BlockedID, block.BlockedID, block.BlockerID).
+			Delete(&entity.Friendship{}).Error; err != nil {
+			return fmt.Errorf("failed to delete friendship: %w", err)
+		}
 
-func (r *friendshipRepository) GetFriendsByUserIDs(userIDs []uint) ([]*entity.User, error) {
-	if len(userIDs) == 0 {
-		return []*entity.User{}, nil
-	}
+		if err := tx.Model(&entity.WorkoutGroupMember{}).
+			Where("status = ?", entity.WorkoutGroupMemberStatusInvited).
+			Where("(user_id = ? AND invited_by_id = ?) OR (user_id = ? AND invited_by_id = ?)",
+				block.BlockerID, block.BlockedID, block.BlockedID, block.BlockerID).
+			Update("status", entity.WorkoutGroupMemberStatusDeclined).Error; err != nil {
+			return fmt.Errorf("failed to decline workout group invitations: %w", err)
+		}
+		return nil
+	})
+}
 
-	// GetFriendsByUserIDの実装を参考に、バッチで友達を取得
-	var friendships []entity.Friendship
-	if err := r.db.Model(&entity.Friendship{}).
-		Preload("Requester").
-		Preload("Requestee").
-		Where("(requester_id IN ? OR requestee_id IN ?) AND status = ?", userIDs, userIDs, string(entity.Accepted)).
-		Find(&friendships).Error; err != nil {
-		return nil, fmt.Errorf("failed to fetch friendships by user IDs: %w", err)
+// UnblockUser はブロックを解除する（ブロックしていなかった場合はfalse）
+func (r *friendshipRepository) UnblockUser(ctx context.Context, blockerID, blockedID uint) (bool, error) {
+	result := r.db.WithContext(ctx).Unscoped().
+		Where("blocker_id = ? AND blocked_id = ?", blockerID, blockedID).
+		Delete(&entity.UserBlock{})
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to unblock user: %w", result.Error)
 	}
+	return result.RowsAffected > 0, nil
+}
 
-	// 全ての友達ユーザーを収集（重複排除）
-	friendsSet := make(map[uint]*entity.User)
+// AddFriendByQRCode は1回限りのQRコードのトークンの使用（useがnilの場合は記録しない）と友達関係の作成・承認を1トランザクションで行う
+// 友達関係を作成・承認できなかった場合はトークンも使用済みにならない（使用済みだった場合はErrFriendQRTokenUsed）
+func (r *friendshipRepository) AddFriendByQRCode(ctx context.Context, friendship *entity.Friendship, use *entity.FriendQRTokenUse) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if use != nil {
+			result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(use)
+			if result.Error != nil {
+				return fmt.Errorf("failed to record QR code token use: %w", result.Error)
+			}
+			if result.RowsAffected == 0 {
+				return ErrFriendQRTokenUsed
+			}
+		}
 
-	for _, friendship := range friendships {
-		// 対象userIDsに含まれるユーザーの友達を収集
-		for _, userID := range userIDs {
-			if friendship.RequesterID == userID && friendship.Requestee.ID != 0 {
-				friendsSet[friendship.Requestee.ID] = &friendship.Requestee
-			} else if friendship.RequesteeID == userID && friendship.Requester.ID != 0 {
-				friendsSet[friendship.Requester.ID] = &friendship.Requester
+		// 作成時は保留中になるため、作成してから承認する
+		if friendship.ID == 0 {
+			if err := createFriendship(tx, friendship); err != nil {
+				return err
 			}
 		}
-	}
+		if err := friendship.Accept(); err != nil {
+			return err
+		}
+		return tx.Save(friendship).Error
+	})
+}
 
-	// 結果を構築
-	result := make([]*entity.User, 0, len(friendsSet))
-	for _, friend := range friendsSet {
-		result = append(result, friend)
+// GetBlockedUsers はユーザーがブロックしているユーザーをブロックした順に取得
+func (r *friendshipRepository) GetBlockedUsers(ctx context.Context, blockerID uint) ([]*entity.User, error) {
+	var users []*entity.User
+	if err := r.db.WithContext(ctx).
+		Joins("JOIN user_blocks ON user_blocks.blocked_id = users.id").
+		Where("user_blocks.blocker_id = ?", blockerID).
+		Order("user_blocks.id").
+		Find(&users).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch blocked users: %w", err)
 	}
+	return users, nil
+}
 
-	return result, nil
+func (r *friendshipRepository) GetDB() *gorm.DB {
+	return r.db
 }
 
-func (r *friendshipRepository) GetFriendshipsByUserIDs(userIDs []uint) ([]*entity.Friendship, error) {
-	if len(userIDs) == 0 {
+// Batch methods for DataLoader
+func (r *friendshipRepository) GetFriendshipsByIDs(friendshipIDs []uint) ([]*entity.Friendship, error) {
+	if len(friendshipIDs) == 0 {
 		return []*entity.Friendship{}, nil
 	}
 
-	// 友達関係データを取得（承認済みのみ）
 	var friendships []entity.Friendship
-	if err := r.db.Model(&entity.Friendship{}).
-		Preload("Requester").
-		Preload("Requestee").
-		Where("(requester_id IN ? OR requestee_id IN ?) AND status = ?", userIDs, userIDs, string(entity.Accepted)).
-		Find(&friendships).Error; err != nil {
-		return nil, fmt.Errorf("failed to fetch friendships by user IDs: %w", err)
+	if err := r.db.Where("id IN ?", friendshipIDs).Find(&friendships).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch friendships by IDs: %w", err)
 	}
 
 	// 値のスライスをポインタのスライスに変換
@@ -230,54 +309,129 @@ func (r *friendshipRepository) GetFriendshipRequestsByUserIDs(userIDs []uint) ([
 	return result, nil
 }
 
-func (r *friendshipRepository) GetRecommendedUsersByUserIDs(userIDs []uint) ([]*entity.User, error) {
-	if len(userIDs) == 0 {
-		return []*entity.User{}, nil
-	}
-
-	// GetRecommendedUsersByUserIDの実装を参考に、バッチで推奨ユーザーを取得
-	// 各ユーザーと友達関係にあるユーザーIDを取得
-	var excludeIDsForAll []uint
-	for _, userID := range userIDs {
-		var excludeIDs []uint
-		if err := r.db.Model(&entity.Friendship{}).
-			Select("CASE WHEN requester_id = ? THEN requestee_id ELSE requester_id END", userID).
-			Where("requester_id = ? OR requestee_id = ?", userID, userID).
-			Scan(&excludeIDs).Error; err != nil {
-			continue // エラーの場合はスキップ
-		}
+// friendPairsQuery はユーザー（owner_id）と友達（user_id）の組を返すSQL
+const friendPairsQuery = `
+	SELECT requester_id AS owner_id, requestee_id AS user_id
+	FROM friendships
+	WHERE deleted_at IS NULL AND status = @status AND requester_id IN @ids
+	UNION ALL
+	SELECT requestee_id AS owner_id, requester_id AS user_id
+	FROM friendships
+	WHERE deleted_at IS NULL AND status = @status AND requestee_id IN @ids`
+
+// recommendedPairsQuery はユーザー（owner_id）と推奨ユーザー（user_id）の組を返すSQL
+// 自分自身と、ステータスに関わらず友達関係のレコードが存在するユーザー、どちらかがブロックしているユーザー、非表示にしたユーザーは除外する
+const recommendedPairsQuery = `
+	SELECT owners.id AS owner_id, users.id AS user_id
+	FROM users AS owners
+	JOIN users ON users.id <> owners.id
+	WHERE owners.id IN @ids
+	AND NOT EXISTS (
+		SELECT 1 FROM friendships
+		WHERE friendships.deleted_at IS NULL
+		AND (
+			(friendships.requester_id = owners.id AND friendships.requestee_id = users.id)
+			OR (friendships.requester_id = users.id AND friendships.requestee_id = owners.id)
+		)
+	)
+	AND NOT EXISTS (
+		SELECT 1 FROM user_blocks
+		WHERE user_blocks.deleted_at IS NULL
+		AND (
+			(user_blocks.blocker_id = owners.id AND user_blocks.blocked_id = users.id)
+			OR (user_blocks.blocker_id = users.id AND user_blocks.blocked_id = owners.id)
+		)
+	)
+	AND NOT EXISTS (
+		SELECT 1 FROM recommendation_dismissals
+		WHERE recommendation_dismissals.deleted_at IS NULL
+		AND recommendation_dismissals.user_id = owners.id
+		AND recommendation_dismissals.dismissed_user_id = users.id
+	)`
+
+// GetFriendPagesByUserIDs はバッチでUserID別に友達をページ取得
+func (r *friendshipRepository) GetFriendPagesByUserIDs(userIDs []uint, args base.PageArgs) (map[uint]*base.Page[entity.User], error) {
+	return r.getUserPages(friendPairsQuery, userIDs, args)
+}
 
-		// 現在のユーザーも除外リストに追加
-		excludeIDs = append(excludeIDs, userID)
-		excludeIDsForAll = append(excludeIDsForAll, excludeIDs...)
-	}
+// GetRecommendedUserPagesByUserIDs はバッチでUserID別に推奨ユーザーをページ取得
+func (r *friendshipRepository) GetRecommendedUserPagesByUserIDs(userIDs []uint, args base.PageArgs) (map[uint]*base.Page[entity.User], error) {
+	return r.getUserPages(recommendedPairsQuery, userIDs, args)
+}
 
-	// 重複を除去
-	excludeSet := make(map[uint]bool)
-	for _, id := range excludeIDsForAll {
-		excludeSet[id] = true
+// getUserPages は owner_id/user_id の組を返すSQLを元に、owner_id別にユーザーをID順でページ取得する
+func (r *friendshipRepository) getUserPages(pairsQuery string, userIDs []uint, args base.PageArgs) (map[uint]*base.Page[entity.User], error) {
+	result := make(map[uint]*base.Page[entity.User])
+	if len(userIDs) == 0 {
+		return result, nil
 	}
 
-	var finalExcludeIDs []uint
-	for id := range excludeSet {
-		finalExcludeIDs = append(finalExcludeIDs, id)
+	params := map[string]interface{}{
+		"ids":    userIDs,
+		"status": string(entity.Accepted),
+		"after":  args.After,
+		"limit":  args.First + 1,
+	}
+	// 削除済みのユーザーは対象外
+	pairs := `SELECT pairs.owner_id, pairs.user_id FROM (` + pairsQuery + `) AS pairs
+		JOIN users AS targets ON targets.id = pairs.user_id AND targets.deleted_at IS NULL`
+
+	// owner_id別の総件数を取得
+	var counts []struct {
+		OwnerID uint
+		Count   int64
+	}
+	if err := r.db.Raw(`SELECT owner_id, COUNT(*) AS count FROM (`+pairs+`) AS counted GROUP BY owner_id`, params).
+		Scan(&counts).Error; err != nil {
+		return nil, fmt.Errorf("failed to count users: %w", err)
+	}
+	for _, count := range counts {
+		result[count.OwnerID] = &base.Page[entity.User]{
+			Items:      []*entity.User{},
+			TotalCount: count.Count,
+		}
 	}
 
-	// 除外リストに含まれないユーザーを推奨ユーザーとして取得
-	var recommendedUsers []entity.User
-	query := r.db.Model(&entity.User{})
-	if len(finalExcludeIDs) > 0 {
-		query = query.Where("id NOT IN ?", finalExcludeIDs)
+	// owner_id別に first+1 件を取得（1件多く取得して次ページの有無を判定）
+	var rows []struct {
+		OwnerID uint
+		UserID  uint
+	}
+	if err := r.db.Raw(`
+		SELECT owner_id, user_id FROM (
+			SELECT owner_id, user_id, ROW_NUMBER() OVER (PARTITION BY owner_id ORDER BY user_id) AS rn
+			FROM (`+pairs+`) AS paired
+			WHERE user_id > @after
+		) AS ranked
+		WHERE rn <= @limit
+		ORDER BY owner_id, rn`, params).
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch user pages: %w", err)
 	}
 
-	if err := query.Limit(50).Find(&recommendedUsers).Error; err != nil { // 適度な制限を設定
-		return nil, fmt.Errorf("failed to fetch recommended users: %w", err)
+	// ユーザー本体を取得
+	targetIDs := make([]uint, 0, len(rows))
+	for _, row := range rows {
+		targetIDs = append(targetIDs, row.UserID)
+	}
+	var users []entity.User
+	if len(targetIDs) > 0 {
+		if err := r.db.Where("id IN ?", targetIDs).Find(&users).Error; err != nil {
+			return nil, fmt.Errorf("failed to fetch users by IDs: %w", err)
+		}
+	}
+	userMap := make(map[uint]*entity.User, len(users))
+	for i := range users {
+		userMap[users[i].ID] = &users[i]
 	}
 
-	// 値のスライスをポインタのスライスに変換
-	result := make([]*entity.User, len(recommendedUsers))
-	for i := range recommendedUsers {
-		result[i] = &recommendedUsers[i]
+	for _, row := range rows {
+		page, exists := result[row.OwnerID]
+		user, found := userMap[row.UserID]
+		if !exists || !found {
+			continue
+		}
+		page.Append(user, args.First)
 	}
 
 	return result, nil
@@ -2,11 +2,17 @@ package friendship
 
 import (
 	"app/entity"
+	"app/friendqr"
+	"app/graph/errcode"
 	"app/graph/model"
 	"app/graph/services/common"
+	"app/graph/services/event"
+	"app/graph/services/notification"
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
+	"time"
 )
 
 type FriendshipService interface {
@@ -17,24 +23,39 @@ type FriendshipService interface {
 	SendFriendshipRequest(ctx context.Context, input model.SendFriendshipRequest) (*model.Friendship, error)
 	AcceptFriendshipRequest(ctx context.Context, input model.AcceptFriendshipRequest) (*model.Friendship, error)
 	RejectFriendshipRequest(ctx context.Context, input model.RejectFriendshipRequest) (*model.Friendship, error)
+	GenerateFriendQRToken(ctx context.Context, singleUse bool) (*model.FriendQRToken, error)
 	AddFriendByQRCode(ctx context.Context, input model.AddFriendByQRCode) (*model.Friendship, error)
+	CancelFriendshipRequest(ctx context.Context, input model.CancelFriendshipRequest) (bool, error)
+	RemoveFriend(ctx context.Context, input model.RemoveFriend) (bool, error)
+	BlockUser(ctx context.Context, input model.BlockUser) (bool, error)
+	UnblockUser(ctx context.Context, input model.UnblockUser) (bool, error)
+	GetBlockedUsers(ctx context.Context) ([]*model.User, error)
+	SubscribeFriendshipRequestReceived(ctx context.Context) (<-chan *model.Friendship, error)
 	// DataLoader使用メソッド
-	GetFriendsWithDataLoader(ctx context.Context, userID string) ([]*model.User, error)
+	GetFriendsWithDataLoader(ctx context.Context, userID string, first *int32, after *string) (*model.UserConnection, error)
 	GetFriendshipRequestsWithDataLoader(ctx context.Context, userID string) ([]*model.Friendship, error)
-	GetRecommendedUsersWithDataLoader(ctx context.Context, userID string) ([]*model.User, error)
+	GetRecommendedUsersWithDataLoader(ctx context.Context, userID string, first *int32, after *string) (*model.UserConnection, error)
 }
 
 type friendshipService struct {
-	repo      FriendshipRepository
-	converter *FriendshipConverter
-	common    common.CommonRepository
+	repo       FriendshipRepository
+	converter  *FriendshipConverter
+	common     common.CommonRepository
+	events     *event.Bus
+	notifier   *notification.Notifier
+	qrSigner   *friendqr.Signer
+	dataLoader *FriendshipDataLoader // DataLoaderを統合
 }
 
-func NewFriendshipService(repo FriendshipRepository, converter *FriendshipConverter) FriendshipService {
+func NewFriendshipService(repo FriendshipRepository, converter *FriendshipConverter, dataLoader *FriendshipDataLoader, events *event.Bus, qrSigner *friendqr.Signer) FriendshipService {
 	return &friendshipService{
-		repo:      repo,
-		converter: converter,
-		common:    common.NewCommonRepository(repo.GetDB()),
+		repo:       repo,
+		converter:  converter,
+		common:     common.NewCommonRepository(repo.GetDB()),
+		events:     events,
+		notifier:   notification.NewNotifier(repo.GetDB()),
+		qrSigner:   qrSigner,
+		dataLoader: dataLoader,
 	}
 }
 
@@ -70,6 +91,8 @@ func (s *friendshipService) GetRecommendedUsers(ctx context.Context, userID stri
 	return s.converter.ToModelUsersFromPointers(users), nil
 }
 
+// SendFriendshipRequest は友達リクエストを送る
+// 拒否されたリクエストはFriendshipRequestCooldownが経過した後に送り直せる
 func (s *friendshipService) SendFriendshipRequest(ctx context.Context, input model.SendFriendshipRequest) (*model.Friendship, error) {
 	// 現在のユーザーを取得
 	currentUser, err := s.common.GetCurrentUser(ctx)
@@ -77,22 +100,63 @@ func (s *friendshipService) SendFriendshipRequest(ctx context.Context, input mod
 		return nil, fmt.Errorf("failed to get current user: %w", err)
 	}
 
-	requesteeID, err := strconv.ParseUint(input.RequesteeID, 10, 32)
+	requesteeID, err := parseUserID(input.RequesteeID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid requestee ID: %s", input.RequesteeID)
+		return nil, err
+	}
+	if currentUser.ID == requesteeID {
+		return nil, fmt.Errorf("cannot send friendship request to yourself")
+	}
+	if err := s.checkNotBlocked(ctx, currentUser.ID, requesteeID); err != nil {
+		return nil, err
 	}
 
-	friendship := entity.Friendship{
-		RequesterID: currentUser.ID,
-		RequesteeID: uint(requesteeID),
-		Status:      string(entity.Pending),
+	friendship, err := s.repo.GetFriendshipBetween(ctx, currentUser.ID, requesteeID)
+	if err != nil {
+		return nil, err
 	}
 
-	if err := s.repo.CreateFriendship(ctx, &friendship); err != nil {
-		return nil, fmt.Errorf("failed to create friendship: %w", err)
+	if friendship == nil {
+		friendship = &entity.Friendship{
+			RequesterID: currentUser.ID,
+			RequesteeID: requesteeID,
+			Status:      string(entity.Pending),
+		}
+		if err := s.repo.CreateFriendship(ctx, friendship); err != nil {
+			if errors.Is(err, ErrFriendshipExists) {
+				// 同時に相手からもリクエストが送られた場合
+				return nil, fmt.Errorf("friendship request already exists")
+			}
+			return nil, fmt.Errorf("failed to create friendship: %w", err)
+		}
+	} else {
+		switch friendship.Status {
+		case string(entity.Accepted):
+			return nil, fmt.Errorf("already friends")
+		case string(entity.Pending):
+			if friendship.RequesterID == currentUser.ID {
+				return nil, fmt.Errorf("friendship request already sent")
+			}
+			// 相手からのリクエストが保留中の場合は承認する
+			return s.acceptFriendship(ctx, friendship)
+		}
+
+		if err := friendship.Resend(currentUser.ID, time.Now()); err != nil {
+			return nil, err
+		}
+		if err := s.repo.UpdateFriendship(ctx, friendship); err != nil {
+			return nil, fmt.Errorf("failed to update friendship: %w", err)
+		}
 	}
 
-	return s.converter.ToModelFriendship(friendship), nil
+	s.notifier.Notify(ctx, entity.NewFriendRequestReceivedNotification(*friendship))
+	s.events.PublishFriendshipRequested(ctx, event.FriendshipRequested{
+		FriendshipID: friendship.ID,
+		RequesterID:  friendship.RequesterID,
+		RequesteeID:  friendship.RequesteeID,
+	})
+
+	return s.converter.ToModelFriendship(*friendship), nil
 }
 
 func (s *friendshipService) AcceptFriendshipRequest(ctx context.Context, input model.AcceptFriendshipRequest) (*model.Friendship, error) {
@@ -108,13 +172,7 @@ func (s *friendshipService) AcceptFriendshipRequest(ctx context.Context, input m
 		return nil, err
 	}
 
-	// ステータスをAcceptedに更新
-	friendRequest.Status = string(entity.Accepted)
-	if err := s.repo.UpdateFriendship(ctx, friendRequest); err != nil {
-		return nil, fmt.Errorf("failed to update friendship: %w", err)
-	}
-
-	return s.converter.ToModelFriendship(*friendRequest), nil
+	return s.acceptFriendship(ctx, friendRequest)
 }
 
 func (s *friendshipService) RejectFriendshipRequest(ctx context.Context, input model.RejectFriendshipRequest) (*model.Friendship, error) {
@@ -131,7 +189,9 @@ func (s *friendshipService) RejectFriendshipRequest(ctx context.Context, input m
 	}
 
 	// ステータスをRejectedに更新
-	friendRequest.Status = string(entity.Rejected)
+	if err := friendRequest.Reject(time.Now()); err != nil {
+		return nil, err
+	}
 	if err := s.repo.UpdateFriendship(ctx, friendRequest); err != nil {
 		return nil, fmt.Errorf("failed to update friendship: %w", err)
 	}
@@ -139,6 +199,23 @@ func (s *friendshipService) RejectFriendshipRequest(ctx context.Context, input m
 	return s.converter.ToModelFriendship(*friendRequest), nil
 }
 
+// GenerateFriendQRToken は友達追加用のQRコードに埋め込むトークンを発行する
+// トークンはfriendqr.TokenTTLで失効し、singleUseの場合は1回だけ使える
+func (s *friendshipService) GenerateFriendQRToken(ctx context.Context, singleUse bool) (*model.FriendQRToken, error) {
+	currentUser, err := s.common.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	token, claims, err := s.qrSigner.Issue(currentUser.ID, singleUse)
+	if err != nil {
+		return nil, err
+	}
+	return s.converter.ToModelFriendQRToken(token, claims), nil
+}
+
+// AddFriendByQRCode はQRコードのトークンを表示したユーザーと友達になる
+// QRコードの表示を相手の同意とみなし、保留中・拒否済みのリクエストがあっても承認する
 func (s *friendshipService) AddFriendByQRCode(ctx context.Context, input model.AddFriendByQRCode) (*model.Friendship, error) {
 	// 現在のユーザーを取得
 	currentUser, err := s.common.GetCurrentUser(ctx)
@@ -146,50 +223,229 @@ func (s *friendshipService) AddFriendByQRCode(ctx context.Context, input model.A
 		return nil, fmt.Errorf("failed to get current user: %w", err)
 	}
 
-	targetUserID, err := strconv.ParseUint(input.TargetUserID, 10, 32)
+	claims, err := s.qrSigner.Verify(input.Token)
 	if err != nil {
-		return nil, fmt.Errorf("invalid target user ID: %s", input.TargetUserID)
+		return nil, err
 	}
+	ownerID := claims.UserID
 
 	// 自分自身との友達関係は作成しない
-	if currentUser.ID == uint(targetUserID) {
+	if currentUser.ID == ownerID {
 		return nil, fmt.Errorf("cannot add yourself as a friend")
 	}
+	if err := s.checkNotBlocked(ctx, currentUser.ID, ownerID); err != nil {
+		return nil, err
+	}
 
-	// 既存の友達関係をチェック
-	var existingFriendship entity.Friendship
-	if err := s.repo.GetDB().Where(
-		"(requester_id = ? AND requestee_id = ?) OR (requester_id = ? AND requestee_id = ?)",
-		currentUser.ID, uint(targetUserID), uint(targetUserID), currentUser.ID,
-	).First(&existingFriendship).Error; err == nil {
-		// 既存の友達関係が存在する場合
-		if existingFriendship.Status == string(entity.Accepted) {
-			return nil, fmt.Errorf("already friends")
-		} else if existingFriendship.Status == string(entity.Pending) {
-			// 保留中のリクエストがある場合は承認する
-			existingFriendship.Status = string(entity.Accepted)
-			if err := s.repo.UpdateFriendship(ctx, &existingFriendship); err != nil {
-				return nil, fmt.Errorf("failed to update friendship: %w", err)
-			}
-			return s.converter.ToModelFriendship(existingFriendship), nil
+	friendship, err := s.repo.GetFriendshipBetween(ctx, currentUser.ID, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	if friendship != nil && friendship.Status == string(entity.Accepted) {
+		return nil, fmt.Errorf("already friends")
+	}
+
+	var use *entity.FriendQRTokenUse
+	if claims.SingleUse {
+		use = &entity.FriendQRTokenUse{
+			TokenID:   claims.TokenID,
+			IssuerID:  ownerID,
+			UsedByID:  currentUser.ID,
+			ExpiresAt: claims.ExpiresAt,
 		}
 	}
 
-	// 新しい友達関係を作成（直接Acceptedステータスで）
-	friendship := entity.Friendship{
-		RequesterID: currentUser.ID,
-		RequesteeID: uint(targetUserID),
-		Status:      string(entity.Accepted),
+	switch {
+	case friendship == nil:
+		// QRコードを表示したユーザーからのリクエストを読み取ったユーザーが承認した扱いにする
+		friendship = &entity.Friendship{RequesterID: ownerID, RequesteeID: currentUser.ID}
+	case friendship.Status == string(entity.Rejected):
+		if err := friendship.Reopen(ownerID); err != nil {
+			return nil, err
+		}
+	}
+
+	// 友達関係を作成できなかった場合にトークンだけが使用済みにならないよう、同じトランザクションで記録する
+	if err := s.repo.AddFriendByQRCode(ctx, friendship, use); err != nil {
+		switch {
+		case errors.Is(err, ErrFriendQRTokenUsed):
+			return nil, fmt.Errorf("QR code has already been used")
+		case errors.Is(err, ErrFriendshipExists):
+			return nil, fmt.Errorf("friendship request already exists")
+		}
+		return nil, fmt.Errorf("failed to add friend: %w", err)
+	}
+
+	s.notifier.Notify(ctx, entity.NewFriendRequestAcceptedNotification(*friendship))
+
+	return s.converter.ToModelFriendship(*friendship), nil
+}
+
+// CancelFriendshipRequest は自分が送った保留中の友達リクエストを取り消す
+func (s *friendshipService) CancelFriendshipRequest(ctx context.Context, input model.CancelFriendshipRequest) (bool, error) {
+	currentUser, err := s.common.GetCurrentUser(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	friendship, err := s.repo.GetFriendshipByID(ctx, input.FriendshipID)
+	if err != nil {
+		return false, err
+	}
+	if friendship.RequesterID != currentUser.ID || friendship.Status != string(entity.Pending) {
+		return false, fmt.Errorf("friendship request not found")
+	}
+
+	if err := s.repo.DeleteFriendship(ctx, friendship); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// RemoveFriend は友達関係を解消する（どちらからでも解消でき、再度リクエストを送れる）
+func (s *friendshipService) RemoveFriend(ctx context.Context, input model.RemoveFriend) (bool, error) {
+	currentUser, err := s.common.GetCurrentUser(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to get current user: %w", err)
 	}
 
-	if err := s.repo.CreateFriendship(ctx, &friendship); err != nil {
-		return nil, fmt.Errorf("failed to create friendship: %w", err)
+	friendID, err := parseUserID(input.UserID)
+	if err != nil {
+		return false, err
 	}
 
-	return s.converter.ToModelFriendship(friendship), nil
+	friendship, err := s.repo.GetFriendshipBetween(ctx, currentUser.ID, friendID)
+	if err != nil {
+		return false, err
+	}
+	if friendship == nil || friendship.Status != string(entity.Accepted) {
+		return false, fmt.Errorf("not friends with user: %s", input.UserID)
+	}
+
+	if err := s.repo.DeleteFriendship(ctx, friendship); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// BlockUser はユーザーをブロックする
+// 友達関係・友達リクエスト・2人の間のグループへの招待は取り消される
+func (s *friendshipService) BlockUser(ctx context.Context, input model.BlockUser) (bool, error) {
+	currentUser, err := s.common.GetCurrentUser(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	blockedID, err := parseUserID(input.UserID)
+	if err != nil {
+		return false, err
+	}
+
+	block := &entity.UserBlock{BlockerID: currentUser.ID, BlockedID: blockedID}
+	if err := s.repo.BlockUser(ctx, block); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// UnblockUser はブロックを解除する（友達関係は元に戻らない）
+// ブロックしていなかった場合はfalseを返す
+func (s *friendshipService) UnblockUser(ctx context.Context, input model.UnblockUser) (bool, error) {
+	currentUser, err := s.common.GetCurrentUser(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	blockedID, err := parseUserID(input.UserID)
+	if err != nil {
+		return false, err
+	}
+
+	return s.repo.UnblockUser(ctx, currentUser.ID, blockedID)
+}
+
+// GetBlockedUsers は現在のユーザーがブロックしているユーザーを取得
+func (s *friendshipService) GetBlockedUsers(ctx context.Context) ([]*model.User, error) {
+	currentUser, err := s.common.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	users, err := s.repo.GetBlockedUsers(ctx, currentUser.ID)
+	if err != nil {
+		return nil, err
+	}
+	return s.converter.ToModelUsersFromPointers(users), nil
+}
+
+// SubscribeFriendshipRequestReceived は現在のユーザー宛ての友達リクエストを購読する
+func (s *friendshipService) SubscribeFriendshipRequestReceived(ctx context.Context) (<-chan *model.Friendship, error) {
+	currentUser, err := s.common.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	events, err := s.events.SubscribeFriendshipRequested(ctx, currentUser.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	friendships := make(chan *model.Friendship)
+	go func() {
+		defer close(friendships)
+		for requested := range events {
+			// 配信までの間に取り消された・承認されたリクエストは送らない
+			friendship, err := s.repo.GetFriendshipByID(ctx, strconv.FormatUint(uint64(requested.FriendshipID), 10))
+			if err != nil || friendship.Status != string(entity.Pending) {
+				continue
+			}
+
+			select {
+			case friendships <- s.converter.ToModelFriendship(*friendship):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return friendships, nil
 }
 
 // ヘルパー関数
+// acceptFriendship は保留中の友達リクエストを承認し、申請者に通知する
+func (s *friendshipService) acceptFriendship(ctx context.Context, friendship *entity.Friendship) (*model.Friendship, error) {
+	if err := friendship.Accept(); err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateFriendship(ctx, friendship); err != nil {
+		return nil, fmt.Errorf("failed to update friendship: %w", err)
+	}
+
+	s.notifier.Notify(ctx, entity.NewFriendRequestAcceptedNotification(*friendship))
+
+	return s.converter.ToModelFriendship(*friendship), nil
+}
+
+// checkNotBlocked はどちらかのユーザーがもう一方をブロックしている場合にエラーを返す
+func (s *friendshipService) checkNotBlocked(ctx context.Context, userID, otherUserID uint) error {
+	blocked, err := s.repo.IsBlockedBetween(ctx, userID, otherUserID)
+	if err != nil {
+		return err
+	}
+	if blocked {
+		return errcode.NewForbidden(ctx, "cannot send friendship request to this user")
+	}
+	return nil
+}
+
+func parseUserID(userID string) (uint, error) {
+	id, err := strconv.ParseUint(userID, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid user ID: %s", userID)
+	}
+	return uint(id), nil
+}
+
 func (s *friendshipService) getFriendshipRequest(ctx context.Context, currentUser *entity.User, friendshipID string) (*entity.Friendship, error) {
 	request := currentUser.GetFriendshipRequest(s.repo.GetDB(), friendshipID)
 	if request == nil {
@@ -199,10 +455,17 @@ func (s *friendshipService) getFriendshipRequest(ctx context.Context, currentUse
 }
 
 // DataLoader使用メソッド
-func (s *friendshipService) GetFriendsWithDataLoader(ctx context.Context, userID string) ([]*model.User, error) {
-	// contextからDataLoadersを取得する必要があるが、循環インポートを避けるため
-	// 現在は既存の実装を使用
-	return s.GetFriends(ctx, userID)
+func (s *friendshipService) GetFriendsWithDataLoader(ctx context.Context, userID string, first *int32, after *string) (*model.UserConnection, error) {
+	args, err := common.NewPageArgs(first, after)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := s.dataLoader.LoadFriendPageByUserID(ctx, userID, args)
+	if err != nil {
+		return nil, err
+	}
+	return s.converter.ToModelUserConnection(page, args), nil
 }
 
 func (s *friendshipService) GetFriendshipRequestsWithDataLoader(ctx context.Context, userID string) ([]*model.Friendship, error) {
@@ -210,7 +473,15 @@ func (s *friendshipService) GetFriendshipRequestsWithDataLoader(ctx context.Cont
 	return s.GetFriendshipRequests(ctx, userID)
 }
 
-func (s *friendshipService) GetRecommendedUsersWithDataLoader(ctx context.Context, userID string) ([]*model.User, error) {
-	// 現在は既存の実装を使用
-	return s.GetRecommendedUsers(ctx, userID)
+func (s *friendshipService) GetRecommendedUsersWithDataLoader(ctx context.Context, userID string, first *int32, after *string) (*model.UserConnection, error) {
+	args, err := common.NewPageArgs(first, after)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := s.dataLoader.LoadRecommendedByUserID(ctx, userID, args)
+	if err != nil {
+		return nil, err
+	}
+	return s.converter.ToModelUserConnection(page, args), nil
 }
@@ -0,0 +1,95 @@
+package friendship
+
+import (
+	"app/entity"
+	"app/graph/services/common"
+	"app/graph/services/common/base"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeFriendshipRepository はユーザーID順に並んだユーザーからページを作成し、受け取ったページ引数を保持する
+type fakeFriendshipRepository struct {
+	FriendshipRepository
+	friends     map[uint][]uint
+	recommended map[uint][]uint
+	args        []base.PageArgs
+}
+
+func (r *fakeFriendshipRepository) GetFriendPagesByUserIDs(userIDs []uint, args base.PageArgs) (map[uint]*base.Page[entity.User], error) {
+	return r.pages(r.friends, userIDs, args), nil
+}
+
+func (r *fakeFriendshipRepository) GetRecommendedUserPagesByUserIDs(userIDs []uint, args base.PageArgs) (map[uint]*base.Page[entity.User], error) {
+	return r.pages(r.recommended, userIDs, args), nil
+}
+
+func (r *fakeFriendshipRepository) pages(users map[uint][]uint, userIDs []uint, args base.PageArgs) map[uint]*base.Page[entity.User] {
+	r.args = append(r.args, args)
+	result := make(map[uint]*base.Page[entity.User])
+	for _, userID := range userIDs {
+		page := &base.Page[entity.User]{Items: []*entity.User{}, TotalCount: int64(len(users[userID]))}
+		for _, id := range users[userID] {
+			if id <= args.After {
+				continue
+			}
+			user := &entity.User{}
+			user.ID = id
+			page.Append(user, args.First)
+		}
+		result[userID] = page
+	}
+	return result
+}
+
+func TestFriendshipService_UserConnections(t *testing.T) {
+	repo := &fakeFriendshipRepository{
+		friends:     map[uint][]uint{1: {2, 3, 4}},
+		recommended: map[uint][]uint{1: {5, 6}},
+	}
+	service := &friendshipService{
+		repo:       repo,
+		converter:  NewFriendshipConverter(),
+		dataLoader: NewFriendshipDataLoader(repo),
+	}
+	ctx := context.Background()
+	int32Ptr := func(v int32) *int32 { return &v }
+
+	t.Run("Friends are paged by the repository", func(t *testing.T) {
+		after := common.EncodeCursor(2)
+		connection, err := service.GetFriendsWithDataLoader(ctx, "1", int32Ptr(1), &after)
+		assert.NoError(t, err)
+		assert.Equal(t, base.PageArgs{First: 1, After: 2}, repo.args[len(repo.args)-1])
+		assert.Len(t, connection.Edges, 1)
+		assert.Equal(t, "3", connection.Edges[0].Node.ID)
+		assert.True(t, connection.PageInfo.HasNextPage)
+		assert.True(t, connection.PageInfo.HasPreviousPage)
+		assert.Equal(t, int32(3), connection.TotalCount)
+	})
+
+	t.Run("Zero page size returns only the total count", func(t *testing.T) {
+		connection, err := service.GetRecommendedUsersWithDataLoader(ctx, "1", int32Ptr(0), nil)
+		assert.NoError(t, err)
+		assert.Equal(t, base.PageArgs{First: 0}, repo.args[len(repo.args)-1])
+		assert.Empty(t, connection.Edges)
+		assert.True(t, connection.PageInfo.HasNextPage)
+		assert.Equal(t, int32(2), connection.TotalCount)
+	})
+
+	t.Run("Negative page size is rejected", func(t *testing.T) {
+		calls := len(repo.args)
+		_, err := service.GetFriendsWithDataLoader(ctx, "1", int32Ptr(-1), nil)
+		assert.Error(t, err)
+		assert.Len(t, repo.args, calls)
+	})
+
+	t.Run("User without friends gets an empty page", func(t *testing.T) {
+		connection, err := service.GetFriendsWithDataLoader(ctx, "9", nil, nil)
+		assert.NoError(t, err)
+		assert.Empty(t, connection.Edges)
+		assert.False(t, connection.PageInfo.HasNextPage)
+		assert.Equal(t, int32(0), connection.TotalCount)
+	})
+}
@@ -0,0 +1,84 @@
+package notification
+
+import (
+	"app/entity"
+	"app/graph/model"
+	"app/graph/services/common"
+	"app/graph/services/common/base"
+	"fmt"
+)
+
+type NotificationConverter struct{}
+
+func NewNotificationConverter() *NotificationConverter {
+	return &NotificationConverter{}
+}
+
+func (c *NotificationConverter) ToModelNotification(notification entity.Notification) *model.Notification {
+	formatID := func(id *uint) *string {
+		if id == nil {
+			return nil
+		}
+		formatted := fmt.Sprintf("%d", *id)
+		return &formatted
+	}
+
+	result := &model.Notification{
+		ID:               fmt.Sprintf("%d", notification.ID),
+		Type:             notification.TypeToGraphQL(),
+		ActorID:          formatID(notification.ActorID),
+		FriendshipID:     formatID(notification.FriendshipID),
+		WorkoutGroupID:   formatID(notification.WorkoutGroupID),
+		PersonalRecordID: formatID(notification.PersonalRecordID),
+		Read:             notification.IsRead(),
+		CreatedAt:        notification.CreatedAt.Format(common.TimeFormat),
+	}
+	if notification.ReadAt != nil {
+		readAt := notification.ReadAt.Format(common.TimeFormat)
+		result.ReadAt = &readAt
+	}
+	return result
+}
+
+// ToModelNotificationConnection は通知のページをRelay形式のConnectionに変換します
+func (c *NotificationConverter) ToModelNotificationConnection(page *base.Page[entity.Notification], args base.PageArgs) *model.NotificationConnection {
+	edges := make([]*model.NotificationEdge, 0, len(page.Items))
+	cursors := make([]string, 0, len(page.Items))
+	for _, notification := range page.Items {
+		if notification == nil {
+			continue
+		}
+		cursor := common.EncodeCursor(notification.ID)
+		edges = append(edges, &model.NotificationEdge{
+			Cursor: cursor,
+			Node:   c.ToModelNotification(*notification),
+		})
+		cursors = append(cursors, cursor)
+	}
+
+	return &model.NotificationConnection{
+		Edges:      edges,
+		PageInfo:   common.ToModelPageInfo(cursors, page.HasNextPage, args),
+		TotalCount: int32(page.TotalCount),
+	}
+}
+
+func (c *NotificationConverter) ToModelNotificationPreferences(preference entity.NotificationPreference) *model.NotificationPreferences {
+	formatMinute := func(minute *int) *string {
+		if minute == nil {
+			return nil
+		}
+		formatted := entity.FormatMinuteOfDay(*minute)
+		return &formatted
+	}
+
+	return &model.NotificationPreferences{
+		PushEnabled:           preference.PushEnabled,
+		FriendRequestReceived: preference.FriendRequestReceived,
+		FriendRequestAccepted: preference.FriendRequestAccepted,
+		WorkoutGroupInvited:   preference.WorkoutGroupInvited,
+		QuietHoursStart:       formatMinute(preference.QuietHoursStart),
+		QuietHoursEnd:         formatMinute(preference.QuietHoursEnd),
+		TimeZone:              preference.TimeZone,
+	}
+}
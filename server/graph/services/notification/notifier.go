@@ -0,0 +1,37 @@
+package notification
+
+import (
+	"app/entity"
+	"context"
+	"log"
+
+	"gorm.io/gorm"
+)
+
+// Notifier は他のサービスから通知を記録する
+// 通知の記録に失敗しても元の操作は失敗させないようにログに残すだけにする
+type Notifier struct {
+	repo NotificationRepository
+}
+
+func NewNotifier(db *gorm.DB) *Notifier {
+	return &Notifier{repo: NewNotificationRepository(db)}
+}
+
+// Notify は通知を記録する
+func (n *Notifier) Notify(ctx context.Context, notifications ...entity.Notification) {
+	if err := n.repo.CreateNotifications(ctx, notifications); err != nil {
+		log.Printf("failed to create notifications: %v", err)
+	}
+}
+
+// NotifyPersonalRecord は自己ベストの更新をユーザーの友達に通知する
+func (n *Notifier) NotifyPersonalRecord(ctx context.Context, record entity.PersonalRecord) {
+	friendIDs, err := n.repo.GetFriendIDs(ctx, record.UserID)
+	if err != nil {
+		log.Printf("failed to notify personal record: %v", err)
+		return
+	}
+
+	n.Notify(ctx, entity.NewFriendPersonalRecordNotifications(record, friendIDs)...)
+}
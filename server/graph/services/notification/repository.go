@@ -0,0 +1,135 @@
+package notification
+
+import (
+	"app/entity"
+	"app/graph/services/common/base"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type NotificationRepository interface {
+	GetNotificationPage(ctx context.Context, userID uint, unreadOnly bool, args base.PageArgs) (*base.Page[entity.Notification], error)
+	CountUnread(ctx context.Context, userID uint) (int64, error)
+	CreateNotifications(ctx context.Context, notifications []entity.Notification) error
+	MarkRead(ctx context.Context, userID uint, notificationIDs []uint, readAt time.Time) error
+	GetFriendIDs(ctx context.Context, userID uint) ([]uint, error)
+	GetPreference(ctx context.Context, userID uint) (*entity.NotificationPreference, error)
+	SavePreference(ctx context.Context, preference *entity.NotificationPreference) error
+}
+
+type notificationRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationRepository(db *gorm.DB) NotificationRepository {
+	return &notificationRepository{db: db}
+}
+
+// GetNotificationPage はユーザーの通知を新しい順でページ取得
+func (r *notificationRepository) GetNotificationPage(ctx context.Context, userID uint, unreadOnly bool, args base.PageArgs) (*base.Page[entity.Notification], error) {
+	query := r.db.WithContext(ctx).Model(&entity.Notification{}).Where("user_id = ?", userID)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+
+	page := &base.Page[entity.Notification]{Items: []*entity.Notification{}}
+	if err := query.Session(&gorm.Session{}).Count(&page.TotalCount).Error; err != nil {
+		return nil, fmt.Errorf("failed to count notifications: %w", err)
+	}
+
+	if args.After != 0 {
+		query = query.Where("id < ?", args.After)
+	}
+
+	// 1件多く取得して次ページの有無を判定
+	var notifications []*entity.Notification
+	if err := query.Order("id DESC").Limit(args.First + 1).Find(&notifications).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch notifications: %w", err)
+	}
+
+	for _, notification := range notifications {
+		page.Append(notification, args.First)
+	}
+
+	return page, nil
+}
+
+// CountUnread はユーザーの未読の通知の件数を取得
+func (r *notificationRepository) CountUnread(ctx context.Context, userID uint) (int64, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&entity.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count unread notifications: %w", err)
+	}
+	return count, nil
+}
+
+// CreateNotifications は通知を作成し、プッシュ通知でも送る種類の通知は送信待ちに登録する
+func (r *notificationRepository) CreateNotifications(ctx context.Context, notifications []entity.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&notifications).Error; err != nil {
+			return err
+		}
+
+		deliveries := entity.NewPushDeliveries(notifications, time.Now())
+		if len(deliveries) == 0 {
+			return nil
+		}
+		return tx.Create(&deliveries).Error
+	})
+}
+
+// MarkRead はユーザーの未読の通知を既読にする（notificationIDsがnilの場合は全て）
+func (r *notificationRepository) MarkRead(ctx context.Context, userID uint, notificationIDs []uint, readAt time.Time) error {
+	query := r.db.WithContext(ctx).Model(&entity.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID)
+	if notificationIDs != nil {
+		query = query.Where("id IN ?", notificationIDs)
+	}
+
+	if err := query.Update("read_at", readAt).Error; err != nil {
+		return fmt.Errorf("failed to mark notifications as read: %w", err)
+	}
+	return nil
+}
+
+// GetFriendIDs はユーザーの友達（承認済み）のIDを取得
+func (r *notificationRepository) GetFriendIDs(ctx context.Context, userID uint) ([]uint, error) {
+	var friendIDs []uint
+	if err := r.db.WithContext(ctx).Model(&entity.Friendship{}).
+		Select("CASE WHEN requester_id = ? THEN requestee_id ELSE requester_id END AS friend_id", userID).
+		Where("(requester_id = ? OR requestee_id = ?) AND status = ?", userID, userID, string(entity.Accepted)).
+		Pluck("friend_id", &friendIDs).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch friend IDs: %w", err)
+	}
+	return friendIDs, nil
+}
+
+// GetPreference はユーザーのプッシュ通知の設定を取得（保存されていない場合はデフォルトの設定）
+func (r *notificationRepository) GetPreference(ctx context.Context, userID uint) (*entity.NotificationPreference, error) {
+	var preference entity.NotificationPreference
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).First(&preference).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		preference = entity.DefaultNotificationPreference(userID)
+		return &preference, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch notification preference: %w", err)
+	}
+	return &preference, nil
+}
+
+func (r *notificationRepository) SavePreference(ctx context.Context, preference *entity.NotificationPreference) error {
+	if err := r.db.WithContext(ctx).Save(preference).Error; err != nil {
+		return fmt.Errorf("failed to save notification preference: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package notification
+
+import (
+	"app/entity"
+	"app/graph/model"
+	"app/graph/services/common"
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+type NotificationService interface {
+	GetNotifications(ctx context.Context, unreadOnly bool, first *int32, after *string) (*model.NotificationConnection, error)
+	GetUnreadCount(ctx context.Context) (int32, error)
+	MarkNotificationsRead(ctx context.Context, input model.MarkNotificationsRead) (int32, error)
+	GetPreferences(ctx context.Context) (*model.NotificationPreferences, error)
+	UpdatePreferences(ctx context.Context, input model.UpdateNotificationPreferences) (*model.NotificationPreferences, error)
+}
+
+type notificationService struct {
+	repo      NotificationRepository
+	converter *NotificationConverter
+	common    common.CommonRepository
+}
+
+func NewNotificationService(repo NotificationRepository, converter *NotificationConverter) NotificationService {
+	return &notificationService{
+		repo:      repo,
+		converter: converter,
+		common:    common.NewCommonRepository(repo.(*notificationRepository).db),
+	}
+}
+
+// GetNotifications は現在のユーザーの通知を新しい順で取得
+func (s *notificationService) GetNotifications(ctx context.Context, unreadOnly bool, first *int32, after *string) (*model.NotificationConnection, error) {
+	currentUser, err := s.common.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	args, err := common.NewPageArgs(first, after)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := s.repo.GetNotificationPage(ctx, currentUser.ID, unreadOnly, args)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.converter.ToModelNotificationConnection(page, args), nil
+}
+
+// GetUnreadCount は現在のユーザーの未読の通知の件数を取得
+func (s *notificationService) GetUnreadCount(ctx context.Context) (int32, error) {
+	currentUser, err := s.common.GetCurrentUser(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	count, err := s.repo.CountUnread(ctx, currentUser.ID)
+	if err != nil {
+		return 0, err
+	}
+	return int32(count), nil
+}
+
+// MarkNotificationsRead は通知を既読にし、残りの未読件数を返す（IDを省略した場合は全て既読にする）
+// 他のユーザーの通知のIDは無視する
+func (s *notificationService) MarkNotificationsRead(ctx context.Context, input model.MarkNotificationsRead) (int32, error) {
+	currentUser, err := s.common.GetCurrentUser(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	var notificationIDs []uint
+	if input.Ids != nil {
+		notificationIDs = make([]uint, 0, len(input.Ids))
+		for _, id := range input.Ids {
+			notificationID, err := strconv.ParseUint(id, 10, 32)
+			if err != nil {
+				return 0, fmt.Errorf("invalid notification ID: %s", id)
+			}
+			notificationIDs = append(notificationIDs, uint(notificationID))
+		}
+	}
+
+	if err := s.repo.MarkRead(ctx, currentUser.ID, notificationIDs, time.Now()); err != nil {
+		return 0, err
+	}
+
+	count, err := s.repo.CountUnread(ctx, currentUser.ID)
+	if err != nil {
+		return 0, err
+	}
+	return int32(count), nil
+}
+
+// GetPreferences は現在のユーザーのプッシュ通知の設定を取得
+func (s *notificationService) GetPreferences(ctx context.Context) (*model.NotificationPreferences, error) {
+	currentUser, err := s.common.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	preference, err := s.repo.GetPreference(ctx, currentUser.ID)
+	if err != nil {
+		return nil, err
+	}
+	return s.converter.ToModelNotificationPreferences(*preference), nil
+}
+
+// UpdatePreferences は現在のユーザーのプッシュ通知の設定を更新する（指定した項目のみ）
+func (s *notificationService) UpdatePreferences(ctx context.Context, input model.UpdateNotificationPreferences) (*model.NotificationPreferences, error) {
+	currentUser, err := s.common.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	preference, err := s.repo.GetPreference(ctx, currentUser.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.PushEnabled != nil {
+		preference.PushEnabled = *input.PushEnabled
+	}
+	if input.FriendRequestReceived != nil {
+		preference.FriendRequestReceived = *input.FriendRequestReceived
+	}
+	if input.FriendRequestAccepted != nil {
+		preference.FriendRequestAccepted = *input.FriendRequestAccepted
+	}
+	if input.WorkoutGroupInvited != nil {
+		preference.WorkoutGroupInvited = *input.WorkoutGroupInvited
+	}
+	if input.TimeZone != nil {
+		preference.TimeZone = *input.TimeZone
+	}
+
+	if input.ClearQuietHours != nil && *input.ClearQuietHours {
+		if input.QuietHoursStart != nil || input.QuietHoursEnd != nil {
+			return nil, fmt.Errorf("cannot set and clear quiet hours at the same time")
+		}
+		preference.QuietHoursStart = nil
+		preference.QuietHoursEnd = nil
+	}
+	if input.QuietHoursStart != nil {
+		start, err := entity.ParseMinuteOfDay(*input.QuietHoursStart)
+		if err != nil {
+			return nil, err
+		}
+		preference.QuietHoursStart = &start
+	}
+	if input.QuietHoursEnd != nil {
+		end, err := entity.ParseMinuteOfDay(*input.QuietHoursEnd)
+		if err != nil {
+			return nil, err
+		}
+		preference.QuietHoursEnd = &end
+	}
+
+	if err := s.repo.SavePreference(ctx, preference); err != nil {
+		return nil, err
+	}
+	return s.converter.ToModelNotificationPreferences(*preference), nil
+}
@@ -0,0 +1,53 @@
+package personal_record
+
+import (
+	"app/entity"
+	"app/graph/model"
+	"app/graph/services/common"
+	"fmt"
+)
+
+type PersonalRecordConverter struct{}
+
+func NewPersonalRecordConverter() *PersonalRecordConverter {
+	return &PersonalRecordConverter{}
+}
+
+func (c *PersonalRecordConverter) ToModelPersonalRecord(record entity.PersonalRecord) *model.PersonalRecord {
+	var setLogID *string
+	if record.SetLogID != nil {
+		id := fmt.Sprintf("%d", *record.SetLogID)
+		setLogID = &id
+	}
+
+	return &model.PersonalRecord{
+		ID:         fmt.Sprintf("%d", record.ID),
+		Type:       record.TypeToGraphQL(),
+		ExerciseID: fmt.Sprintf("%d", record.ExerciseID),
+		WorkoutID:  fmt.Sprintf("%d", record.WorkoutID),
+		SetLogID:   setLogID,
+		Value:      record.Value,
+		WeightKg:   record.Weight,
+		RepCount:   int32(record.RepCount),
+		IsCurrent:  record.IsCurrent,
+		AchievedAt: record.AchievedAt.Format(common.TimeFormat),
+	}
+}
+
+func (c *PersonalRecordConverter) ToModelPersonalRecords(records []entity.PersonalRecord) []*model.PersonalRecord {
+	result := make([]*model.PersonalRecord, len(records))
+	for i, record := range records {
+		result[i] = c.ToModelPersonalRecord(record)
+	}
+	return result
+}
+
+func (c *PersonalRecordConverter) ToModelPersonalRecordsFromPointers(records []*entity.PersonalRecord) []*model.PersonalRecord {
+	result := make([]*model.PersonalRecord, len(records))
+	for i, record := range records {
+		if record != nil {
+			result[i] = c.ToModelPersonalRecord(*record)
+		}
+	}
+	return result
+}
@@ -0,0 +1,70 @@
+package personal_record
+
+import (
+	"app/entity"
+	"app/graph/services/common/base"
+	"context"
+)
+
+// PersonalRecordDataLoader は PersonalRecord エンティティの遅延ローディングを担当
+type PersonalRecordDataLoader struct {
+	repository            PersonalRecordRepository
+	byUserIDLoader        *base.BaseArrayLoader[entity.PersonalRecord]
+	historyByUserIDLoader *base.BaseArrayLoader[entity.PersonalRecord]
+}
+
+// NewPersonalRecordDataLoader は新しいDataLoaderを作成
+func NewPersonalRecordDataLoader(repository PersonalRecordRepository) *PersonalRecordDataLoader {
+	loader := &PersonalRecordDataLoader{
+		repository: repository,
+	}
+
+	// ByUserID用のローダー（現在の自己ベスト）
+	loader.byUserIDLoader = base.NewBaseArrayLoader(
+		nil, // dbは不要（repositoryを使用）
+		loader.fetchByUserIDs,
+		loader.createUserIDMap,
+		base.ParseUintKey,
+	)
+
+	// HistoryByUserID用のローダー（更新履歴を含む）
+	loader.historyByUserIDLoader = base.NewBaseArrayLoader(
+		nil, // dbは不要（repositoryを使用）
+		loader.fetchHistoriesByUserIDs,
+		loader.createUserIDMap,
+		base.ParseUintKey,
+	)
+
+	return loader
+}
+
+// LoadByUserID は指定されたUserIDの現在の自己ベストを取得
+func (l *PersonalRecordDataLoader) LoadByUserID(ctx context.Context, userID string) ([]*entity.PersonalRecord, error) {
+	return l.byUserIDLoader.Load(ctx, userID)
+}
+
+// LoadHistoryByUserID は指定されたUserIDの自己ベスト履歴を取得
+func (l *PersonalRecordDataLoader) LoadHistoryByUserID(ctx context.Context, userID string) ([]*entity.PersonalRecord, error) {
+	return l.historyByUserIDLoader.Load(ctx, userID)
+}
+
+// fetchByUserIDs はRepository経由でUserID別にデータを取得
+func (l *PersonalRecordDataLoader) fetchByUserIDs(userIDs []uint) ([]*entity.PersonalRecord, error) {
+	return l.repository.GetPersonalRecordsByUserIDs(userIDs)
+}
+
+// fetchHistoriesByUserIDs はRepository経由でUserID別に履歴を取得
+func (l *PersonalRecordDataLoader) fetchHistoriesByUserIDs(userIDs []uint) ([]*entity.PersonalRecord, error) {
+	return l.repository.GetPersonalRecordHistoriesByUserIDs(userIDs)
+}
+
+// createUserIDMap はUserID別にデータをマップ化
+func (l *PersonalRecordDataLoader) createUserIDMap(records []*entity.PersonalRecord) map[uint][]*entity.PersonalRecord {
+	result := make(map[uint][]*entity.PersonalRecord)
+	for _, record := range records {
+		if record != nil {
+			result[record.UserID] = append(result[record.UserID], record)
+		}
+	}
+	return result
+}
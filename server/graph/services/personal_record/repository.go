@@ -0,0 +1,258 @@
+package personal_record
+
+import (
+	"app/entity"
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type PersonalRecordRepository interface {
+	GetPersonalRecordByID(ctx context.Context, personalRecordID string) (*entity.PersonalRecord, error)
+	GetPersonalRecordsByUserIDAndExerciseID(ctx context.Context, userID, exerciseID uint, history bool) ([]*entity.PersonalRecord, error)
+	RecordSetLog(ctx context.Context, setLog *entity.SetLog) ([]*entity.PersonalRecord, error)
+	RebuildByWorkoutExerciseID(ctx context.Context, workoutExerciseID uint) error
+	RebuildPersonalRecords(ctx context.Context, userID, exerciseID uint) error
+	// Batch methods for DataLoader
+	GetPersonalRecordsByUserIDs(userIDs []uint) ([]*entity.PersonalRecord, error)
+	GetPersonalRecordHistoriesByUserIDs(userIDs []uint) ([]*entity.PersonalRecord, error)
+}
+
+type personalRecordRepository struct {
+	db *gorm.DB
+}
+
+func NewPersonalRecordRepository(db *gorm.DB) PersonalRecordRepository {
+	return &personalRecordRepository{db: db}
+}
+
+// recordTarget は自己ベストの集計対象（ユーザー・種目・ワークアウト）
+type recordTarget struct {
+	UserID       uint
+	ExerciseID   uint
+	WorkoutID    uint
+	TrackingType entity.TrackingType
+}
+
+// recordSetRow は自己ベスト再計算用のセット行
+type recordSetRow struct {
+	ID        uint
+	WorkoutID uint
+	Weight    float64
+	RepCount  int
+	CreatedAt time.Time
+}
+
+func (r *personalRecordRepository) GetPersonalRecordByID(ctx context.Context, personalRecordID string) (*entity.PersonalRecord, error) {
+	var record entity.PersonalRecord
+	if err := r.db.Where("id = ?", personalRecordID).First(&record).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch personal record: %w", err)
+	}
+
+	return &record, nil
+}
+
+func (r *personalRecordRepository) GetPersonalRecordsByUserIDAndExerciseID(ctx context.Context, userID, exerciseID uint, history bool) ([]*entity.PersonalRecord, error) {
+	query := r.db.Where("user_id = ? AND exercise_id = ?", userID, exerciseID)
+	if !history {
+		query = query.Where("is_current = ?", true)
+	}
+
+	var records []*entity.PersonalRecord
+	if err := query.Order("achieved_at DESC, id DESC").Find(&records).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch personal records: %w", err)
+	}
+
+	return records, nil
+}
+
+// RecordSetLog は作成されたセットを評価し、自己ベストを更新する（新たに達成した自己ベストを返す）
+// 予定のセット（未完了）と重量×レップ数で記録しない種目のセットは評価しない
+func (r *personalRecordRepository) RecordSetLog(ctx context.Context, setLog *entity.SetLog) ([]*entity.PersonalRecord, error) {
+	if !setLog.Completed {
+		return nil, nil
+	}
+
+	target, err := r.getTarget(setLog.WorkoutExerciseID)
+	if err != nil {
+		return nil, err
+	}
+	if !target.TrackingType.IsWeighted() {
+		return nil, nil
+	}
+
+	var currentRecords []*entity.PersonalRecord
+	if err := r.db.Where("user_id = ? AND exercise_id = ? AND is_current = ?", target.UserID, target.ExerciseID, true).
+		Find(&currentRecords).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch current personal records: %w", err)
+	}
+
+	// このセットを含む、同ワークアウト内の同種目の総ボリューム
+	var sessionVolume float64
+	if err := r.db.Model(&entity.SetLog{}).
+		Select("COALESCE(SUM(set_logs.weight * set_logs.rep_count), 0)").
+		Joins("inner join workout_exercises on workout_exercises.id = set_logs.workout_exercise_id").
+		Where("workout_exercises.workout_id = ? AND workout_exercises.exercise_id = ?", target.WorkoutID, target.ExerciseID).
+		Where("workout_exercises.deleted_at IS NULL").
+		Where("set_logs.completed = ?", true).
+		Scan(&sessionVolume).Error; err != nil {
+		return nil, fmt.Errorf("failed to calculate session volume: %w", err)
+	}
+
+	tracker := entity.NewPersonalRecordTracker(target.UserID, target.ExerciseID, currentRecords)
+	created, updated := tracker.Apply(entity.PersonalRecordSet{
+		SetLogID:      setLog.ID,
+		WorkoutID:     target.WorkoutID,
+		Weight:        setLog.Weight,
+		RepCount:      setLog.RepCount,
+		SessionVolume: sessionVolume,
+		AchievedAt:    setLog.CreatedAt,
+	})
+
+	for _, record := range updated {
+		if err := r.db.Save(record).Error; err != nil {
+			return nil, fmt.Errorf("failed to update personal record: %w", err)
+		}
+	}
+	if len(created) > 0 {
+		if err := r.db.Create(&created).Error; err != nil {
+			return nil, fmt.Errorf("failed to create personal records: %w", err)
+		}
+	}
+
+	return created, nil
+}
+
+// RebuildByWorkoutExerciseID は WorkoutExercise が属するユーザー・種目の自己ベストを再計算する
+func (r *personalRecordRepository) RebuildByWorkoutExerciseID(ctx context.Context, workoutExerciseID uint) error {
+	target, err := r.getTarget(workoutExerciseID)
+	if err != nil {
+		return err
+	}
+
+	return r.RebuildPersonalRecords(ctx, target.UserID, target.ExerciseID)
+}
+
+// RebuildPersonalRecords は残っている完了済みのセットを記録順に再評価し、履歴を含めて自己ベストを作り直す
+// 再評価しても変わらない記録は既存の行を更新し、無効になった記録のみ削除する
+func (r *personalRecordRepository) RebuildPersonalRecords(ctx context.Context, userID, exerciseID uint) error {
+	var existing []*entity.PersonalRecord
+	if err := r.db.Where("user_id = ? AND exercise_id = ?", userID, exerciseID).
+		Order("achieved_at ASC, id ASC").
+		Find(&existing).Error; err != nil {
+		return fmt.Errorf("failed to fetch personal records: %w", err)
+	}
+
+	rebuilt, err := r.rebuildRecords(userID, exerciseID)
+	if err != nil {
+		return err
+	}
+
+	created, updated, deleted := entity.SyncPersonalRecords(existing, rebuilt)
+
+	if len(deleted) > 0 {
+		deletedIDs := make([]uint, len(deleted))
+		for i, record := range deleted {
+			deletedIDs[i] = record.ID
+		}
+		if err := r.db.Unscoped().Where("id IN ?", deletedIDs).Delete(&entity.PersonalRecord{}).Error; err != nil {
+			return fmt.Errorf("failed to delete personal records: %w", err)
+		}
+	}
+	for _, record := range updated {
+		if err := r.db.Save(record).Error; err != nil {
+			return fmt.Errorf("failed to update personal record: %w", err)
+		}
+	}
+	if len(created) > 0 {
+		if err := r.db.Create(&created).Error; err != nil {
+			return fmt.Errorf("failed to create personal records: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// rebuildRecords は完了済みのセットを記録順に再評価した自己ベスト（履歴を含む）を返す
+func (r *personalRecordRepository) rebuildRecords(userID, exerciseID uint) ([]*entity.PersonalRecord, error) {
+	var exercise entity.Exercise
+	if err := r.db.Unscoped().Select("tracking_type").Where("id = ?", exerciseID).Take(&exercise).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch exercise %d: %w", exerciseID, err)
+	}
+	if !exercise.TrackingType.IsWeighted() {
+		return nil, nil
+	}
+
+	var rows []recordSetRow
+	if err := r.db.Model(&entity.SetLog{}).
+		Select("set_logs.id, workout_exercises.workout_id, set_logs.weight, set_logs.rep_count, set_logs.created_at").
+		Joins("inner join workout_exercises on workout_exercises.id = set_logs.workout_exercise_id").
+		Joins("inner join workouts on workouts.id = workout_exercises.workout_id").
+		Where("workouts.user_id = ? AND workout_exercises.exercise_id = ?", userID, exerciseID).
+		Where("workout_exercises.deleted_at IS NULL AND workouts.deleted_at IS NULL").
+		Where("set_logs.completed = ?", true).
+		Order("set_logs.created_at ASC, set_logs.id ASC").
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch set logs: %w", err)
+	}
+
+	sets := make([]entity.PersonalRecordSet, len(rows))
+	for i, row := range rows {
+		sets[i] = entity.PersonalRecordSet{
+			SetLogID:   row.ID,
+			WorkoutID:  row.WorkoutID,
+			Weight:     row.Weight,
+			RepCount:   row.RepCount,
+			AchievedAt: row.CreatedAt,
+		}
+	}
+
+	return entity.ReplayPersonalRecords(userID, exerciseID, sets), nil
+}
+
+func (r *personalRecordRepository) getTarget(workoutExerciseID uint) (*recordTarget, error) {
+	var target recordTarget
+	if err := r.db.Model(&entity.WorkoutExercise{}).
+		Select("workouts.user_id, workout_exercises.exercise_id, workout_exercises.workout_id, exercises.tracking_type").
+		Joins("inner join workouts on workouts.id = workout_exercises.workout_id").
+		Joins("inner join exercises on exercises.id = workout_exercises.exercise_id").
+		Where("workout_exercises.id = ?", workoutExerciseID).
+		Take(&target).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch workout exercise %d: %w", workoutExerciseID, err)
+	}
+
+	return &target, nil
+}
+
+// Batch methods for DataLoader
+func (r *personalRecordRepository) GetPersonalRecordsByUserIDs(userIDs []uint) ([]*entity.PersonalRecord, error) {
+	if len(userIDs) == 0 {
+		return []*entity.PersonalRecord{}, nil
+	}
+
+	var records []*entity.PersonalRecord
+	if err := r.db.Where("user_id IN ? AND is_current = ?", userIDs, true).
+		Order("exercise_id ASC, type ASC, weight ASC").
+		Find(&records).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch personal records by user IDs: %w", err)
+	}
+
+	return records, nil
+}
+
+func (r *personalRecordRepository) GetPersonalRecordHistoriesByUserIDs(userIDs []uint) ([]*entity.PersonalRecord, error) {
+	if len(userIDs) == 0 {
+		return []*entity.PersonalRecord{}, nil
+	}
+
+	var records []*entity.PersonalRecord
+	if err := r.db.Where("user_id IN ?", userIDs).
+		Order("achieved_at DESC, id DESC").
+		Find(&records).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch personal record histories by user IDs: %w", err)
+	}
+
+	return records, nil
+}
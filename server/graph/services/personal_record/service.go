@@ -0,0 +1,74 @@
+package personal_record
+
+import (
+	"app/graph/model"
+	"app/graph/services/common"
+	"context"
+	"fmt"
+	"strconv"
+)
+
+type PersonalRecordService interface {
+	GetPersonalRecordByID(ctx context.Context, personalRecordID string) (*model.PersonalRecord, error)
+	GetMyRecordsByExerciseID(ctx context.Context, exerciseID string, history bool) ([]*model.PersonalRecord, error)
+	// DataLoader使用メソッド
+	GetPersonalRecordsByUserIDWithDataLoader(ctx context.Context, userID string, history bool) ([]*model.PersonalRecord, error)
+}
+
+type personalRecordService struct {
+	repo       PersonalRecordRepository
+	converter  *PersonalRecordConverter
+	common     common.CommonRepository
+	dataLoader *PersonalRecordDataLoader // DataLoaderを統合
+}
+
+func NewPersonalRecordService(repo PersonalRecordRepository, converter *PersonalRecordConverter, dataLoader *PersonalRecordDataLoader) PersonalRecordService {
+	return &personalRecordService{
+		repo:       repo,
+		converter:  converter,
+		common:     common.NewCommonRepository(repo.(*personalRecordRepository).db),
+		dataLoader: dataLoader,
+	}
+}
+
+func (s *personalRecordService) GetPersonalRecordByID(ctx context.Context, personalRecordID string) (*model.PersonalRecord, error) {
+	record, err := s.repo.GetPersonalRecordByID(ctx, personalRecordID)
+	if err != nil {
+		return nil, err
+	}
+	return s.converter.ToModelPersonalRecord(*record), nil
+}
+
+// GetMyRecordsByExerciseID は現在のユーザーの指定種目の自己ベストを取得
+func (s *personalRecordService) GetMyRecordsByExerciseID(ctx context.Context, exerciseID string, history bool) ([]*model.PersonalRecord, error) {
+	currentUser, err := s.common.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	id, err := strconv.ParseUint(exerciseID, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid exercise ID: %s", exerciseID)
+	}
+
+	records, err := s.repo.GetPersonalRecordsByUserIDAndExerciseID(ctx, currentUser.ID, uint(id), history)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get personal records: %w", err)
+	}
+
+	return s.converter.ToModelPersonalRecordsFromPointers(records), nil
+}
+
+// DataLoader使用メソッド
+func (s *personalRecordService) GetPersonalRecordsByUserIDWithDataLoader(ctx context.Context, userID string, history bool) ([]*model.PersonalRecord, error) {
+	loadFunc := s.dataLoader.LoadByUserID
+	if history {
+		loadFunc = s.dataLoader.LoadHistoryByUserID
+	}
+
+	records, err := loadFunc(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.converter.ToModelPersonalRecordsFromPointers(records), nil
+}
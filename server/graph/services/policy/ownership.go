@@ -0,0 +1,167 @@
+package policy
+
+import (
+	"app/entity"
+	"app/graph/errcode"
+	"context"
+	"fmt"
+)
+
+// OwnershipPolicy はリソースの所有者・グループのメンバーかどうかで書き込みを許可する
+// 他のユーザーのリソースへの書き込みはFORBIDDENエラーで拒否する
+type OwnershipPolicy struct {
+	repo OwnershipRepository
+}
+
+func NewOwnershipPolicy(repo OwnershipRepository) *OwnershipPolicy {
+	return &OwnershipPolicy{repo: repo}
+}
+
+// AuthorizeUser は本人、またはユーザーを管理できるユーザー以外を拒否する
+func (p *OwnershipPolicy) AuthorizeUser(ctx context.Context, user *entity.User, targetUserID uint) error {
+	if user.ID == targetUserID {
+		return nil
+	}
+	return Authorize(ctx, user, ManageUsers)
+}
+
+// AuthorizeWorkout はワークアウトの所有者以外を拒否する
+func (p *OwnershipPolicy) AuthorizeWorkout(ctx context.Context, user *entity.User, workoutID uint) error {
+	ownerID, err := p.repo.GetWorkoutOwnerID(ctx, workoutID)
+	if err != nil {
+		return err
+	}
+	return authorizeOwner(ctx, user, ownerID, "workout")
+}
+
+// AuthorizeWorkoutExercise はワークアウト種目を含むワークアウトの所有者以外を拒否する
+func (p *OwnershipPolicy) AuthorizeWorkoutExercise(ctx context.Context, user *entity.User, workoutExerciseID uint) error {
+	ownerID, err := p.repo.GetWorkoutExerciseOwnerID(ctx, workoutExerciseID)
+	if err != nil {
+		return err
+	}
+	return authorizeOwner(ctx, user, ownerID, "workout exercise")
+}
+
+// AuthorizeSetLog はセットを含むワークアウトの所有者以外を拒否する
+func (p *OwnershipPolicy) AuthorizeSetLog(ctx context.Context, user *entity.User, setLogID uint) error {
+	ownerID, err := p.repo.GetSetLogOwnerID(ctx, setLogID)
+	if err != nil {
+		return err
+	}
+	return authorizeOwner(ctx, user, ownerID, "set log")
+}
+
+// AuthorizeWorkoutGroupMember はグループに参加中のメンバー以外を拒否する
+func (p *OwnershipPolicy) AuthorizeWorkoutGroupMember(ctx context.Context, user *entity.User, workoutGroupID uint) (*entity.WorkoutGroupMember, error) {
+	member, err := p.repo.GetWorkoutGroupMember(ctx, workoutGroupID, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if member == nil || !member.IsJoined() {
+		return nil, errcode.NewForbidden(ctx, "not a member of the workout group")
+	}
+	return member, nil
+}
+
+// AuthorizeManageWorkoutGroup はグループの作成者・管理者以外を拒否する
+func (p *OwnershipPolicy) AuthorizeManageWorkoutGroup(ctx context.Context, user *entity.User, workoutGroupID uint) (*entity.WorkoutGroupMember, error) {
+	member, err := p.AuthorizeWorkoutGroupMember(ctx, user, workoutGroupID)
+	if err != nil {
+		return nil, err
+	}
+	if !member.CanManageMembers() {
+		return nil, errcode.NewForbidden(ctx, "workout group admin role required")
+	}
+	return member, nil
+}
+
+// AuthorizeDeleteWorkoutGroup はグループの作成者、またはコンテンツを管理できるユーザー以外を拒否する
+func (p *OwnershipPolicy) AuthorizeDeleteWorkoutGroup(ctx context.Context, user *entity.User, workoutGroupID uint) error {
+	member, err := p.repo.GetWorkoutGroupMember(ctx, workoutGroupID, user.ID)
+	if err != nil {
+		return err
+	}
+	if member != nil && member.IsJoined() && member.Role == entity.WorkoutGroupMemberRoleOwner {
+		return nil
+	}
+	return Authorize(ctx, user, ModerateContent)
+}
+
+// AuthorizeInviteWorkoutGroupMember はグループにユーザーを招待できるか確認する
+// 招待できるのはグループの作成者・管理者のみで、招待されるユーザーは友達（相互に承認済み）に限る
+func (p *OwnershipPolicy) AuthorizeInviteWorkoutGroupMember(ctx context.Context, user *entity.User, workoutGroupID, inviteeID uint) error {
+	if _, err := p.AuthorizeManageWorkoutGroup(ctx, user, workoutGroupID); err != nil {
+		return err
+	}
+
+	if inviteeID == user.ID {
+		return fmt.Errorf("already a member of the workout group")
+	}
+
+	areFriends, err := p.repo.AreFriends(ctx, user.ID, inviteeID)
+	if err != nil {
+		return err
+	}
+	if !areFriends {
+		return errcode.NewForbidden(ctx, "only friends can be invited to the workout group")
+	}
+
+	return nil
+}
+
+// AuthorizeKickWorkoutGroupMember はメンバーを削除できるか確認する（自分より弱いロールのメンバーのみ）
+func (p *OwnershipPolicy) AuthorizeKickWorkoutGroupMember(ctx context.Context, user *entity.User, target *entity.WorkoutGroupMember) error {
+	member, err := p.AuthorizeManageWorkoutGroup(ctx, user, target.WorkoutGroupID)
+	if err != nil {
+		return err
+	}
+	if !member.CanKick(target) {
+		return errcode.NewForbidden(ctx, "cannot remove a member with the same or higher role")
+	}
+	return nil
+}
+
+// AuthorizeExercises は閲覧できない・アーカイブされた種目が含まれている場合に拒否する
+func (p *OwnershipPolicy) AuthorizeExercises(ctx context.Context, userID uint, exerciseIDs []uint) error {
+	if len(exerciseIDs) == 0 {
+		return nil
+	}
+
+	visibleIDs, err := p.repo.GetVisibleExerciseIDs(ctx, userID, exerciseIDs)
+	if err != nil {
+		return err
+	}
+
+	visible := make(map[uint]bool, len(visibleIDs))
+	for _, id := range visibleIDs {
+		visible[id] = true
+	}
+	for _, id := range exerciseIDs {
+		if !visible[id] {
+			return errcode.NewForbidden(ctx, fmt.Sprintf("exercise %d is not available", id))
+		}
+	}
+	return nil
+}
+
+// BlockedUserIDs はユーザーに表示しないユーザー（どちらかがブロックしている）のIDを返す
+func (p *OwnershipPolicy) BlockedUserIDs(ctx context.Context, user *entity.User) (map[uint]bool, error) {
+	userIDs, err := p.repo.GetBlockedUserIDs(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	blocked := make(map[uint]bool, len(userIDs))
+	for _, userID := range userIDs {
+		blocked[userID] = true
+	}
+	return blocked, nil
+}
+
+func authorizeOwner(ctx context.Context, user *entity.User, ownerID uint, resource string) error {
+	if user.ID != ownerID {
+		return errcode.NewForbidden(ctx, "not the owner of the "+resource)
+	}
+	return nil
+}
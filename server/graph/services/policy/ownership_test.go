@@ -0,0 +1,235 @@
+package policy
+
+import (
+	"app/entity"
+	"app/graph/errcode"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// fakeOwnershipRepository はテスト用の所有者情報
+type fakeOwnershipRepository struct {
+	workoutOwners         map[uint]uint
+	workoutExerciseOwners map[uint]uint
+	setLogOwners          map[uint]uint
+	groupMembers          map[uint][]entity.WorkoutGroupMember
+	friends               map[[2]uint]bool
+	blocks                map[[2]uint]bool
+	visibleExercises      map[uint][]uint // ユーザーIDごとの閲覧できる種目ID
+}
+
+func (r *fakeOwnershipRepository) GetWorkoutOwnerID(ctx context.Context, workoutID uint) (uint, error) {
+	return lookupOwner(r.workoutOwners, workoutID)
+}
+
+func (r *fakeOwnershipRepository) GetWorkoutExerciseOwnerID(ctx context.Context, workoutExerciseID uint) (uint, error) {
+	return lookupOwner(r.workoutExerciseOwners, workoutExerciseID)
+}
+
+func (r *fakeOwnershipRepository) GetSetLogOwnerID(ctx context.Context, setLogID uint) (uint, error) {
+	return lookupOwner(r.setLogOwners, setLogID)
+}
+
+func (r *fakeOwnershipRepository) GetWorkoutGroupMember(ctx context.Context, workoutGroupID, userID uint) (*entity.WorkoutGroupMember, error) {
+	for _, member := range r.groupMembers[workoutGroupID] {
+		if member.UserID == userID {
+			return &member, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeOwnershipRepository) AreFriends(ctx context.Context, userID, otherUserID uint) (bool, error) {
+	return r.friends[[2]uint{userID, otherUserID}] || r.friends[[2]uint{otherUserID, userID}], nil
+}
+
+func (r *fakeOwnershipRepository) GetBlockedUserIDs(ctx context.Context, userID uint) ([]uint, error) {
+	var userIDs []uint
+	for pair := range r.blocks {
+		switch userID {
+		case pair[0]:
+			userIDs = append(userIDs, pair[1])
+		case pair[1]:
+			userIDs = append(userIDs, pair[0])
+		}
+	}
+	return userIDs, nil
+}
+
+func (r *fakeOwnershipRepository) GetVisibleExerciseIDs(ctx context.Context, userID uint, exerciseIDs []uint) ([]uint, error) {
+	var visibleIDs []uint
+	for _, id := range r.visibleExercises[userID] {
+		for _, exerciseID := range exerciseIDs {
+			if id == exerciseID {
+				visibleIDs = append(visibleIDs, id)
+				break
+			}
+		}
+	}
+	return visibleIDs, nil
+}
+
+func lookupOwner(owners map[uint]uint, id uint) (uint, error) {
+	ownerID, exists := owners[id]
+	if !exists {
+		return 0, ErrNotFound
+	}
+	return ownerID, nil
+}
+
+const (
+	aliceID uint = 1
+	bobID   uint = 2
+	carolID uint = 3
+	daveID  uint = 4
+)
+
+func groupMember(userID uint, role entity.WorkoutGroupMemberRole, status entity.WorkoutGroupMemberStatus) entity.WorkoutGroupMember {
+	return entity.WorkoutGroupMember{WorkoutGroupID: 50, UserID: userID, Role: role, Status: status}
+}
+
+func newTestOwnershipPolicy() *OwnershipPolicy {
+	return NewOwnershipPolicy(&fakeOwnershipRepository{
+		workoutOwners:         map[uint]uint{10: aliceID, 20: bobID},
+		workoutExerciseOwners: map[uint]uint{100: aliceID, 200: bobID},
+		setLogOwners:          map[uint]uint{1000: aliceID, 2000: bobID},
+		groupMembers: map[uint][]entity.WorkoutGroupMember{50: {
+			groupMember(aliceID, entity.WorkoutGroupMemberRoleOwner, entity.WorkoutGroupMemberStatusJoined),
+			groupMember(carolID, entity.WorkoutGroupMemberRoleMember, entity.WorkoutGroupMemberStatusJoined),
+			groupMember(daveID, entity.WorkoutGroupMemberRoleAdmin, entity.WorkoutGroupMemberStatusInvited),
+		}},
+		friends:          map[[2]uint]bool{{aliceID, bobID}: true, {carolID, bobID}: true},
+		blocks:           map[[2]uint]bool{{carolID, daveID}: true},
+		visibleExercises: map[uint][]uint{aliceID: {1, 2}, bobID: {1}},
+	})
+}
+
+func testUser(id uint, roles ...entity.Role) *entity.User {
+	user := &entity.User{Model: gorm.Model{ID: id}}
+	for _, role := range roles {
+		user.Roles = append(user.Roles, entity.UserRole{UserID: id, Role: role})
+	}
+	return user
+}
+
+func TestOwnershipPolicy_CrossUserWritesAreRejected(t *testing.T) {
+	p := newTestOwnershipPolicy()
+	ctx := context.Background()
+	alice := testUser(aliceID)
+
+	tests := []struct {
+		name      string
+		authorize func() error
+	}{
+		{name: "DeleteUser of another user", authorize: func() error { return p.AuthorizeUser(ctx, alice, bobID) }},
+		{name: "Write to another user's workout", authorize: func() error { return p.AuthorizeWorkout(ctx, alice, 20) }},
+		{name: "CreateSetLog on another user's workout exercise", authorize: func() error { return p.AuthorizeWorkoutExercise(ctx, alice, 200) }},
+		{name: "Update another user's set log", authorize: func() error { return p.AuthorizeSetLog(ctx, alice, 2000) }},
+		{name: "Invite to a group the caller is not in", authorize: func() error { return p.AuthorizeInviteWorkoutGroupMember(ctx, testUser(bobID), 50, aliceID) }},
+		{name: "Invite a non-friend", authorize: func() error { return p.AuthorizeInviteWorkoutGroupMember(ctx, alice, 50, daveID) }},
+		{name: "Invite by a plain member", authorize: func() error { return p.AuthorizeInviteWorkoutGroupMember(ctx, testUser(carolID), 50, bobID) }},
+		{name: "Invite by a user who has not accepted the invitation", authorize: func() error { return p.AuthorizeInviteWorkoutGroupMember(ctx, testUser(daveID), 50, bobID) }},
+		{name: "Delete a group the caller does not own", authorize: func() error { return p.AuthorizeDeleteWorkoutGroup(ctx, testUser(carolID), 50) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.authorize()
+			assert.True(t, errcode.Is(err, errcode.Forbidden), "expected FORBIDDEN, got %v", err)
+		})
+	}
+}
+
+func TestOwnershipPolicy_OwnWritesAreAllowed(t *testing.T) {
+	p := newTestOwnershipPolicy()
+	ctx := context.Background()
+	alice := testUser(aliceID)
+
+	assert.NoError(t, p.AuthorizeUser(ctx, alice, aliceID))
+	assert.NoError(t, p.AuthorizeWorkout(ctx, alice, 10))
+	assert.NoError(t, p.AuthorizeWorkoutExercise(ctx, alice, 100))
+	assert.NoError(t, p.AuthorizeSetLog(ctx, alice, 1000))
+	assert.NoError(t, p.AuthorizeInviteWorkoutGroupMember(ctx, alice, 50, bobID))
+	assert.NoError(t, p.AuthorizeDeleteWorkoutGroup(ctx, alice, 50))
+}
+
+func TestOwnershipPolicy_AdminCanDeleteOtherUsers(t *testing.T) {
+	p := newTestOwnershipPolicy()
+	ctx := context.Background()
+
+	assert.NoError(t, p.AuthorizeUser(ctx, testUser(aliceID, entity.RoleAdmin), bobID))
+
+	err := p.AuthorizeUser(ctx, testUser(aliceID, entity.RoleModerator), bobID)
+	assert.True(t, errcode.Is(err, errcode.Forbidden))
+
+	// 管理者でも他のユーザーのワークアウトには書き込めない
+	err = p.AuthorizeWorkout(ctx, testUser(aliceID, entity.RoleAdmin), 20)
+	assert.True(t, errcode.Is(err, errcode.Forbidden))
+}
+
+func TestOwnershipPolicy_MissingResource(t *testing.T) {
+	p := newTestOwnershipPolicy()
+
+	err := p.AuthorizeSetLog(context.Background(), testUser(aliceID), 9999)
+	assert.True(t, errors.Is(err, ErrNotFound))
+	assert.False(t, errcode.Is(err, errcode.Forbidden))
+}
+
+func TestOwnershipPolicy_InviteSelf(t *testing.T) {
+	p := newTestOwnershipPolicy()
+
+	err := p.AuthorizeInviteWorkoutGroupMember(context.Background(), testUser(aliceID), 50, aliceID)
+	assert.Error(t, err)
+	assert.False(t, errcode.Is(err, errcode.Forbidden))
+}
+
+func TestOwnershipPolicy_KickWorkoutGroupMember(t *testing.T) {
+	p := newTestOwnershipPolicy()
+	ctx := context.Background()
+	owner := groupMember(aliceID, entity.WorkoutGroupMemberRoleOwner, entity.WorkoutGroupMemberStatusJoined)
+	member := groupMember(carolID, entity.WorkoutGroupMemberRoleMember, entity.WorkoutGroupMemberStatusJoined)
+
+	assert.NoError(t, p.AuthorizeKickWorkoutGroupMember(ctx, testUser(aliceID), &member))
+
+	err := p.AuthorizeKickWorkoutGroupMember(ctx, testUser(carolID), &owner)
+	assert.True(t, errcode.Is(err, errcode.Forbidden))
+}
+
+func TestOwnershipPolicy_ModeratorCanDeleteWorkoutGroup(t *testing.T) {
+	p := newTestOwnershipPolicy()
+
+	assert.NoError(t, p.AuthorizeDeleteWorkoutGroup(context.Background(), testUser(bobID, entity.RoleModerator), 50))
+}
+
+func TestOwnershipPolicy_BlockedUserIDsInBothDirections(t *testing.T) {
+	p := newTestOwnershipPolicy()
+	ctx := context.Background()
+
+	blockedByCarol, err := p.BlockedUserIDs(ctx, testUser(carolID))
+	assert.NoError(t, err)
+	assert.Equal(t, map[uint]bool{daveID: true}, blockedByCarol)
+
+	blockingDave, err := p.BlockedUserIDs(ctx, testUser(daveID))
+	assert.NoError(t, err)
+	assert.Equal(t, map[uint]bool{carolID: true}, blockingDave)
+
+	none, err := p.BlockedUserIDs(ctx, testUser(aliceID))
+	assert.NoError(t, err)
+	assert.Empty(t, none)
+}
+
+func TestOwnershipPolicy_AuthorizeExercises(t *testing.T) {
+	p := newTestOwnershipPolicy()
+	ctx := context.Background()
+
+	assert.NoError(t, p.AuthorizeExercises(ctx, aliceID, []uint{1, 2, 1}))
+	assert.NoError(t, p.AuthorizeExercises(ctx, bobID, nil))
+
+	// 閲覧できない・アーカイブされた種目が1つでも含まれていれば拒否する
+	err := p.AuthorizeExercises(ctx, bobID, []uint{1, 2})
+	assert.True(t, errcode.Is(err, errcode.Forbidden))
+}
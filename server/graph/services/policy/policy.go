@@ -0,0 +1,57 @@
+package policy
+
+import (
+	"app/entity"
+	"app/graph/errcode"
+	"context"
+)
+
+// Permission はロールによって許可される操作
+type Permission string
+
+const (
+	ViewAllUsers          Permission = "view_all_users"          // 全ユーザーの一覧を閲覧する
+	ManageUsers           Permission = "manage_users"            // 他のユーザーを削除する
+	ManageRoles           Permission = "manage_roles"            // ロールを付与・剥奪する
+	ManageExerciseCatalog Permission = "manage_exercise_catalog" // 種目をカタログに昇格する
+	ModerateContent       Permission = "moderate_content"        // 他のユーザーが作成したコンテンツをアーカイブ・削除する
+	PublishProgram        Permission = "publish_program"         // トレーニングプログラムを公開する
+)
+
+// rolePermissions はロールごとに許可される操作
+var rolePermissions = map[entity.Role][]Permission{
+	entity.RoleUser:      {},
+	entity.RoleCoach:     {PublishProgram},
+	entity.RoleModerator: {ViewAllUsers, ManageExerciseCatalog, ModerateContent},
+	entity.RoleAdmin: {
+		ViewAllUsers,
+		ManageUsers,
+		ManageRoles,
+		ManageExerciseCatalog,
+		ModerateContent,
+		PublishProgram,
+	},
+}
+
+// Can はユーザーが操作を許可されているかどうか（Rolesを読み込んでおく必要がある）
+func Can(user *entity.User, permission Permission) bool {
+	if user == nil {
+		return false
+	}
+	for _, role := range user.RoleList() {
+		for _, p := range rolePermissions[role] {
+			if p == permission {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Authorize は操作が許可されていない場合にFORBIDDENエラーを返す
+func Authorize(ctx context.Context, user *entity.User, permission Permission) error {
+	if !Can(user, permission) {
+		return errcode.NewForbidden(ctx, "permission denied: "+string(permission))
+	}
+	return nil
+}
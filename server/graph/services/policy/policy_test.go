@@ -0,0 +1,45 @@
+package policy
+
+import (
+	"app/entity"
+	"app/graph/errcode"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCan(t *testing.T) {
+	tests := []struct {
+		name       string
+		roles      []entity.Role
+		permission Permission
+		expected   bool
+	}{
+		{name: "User cannot view all users", permission: ViewAllUsers, expected: false},
+		{name: "Coach can publish programs", roles: []entity.Role{entity.RoleCoach}, permission: PublishProgram, expected: true},
+		{name: "Coach cannot moderate content", roles: []entity.Role{entity.RoleCoach}, permission: ModerateContent, expected: false},
+		{name: "Moderator can manage the exercise catalog", roles: []entity.Role{entity.RoleModerator}, permission: ManageExerciseCatalog, expected: true},
+		{name: "Moderator cannot manage roles", roles: []entity.Role{entity.RoleModerator}, permission: ManageRoles, expected: false},
+		{name: "Admin can manage roles", roles: []entity.Role{entity.RoleAdmin}, permission: ManageRoles, expected: true},
+		{name: "Permissions of multiple roles are combined", roles: []entity.Role{entity.RoleCoach, entity.RoleModerator}, permission: PublishProgram, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &entity.User{}
+			for _, role := range tt.roles {
+				user.Roles = append(user.Roles, entity.UserRole{Role: role})
+			}
+			assert.Equal(t, tt.expected, Can(user, tt.permission))
+		})
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	err := Authorize(context.Background(), &entity.User{}, ManageUsers)
+	assert.True(t, errcode.Is(err, errcode.Forbidden))
+
+	assert.False(t, Can(nil, ViewAllUsers))
+	assert.NoError(t, Authorize(context.Background(), &entity.User{Roles: []entity.UserRole{{Role: entity.RoleAdmin}}}, ManageUsers))
+}
@@ -0,0 +1,147 @@
+package policy
+
+import (
+	"app/entity"
+	"context"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// ErrNotFound は所有者を確認する対象が存在しない
+var ErrNotFound = errors.New("not found")
+
+// OwnershipRepository は所有者・メンバーの確認に必要な情報を取得する
+type OwnershipRepository interface {
+	GetWorkoutOwnerID(ctx context.Context, workoutID uint) (uint, error)
+	GetWorkoutExerciseOwnerID(ctx context.Context, workoutExerciseID uint) (uint, error)
+	GetSetLogOwnerID(ctx context.Context, setLogID uint) (uint, error)
+	GetWorkoutGroupMember(ctx context.Context, workoutGroupID, userID uint) (*entity.WorkoutGroupMember, error)
+	AreFriends(ctx context.Context, userID, otherUserID uint) (bool, error)
+	GetBlockedUserIDs(ctx context.Context, userID uint) ([]uint, error)
+	GetVisibleExerciseIDs(ctx context.Context, userID uint, exerciseIDs []uint) ([]uint, error)
+}
+
+type ownershipRepository struct {
+	db *gorm.DB
+}
+
+func NewOwnershipRepository(db *gorm.DB) OwnershipRepository {
+	return &ownershipRepository{db: db}
+}
+
+func (r *ownershipRepository) GetWorkoutOwnerID(ctx context.Context, workoutID uint) (uint, error) {
+	var ownerIDs []uint
+	if err := r.db.Model(&entity.Workout{}).
+		Where("id = ?", workoutID).
+		Limit(1).
+		Pluck("user_id", &ownerIDs).Error; err != nil {
+		return 0, fmt.Errorf("failed to fetch workout owner: %w", err)
+	}
+	return firstOwnerID(ownerIDs, "workout", workoutID)
+}
+
+// GetWorkoutExerciseOwnerID は WorkoutExercise -> Workout を辿って所有ユーザーIDを取得
+func (r *ownershipRepository) GetWorkoutExerciseOwnerID(ctx context.Context, workoutExerciseID uint) (uint, error) {
+	var ownerIDs []uint
+	if err := r.db.Model(&entity.Workout{}).
+		Joins("inner join workout_exercises on workout_exercises.workout_id = workouts.id").
+		Where("workout_exercises.id = ?", workoutExerciseID).
+		Where("workout_exercises.deleted_at IS NULL").
+		Limit(1).
+		Pluck("workouts.user_id", &ownerIDs).Error; err != nil {
+		return 0, fmt.Errorf("failed to fetch workout exercise owner: %w", err)
+	}
+	return firstOwnerID(ownerIDs, "workout exercise", workoutExerciseID)
+}
+
+// GetSetLogOwnerID は SetLog -> WorkoutExercise -> Workout を辿って所有ユーザーIDを取得
+func (r *ownershipRepository) GetSetLogOwnerID(ctx context.Context, setLogID uint) (uint, error) {
+	var ownerIDs []uint
+	if err := r.db.Model(&entity.Workout{}).
+		Joins("inner join workout_exercises on workout_exercises.workout_id = workouts.id").
+		Joins("inner join set_logs on set_logs.workout_exercise_id = workout_exercises.id").
+		Where("set_logs.id = ?", setLogID).
+		Where("set_logs.deleted_at IS NULL").
+		Where("workout_exercises.deleted_at IS NULL").
+		Limit(1).
+		Pluck("workouts.user_id", &ownerIDs).Error; err != nil {
+		return 0, fmt.Errorf("failed to fetch set log owner: %w", err)
+	}
+	return firstOwnerID(ownerIDs, "set log", setLogID)
+}
+
+// GetWorkoutGroupMember はグループのメンバー情報を取得する（招待されたことがない場合はnil）
+func (r *ownershipRepository) GetWorkoutGroupMember(ctx context.Context, workoutGroupID, userID uint) (*entity.WorkoutGroupMember, error) {
+	var members []entity.WorkoutGroupMember
+	if err := r.db.
+		Where("workout_group_id = ? AND user_id = ?", workoutGroupID, userID).
+		Limit(1).
+		Find(&members).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch workout group member: %w", err)
+	}
+	if len(members) == 0 {
+		return nil, nil
+	}
+	return &members[0], nil
+}
+
+func (r *ownershipRepository) AreFriends(ctx context.Context, userID, otherUserID uint) (bool, error) {
+	var count int64
+	if err := r.db.Model(&entity.Friendship{}).
+		Where("status = ?", entity.Accepted).
+		Where("(requester_id = ? AND requestee_id = ?) OR (requester_id = ? AND requestee_id = ?)", userID, otherUserID, otherUserID, userID).
+		Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check friendship: %w", err)
+	}
+	return count > 0, nil
+}
+
+// GetBlockedUserIDs はユーザーがブロックした・ユーザーをブロックしたユーザーのIDを取得
+func (r *ownershipRepository) GetBlockedUserIDs(ctx context.Context, userID uint) ([]uint, error) {
+	var userIDs []uint
+	if err := r.db.Model(&entity.UserBlock{}).
+		Select("CASE WHEN blocker_id = ? THEN blocked_id ELSE blocker_id END AS user_id", userID).
+		Where("blocker_id = ? OR blocked_id = ?", userID, userID).
+		Pluck("user_id", &userIDs).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch blocked users: %w", err)
+	}
+	return userIDs, nil
+}
+
+// VisibleExercises は指定ユーザーが閲覧できる種目に絞り込む（カタログ・自分の種目・公開範囲内の他ユーザーの種目）
+func VisibleExercises(userID uint) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where(
+			"exercises.user_id IS NULL OR exercises.user_id = ? OR exercises.visibility = ? OR (exercises.visibility = ? AND EXISTS (?))",
+			userID,
+			entity.ExerciseVisibilityPublic,
+			entity.ExerciseVisibilityFriends,
+			db.Session(&gorm.Session{NewDB: true}).Model(&entity.Friendship{}).
+				Select("1").
+				Where("friendships.status = ?", entity.Accepted).
+				Where("(friendships.requester_id = exercises.user_id AND friendships.requestee_id = ?) OR (friendships.requestee_id = exercises.user_id AND friendships.requester_id = ?)", userID, userID),
+		)
+	}
+}
+
+// GetVisibleExerciseIDs は指定した種目のうち、ユーザーが閲覧できるアーカイブされていない種目のIDを取得
+func (r *ownershipRepository) GetVisibleExerciseIDs(ctx context.Context, userID uint, exerciseIDs []uint) ([]uint, error) {
+	var visibleIDs []uint
+	if err := r.db.Model(&entity.Exercise{}).
+		Scopes(VisibleExercises(userID)).
+		Where("exercises.id IN ?", exerciseIDs).
+		Where("exercises.archived_at IS NULL").
+		Pluck("exercises.id", &visibleIDs).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch visible exercises: %w", err)
+	}
+	return visibleIDs, nil
+}
+
+func firstOwnerID(ownerIDs []uint, resource string, id uint) (uint, error) {
+	if len(ownerIDs) == 0 {
+		return 0, fmt.Errorf("%s %d: %w", resource, id, ErrNotFound)
+	}
+	return ownerIDs[0], nil
+}
@@ -37,6 +37,8 @@ func (c *ProfileConverter) ToModelProfile(profile entity.Profile) *model.Profile
 		Gender:        profile.GenderToGraphQL(),
 		Height:        profile.Height,
 		Weight:        profile.Weight,
+		Bmi:           profile.BMI(),
+		WeightUnit:    profile.WeightUnitToGraphQL(),
 		ActivityLevel: profile.ActivityLevelToGraphQL(),
 		ImageURL:      formatString(profile.ImageURL),
 		CreatedAt:     profile.CreatedAt.Format(common.TimeFormat),
@@ -11,8 +11,8 @@ import (
 
 type ProfileRepository interface {
 	GetProfileByUserID(ctx context.Context, userID string) (*entity.Profile, error)
-	CreateProfile(ctx context.Context, profile *entity.Profile) error
-	UpdateProfile(ctx context.Context, profile *entity.Profile) error
+	CreateProfile(ctx context.Context, profile *entity.Profile, measurement *entity.BodyMeasurement) error
+	UpdateProfile(ctx context.Context, profile *entity.Profile, measurement *entity.BodyMeasurement) error
 	GetDB() *gorm.DB
 
 	// バッチ取得メソッド（DataLoader用）
@@ -27,6 +27,16 @@ func NewProfileRepository(db *gorm.DB) ProfileRepository {
 	return &profileRepository{db: db}
 }
 
+// withLatestWeight は最新の体重の記録をプロフィールの体重として取得する
+func withLatestWeight(db *gorm.DB) *gorm.DB {
+	return db.Select("profiles.*, (?) AS weight", db.Session(&gorm.Session{NewDB: true}).
+		Model(&entity.BodyMeasurement{}).
+		Select("body_measurements.weight").
+		Where("body_measurements.user_id = profiles.user_id AND body_measurements.weight IS NOT NULL").
+		Order("body_measurements.measured_at DESC, body_measurements.id DESC").
+		Limit(1))
+}
+
 func (r *profileRepository) GetProfileByUserID(ctx context.Context, userID string) (*entity.Profile, error) {
 	id, err := strconv.ParseUint(userID, 10, 32)
 	if err != nil {
@@ -34,7 +44,7 @@ func (r *profileRepository) GetProfileByUserID(ctx context.Context, userID strin
 	}
 
 	var profile entity.Profile
-	if err := r.db.Where("user_id = ?", uint(id)).First(&profile).Error; err != nil {
+	if err := r.db.Scopes(withLatestWeight).Where("user_id = ?", uint(id)).First(&profile).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
 		}
@@ -44,12 +54,35 @@ func (r *profileRepository) GetProfileByUserID(ctx context.Context, userID strin
 	return &profile, nil
 }
 
-func (r *profileRepository) CreateProfile(ctx context.Context, profile *entity.Profile) error {
-	return r.db.Create(profile).Error
+// CreateProfile はプロフィールを作成する（measurement を指定した場合は同じトランザクションで体重を記録する）
+func (r *profileRepository) CreateProfile(ctx context.Context, profile *entity.Profile, measurement *entity.BodyMeasurement) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(profile).Error; err != nil {
+			return err
+		}
+		return logWeight(tx, profile, measurement)
+	})
+}
+
+// UpdateProfile はプロフィールを更新する（measurement を指定した場合は同じトランザクションで体重を記録する）
+func (r *profileRepository) UpdateProfile(ctx context.Context, profile *entity.Profile, measurement *entity.BodyMeasurement) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(profile).Error; err != nil {
+			return err
+		}
+		return logWeight(tx, profile, measurement)
+	})
 }
 
-func (r *profileRepository) UpdateProfile(ctx context.Context, profile *entity.Profile) error {
-	return r.db.Save(profile).Error
+func logWeight(tx *gorm.DB, profile *entity.Profile, measurement *entity.BodyMeasurement) error {
+	if measurement == nil {
+		return nil
+	}
+	if err := tx.Create(measurement).Error; err != nil {
+		return fmt.Errorf("failed to log body measurement: %w", err)
+	}
+	profile.Weight = measurement.Weight
+	return nil
 }
 
 func (r *profileRepository) GetDB() *gorm.DB {
@@ -63,7 +96,7 @@ func (r *profileRepository) GetProfilesByUserIDs(userIDs []uint) ([]*entity.Prof
 	}
 
 	var profiles []entity.Profile
-	if err := r.db.Where("user_id IN ?", userIDs).Find(&profiles).Error; err != nil {
+	if err := r.db.Scopes(withLatestWeight).Where("user_id IN ?", userIDs).Find(&profiles).Error; err != nil {
 		return nil, fmt.Errorf("failed to fetch profiles by user IDs: %w", err)
 	}
 
@@ -69,19 +69,19 @@ func (s *profileService) CreateProfile(ctx context.Context, input model.CreatePr
 		profile.Height = input.Height
 	}
 
-	if input.Weight != nil {
-		profile.Weight = input.Weight
-	}
+	measurement := newWeightMeasurement(currentUser.ID, input.Weight)
 
 	if input.ActivityLevel != nil {
 		profile.ActivityLevelFromGraphQL(input.ActivityLevel)
 	}
 
+	profile.WeightUnitFromGraphQL(input.WeightUnit)
+
 	if input.ImageURL != nil {
 		profile.ImageURL = *input.ImageURL
 	}
 
-	if err := s.repo.CreateProfile(ctx, &profile); err != nil {
+	if err := s.repo.CreateProfile(ctx, &profile, measurement); err != nil {
 		return nil, fmt.Errorf("failed to create profile: %w", err)
 	}
 
@@ -125,21 +125,35 @@ func (s *profileService) UpdateProfile(ctx context.Context, input model.UpdatePr
 		existingProfile.Height = input.Height
 	}
 
-	if input.Weight != nil {
-		existingProfile.Weight = input.Weight
-	}
+	measurement := newWeightMeasurement(currentUser.ID, input.Weight)
 
 	if input.ActivityLevel != nil {
 		existingProfile.ActivityLevelFromGraphQL(input.ActivityLevel)
 	}
 
+	if input.WeightUnit != nil {
+		existingProfile.WeightUnitFromGraphQL(input.WeightUnit)
+	}
+
 	if input.ImageURL != nil {
 		existingProfile.ImageURL = *input.ImageURL
 	}
 
-	if err := s.repo.UpdateProfile(ctx, existingProfile); err != nil {
+	if err := s.repo.UpdateProfile(ctx, existingProfile, measurement); err != nil {
 		return nil, fmt.Errorf("failed to update profile: %w", err)
 	}
 
 	return s.converter.ToModelProfile(*existingProfile), nil
 }
+
+// newWeightMeasurement は入力された体重（kg）を現在日時の記録にする（未入力の場合はnil）
+func newWeightMeasurement(userID uint, weight *float64) *entity.BodyMeasurement {
+	if weight == nil {
+		return nil
+	}
+	return &entity.BodyMeasurement{
+		UserID:     userID,
+		MeasuredAt: time.Now(),
+		Weight:     weight,
+	}
+}
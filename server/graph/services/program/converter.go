@@ -0,0 +1,108 @@
+package program
+
+import (
+	"app/entity"
+	"app/graph/model"
+	"app/graph/services/common"
+	"fmt"
+)
+
+type ProgramConverter struct{}
+
+func NewProgramConverter() *ProgramConverter {
+	return &ProgramConverter{}
+}
+
+func (c *ProgramConverter) ToModelProgram(program entity.Program) *model.Program {
+	var description *string
+	if program.Description != "" {
+		description = &program.Description
+	}
+
+	days := make([]*model.ProgramDay, len(program.Days))
+	for i, day := range program.Days {
+		days[i] = c.ToModelProgramDay(day)
+	}
+
+	return &model.Program{
+		ID:          fmt.Sprintf("%d", program.ID),
+		Name:        program.Name,
+		Description: description,
+		UserID:      fmt.Sprintf("%d", program.UserID),
+		WeekCount:   int32(program.WeekCount()),
+		Days:        days,
+		CreatedAt:   program.CreatedAt.Format(common.TimeFormat),
+		UpdatedAt:   program.UpdatedAt.Format(common.TimeFormat),
+	}
+}
+
+func (c *ProgramConverter) ToModelProgramsFromPointers(programs []*entity.Program) []*model.Program {
+	result := make([]*model.Program, len(programs))
+	for i, program := range programs {
+		if program != nil {
+			result[i] = c.ToModelProgram(*program)
+		}
+	}
+	return result
+}
+
+func (c *ProgramConverter) ToModelProgramDay(day entity.ProgramDay) *model.ProgramDay {
+	var name *string
+	if day.Name != "" {
+		name = &day.Name
+	}
+
+	exercises := make([]*model.ProgramExercise, len(day.Exercises))
+	for i, exercise := range day.Exercises {
+		exercises[i] = c.ToModelProgramExercise(exercise)
+	}
+
+	return &model.ProgramDay{
+		ID:        fmt.Sprintf("%d", day.ID),
+		Week:      int32(day.Week),
+		Day:       int32(day.Day),
+		Name:      name,
+		Exercises: exercises,
+	}
+}
+
+func (c *ProgramConverter) ToModelProgramExercise(exercise entity.ProgramExercise) *model.ProgramExercise {
+	return &model.ProgramExercise{
+		ID:                     fmt.Sprintf("%d", exercise.ID),
+		ExerciseID:             fmt.Sprintf("%d", exercise.ExerciseID),
+		Position:               int32(exercise.Position),
+		Sets:                   int32(exercise.Sets),
+		Reps:                   int32(exercise.Reps),
+		TargetType:             exercise.TargetTypeToGraphQL(),
+		TargetValue:            exercise.TargetValue,
+		ProgressionIncrementKg: exercise.ProgressionIncrement,
+	}
+}
+
+func (c *ProgramConverter) ToModelProgramEnrollment(enrollment entity.ProgramEnrollment) *model.ProgramEnrollment {
+	var endedAt *string
+	if enrollment.EndedAt != nil {
+		formatted := enrollment.EndedAt.Format(common.TimeFormat)
+		endedAt = &formatted
+	}
+
+	return &model.ProgramEnrollment{
+		ID:        fmt.Sprintf("%d", enrollment.ID),
+		ProgramID: fmt.Sprintf("%d", enrollment.ProgramID),
+		StartedAt: enrollment.StartedAt.Format(common.TimeFormat),
+		EndedAt:   endedAt,
+		IsActive:  enrollment.IsActive(),
+	}
+}
+
+func (c *ProgramConverter) ToModelPrescribedExercise(exercise entity.ProgramExercise, trainingMax, weight *float64) *model.PrescribedExercise {
+	return &model.PrescribedExercise{
+		ExerciseID:    fmt.Sprintf("%d", exercise.ExerciseID),
+		Sets:          int32(exercise.Sets),
+		Reps:          int32(exercise.Reps),
+		TargetType:    exercise.TargetTypeToGraphQL(),
+		TargetValue:   exercise.TargetValue,
+		TrainingMaxKg: trainingMax,
+		WeightKg:      weight,
+	}
+}
@@ -0,0 +1,187 @@
+package program
+
+import (
+	"app/entity"
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ProgramRepository interface {
+	GetPrograms(ctx context.Context) ([]*entity.Program, error)
+	GetProgramByID(ctx context.Context, id string) (*entity.Program, error)
+	CreateProgram(ctx context.Context, program *entity.Program) error
+	DeleteProgram(ctx context.Context, id uint) error
+	GetEnrollmentByID(ctx context.Context, id string) (*entity.ProgramEnrollment, error)
+	GetActiveEnrollmentByUserID(ctx context.Context, userID uint) (*entity.ProgramEnrollment, error)
+	EnrollProgram(ctx context.Context, enrollment *entity.ProgramEnrollment) error
+	EndActiveEnrollment(ctx context.Context, userID uint) (bool, error)
+	GetEstimatedOneRepMaxes(ctx context.Context, userID uint, exerciseIDs []uint) (map[uint]float64, error)
+	CountSessions(ctx context.Context, enrollmentID uint) (int64, error)
+	CountCompletedSessions(ctx context.Context, enrollmentID, exerciseID uint, sets, reps int) (int64, error)
+}
+
+type programRepository struct {
+	db *gorm.DB
+}
+
+func NewProgramRepository(db *gorm.DB) ProgramRepository {
+	return &programRepository{db: db}
+}
+
+// withDays は週・日・種目の順に並べて日と種目を読み込む
+func (r *programRepository) withDays() *gorm.DB {
+	return r.db.
+		Preload("Days", func(db *gorm.DB) *gorm.DB {
+			return db.Order("week ASC, day ASC")
+		}).
+		Preload("Days.Exercises", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position ASC")
+		})
+}
+
+func (r *programRepository) GetPrograms(ctx context.Context) ([]*entity.Program, error) {
+	var programs []*entity.Program
+	if err := r.withDays().Order("id ASC").Find(&programs).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch programs: %w", err)
+	}
+
+	return programs, nil
+}
+
+func (r *programRepository) GetProgramByID(ctx context.Context, id string) (*entity.Program, error) {
+	programID, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid program ID: %s", id)
+	}
+
+	var program entity.Program
+	if err := r.withDays().Where("id = ?", uint(programID)).First(&program).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch program: %w", err)
+	}
+
+	return &program, nil
+}
+
+// CreateProgram はプログラム・日・種目を作成する（GORMのアソシエーションで同一トランザクション）
+func (r *programRepository) CreateProgram(ctx context.Context, program *entity.Program) error {
+	return r.db.Create(program).Error
+}
+
+func (r *programRepository) DeleteProgram(ctx context.Context, id uint) error {
+	return r.db.Unscoped().Delete(&entity.Program{}, id).Error
+}
+
+func (r *programRepository) GetEnrollmentByID(ctx context.Context, id string) (*entity.ProgramEnrollment, error) {
+	enrollmentID, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid program enrollment ID: %s", id)
+	}
+
+	var enrollment entity.ProgramEnrollment
+	if err := r.db.Preload("TrainingMaxes").Where("id = ?", uint(enrollmentID)).First(&enrollment).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch program enrollment: %w", err)
+	}
+
+	return &enrollment, nil
+}
+
+// GetActiveEnrollmentByUserID は参加中のプログラムを取得する（参加していない場合はnil）
+func (r *programRepository) GetActiveEnrollmentByUserID(ctx context.Context, userID uint) (*entity.ProgramEnrollment, error) {
+	var enrollments []*entity.ProgramEnrollment
+	if err := r.db.Preload("TrainingMaxes").
+		Where("user_id = ? AND ended_at IS NULL", userID).
+		Order("started_at DESC").
+		Limit(1).
+		Find(&enrollments).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch active program enrollment: %w", err)
+	}
+
+	if len(enrollments) == 0 {
+		return nil, nil
+	}
+
+	return enrollments[0], nil
+}
+
+// EnrollProgram は参加中のプログラムを終了し、新しいプログラムに参加する
+func (r *programRepository) EnrollProgram(ctx context.Context, enrollment *entity.ProgramEnrollment) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&entity.ProgramEnrollment{}).
+			Where("user_id = ? AND ended_at IS NULL", enrollment.UserID).
+			UpdateColumn("ended_at", enrollment.StartedAt).Error; err != nil {
+			return fmt.Errorf("failed to end active program enrollment: %w", err)
+		}
+
+		return tx.Create(enrollment).Error
+	})
+}
+
+func (r *programRepository) EndActiveEnrollment(ctx context.Context, userID uint) (bool, error) {
+	result := r.db.Model(&entity.ProgramEnrollment{}).
+		Where("user_id = ? AND ended_at IS NULL", userID).
+		UpdateColumn("ended_at", time.Now())
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to end program enrollment: %w", result.Error)
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// GetEstimatedOneRepMaxes は自己ベストの推定1RMを種目別に取得する
+func (r *programRepository) GetEstimatedOneRepMaxes(ctx context.Context, userID uint, exerciseIDs []uint) (map[uint]float64, error) {
+	result := make(map[uint]float64)
+	if len(exerciseIDs) == 0 {
+		return result, nil
+	}
+
+	var records []entity.PersonalRecord
+	if err := r.db.Where("user_id = ? AND exercise_id IN ? AND type = ? AND is_current = ?", userID, exerciseIDs, entity.EstimatedOneRepMax, true).
+		Find(&records).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch estimated one rep maxes: %w", err)
+	}
+
+	for _, record := range records {
+		result[record.ExerciseID] = record.Value
+	}
+
+	return result, nil
+}
+
+// CountSessions はプログラムの処方で開始し、完了済みのセットを記録したワークアウトの数を取得する
+func (r *programRepository) CountSessions(ctx context.Context, enrollmentID uint) (int64, error) {
+	var count int64
+	if err := r.db.Model(&entity.Workout{}).
+		Where("workouts.program_enrollment_id = ?", enrollmentID).
+		Where("EXISTS (?)", r.db.Model(&entity.SetLog{}).
+			Select("1").
+			Joins("inner join workout_exercises on workout_exercises.id = set_logs.workout_exercise_id AND workout_exercises.deleted_at IS NULL").
+			Where("workout_exercises.workout_id = workouts.id AND set_logs.completed = ?", true)).
+		Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count sessions: %w", err)
+	}
+
+	return count, nil
+}
+
+// CountCompletedSessions はプログラムのワークアウトのうち、指定レップ数以上のセットを指定セット数以上行ったワークアウトの数を取得する
+func (r *programRepository) CountCompletedSessions(ctx context.Context, enrollmentID, exerciseID uint, sets, reps int) (int64, error) {
+	completed := r.db.Model(&entity.SetLog{}).
+		Select("workout_exercises.workout_id").
+		Joins("inner join workout_exercises on workout_exercises.id = set_logs.workout_exercise_id AND workout_exercises.deleted_at IS NULL").
+		Joins("inner join workouts on workouts.id = workout_exercises.workout_id AND workouts.deleted_at IS NULL").
+		Where("workouts.program_enrollment_id = ? AND workout_exercises.exercise_id = ?", enrollmentID, exerciseID).
+		Where("set_logs.rep_count >= ? AND set_logs.completed = ?", reps, true).
+		Group("workout_exercises.workout_id").
+		Having("COUNT(*) >= ?", sets)
+
+	var count int64
+	if err := r.db.Table("(?) AS completed", completed).Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count completed sessions: %w", err)
+	}
+
+	return count, nil
+}
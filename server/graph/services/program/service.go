@@ -0,0 +1,331 @@
+package program
+
+import (
+	"app/entity"
+	"app/graph/errcode"
+	"app/graph/model"
+	"app/graph/services/common"
+	"app/graph/services/policy"
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+type ProgramService interface {
+	GetPrograms(ctx context.Context) ([]*model.Program, error)
+	GetProgram(ctx context.Context, id string) (*model.Program, error)
+	CreateProgram(ctx context.Context, input model.CreateProgram) (*model.Program, error)
+	DeleteProgram(ctx context.Context, input model.DeleteProgram) (bool, error)
+	GetMyProgramEnrollment(ctx context.Context) (*model.ProgramEnrollment, error)
+	EnrollProgram(ctx context.Context, input model.EnrollProgram) (*model.ProgramEnrollment, error)
+	LeaveProgram(ctx context.Context) (bool, error)
+	GetTodaysWorkout(ctx context.Context, enrollmentID string) (*model.PrescribedWorkout, error)
+}
+
+type programService struct {
+	repo      ProgramRepository
+	converter *ProgramConverter
+	common    common.CommonRepository
+	ownership *policy.OwnershipPolicy
+}
+
+func NewProgramService(repo ProgramRepository, converter *ProgramConverter) ProgramService {
+	return &programService{
+		repo:      repo,
+		converter: converter,
+		common:    common.NewCommonRepository(repo.(*programRepository).db),
+		ownership: policy.NewOwnershipPolicy(policy.NewOwnershipRepository(repo.(*programRepository).db)),
+	}
+}
+
+func (s *programService) GetPrograms(ctx context.Context) ([]*model.Program, error) {
+	programs, err := s.repo.GetPrograms(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get programs: %w", err)
+	}
+	return s.converter.ToModelProgramsFromPointers(programs), nil
+}
+
+func (s *programService) GetProgram(ctx context.Context, id string) (*model.Program, error) {
+	program, err := s.repo.GetProgramByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get program %s: %w", id, err)
+	}
+	return s.converter.ToModelProgram(*program), nil
+}
+
+// CreateProgram はプログラムを公開する（コーチ・管理者のみ）
+func (s *programService) CreateProgram(ctx context.Context, input model.CreateProgram) (*model.Program, error) {
+	currentUser, err := s.common.Authorize(ctx, policy.PublishProgram)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(input.Days) == 0 {
+		return nil, fmt.Errorf("program must have at least one day")
+	}
+
+	weightUnit, err := s.resolveWeightUnit(ctx, currentUser.ID, input.WeightUnit)
+	if err != nil {
+		return nil, err
+	}
+
+	var exerciseIDs []uint
+	days := make([]entity.ProgramDay, len(input.Days))
+	for i, dayInput := range input.Days {
+		if len(dayInput.Exercises) == 0 {
+			return nil, fmt.Errorf("week %d day %d must have at least one exercise", dayInput.Week, dayInput.Day)
+		}
+
+		exercises := make([]entity.ProgramExercise, len(dayInput.Exercises))
+		for j, exerciseInput := range dayInput.Exercises {
+			exerciseID, err := strconv.ParseUint(exerciseInput.ExerciseID, 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("invalid exercise ID: %s", exerciseInput.ExerciseID)
+			}
+			exerciseIDs = append(exerciseIDs, uint(exerciseID))
+
+			exercises[j] = entity.ProgramExercise{
+				ExerciseID:  uint(exerciseID),
+				Position:    j + 1,
+				Sets:        int(exerciseInput.Sets),
+				Reps:        int(exerciseInput.Reps),
+				TargetValue: exerciseInput.TargetValue,
+			}
+			exercises[j].TargetTypeFromGraphQL(exerciseInput.TargetType)
+			if exerciseInput.ProgressionIncrement != nil {
+				exercises[j].ProgressionIncrement = entity.ToKilograms(*exerciseInput.ProgressionIncrement, weightUnit)
+			}
+		}
+
+		days[i] = entity.ProgramDay{
+			Week:      int(dayInput.Week),
+			Day:       int(dayInput.Day),
+			Exercises: exercises,
+		}
+		if dayInput.Name != nil {
+			days[i].Name = *dayInput.Name
+		}
+	}
+
+	if err := s.ownership.AuthorizeExercises(ctx, currentUser.ID, exerciseIDs); err != nil {
+		return nil, err
+	}
+
+	program := entity.Program{
+		UserID: currentUser.ID,
+		Name:   input.Name,
+		Days:   days,
+	}
+	if input.Description != nil {
+		program.Description = *input.Description
+	}
+
+	if err := s.repo.CreateProgram(ctx, &program); err != nil {
+		return nil, fmt.Errorf("failed to create program: %w", err)
+	}
+
+	return s.converter.ToModelProgram(program), nil
+}
+
+// DeleteProgram はプログラムを削除する（作成者、またはコンテンツを管理できるユーザーのみ）
+func (s *programService) DeleteProgram(ctx context.Context, input model.DeleteProgram) (bool, error) {
+	currentUser, err := s.common.GetCurrentUser(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	program, err := s.repo.GetProgramByID(ctx, input.ID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get program: %w", err)
+	}
+
+	if program.UserID != currentUser.ID {
+		if err := policy.Authorize(ctx, currentUser, policy.ModerateContent); err != nil {
+			return false, err
+		}
+	}
+
+	if err := s.repo.DeleteProgram(ctx, program.ID); err != nil {
+		return false, fmt.Errorf("failed to delete program: %w", err)
+	}
+
+	return true, nil
+}
+
+func (s *programService) GetMyProgramEnrollment(ctx context.Context) (*model.ProgramEnrollment, error) {
+	currentUser, err := s.common.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	enrollment, err := s.repo.GetActiveEnrollmentByUserID(ctx, currentUser.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get program enrollment: %w", err)
+	}
+
+	if enrollment == nil {
+		return nil, nil
+	}
+
+	return s.converter.ToModelProgramEnrollment(*enrollment), nil
+}
+
+// EnrollProgram はプログラムに参加する（参加中のプログラムは終了する）
+// トレーニングマックスが指定されていない種目は自己ベストの推定1RMを使用する
+func (s *programService) EnrollProgram(ctx context.Context, input model.EnrollProgram) (*model.ProgramEnrollment, error) {
+	currentUser, err := s.common.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	program, err := s.repo.GetProgramByID(ctx, input.ProgramID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get program: %w", err)
+	}
+
+	weightUnit, err := s.resolveWeightUnit(ctx, currentUser.ID, input.WeightUnit)
+	if err != nil {
+		return nil, err
+	}
+
+	// プログラムに含まれる種目
+	var exerciseIDs []uint
+	included := make(map[uint]bool)
+	for _, day := range program.Days {
+		for _, exercise := range day.Exercises {
+			if !included[exercise.ExerciseID] {
+				included[exercise.ExerciseID] = true
+				exerciseIDs = append(exerciseIDs, exercise.ExerciseID)
+			}
+		}
+	}
+
+	trainingMaxes, err := s.repo.GetEstimatedOneRepMaxes(ctx, currentUser.ID, exerciseIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get estimated one rep maxes: %w", err)
+	}
+
+	for _, trainingMaxInput := range input.TrainingMaxes {
+		exerciseID, err := strconv.ParseUint(trainingMaxInput.ExerciseID, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exercise ID: %s", trainingMaxInput.ExerciseID)
+		}
+		if !included[uint(exerciseID)] {
+			return nil, fmt.Errorf("exercise %s is not part of the program", trainingMaxInput.ExerciseID)
+		}
+		trainingMaxes[uint(exerciseID)] = entity.ToKilograms(trainingMaxInput.Weight, weightUnit)
+	}
+
+	enrollment := entity.ProgramEnrollment{
+		UserID:    currentUser.ID,
+		ProgramID: program.ID,
+		StartedAt: time.Now(),
+	}
+	for _, exerciseID := range exerciseIDs {
+		if weight, exists := trainingMaxes[exerciseID]; exists {
+			enrollment.TrainingMaxes = append(enrollment.TrainingMaxes, entity.ProgramTrainingMax{
+				ExerciseID: exerciseID,
+				Weight:     weight,
+			})
+		}
+	}
+
+	if err := s.repo.EnrollProgram(ctx, &enrollment); err != nil {
+		return nil, fmt.Errorf("failed to enroll program: %w", err)
+	}
+
+	return s.converter.ToModelProgramEnrollment(enrollment), nil
+}
+
+func (s *programService) LeaveProgram(ctx context.Context) (bool, error) {
+	currentUser, err := s.common.GetCurrentUser(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	ended, err := s.repo.EndActiveEnrollment(ctx, currentUser.ID)
+	if err != nil {
+		return false, fmt.Errorf("failed to leave program: %w", err)
+	}
+
+	return ended, nil
+}
+
+// GetTodaysWorkout はプログラムの処方で開始したワークアウトの数から次に行う日を求め、処方重量を計算する
+// プログラムを最後まで終えている場合、または参加を終了している場合はnilを返す
+func (s *programService) GetTodaysWorkout(ctx context.Context, enrollmentID string) (*model.PrescribedWorkout, error) {
+	currentUser, err := s.common.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	enrollment, err := s.repo.GetEnrollmentByID(ctx, enrollmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get program enrollment: %w", err)
+	}
+
+	if enrollment.UserID != currentUser.ID {
+		return nil, errcode.NewForbidden(ctx, "program enrollment is not owned by the current user")
+	}
+
+	if !enrollment.IsActive() {
+		return nil, nil
+	}
+
+	program, err := s.repo.GetProgramByID(ctx, fmt.Sprintf("%d", enrollment.ProgramID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get program: %w", err)
+	}
+
+	sessions, err := s.repo.CountSessions(ctx, enrollment.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count sessions: %w", err)
+	}
+
+	if sessions >= int64(len(program.Days)) {
+		return nil, nil
+	}
+	day := program.Days[sessions]
+
+	exercises := make([]*model.PrescribedExercise, len(day.Exercises))
+	for i, exercise := range day.Exercises {
+		trainingMax, exists := enrollment.TrainingMaxFor(exercise.ExerciseID)
+		if !exists {
+			// トレーニングマックスが分からない種目は重量を処方しない
+			exercises[i] = s.converter.ToModelPrescribedExercise(exercise, nil, nil)
+			continue
+		}
+
+		completed, err := s.repo.CountCompletedSessions(ctx, enrollment.ID, exercise.ExerciseID, exercise.Sets, exercise.Reps)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count completed sessions: %w", err)
+		}
+
+		progressed := exercise.ProgressedTrainingMax(trainingMax, int(completed))
+		weight := exercise.PrescribedWeight(progressed)
+		exercises[i] = s.converter.ToModelPrescribedExercise(exercise, &progressed, &weight)
+	}
+
+	return &model.PrescribedWorkout{
+		ProgramDay: s.converter.ToModelProgramDay(day),
+		Week:       int32(day.Week),
+		Day:        int32(day.Day),
+		Exercises:  exercises,
+	}, nil
+}
+
+// ヘルパー関数
+// resolveWeightUnit は入力の重量単位を決定する（未指定の場合はプロフィールの単位）
+func (s *programService) resolveWeightUnit(ctx context.Context, userID uint, unit *model.WeightUnit) (entity.WeightUnit, error) {
+	if unit != nil {
+		return entity.WeightUnitFromGraphQL(*unit), nil
+	}
+
+	weightUnit, err := s.common.GetPreferredWeightUnit(ctx, userID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get preferred weight unit: %w", err)
+	}
+	return weightUnit, nil
+}
@@ -0,0 +1,164 @@
+package program
+
+import (
+	"app/entity"
+	"app/graph/errcode"
+	"app/graph/model"
+	"app/graph/services/common"
+	"app/graph/services/policy"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// fakeCommonRepository はログイン中のコーチを固定で返す
+type fakeCommonRepository struct {
+	common.CommonRepository
+	user *entity.User
+}
+
+func (r *fakeCommonRepository) GetCurrentUser(ctx context.Context) (*entity.User, error) {
+	return r.user, nil
+}
+
+func (r *fakeCommonRepository) Authorize(ctx context.Context, permission policy.Permission) (*entity.User, error) {
+	return r.user, nil
+}
+
+// fakeOwnershipRepository は閲覧できる種目のみを返す
+type fakeOwnershipRepository struct {
+	policy.OwnershipRepository
+	visibleExerciseIDs []uint
+}
+
+func (r *fakeOwnershipRepository) GetVisibleExerciseIDs(ctx context.Context, userID uint, exerciseIDs []uint) ([]uint, error) {
+	return r.visibleExerciseIDs, nil
+}
+
+// fakeProgramRepository は作成したプログラムを保持し、参加ごとのワークアウトの数を返す
+type fakeProgramRepository struct {
+	ProgramRepository
+	created  []*entity.Program
+	program  *entity.Program
+	sessions map[uint]int64 // 参加IDごとのプログラムのワークアウトの数
+}
+
+func (r *fakeProgramRepository) GetProgramByID(ctx context.Context, id string) (*entity.Program, error) {
+	return r.program, nil
+}
+
+func (r *fakeProgramRepository) GetEnrollmentByID(ctx context.Context, id string) (*entity.ProgramEnrollment, error) {
+	return &entity.ProgramEnrollment{Model: gorm.Model{ID: 7}, UserID: 1, ProgramID: 1}, nil
+}
+
+func (r *fakeProgramRepository) CountSessions(ctx context.Context, enrollmentID uint) (int64, error) {
+	return r.sessions[enrollmentID], nil
+}
+
+func (r *fakeProgramRepository) CreateProgram(ctx context.Context, program *entity.Program) error {
+	r.created = append(r.created, program)
+	return nil
+}
+
+func TestProgramService_CreateProgram(t *testing.T) {
+	kg := model.WeightUnitKg
+	day := func(week, day int32, exerciseIDs ...string) *model.ProgramDayInput {
+		input := &model.ProgramDayInput{Week: week, Day: day}
+		for _, id := range exerciseIDs {
+			input.Exercises = append(input.Exercises, &model.ProgramExerciseInput{
+				ExerciseID:  id,
+				Sets:        3,
+				Reps:        5,
+				TargetType:  model.ProgramTargetTypePercentOneRepMax,
+				TargetValue: 75,
+			})
+		}
+		return input
+	}
+
+	tests := []struct {
+		name      string
+		days      []*model.ProgramDayInput
+		expectErr func(err error) bool
+	}{
+		{
+			name: "Visible exercises",
+			days: []*model.ProgramDayInput{day(1, 1, "1"), day(1, 2, "2")},
+		},
+		{
+			name:      "Exercise not visible or archived is rejected",
+			days:      []*model.ProgramDayInput{day(1, 1, "1"), day(1, 2, "3")},
+			expectErr: func(err error) bool { return errcode.Is(err, errcode.Forbidden) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeProgramRepository{}
+			service := &programService{
+				repo:      repo,
+				converter: NewProgramConverter(),
+				common:    &fakeCommonRepository{user: &entity.User{Model: gorm.Model{ID: 1}}},
+				ownership: policy.NewOwnershipPolicy(&fakeOwnershipRepository{visibleExerciseIDs: []uint{1, 2}}),
+			}
+
+			_, err := service.CreateProgram(context.Background(), model.CreateProgram{Name: "5/3/1", WeightUnit: &kg, Days: tt.days})
+			if tt.expectErr != nil {
+				assert.True(t, tt.expectErr(err), err)
+				assert.Empty(t, repo.created)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, repo.created, 1)
+		})
+	}
+}
+
+func TestProgramService_GetTodaysWorkout(t *testing.T) {
+	program := &entity.Program{
+		Model:  gorm.Model{ID: 1},
+		UserID: 1,
+		Days:   []entity.ProgramDay{{Week: 1, Day: 1}, {Week: 1, Day: 2}},
+	}
+
+	tests := []struct {
+		name        string
+		userID      uint
+		sessions    map[uint]int64
+		expectedDay *int32
+		expectErr   bool
+	}{
+		{name: "First day", userID: 1, sessions: map[uint]int64{}, expectedDay: ptr(int32(1))},
+		{name: "Only workouts of the enrollment are counted", userID: 1, sessions: map[uint]int64{7: 1, 8: 5}, expectedDay: ptr(int32(2))},
+		{name: "Program is finished", userID: 1, sessions: map[uint]int64{7: 2}},
+		{name: "Enrollment of another user is rejected", userID: 2, expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &programService{
+				repo:      &fakeProgramRepository{program: program, sessions: tt.sessions},
+				converter: NewProgramConverter(),
+				common:    &fakeCommonRepository{user: &entity.User{Model: gorm.Model{ID: tt.userID}}},
+			}
+
+			workout, err := service.GetTodaysWorkout(context.Background(), "7")
+			if tt.expectErr {
+				assert.True(t, errcode.Is(err, errcode.Forbidden), err)
+				return
+			}
+			assert.NoError(t, err)
+			if tt.expectedDay == nil {
+				assert.Nil(t, workout)
+				return
+			}
+			assert.Equal(t, *tt.expectedDay, workout.Day)
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,32 @@
+package recommendation
+
+import (
+	"app/entity"
+	"app/graph/model"
+	"app/graph/services/common"
+)
+
+type RecommendationConverter struct {
+	common *common.CommonConverter
+}
+
+func NewRecommendationConverter() *RecommendationConverter {
+	return &RecommendationConverter{
+		common: common.NewCommonConverter(),
+	}
+}
+
+func (c *RecommendationConverter) ToModelFriendRecommendation(user entity.User, signals entity.FriendRecommendationSignals) *model.FriendRecommendation {
+	sharedCategories := signals.SharedCategories
+	if sharedCategories == nil {
+		sharedCategories = []string{}
+	}
+	return &model.FriendRecommendation{
+		User:              c.common.ToModelUser(user),
+		Score:             signals.Score(),
+		MutualFriendCount: int32(signals.MutualFriends),
+		SharedGroupCount:  int32(signals.SharedGroups),
+		SharedCategories:  sharedCategories,
+		Reasons:           signals.Reasons(),
+	}
+}
@@ -0,0 +1,225 @@
+package recommendation
+
+import (
+	"app/entity"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// profileCandidateLimit はプロフィールが近いだけのユーザーを候補にする上限
+// 共通の友達・グループ・カテゴリがないユーザーでも推奨を表示するための補完で、全ユーザーは対象にしない
+const profileCandidateLimit = 200
+
+type RecommendationRepository interface {
+	GetExcludedUserIDs(ctx context.Context, userID uint) (map[uint]bool, error)
+	CountMutualFriends(ctx context.Context, userID uint) (map[uint]int, error)
+	CountSharedGroups(ctx context.Context, userID uint) (map[uint]int, error)
+	GetSharedCategories(ctx context.Context, userID uint, since time.Time) (map[uint][]string, error)
+	GetProfileCandidateIDs(ctx context.Context, profile *entity.Profile) ([]uint, error)
+	GetProfilesByUserIDs(ctx context.Context, userIDs []uint) (map[uint]*entity.Profile, error)
+	GetUsersByIDs(ctx context.Context, userIDs []uint) (map[uint]*entity.User, error)
+	CreateDismissal(ctx context.Context, dismissal *entity.RecommendationDismissal) error
+}
+
+type recommendationRepository struct {
+	db *gorm.DB
+}
+
+func NewRecommendationRepository(db *gorm.DB) RecommendationRepository {
+	return &recommendationRepository{db: db}
+}
+
+// GetExcludedUserIDs は推奨しないユーザーのIDを取得する
+// 自分自身と、ステータスに関わらず友達関係のレコードが存在するユーザー、どちらかがブロックしているユーザー、非表示にしたユーザー
+func (r *recommendationRepository) GetExcludedUserIDs(ctx context.Context, userID uint) (map[uint]bool, error) {
+	var ids []uint
+	if err := r.db.WithContext(ctx).Raw(`
+		SELECT CASE WHEN requester_id = @user THEN requestee_id ELSE requester_id END
+		FROM friendships
+		WHERE deleted_at IS NULL AND (requester_id = @user OR requestee_id = @user)
+		UNION
+		SELECT CASE WHEN blocker_id = @user THEN blocked_id ELSE blocker_id END
+		FROM user_blocks
+		WHERE deleted_at IS NULL AND (blocker_id = @user OR blocked_id = @user)
+		UNION
+		SELECT dismissed_user_id
+		FROM recommendation_dismissals
+		WHERE deleted_at IS NULL AND user_id = @user`,
+		map[string]interface{}{"user": userID}).
+		Scan(&ids).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch excluded users: %w", err)
+	}
+
+	excluded := map[uint]bool{userID: true}
+	for _, id := range ids {
+		excluded[id] = true
+	}
+	return excluded, nil
+}
+
+// CountMutualFriends は友達の友達ごとに共通の友達の数を数える
+func (r *recommendationRepository) CountMutualFriends(ctx context.Context, userID uint) (map[uint]int, error) {
+	var rows []struct {
+		UserID uint
+		Count  int
+	}
+	if err := r.db.WithContext(ctx).Raw(`
+		WITH friend_pairs AS (
+			SELECT requester_id AS owner_id, requestee_id AS user_id
+			FROM friendships WHERE deleted_at IS NULL AND status = @status
+			UNION ALL
+			SELECT requestee_id AS owner_id, requester_id AS user_id
+			FROM friendships WHERE deleted_at IS NULL AND status = @status
+		)
+		SELECT others.user_id, COUNT(*) AS count
+		FROM friend_pairs AS mine
+		JOIN friend_pairs AS others ON others.owner_id = mine.user_id
+		WHERE mine.owner_id = @user AND others.user_id <> @user
+		GROUP BY others.user_id`,
+		map[string]interface{}{"user": userID, "status": string(entity.Accepted)}).
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to count mutual friends: %w", err)
+	}
+
+	counts := make(map[uint]int, len(rows))
+	for _, row := range rows {
+		counts[row.UserID] = row.Count
+	}
+	return counts, nil
+}
+
+// CountSharedGroups はユーザーごとに一緒に参加しているワークアウトグループの数を数える
+func (r *recommendationRepository) CountSharedGroups(ctx context.Context, userID uint) (map[uint]int, error) {
+	var rows []struct {
+		UserID uint
+		Count  int
+	}
+	if err := r.db.WithContext(ctx).Raw(`
+		SELECT others.user_id, COUNT(DISTINCT others.workout_group_id) AS count
+		FROM workout_group_members AS mine
+		JOIN workout_group_members AS others ON others.workout_group_id = mine.workout_group_id
+		JOIN workout_groups ON workout_groups.id = mine.workout_group_id AND workout_groups.deleted_at IS NULL
+		WHERE mine.user_id = @user AND others.user_id <> @user
+		AND mine.status = @status AND others.status = @status
+		AND mine.deleted_at IS NULL AND others.deleted_at IS NULL
+		GROUP BY others.user_id`,
+		map[string]interface{}{"user": userID, "status": entity.WorkoutGroupMemberStatusJoined}).
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to count shared workout groups: %w", err)
+	}
+
+	counts := make(map[uint]int, len(rows))
+	for _, row := range rows {
+		counts[row.UserID] = row.Count
+	}
+	return counts, nil
+}
+
+// GetSharedCategories はユーザーごとにsince以降のワークアウトで共通して記録したトレーニングカテゴリを取得する
+func (r *recommendationRepository) GetSharedCategories(ctx context.Context, userID uint, since time.Time) (map[uint][]string, error) {
+	var rows []struct {
+		UserID   uint
+		Category string
+	}
+	if err := r.db.WithContext(ctx).Raw(`
+		WITH user_categories AS (
+			SELECT DISTINCT workouts.user_id, exercises.category
+			FROM workouts
+			JOIN workout_exercises ON workout_exercises.workout_id = workouts.id AND workout_exercises.deleted_at IS NULL
+			JOIN exercises ON exercises.id = workout_exercises.exercise_id
+			WHERE workouts.deleted_at IS NULL
+			AND COALESCE(workouts.date, workouts.created_at) >= @since
+			AND exercises.category <> ''
+		)
+		SELECT others.user_id, others.category
+		FROM user_categories AS mine
+		JOIN user_categories AS others ON others.category = mine.category
+		WHERE mine.user_id = @user AND others.user_id <> @user
+		ORDER BY others.user_id, others.category`,
+		map[string]interface{}{"user": userID, "since": since}).
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch shared categories: %w", err)
+	}
+
+	categories := make(map[uint][]string)
+	for _, row := range rows {
+		categories[row.UserID] = append(categories[row.UserID], row.Category)
+	}
+	return categories, nil
+}
+
+// GetProfileCandidateIDs は活動レベルが同じ、または年齢が近いユーザーのIDを新しい順に取得する
+func (r *recommendationRepository) GetProfileCandidateIDs(ctx context.Context, profile *entity.Profile) ([]uint, error) {
+	if profile == nil || (profile.ActivityLevel == "" && profile.BirthDate == nil) {
+		return nil, nil
+	}
+
+	var conditions []string
+	var args []interface{}
+	if profile.ActivityLevel != "" {
+		conditions = append(conditions, "activity_level = ?")
+		args = append(args, profile.ActivityLevel)
+	}
+	if profile.BirthDate != nil {
+		conditions = append(conditions, "birth_date BETWEEN ? AND ?")
+		args = append(args,
+			profile.BirthDate.AddDate(-entity.RecommendationAgeRange, 0, 0),
+			profile.BirthDate.AddDate(entity.RecommendationAgeRange, 0, 0))
+	}
+
+	var ids []uint
+	if err := r.db.WithContext(ctx).Model(&entity.Profile{}).
+		Where("user_id <> ?", profile.UserID).
+		Where("("+strings.Join(conditions, " OR ")+")", args...).
+		Order("user_id DESC").
+		Limit(profileCandidateLimit).
+		Pluck("user_id", &ids).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch profile candidates: %w", err)
+	}
+	return ids, nil
+}
+
+func (r *recommendationRepository) GetProfilesByUserIDs(ctx context.Context, userIDs []uint) (map[uint]*entity.Profile, error) {
+	profiles := make(map[uint]*entity.Profile, len(userIDs))
+	if len(userIDs) == 0 {
+		return profiles, nil
+	}
+
+	var rows []entity.Profile
+	if err := r.db.WithContext(ctx).Where("user_id IN ?", userIDs).Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch profiles: %w", err)
+	}
+	for i := range rows {
+		profiles[rows[i].UserID] = &rows[i]
+	}
+	return profiles, nil
+}
+
+func (r *recommendationRepository) GetUsersByIDs(ctx context.Context, userIDs []uint) (map[uint]*entity.User, error) {
+	users := make(map[uint]*entity.User, len(userIDs))
+	if len(userIDs) == 0 {
+		return users, nil
+	}
+
+	var rows []entity.User
+	if err := r.db.WithContext(ctx).Where("id IN ?", userIDs).Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch users: %w", err)
+	}
+	for i := range rows {
+		users[rows[i].ID] = &rows[i]
+	}
+	return users, nil
+}
+
+// CreateDismissal はユーザーを推奨から非表示にする（非表示にしていた場合は何もしない）
+func (r *recommendationRepository) CreateDismissal(ctx context.Context, dismissal *entity.RecommendationDismissal) error {
+	if err := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(dismissal).Error; err != nil {
+		return fmt.Errorf("failed to dismiss recommendation: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,186 @@
+package recommendation
+
+import (
+	"app/entity"
+	"app/graph/model"
+	"app/graph/services/common"
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+)
+
+const (
+	DefaultRecommendationCount = 20
+	MaxRecommendationCount     = 50
+)
+
+type RecommendationService interface {
+	GetFriendRecommendations(ctx context.Context, first *int32) ([]*model.FriendRecommendation, error)
+	DismissRecommendation(ctx context.Context, input model.DismissRecommendation) (bool, error)
+}
+
+type recommendationService struct {
+	repo      RecommendationRepository
+	converter *RecommendationConverter
+	common    common.CommonRepository
+}
+
+func NewRecommendationService(repo RecommendationRepository, converter *RecommendationConverter) RecommendationService {
+	return &recommendationService{
+		repo:      repo,
+		converter: converter,
+		common:    common.NewCommonRepository(repo.(*recommendationRepository).db),
+	}
+}
+
+// GetFriendRecommendations は現在のユーザーに友達として推奨するユーザーをスコアの高い順に取得する
+// 共通の友達・一緒に参加しているグループ・共通のトレーニングカテゴリ・プロフィールの近さでスコアを付け、共通点がないユーザーは含めない
+func (s *recommendationService) GetFriendRecommendations(ctx context.Context, first *int32) ([]*model.FriendRecommendation, error) {
+	currentUser, err := s.common.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	limit := DefaultRecommendationCount
+	if first != nil {
+		if *first < 0 {
+			return nil, fmt.Errorf("first must be a non-negative integer")
+		}
+		limit = min(int(*first), MaxRecommendationCount)
+	}
+
+	signals, err := s.collectSignals(ctx, currentUser.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// スコアの高い順（同点の場合は新しいユーザー順）に並べる
+	candidateIDs := make([]uint, 0, len(signals))
+	for id, signal := range signals {
+		if signal.Score() > 0 {
+			candidateIDs = append(candidateIDs, id)
+		}
+	}
+	sort.Slice(candidateIDs, func(i, j int) bool {
+		scoreI, scoreJ := signals[candidateIDs[i]].Score(), signals[candidateIDs[j]].Score()
+		if scoreI != scoreJ {
+			return scoreI > scoreJ
+		}
+		return candidateIDs[i] > candidateIDs[j]
+	})
+
+	// 削除済みのユーザーを除くためにユーザーを取得してから件数を絞る
+	users, err := s.repo.GetUsersByIDs(ctx, candidateIDs)
+	if err != nil {
+		return nil, err
+	}
+	recommendations := make([]*model.FriendRecommendation, 0, limit)
+	for _, id := range candidateIDs {
+		if len(recommendations) == limit {
+			break
+		}
+		if user, ok := users[id]; ok {
+			recommendations = append(recommendations, s.converter.ToModelFriendRecommendation(*user, *signals[id]))
+		}
+	}
+	return recommendations, nil
+}
+
+// DismissRecommendation はユーザーを推奨から非表示にする（友達リクエストは引き続き送れる）
+func (s *recommendationService) DismissRecommendation(ctx context.Context, input model.DismissRecommendation) (bool, error) {
+	currentUser, err := s.common.GetCurrentUser(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	dismissedID, err := strconv.ParseUint(input.UserID, 10, 32)
+	if err != nil {
+		return false, fmt.Errorf("invalid user ID: %s", input.UserID)
+	}
+
+	dismissal := &entity.RecommendationDismissal{UserID: currentUser.ID, DismissedUserID: uint(dismissedID)}
+	if err := s.repo.CreateDismissal(ctx, dismissal); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// ヘルパー関数
+// collectSignals は推奨の候補ごとに共通点を集める（友達関係・ブロック・非表示のユーザーは除く）
+func (s *recommendationService) collectSignals(ctx context.Context, userID uint) (map[uint]*entity.FriendRecommendationSignals, error) {
+	excluded, err := s.repo.GetExcludedUserIDs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	signals := make(map[uint]*entity.FriendRecommendationSignals)
+	signalOf := func(id uint) *entity.FriendRecommendationSignals {
+		if excluded[id] {
+			return nil
+		}
+		if _, ok := signals[id]; !ok {
+			signals[id] = &entity.FriendRecommendationSignals{}
+		}
+		return signals[id]
+	}
+
+	mutualFriends, err := s.repo.CountMutualFriends(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for id, count := range mutualFriends {
+		if signal := signalOf(id); signal != nil {
+			signal.MutualFriends = count
+		}
+	}
+
+	sharedGroups, err := s.repo.CountSharedGroups(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for id, count := range sharedGroups {
+		if signal := signalOf(id); signal != nil {
+			signal.SharedGroups = count
+		}
+	}
+
+	sharedCategories, err := s.repo.GetSharedCategories(ctx, userID, time.Now().Add(-entity.RecommendationCategoryPeriod))
+	if err != nil {
+		return nil, err
+	}
+	for id, categories := range sharedCategories {
+		if signal := signalOf(id); signal != nil {
+			signal.SharedCategories = categories
+		}
+	}
+
+	// プロフィールが近いユーザーも候補に加え、全ての候補のプロフィールを比べる
+	profiles, err := s.repo.GetProfilesByUserIDs(ctx, []uint{userID})
+	if err != nil {
+		return nil, err
+	}
+	profile := profiles[userID]
+	profileCandidateIDs, err := s.repo.GetProfileCandidateIDs(ctx, profile)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range profileCandidateIDs {
+		signalOf(id)
+	}
+
+	candidateIDs := make([]uint, 0, len(signals))
+	for id := range signals {
+		candidateIDs = append(candidateIDs, id)
+	}
+	candidateProfiles, err := s.repo.GetProfilesByUserIDs(ctx, candidateIDs)
+	if err != nil {
+		return nil, err
+	}
+	for id, signal := range signals {
+		signal.CompareProfiles(profile, candidateProfiles[id])
+	}
+
+	return signals, nil
+}
@@ -0,0 +1,23 @@
+package role
+
+import (
+	"app/entity"
+	"app/graph/model"
+)
+
+type RoleConverter struct{}
+
+func NewRoleConverter() *RoleConverter {
+	return &RoleConverter{}
+}
+
+// ToModelRoles はユーザーに付与されたロールを一般ユーザーを含むロール一覧に変換
+func (c *RoleConverter) ToModelRoles(userRoles []*entity.UserRole) []model.Role {
+	result := []model.Role{model.RoleUser}
+	for _, userRole := range userRoles {
+		if userRole != nil && userRole.Role.IsValid() {
+			result = append(result, userRole.Role.ToGraphQL())
+		}
+	}
+	return result
+}
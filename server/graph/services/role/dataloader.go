@@ -0,0 +1,51 @@
+package role
+
+import (
+	"app/entity"
+	"app/graph/services/common/base"
+	"context"
+)
+
+// RoleDataLoader は UserRole エンティティの遅延ローディングを担当
+type RoleDataLoader struct {
+	repository     RoleRepository
+	byUserIDLoader *base.BaseArrayLoader[entity.UserRole]
+}
+
+// NewRoleDataLoader は新しいDataLoaderを作成
+func NewRoleDataLoader(repository RoleRepository) *RoleDataLoader {
+	loader := &RoleDataLoader{
+		repository: repository,
+	}
+
+	// ByUserID用のローダー
+	loader.byUserIDLoader = base.NewBaseArrayLoader(
+		nil, // dbは不要（repositoryを使用）
+		loader.fetchByUserIDs,
+		loader.createUserIDMap,
+		base.ParseUintKey,
+	)
+
+	return loader
+}
+
+// LoadByUserID は指定されたUserIDのロールを取得
+func (l *RoleDataLoader) LoadByUserID(ctx context.Context, userID string) ([]*entity.UserRole, error) {
+	return l.byUserIDLoader.Load(ctx, userID)
+}
+
+// fetchByUserIDs はRepository経由でUserID別にデータを取得
+func (l *RoleDataLoader) fetchByUserIDs(userIDs []uint) ([]*entity.UserRole, error) {
+	return l.repository.GetRolesByUserIDs(userIDs)
+}
+
+// createUserIDMap はUserID別にデータをマップ化
+func (l *RoleDataLoader) createUserIDMap(roles []*entity.UserRole) map[uint][]*entity.UserRole {
+	result := make(map[uint][]*entity.UserRole)
+	for _, role := range roles {
+		if role != nil {
+			result[role.UserID] = append(result[role.UserID], role)
+		}
+	}
+	return result
+}
@@ -0,0 +1,107 @@
+package role
+
+import (
+	"app/entity"
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type RoleRepository interface {
+	GetUserByID(ctx context.Context, userID string) (*entity.User, error)
+	GrantRole(ctx context.Context, user *entity.User, role entity.Role) error
+	RevokeRole(ctx context.Context, user *entity.User, role entity.Role) error
+
+	// バッチ取得メソッド（DataLoader用）
+	GetRolesByUserIDs(userIDs []uint) ([]*entity.UserRole, error)
+}
+
+type roleRepository struct {
+	db *gorm.DB
+}
+
+func NewRoleRepository(db *gorm.DB) RoleRepository {
+	return &roleRepository{db: db}
+}
+
+// GetUserByID はユーザーをロール付きで取得
+func (r *roleRepository) GetUserByID(ctx context.Context, userID string) (*entity.User, error) {
+	id, err := strconv.ParseUint(userID, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %s", userID)
+	}
+
+	var user entity.User
+	if err := r.db.Preload("Roles").First(&user, uint(id)).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch user: %w", err)
+	}
+
+	return &user, nil
+}
+
+// GrantRole はロールを付与し、ロールの変更日時を更新する（付与済みの場合は何もしない）
+func (r *roleRepository) GrantRole(ctx context.Context, user *entity.User, role entity.Role) error {
+	if user.HasRole(role) {
+		return nil
+	}
+
+	userRole := entity.UserRole{UserID: user.ID, Role: role}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&userRole).Error; err != nil {
+			return err
+		}
+		user.Roles = append(user.Roles, userRole)
+		return touchRolesSyncedAt(tx, user)
+	})
+}
+
+// RevokeRole はロールを剥奪し、ロールの変更日時を更新する
+func (r *roleRepository) RevokeRole(ctx context.Context, user *entity.User, role entity.Role) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// 再付与できるように物理削除する（user_id, roleのユニーク制約）
+		if err := tx.Unscoped().Where("user_id = ? AND role = ?", user.ID, role).Delete(&entity.UserRole{}).Error; err != nil {
+			return err
+		}
+
+		roles := make([]entity.UserRole, 0, len(user.Roles))
+		for _, userRole := range user.Roles {
+			if userRole.Role != role {
+				roles = append(roles, userRole)
+			}
+		}
+		user.Roles = roles
+		return touchRolesSyncedAt(tx, user)
+	})
+}
+
+// GetRolesByUserIDs はバッチでUserIDsからロールを取得
+func (r *roleRepository) GetRolesByUserIDs(userIDs []uint) ([]*entity.UserRole, error) {
+	if len(userIDs) == 0 {
+		return []*entity.UserRole{}, nil
+	}
+
+	var roles []entity.UserRole
+	if err := r.db.Where("user_id IN ?", userIDs).Order("id ASC").Find(&roles).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch roles by user IDs: %w", err)
+	}
+
+	// ポインタスライスに変換
+	result := make([]*entity.UserRole, len(roles))
+	for i := range roles {
+		result[i] = &roles[i]
+	}
+	return result, nil
+}
+
+// touchRolesSyncedAt はロールの変更日時を記録する（これより前に発行されたトークンのクレームで上書きされないようにする）
+func touchRolesSyncedAt(tx *gorm.DB, user *entity.User) error {
+	now := time.Now()
+	if err := tx.Model(user).Update("roles_synced_at", now).Error; err != nil {
+		return err
+	}
+	user.RolesSyncedAt = &now
+	return nil
+}
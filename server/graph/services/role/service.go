@@ -0,0 +1,114 @@
+package role
+
+import (
+	"app/entity"
+	"app/graph/errcode"
+	"app/graph/model"
+	"app/graph/services/common"
+	"app/graph/services/policy"
+	"context"
+	"fmt"
+	"log"
+)
+
+// ClaimsPublisher はロールを認証基盤のカスタムクレームに反映する
+type ClaimsPublisher interface {
+	SetRoles(ctx context.Context, uid string, roles []entity.Role) error
+}
+
+type RoleService interface {
+	GrantRole(ctx context.Context, input model.GrantRole) (*model.User, error)
+	RevokeRole(ctx context.Context, input model.RevokeRole) (*model.User, error)
+	// DataLoader使用メソッド
+	GetRolesByUserIDWithDataLoader(ctx context.Context, userID string) ([]model.Role, error)
+}
+
+type roleService struct {
+	repo       RoleRepository
+	converter  *RoleConverter
+	dataLoader *RoleDataLoader
+	publisher  ClaimsPublisher
+	common     common.CommonRepository
+}
+
+func NewRoleService(repo RoleRepository, converter *RoleConverter, dataLoader *RoleDataLoader, publisher ClaimsPublisher) RoleService {
+	return &roleService{
+		repo:       repo,
+		converter:  converter,
+		dataLoader: dataLoader,
+		publisher:  publisher,
+		common:     common.NewCommonRepository(repo.(*roleRepository).db),
+	}
+}
+
+func (s *roleService) GrantRole(ctx context.Context, input model.GrantRole) (*model.User, error) {
+	if _, err := s.common.Authorize(ctx, policy.ManageRoles); err != nil {
+		return nil, err
+	}
+
+	role := entity.RoleFromGraphQL(input.Role)
+	if role == entity.RoleUser {
+		return nil, fmt.Errorf("the user role is granted implicitly")
+	}
+
+	user, err := s.repo.GetUserByID(ctx, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.GrantRole(ctx, user, role); err != nil {
+		return nil, fmt.Errorf("failed to grant role: %w", err)
+	}
+	s.publishClaims(ctx, user)
+
+	return common.NewCommonConverter().ToModelUser(*user), nil
+}
+
+func (s *roleService) RevokeRole(ctx context.Context, input model.RevokeRole) (*model.User, error) {
+	currentUser, err := s.common.Authorize(ctx, policy.ManageRoles)
+	if err != nil {
+		return nil, err
+	}
+
+	role := entity.RoleFromGraphQL(input.Role)
+	if role == entity.RoleUser {
+		return nil, fmt.Errorf("the user role cannot be revoked")
+	}
+
+	user, err := s.repo.GetUserByID(ctx, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 管理者が不在にならないよう、自分自身の管理者ロールは剥奪できない
+	if user.ID == currentUser.ID && role == entity.RoleAdmin {
+		return nil, errcode.NewForbidden(ctx, "cannot revoke your own admin role")
+	}
+
+	if err := s.repo.RevokeRole(ctx, user, role); err != nil {
+		return nil, fmt.Errorf("failed to revoke role: %w", err)
+	}
+	s.publishClaims(ctx, user)
+
+	return common.NewCommonConverter().ToModelUser(*user), nil
+}
+
+// DataLoader使用メソッド
+func (s *roleService) GetRolesByUserIDWithDataLoader(ctx context.Context, userID string) ([]model.Role, error) {
+	roles, err := s.dataLoader.LoadByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get roles: %w", err)
+	}
+	return s.converter.ToModelRoles(roles), nil
+}
+
+// publishClaims はロールをカスタムクレームに反映する
+// DBのロールが正であり、クレームはクライアントがトークンを更新した時点で反映されればよいため失敗してもエラーにしない
+func (s *roleService) publishClaims(ctx context.Context, user *entity.User) {
+	if s.publisher == nil {
+		return
+	}
+	if err := s.publisher.SetRoles(ctx, user.UID, user.RoleList()); err != nil {
+		log.Printf("failed to publish role claims for user %d: %v", user.ID, err)
+	}
+}
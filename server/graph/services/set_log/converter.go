@@ -13,11 +13,38 @@ func NewSetLogConverter() *SetLogConverter {
 }
 
 func (c *SetLogConverter) ToModelSetLog(setLog entity.SetLog) *model.SetLog {
+	toInt32 := func(v *int) *int32 {
+		if v == nil {
+			return nil
+		}
+		converted := int32(*v)
+		return &converted
+	}
+
+	var tempo *string
+	if setLog.Tempo != "" {
+		tempo = &setLog.Tempo
+	}
+
+	setType := setLog.SetType
+	if setType == "" {
+		setType = entity.SetTypeWorking
+	}
+
 	return &model.SetLog{
-		ID:        fmt.Sprintf("%d", setLog.ID),
-		Weight:    int32(setLog.Weight),
-		RepCount:  int32(setLog.RepCount),
-		SetNumber: int32(setLog.SetNumber),
+		ID:              fmt.Sprintf("%d", setLog.ID),
+		WeightKg:        setLog.Weight,
+		RepCount:        int32(setLog.RepCount),
+		SetNumber:       int32(setLog.SetNumber),
+		SetType:         setType.ToGraphQL(),
+		Rpe:             setLog.RPE,
+		Rir:             toInt32(setLog.RIR),
+		Tempo:           tempo,
+		RestSeconds:     toInt32(setLog.RestSeconds),
+		Completed:       setLog.Completed,
+		DurationSeconds: toInt32(setLog.DurationSeconds),
+		DistanceMeters:  setLog.DistanceMeters,
+		HeartRate:       toInt32(setLog.HeartRate),
 	}
 }
 
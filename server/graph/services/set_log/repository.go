@@ -2,6 +2,7 @@ package set_log
 
 import (
 	"app/entity"
+	"app/graph/services/personal_record"
 	"context"
 	"fmt"
 	"strconv"
@@ -11,8 +12,12 @@ import (
 
 type SetLogRepository interface {
 	GetSetLogsByWorkoutExerciseID(ctx context.Context, workoutExerciseID string) ([]*entity.SetLog, error)
-	CreateSetLog(ctx context.Context, setLog *entity.SetLog) error
+	GetSetLogByID(ctx context.Context, setLogID string) (*entity.SetLog, error)
+	CreateSetLog(ctx context.Context, setLog *entity.SetLog) ([]*entity.PersonalRecord, error)
+	UpdateSetLog(ctx context.Context, setLog *entity.SetLog) error
+	ReorderSetLogs(ctx context.Context, workoutExerciseID uint, orderedIDs []uint) ([]*entity.SetLog, error)
 	DeleteSetLog(ctx context.Context, setLogID string) error
+	GetWorkoutByWorkoutExerciseID(ctx context.Context, workoutExerciseID uint) (*entity.Workout, error)
 	// Batch methods for DataLoader
 	GetSetLogsByWorkoutExerciseIDs(workoutExerciseIDs []uint) ([]*entity.SetLog, error)
 }
@@ -45,12 +50,128 @@ func (r *setLogRepository) GetSetLogsByWorkoutExerciseID(ctx context.Context, wo
 	return result, nil
 }
 
-func (r *setLogRepository) CreateSetLog(ctx context.Context, setLog *entity.SetLog) error {
-	return r.db.Create(setLog).Error
+func (r *setLogRepository) GetSetLogByID(ctx context.Context, setLogID string) (*entity.SetLog, error) {
+	id, err := strconv.ParseUint(setLogID, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid set log ID: %s", setLogID)
+	}
+
+	var setLog entity.SetLog
+	if err := r.db.Where("id = ?", uint(id)).First(&setLog).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch set log: %w", err)
+	}
+
+	return &setLog, nil
+}
+
+// GetWorkoutByWorkoutExerciseID はワークアウト種目が属するワークアウトを取得する
+func (r *setLogRepository) GetWorkoutByWorkoutExerciseID(ctx context.Context, workoutExerciseID uint) (*entity.Workout, error) {
+	var workout entity.Workout
+	if err := r.db.WithContext(ctx).
+		Joins("JOIN workout_exercises ON workout_exercises.workout_id = workouts.id AND workout_exercises.deleted_at IS NULL").
+		Where("workout_exercises.id = ?", workoutExerciseID).
+		First(&workout).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch workout: %w", err)
+	}
+
+	return &workout, nil
+}
+
+// CreateSetLog はセットを作成し、同じトランザクションで自己ベストを更新する（新たに達成した自己ベストを返す）
+func (r *setLogRepository) CreateSetLog(ctx context.Context, setLog *entity.SetLog) ([]*entity.PersonalRecord, error) {
+	var records []*entity.PersonalRecord
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(setLog).Error; err != nil {
+			return err
+		}
+		created, err := personal_record.NewPersonalRecordRepository(tx).RecordSetLog(ctx, setLog)
+		if err != nil {
+			return err
+		}
+		records = created
+		return nil
+	})
+	return records, err
+}
+
+// UpdateSetLog はセットを更新し、同じトランザクションで自己ベストを再計算する
+func (r *setLogRepository) UpdateSetLog(ctx context.Context, setLog *entity.SetLog) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(setLog).Error; err != nil {
+			return err
+		}
+		return personal_record.NewPersonalRecordRepository(tx).RebuildByWorkoutExerciseID(ctx, setLog.WorkoutExerciseID)
+	})
+}
+
+// ReorderSetLogs は orderedIDs の順に SetNumber を1から振り直す（1トランザクションで実行）
+func (r *setLogRepository) ReorderSetLogs(ctx context.Context, workoutExerciseID uint, orderedIDs []uint) ([]*entity.SetLog, error) {
+	var result []*entity.SetLog
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var setLogs []entity.SetLog
+		if err := tx.Where("workout_exercise_id = ?", workoutExerciseID).Find(&setLogs).Error; err != nil {
+			return fmt.Errorf("failed to fetch set logs: %w", err)
+		}
+
+		renumbered, err := renumberSetLogs(setLogs, orderedIDs)
+		if err != nil {
+			return fmt.Errorf("workout exercise %d: %w", workoutExerciseID, err)
+		}
+		for _, setLog := range renumbered {
+			if err := tx.Save(setLog).Error; err != nil {
+				return fmt.Errorf("failed to update set log %d: %w", setLog.ID, err)
+			}
+		}
+		result = renumbered
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// renumberSetLogs は orderedIDs の順に SetNumber を1から振り直す
+// orderedIDs は WorkoutExercise の全セットを重複なく含む必要がある
+func renumberSetLogs(setLogs []entity.SetLog, orderedIDs []uint) ([]*entity.SetLog, error) {
+	if len(setLogs) != len(orderedIDs) {
+		return nil, fmt.Errorf("ordered IDs must contain every set log")
+	}
+	setLogMap := make(map[uint]*entity.SetLog, len(setLogs))
+	for i := range setLogs {
+		setLogMap[setLogs[i].ID] = &setLogs[i]
+	}
+
+	result := make([]*entity.SetLog, 0, len(orderedIDs))
+	for i, id := range orderedIDs {
+		setLog, exists := setLogMap[id]
+		if !exists {
+			return nil, fmt.Errorf("set log %d is duplicated or does not belong to the workout exercise", id)
+		}
+		delete(setLogMap, id)
+
+		setLog.SetNumber = i + 1
+		result = append(result, setLog)
+	}
+
+	return result, nil
 }
 
+// DeleteSetLog はセットを削除し、同じトランザクションで自己ベストを再計算する
 func (r *setLogRepository) DeleteSetLog(ctx context.Context, setLogID string) error {
-	return r.db.Delete(&entity.SetLog{}, setLogID).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var setLog entity.SetLog
+		if err := tx.Where("id = ?", setLogID).First(&setLog).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&setLog).Error; err != nil {
+			return err
+		}
+		return personal_record.NewPersonalRecordRepository(tx).RebuildByWorkoutExerciseID(ctx, setLog.WorkoutExerciseID)
+	})
 }
 
 // Batch methods for DataLoader
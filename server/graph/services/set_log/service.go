@@ -4,14 +4,23 @@ import (
 	"app/entity"
 	"app/graph/model"
 	"app/graph/services/common"
+	"app/graph/services/event"
+	"app/graph/services/notification"
+	"app/graph/services/policy"
 	"context"
 	"fmt"
+	"log"
 	"strconv"
+	"strings"
+	"time"
 )
 
 type SetLogService interface {
+	GetSetLogByID(ctx context.Context, setLogID string) (*model.SetLog, error)
 	GetSetLogsByWorkoutExerciseID(ctx context.Context, workoutExerciseID string) ([]*model.SetLog, error)
 	CreateSetLog(ctx context.Context, input model.CreateSetLog) (*model.SetLog, error)
+	UpdateSetLog(ctx context.Context, input model.UpdateSetLog) (*model.SetLog, error)
+	ReorderSetLogs(ctx context.Context, input model.ReorderSetLogs) ([]*model.SetLog, error)
 	DeleteSetLog(ctx context.Context, input model.DeleteSetLog) (bool, error)
 	// DataLoader使用メソッド
 	GetSetLogsByWorkoutExerciseIDWithDataLoader(ctx context.Context, workoutExerciseID string) ([]*model.SetLog, error)
@@ -21,18 +30,32 @@ type setLogService struct {
 	repo       SetLogRepository
 	converter  *SetLogConverter
 	common     common.CommonRepository
+	ownership  *policy.OwnershipPolicy
+	events     *event.Bus
+	notifier   *notification.Notifier
 	dataLoader *SetLogDataLoader // DataLoaderを統合
 }
 
-func NewSetLogService(repo SetLogRepository, converter *SetLogConverter, dataLoader *SetLogDataLoader) SetLogService {
+func NewSetLogService(repo SetLogRepository, converter *SetLogConverter, dataLoader *SetLogDataLoader, events *event.Bus) SetLogService {
 	return &setLogService{
 		repo:       repo,
 		converter:  converter,
 		common:     common.NewCommonRepository(repo.(*setLogRepository).db),
+		ownership:  policy.NewOwnershipPolicy(policy.NewOwnershipRepository(repo.(*setLogRepository).db)),
+		events:     events,
+		notifier:   notification.NewNotifier(repo.(*setLogRepository).db),
 		dataLoader: dataLoader,
 	}
 }
 
+func (s *setLogService) GetSetLogByID(ctx context.Context, setLogID string) (*model.SetLog, error) {
+	setLog, err := s.repo.GetSetLogByID(ctx, setLogID)
+	if err != nil {
+		return nil, err
+	}
+	return s.converter.ToModelSetLog(*setLog), nil
+}
+
 func (s *setLogService) GetSetLogsByWorkoutExerciseID(ctx context.Context, workoutExerciseID string) ([]*model.SetLog, error) {
 	setLogs, err := s.repo.GetSetLogsByWorkoutExerciseID(ctx, workoutExerciseID)
 	if err != nil {
@@ -41,33 +64,238 @@ func (s *setLogService) GetSetLogsByWorkoutExerciseID(ctx context.Context, worko
 	return s.converter.ToModelSetLogsFromPointers(setLogs), nil
 }
 
+// CreateSetLog はセットを記録する（ワークアウトの所有者のみ）
 func (s *setLogService) CreateSetLog(ctx context.Context, input model.CreateSetLog) (*model.SetLog, error) {
 	workoutExerciseID, err := strconv.ParseUint(input.WorkoutExerciseID, 10, 32)
 	if err != nil {
 		return nil, fmt.Errorf("invalid workout exercise ID: %s", input.WorkoutExerciseID)
 	}
 
+	if err := s.authorizeWorkoutExercise(ctx, uint(workoutExerciseID)); err != nil {
+		return nil, err
+	}
+
+	// 重量・レップ数は記録方法によっては省略できる（必須項目はエンティティで確認する）
 	setLog := entity.SetLog{
 		WorkoutExerciseID: uint(workoutExerciseID),
-		Weight:            int(*input.Weight),
-		RepCount:          int(*input.RepCount),
 		SetNumber:         int(input.SetNumber),
+		RPE:               input.Rpe,
+		Completed:         input.Completed == nil || *input.Completed,
+		DistanceMeters:    input.DistanceMeters,
+	}
+	if input.Weight != nil {
+		weight, err := s.toKilograms(ctx, *input.Weight, input.WeightUnit)
+		if err != nil {
+			return nil, err
+		}
+		setLog.Weight = weight
+	}
+	if input.RepCount != nil {
+		setLog.RepCount = int(*input.RepCount)
+	}
+	if input.SetType != nil {
+		setLog.SetType = entity.SetTypeFromGraphQL(*input.SetType)
+	}
+	if input.Rir != nil {
+		rir := int(*input.Rir)
+		setLog.RIR = &rir
+	}
+	if input.Tempo != nil {
+		setLog.Tempo = strings.ToUpper(*input.Tempo)
+	}
+	if input.RestSeconds != nil {
+		restSeconds := int(*input.RestSeconds)
+		setLog.RestSeconds = &restSeconds
+	}
+	if input.DurationSeconds != nil {
+		durationSeconds := int(*input.DurationSeconds)
+		setLog.DurationSeconds = &durationSeconds
+	}
+	if input.HeartRate != nil {
+		heartRate := int(*input.HeartRate)
+		setLog.HeartRate = &heartRate
 	}
 
-	if err := s.repo.CreateSetLog(ctx, &setLog); err != nil {
+	records, err := s.repo.CreateSetLog(ctx, &setLog)
+	if err != nil {
 		return nil, fmt.Errorf("failed to create set log: %w", err)
 	}
 
+	// 1つのセットで複数の自己ベストを更新しても、友達への通知は最高重量・推定1RMの1件にまとめる
+	if record := entity.HeadlinePersonalRecord(records); record != nil {
+		s.notifier.NotifyPersonalRecord(ctx, *record)
+	}
+	if setLog.Completed {
+		s.publishSetLogged(ctx, setLog)
+	}
+
 	return s.converter.ToModelSetLog(setLog), nil
 }
 
+// UpdateSetLog はセットを更新する（ワークアウトの所有者のみ）
+// 他のユーザーがセットの有無を確認できないよう、取得する前に所有者を確認する
+func (s *setLogService) UpdateSetLog(ctx context.Context, input model.UpdateSetLog) (*model.SetLog, error) {
+	setLogID, err := strconv.ParseUint(input.SetLogID, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid set log ID: %s", input.SetLogID)
+	}
+
+	if err := s.authorizeSetLog(ctx, uint(setLogID)); err != nil {
+		return nil, err
+	}
+
+	setLog, err := s.repo.GetSetLogByID(ctx, input.SetLogID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get set log: %w", err)
+	}
+
+	// 更新可能なフィールドのみ更新
+	if input.Weight != nil {
+		weight, err := s.toKilograms(ctx, *input.Weight, input.WeightUnit)
+		if err != nil {
+			return nil, err
+		}
+		setLog.Weight = weight
+	}
+	if input.RepCount != nil {
+		setLog.RepCount = int(*input.RepCount)
+	}
+	if input.SetType != nil {
+		setLog.SetType = entity.SetTypeFromGraphQL(*input.SetType)
+	}
+	if input.Rpe != nil {
+		setLog.RPE = input.Rpe
+	}
+	if input.Rir != nil {
+		rir := int(*input.Rir)
+		setLog.RIR = &rir
+	}
+	if input.Tempo != nil {
+		setLog.Tempo = strings.ToUpper(*input.Tempo)
+	}
+	if input.RestSeconds != nil {
+		restSeconds := int(*input.RestSeconds)
+		setLog.RestSeconds = &restSeconds
+	}
+	if input.Completed != nil {
+		setLog.Completed = *input.Completed
+	}
+	if input.DurationSeconds != nil {
+		durationSeconds := int(*input.DurationSeconds)
+		setLog.DurationSeconds = &durationSeconds
+	}
+	if input.DistanceMeters != nil {
+		setLog.DistanceMeters = input.DistanceMeters
+	}
+	if input.HeartRate != nil {
+		heartRate := int(*input.HeartRate)
+		setLog.HeartRate = &heartRate
+	}
+
+	if err := s.repo.UpdateSetLog(ctx, setLog); err != nil {
+		return nil, fmt.Errorf("failed to update set log: %w", err)
+	}
+
+	return s.converter.ToModelSetLog(*setLog), nil
+}
+
+func (s *setLogService) ReorderSetLogs(ctx context.Context, input model.ReorderSetLogs) ([]*model.SetLog, error) {
+	workoutExerciseID, err := strconv.ParseUint(input.WorkoutExerciseID, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid workout exercise ID: %s", input.WorkoutExerciseID)
+	}
+
+	if err := s.authorizeWorkoutExercise(ctx, uint(workoutExerciseID)); err != nil {
+		return nil, err
+	}
+
+	orderedIDs := make([]uint, len(input.OrderedIDs))
+	for i, orderedID := range input.OrderedIDs {
+		id, err := strconv.ParseUint(orderedID, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid set log ID: %s", orderedID)
+		}
+		orderedIDs[i] = uint(id)
+	}
+
+	setLogs, err := s.repo.ReorderSetLogs(ctx, uint(workoutExerciseID), orderedIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reorder set logs: %w", err)
+	}
+
+	return s.converter.ToModelSetLogsFromPointers(setLogs), nil
+}
+
 func (s *setLogService) DeleteSetLog(ctx context.Context, input model.DeleteSetLog) (bool, error) {
+	setLogID, err := strconv.ParseUint(input.SetLogID, 10, 32)
+	if err != nil {
+		return false, fmt.Errorf("invalid set log ID: %s", input.SetLogID)
+	}
+
+	if err := s.authorizeSetLog(ctx, uint(setLogID)); err != nil {
+		return false, err
+	}
+
 	if err := s.repo.DeleteSetLog(ctx, input.SetLogID); err != nil {
 		return false, fmt.Errorf("failed to delete set log: %w", err)
 	}
 	return true, nil
 }
 
+// ヘルパー関数
+// toKilograms は入力された重量をkgに変換する（単位未指定の場合はプロフィールの単位を使用）
+func (s *setLogService) toKilograms(ctx context.Context, weight float64, unit *model.WeightUnit) (float64, error) {
+	if unit != nil {
+		return entity.ToKilograms(weight, entity.WeightUnitFromGraphQL(*unit)), nil
+	}
+
+	preferredUnit, err := s.common.GetViewerWeightUnit(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get preferred weight unit: %w", err)
+	}
+
+	return entity.ToKilograms(weight, preferredUnit), nil
+}
+
+// publishSetLogged はグループのワークアウトでセットを記録したことをグループのメンバーに配信する
+func (s *setLogService) publishSetLogged(ctx context.Context, setLog entity.SetLog) {
+	workout, err := s.repo.GetWorkoutByWorkoutExerciseID(ctx, setLog.WorkoutExerciseID)
+	if err != nil {
+		log.Printf("failed to publish set logged event: %v", err)
+		return
+	}
+	if workout.WorkoutGroupID == nil {
+		return
+	}
+
+	s.events.PublishWorkoutGroupActivity(ctx, event.WorkoutGroupActivity{
+		Type:           event.WorkoutGroupActivitySetLogged,
+		WorkoutGroupID: *workout.WorkoutGroupID,
+		UserID:         workout.UserID,
+		WorkoutID:      &workout.ID,
+		SetLogID:       &setLog.ID,
+		OccurredAt:     time.Now(),
+	})
+}
+
+// authorizeWorkoutExercise は WorkoutExercise -> Workout.UserID を辿って現在のユーザーの所有物か確認する
+func (s *setLogService) authorizeWorkoutExercise(ctx context.Context, workoutExerciseID uint) error {
+	currentUser, err := s.common.GetCurrentUser(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get current user: %w", err)
+	}
+	return s.ownership.AuthorizeWorkoutExercise(ctx, currentUser, workoutExerciseID)
+}
+
+// authorizeSetLog は SetLog -> WorkoutExercise -> Workout.UserID を辿って現在のユーザーの所有物か確認する
+func (s *setLogService) authorizeSetLog(ctx context.Context, setLogID uint) error {
+	currentUser, err := s.common.GetCurrentUser(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get current user: %w", err)
+	}
+	return s.ownership.AuthorizeSetLog(ctx, currentUser, setLogID)
+}
+
 // DataLoader使用メソッド
 func (s *setLogService) GetSetLogsByWorkoutExerciseIDWithDataLoader(ctx context.Context, workoutExerciseID string) ([]*model.SetLog, error) {
 	// 既存のDataLoaderを使用
@@ -0,0 +1,227 @@
+package set_log
+
+import (
+	"app/entity"
+	"app/graph/errcode"
+	"app/graph/model"
+	"app/graph/services/common"
+	"app/graph/services/policy"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// fakeCommonRepository はログイン中のユーザーとプロフィールの重量単位を固定で返す
+type fakeCommonRepository struct {
+	common.CommonRepository
+	user *entity.User
+	unit entity.WeightUnit
+}
+
+func (r *fakeCommonRepository) GetCurrentUser(ctx context.Context) (*entity.User, error) {
+	return r.user, nil
+}
+
+func (r *fakeCommonRepository) GetViewerWeightUnit(ctx context.Context) (entity.WeightUnit, error) {
+	return r.unit, nil
+}
+
+// fakeOwnershipRepository はセット・種目をユーザー1の所有物として返す
+type fakeOwnershipRepository struct {
+	policy.OwnershipRepository
+}
+
+func (r *fakeOwnershipRepository) GetSetLogOwnerID(ctx context.Context, setLogID uint) (uint, error) {
+	return 1, nil
+}
+
+func (r *fakeOwnershipRepository) GetWorkoutExerciseOwnerID(ctx context.Context, workoutExerciseID uint) (uint, error) {
+	return 1, nil
+}
+
+// fakeSetLogRepository は取得・更新・削除・並び替えの呼び出しを保持する
+type fakeSetLogRepository struct {
+	SetLogRepository
+	loaded    []string
+	updated   []*entity.SetLog
+	deleted   []string
+	reordered [][]uint
+}
+
+func (r *fakeSetLogRepository) GetSetLogByID(ctx context.Context, setLogID string) (*entity.SetLog, error) {
+	r.loaded = append(r.loaded, setLogID)
+	return &entity.SetLog{Model: gorm.Model{ID: 5}, WorkoutExerciseID: 10, SetNumber: 1, Weight: 60, RepCount: 5, Completed: true}, nil
+}
+
+func (r *fakeSetLogRepository) UpdateSetLog(ctx context.Context, setLog *entity.SetLog) error {
+	r.updated = append(r.updated, setLog)
+	return nil
+}
+
+func (r *fakeSetLogRepository) DeleteSetLog(ctx context.Context, setLogID string) error {
+	r.deleted = append(r.deleted, setLogID)
+	return nil
+}
+
+func (r *fakeSetLogRepository) ReorderSetLogs(ctx context.Context, workoutExerciseID uint, orderedIDs []uint) ([]*entity.SetLog, error) {
+	r.reordered = append(r.reordered, orderedIDs)
+	return nil, nil
+}
+
+func newTestService(repo *fakeSetLogRepository, userID uint) *setLogService {
+	return &setLogService{
+		repo:      repo,
+		converter: NewSetLogConverter(),
+		common:    &fakeCommonRepository{user: &entity.User{Model: gorm.Model{ID: userID}}, unit: entity.WeightUnitLb},
+		ownership: policy.NewOwnershipPolicy(&fakeOwnershipRepository{}),
+	}
+}
+
+func TestSetLogService_UpdateSetLog(t *testing.T) {
+	weight := 100.0
+	kg := model.WeightUnitKg
+
+	tests := []struct {
+		name           string
+		userID         uint
+		input          model.UpdateSetLog
+		expectedWeight float64
+		expectErr      func(err error) bool
+	}{
+		{
+			name:           "Weight in the preferred unit is stored in kg",
+			userID:         1,
+			input:          model.UpdateSetLog{SetLogID: "5", Weight: &weight},
+			expectedWeight: entity.ToKilograms(100, entity.WeightUnitLb),
+		},
+		{
+			name:           "Weight with an explicit unit",
+			userID:         1,
+			input:          model.UpdateSetLog{SetLogID: "5", Weight: &weight, WeightUnit: &kg},
+			expectedWeight: 100,
+		},
+		{
+			name:           "Omitted fields are kept",
+			userID:         1,
+			input:          model.UpdateSetLog{SetLogID: "5"},
+			expectedWeight: 60,
+		},
+		{
+			name:      "Invalid set log ID",
+			userID:    1,
+			input:     model.UpdateSetLog{SetLogID: "x"},
+			expectErr: func(err error) bool { return err != nil },
+		},
+		{
+			name:      "Set log of another user is rejected",
+			userID:    2,
+			input:     model.UpdateSetLog{SetLogID: "5", Weight: &weight},
+			expectErr: func(err error) bool { return errcode.Is(err, errcode.Forbidden) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeSetLogRepository{}
+			service := newTestService(repo, tt.userID)
+
+			_, err := service.UpdateSetLog(context.Background(), tt.input)
+			if tt.expectErr != nil {
+				assert.True(t, tt.expectErr(err), err)
+				// 他のユーザーのセットは取得する前に拒否する
+				assert.Empty(t, repo.loaded)
+				assert.Empty(t, repo.updated)
+				return
+			}
+			assert.NoError(t, err)
+			// 自己ベストの再計算はリポジトリの更新と同じトランザクションで行う
+			assert.Len(t, repo.updated, 1)
+			assert.InDelta(t, tt.expectedWeight, repo.updated[0].Weight, 0.001)
+			assert.Equal(t, 5, repo.updated[0].RepCount)
+		})
+	}
+}
+
+func TestSetLogService_DeleteSetLog(t *testing.T) {
+	repo := &fakeSetLogRepository{}
+
+	_, err := newTestService(repo, 2).DeleteSetLog(context.Background(), model.DeleteSetLog{SetLogID: "5"})
+	assert.True(t, errcode.Is(err, errcode.Forbidden), err)
+	assert.Empty(t, repo.deleted)
+
+	deleted, err := newTestService(repo, 1).DeleteSetLog(context.Background(), model.DeleteSetLog{SetLogID: "5"})
+	assert.NoError(t, err)
+	assert.True(t, deleted)
+	assert.Equal(t, []string{"5"}, repo.deleted)
+}
+
+func TestSetLogService_ReorderSetLogs(t *testing.T) {
+	tests := []struct {
+		name      string
+		userID    uint
+		ordered   []string
+		expected  []uint
+		expectErr bool
+	}{
+		{name: "Ordered IDs are passed in order", userID: 1, ordered: []string{"3", "1", "2"}, expected: []uint{3, 1, 2}},
+		{name: "Invalid ID is rejected", userID: 1, ordered: []string{"3", "x"}, expectErr: true},
+		{name: "Workout exercise of another user is rejected", userID: 2, ordered: []string{"1"}, expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeSetLogRepository{}
+
+			_, err := newTestService(repo, tt.userID).ReorderSetLogs(context.Background(), model.ReorderSetLogs{WorkoutExerciseID: "10", OrderedIDs: tt.ordered})
+			if tt.expectErr {
+				assert.Error(t, err)
+				assert.Empty(t, repo.reordered)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, [][]uint{tt.expected}, repo.reordered)
+		})
+	}
+}
+
+func TestRenumberSetLogs(t *testing.T) {
+	newSetLogs := func() []entity.SetLog {
+		return []entity.SetLog{
+			{Model: gorm.Model{ID: 1}, SetNumber: 1},
+			{Model: gorm.Model{ID: 2}, SetNumber: 2},
+			{Model: gorm.Model{ID: 3}, SetNumber: 3},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		orderedIDs []uint
+		expected   []uint // 振り直した順のセットID
+		expectErr  bool
+	}{
+		{name: "Set numbers follow the ordered IDs", orderedIDs: []uint{3, 1, 2}, expected: []uint{3, 1, 2}},
+		{name: "Same order", orderedIDs: []uint{1, 2, 3}, expected: []uint{1, 2, 3}},
+		{name: "Partial list is rejected", orderedIDs: []uint{2, 1}, expectErr: true},
+		{name: "Duplicated ID is rejected", orderedIDs: []uint{1, 1, 2}, expectErr: true},
+		{name: "Set log of another workout exercise is rejected", orderedIDs: []uint{1, 2, 4}, expectErr: true},
+		{name: "Extra ID is rejected", orderedIDs: []uint{1, 2, 3, 4}, expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := renumberSetLogs(newSetLogs(), tt.orderedIDs)
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, result, len(tt.expected))
+			for i, setLog := range result {
+				assert.Equal(t, tt.expected[i], setLog.ID)
+				assert.Equal(t, i+1, setLog.SetNumber)
+			}
+		})
+	}
+}
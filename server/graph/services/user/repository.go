@@ -2,6 +2,7 @@ package user
 
 import (
 	"app/entity"
+	"app/graph/errcode"
 	"app/middleware"
 	"context"
 	"fmt"
@@ -33,7 +34,7 @@ func NewUserRepository(db *gorm.DB) UserRepository {
 func (r *userRepository) GetCurrentUser(ctx context.Context) (*entity.User, error) {
 	uid, err := middleware.GetUserUIDFromContext(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("unauthorized: %w", err)
+		return nil, errcode.NewUnauthenticated(ctx, "authentication required")
 	}
 
 	user := entity.User{}
@@ -2,10 +2,14 @@ package user
 
 import (
 	"app/entity"
+	"app/graph/errcode"
 	"app/graph/model"
+	"app/graph/services/common"
+	"app/graph/services/policy"
 	"app/middleware"
 	"context"
 	"fmt"
+	"strconv"
 )
 
 type UserService interface {
@@ -23,6 +27,8 @@ type userService struct {
 	repo       UserRepository
 	converter  *UserConverter
 	dataLoader *UserDataLoader // DataLoaderを統合
+	common     common.CommonRepository
+	ownership  *policy.OwnershipPolicy
 }
 
 func NewUserService(repo UserRepository, converter *UserConverter, dataLoader *UserDataLoader) UserService {
@@ -30,17 +36,19 @@ func NewUserService(repo UserRepository, converter *UserConverter, dataLoader *U
 		repo:       repo,
 		converter:  converter,
 		dataLoader: dataLoader,
+		common:     common.NewCommonRepository(repo.(*userRepository).db),
+		ownership:  policy.NewOwnershipPolicy(policy.NewOwnershipRepository(repo.(*userRepository).db)),
 	}
 }
 
 func (s *userService) GetCurrentUser(ctx context.Context) (*entity.User, error) {
-	return s.repo.GetCurrentUser(ctx)
+	return s.common.GetCurrentUser(ctx)
 }
 
 func (s *userService) GetOrCreateUserByUID(ctx context.Context) (*model.User, error) {
 	uid, err := middleware.GetUserUIDFromContext(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("unauthorized: %w", err)
+		return nil, errcode.NewUnauthenticated(ctx, "authentication required")
 	}
 
 	// 既存ユーザーを取得
@@ -61,7 +69,7 @@ func (s *userService) GetOrCreateUserByUID(ctx context.Context) (*model.User, er
 func (s *userService) GetUserByUID(ctx context.Context) (*model.User, error) {
 	uid, err := middleware.GetUserUIDFromContext(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("unauthorized: %w", err)
+		return nil, errcode.NewUnauthenticated(ctx, "authentication required")
 	}
 
 	user, err := s.repo.GetUserByUID(ctx, uid)
@@ -84,7 +92,12 @@ func (s *userService) GetUserByID(ctx context.Context, userID string) (*model.Us
 	return s.converter.ToModelUser(*user), nil
 }
 
+// GetUsers は全ユーザーを取得する（モデレーター・管理者のみ）
 func (s *userService) GetUsers(ctx context.Context) ([]*model.User, error) {
+	if _, err := s.common.Authorize(ctx, policy.ViewAllUsers); err != nil {
+		return nil, err
+	}
+
 	users, err := s.repo.GetUsers(ctx)
 	if err != nil {
 		return nil, err
@@ -93,9 +106,23 @@ func (s *userService) GetUsers(ctx context.Context) ([]*model.User, error) {
 	return s.converter.ToModelUsers(users), nil
 }
 
+// DeleteUser はユーザーを削除する（本人、またはユーザーを管理できるユーザーのみ）
 func (s *userService) DeleteUser(ctx context.Context, input model.DeleteUser) (bool, error) {
-	id := input.ID
-	if err := s.repo.DeleteUser(ctx, id); err != nil {
+	currentUser, err := s.common.GetCurrentUser(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	id, err := strconv.ParseUint(input.ID, 10, 32)
+	if err != nil {
+		return false, fmt.Errorf("invalid user ID: %s", input.ID)
+	}
+
+	if err := s.ownership.AuthorizeUser(ctx, currentUser, uint(id)); err != nil {
+		return false, err
+	}
+
+	if err := s.repo.DeleteUser(ctx, input.ID); err != nil {
 		return false, err
 	}
 
@@ -4,6 +4,7 @@ import (
 	"app/entity"
 	"app/graph/model"
 	"app/graph/services/common"
+	"app/graph/services/common/base"
 	"fmt"
 	"time"
 )
@@ -29,13 +30,46 @@ func (c *WorkoutConverter) ToModelWorkout(workout entity.Workout) *model.Workout
 		return &formatted
 	}
 
+	formatTime := func(t *time.Time) *string {
+		if t == nil {
+			return nil
+		}
+		formatted := t.Format(time.RFC3339)
+		return &formatted
+	}
+
+	var durationSeconds *int32
+	if duration := workout.Duration(); duration != nil {
+		seconds := int32(duration.Seconds())
+		durationSeconds = &seconds
+	}
+
+	var programEnrollmentID *string
+	if workout.ProgramEnrollmentID != nil {
+		id := fmt.Sprintf("%d", *workout.ProgramEnrollmentID)
+		programEnrollmentID = &id
+	}
+
+	var sessionRPE *int32
+	if workout.SessionRPE != nil {
+		rpe := int32(*workout.SessionRPE)
+		sessionRPE = &rpe
+	}
+
 	return &model.Workout{
-		ID:             fmt.Sprintf("%d", workout.ID),
-		Date:           formatDate(workout.Date),
-		CreatedAt:      workout.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:      workout.UpdatedAt.Format(time.RFC3339),
-		UserID:         fmt.Sprintf("%d", workout.UserID),
-		WorkoutGroupID: workoutGroupID,
+		ID:                  fmt.Sprintf("%d", workout.ID),
+		Date:                formatDate(workout.Date),
+		CreatedAt:           workout.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:           workout.UpdatedAt.Format(time.RFC3339),
+		UserID:              fmt.Sprintf("%d", workout.UserID),
+		WorkoutGroupID:      workoutGroupID,
+		Status:              workout.Status.ToGraphQL(),
+		StartedAt:           formatTime(workout.StartedAt),
+		FinishedAt:          formatTime(workout.FinishedAt),
+		DurationSeconds:     durationSeconds,
+		Notes:               workout.Notes,
+		SessionRpe:          sessionRPE,
+		ProgramEnrollmentID: programEnrollmentID,
 	}
 }
 
@@ -56,3 +90,26 @@ func (c *WorkoutConverter) ToModelWorkoutsFromPointers(workouts []*entity.Workou
 	}
 	return result
 }
+
+// ToModelWorkoutConnection はWorkoutのページをRelay形式のConnectionに変換します
+func (c *WorkoutConverter) ToModelWorkoutConnection(page *base.Page[entity.Workout], args base.PageArgs) *model.WorkoutConnection {
+	edges := make([]*model.WorkoutEdge, 0, len(page.Items))
+	cursors := make([]string, 0, len(page.Items))
+	for _, workout := range page.Items {
+		if workout == nil {
+			continue
+		}
+		cursor := common.EncodeCursor(workout.ID)
+		edges = append(edges, &model.WorkoutEdge{
+			Cursor: cursor,
+			Node:   c.ToModelWorkout(*workout),
+		})
+		cursors = append(cursors, cursor)
+	}
+
+	return &model.WorkoutConnection{
+		Edges:      edges,
+		PageInfo:   common.ToModelPageInfo(cursors, page.HasNextPage, args),
+		TotalCount: int32(page.TotalCount),
+	}
+}
@@ -12,6 +12,7 @@ type WorkoutDataLoader struct {
 	byIDLoader             *base.BaseLoader[*entity.Workout]
 	byUserIDLoader         *base.BaseArrayLoader[entity.Workout]
 	byWorkoutGroupIDLoader *base.BaseArrayLoader[entity.Workout]
+	pageByUserIDLoader     *base.BasePageLoader[entity.Workout]
 }
 
 // NewWorkoutDataLoader は新しいDataLoaderを作成
@@ -44,6 +45,12 @@ func NewWorkoutDataLoader(repository WorkoutRepository) *WorkoutDataLoader {
 		base.ParseUintKey,
 	)
 
+	// PageByUserID用のローダー
+	loader.pageByUserIDLoader = base.NewBasePageLoader(
+		nil, // dbは不要（repositoryを使用）
+		loader.fetchPagesByUserIDs,
+	)
+
 	return loader
 }
 
@@ -62,6 +69,11 @@ func (l *WorkoutDataLoader) LoadByWorkoutGroupID(ctx context.Context, workoutGro
 	return l.byWorkoutGroupIDLoader.Load(ctx, workoutGroupID)
 }
 
+// LoadPageByUserID は指定されたUserIDのWorkoutsをページ単位で取得
+func (l *WorkoutDataLoader) LoadPageByUserID(ctx context.Context, userID string, args base.PageArgs) (*base.Page[entity.Workout], error) {
+	return l.pageByUserIDLoader.Load(ctx, userID, args)
+}
+
 // fetchByIDs はRepository経由でWorkoutID別にデータを取得
 func (l *WorkoutDataLoader) fetchByIDs(workoutIDs []uint) ([]*entity.Workout, error) {
 	return l.repository.GetWorkoutsByIDs(workoutIDs)
@@ -77,6 +89,11 @@ func (l *WorkoutDataLoader) fetchByWorkoutGroupIDs(workoutGroupIDs []uint) ([]*e
 	return l.repository.GetWorkoutsByWorkoutGroupIDs(workoutGroupIDs)
 }
 
+// fetchPagesByUserIDs はRepository経由でUserID別にページを取得
+func (l *WorkoutDataLoader) fetchPagesByUserIDs(userIDs []uint, args base.PageArgs) (map[uint]*base.Page[entity.Workout], error) {
+	return l.repository.GetWorkoutPagesByUserIDs(userIDs, args)
+}
+
 // createIDMap はWorkoutID別にデータをマップ化
 func (l *WorkoutDataLoader) createIDMap(workouts []*entity.Workout) map[uint]*entity.Workout {
 	result := make(map[uint]*entity.Workout)
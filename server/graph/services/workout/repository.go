@@ -2,6 +2,8 @@ package workout
 
 import (
 	"app/entity"
+	"app/graph/services/common/base"
+	"app/graph/services/personal_record"
 	"context"
 	"fmt"
 	"strconv"
@@ -12,12 +14,17 @@ import (
 type WorkoutRepository interface {
 	GetWorkoutByID(ctx context.Context, id string) (*entity.Workout, error)
 	GetWorkoutsByUserID(ctx context.Context, userID string) ([]*entity.Workout, error)
+	GetInProgressWorkout(ctx context.Context, userID uint) (*entity.Workout, error)
+	GetProgramEnrollmentByID(ctx context.Context, id uint) (*entity.ProgramEnrollment, error)
 	CreateWorkout(ctx context.Context, workout *entity.Workout) error
+	StartWorkout(ctx context.Context, workout *entity.Workout) error
+	UpdateWorkout(ctx context.Context, workout *entity.Workout) error
 	DeleteWorkout(ctx context.Context, id string) error
 	// Batch methods for DataLoader
 	GetWorkoutsByIDs(workoutIDs []uint) ([]*entity.Workout, error)
 	GetWorkoutsByUserIDs(userIDs []uint) ([]*entity.Workout, error)
 	GetWorkoutsByWorkoutGroupIDs(workoutGroupIDs []uint) ([]*entity.Workout, error)
+	GetWorkoutPagesByUserIDs(userIDs []uint, args base.PageArgs) (map[uint]*base.Page[entity.Workout], error)
 }
 
 type workoutRepository struct {
@@ -65,8 +72,79 @@ func (r *workoutRepository) CreateWorkout(ctx context.Context, workout *entity.W
 	return r.db.Create(workout).Error
 }
 
+// GetInProgressWorkout はユーザーの実施中のワークアウトを取得する（ない場合はnil）
+func (r *workoutRepository) GetInProgressWorkout(ctx context.Context, userID uint) (*entity.Workout, error) {
+	var workouts []entity.Workout
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ? AND status = ?", userID, entity.WorkoutStatusInProgress).
+		Limit(1).
+		Find(&workouts).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch in-progress workout: %w", err)
+	}
+	if len(workouts) == 0 {
+		return nil, nil
+	}
+	return &workouts[0], nil
+}
+
+// GetProgramEnrollmentByID はプログラムへの参加を取得する（ない場合はnil）
+func (r *workoutRepository) GetProgramEnrollmentByID(ctx context.Context, id uint) (*entity.ProgramEnrollment, error) {
+	var enrollments []entity.ProgramEnrollment
+	if err := r.db.WithContext(ctx).
+		Where("id = ?", id).
+		Limit(1).
+		Find(&enrollments).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch program enrollment: %w", err)
+	}
+	if len(enrollments) == 0 {
+		return nil, nil
+	}
+	return &enrollments[0], nil
+}
+
+// StartWorkout は実施中のワークアウトを中断済みにしてから、ワークアウトを作成・更新する
+func (r *workoutRepository) StartWorkout(ctx context.Context, workout *entity.Workout) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := entity.AbandonInProgressWorkouts(tx, workout.UserID, workout.ID); err != nil {
+			return err
+		}
+		return tx.Save(workout).Error
+	})
+}
+
+func (r *workoutRepository) UpdateWorkout(ctx context.Context, workout *entity.Workout) error {
+	return r.db.WithContext(ctx).Save(workout).Error
+}
+
+// DeleteWorkout はワークアウトを削除し、含まれていた種目の自己ベストを再計算する
 func (r *workoutRepository) DeleteWorkout(ctx context.Context, id string) error {
-	return r.db.Unscoped().Delete(&entity.Workout{}, id).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var workout entity.Workout
+		if err := tx.Where("id = ?", id).First(&workout).Error; err != nil {
+			return err
+		}
+
+		var exerciseIDs []uint
+		if err := tx.Model(&entity.WorkoutExercise{}).
+			Where("workout_id = ?", workout.ID).
+			Distinct().
+			Pluck("exercise_id", &exerciseIDs).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Unscoped().Delete(&entity.Workout{}, workout.ID).Error; err != nil {
+			return err
+		}
+
+		recordRepo := personal_record.NewPersonalRecordRepository(tx)
+		for _, exerciseID := range exerciseIDs {
+			if err := recordRepo.RebuildPersonalRecords(ctx, workout.UserID, exerciseID); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
 }
 
 // Batch methods for DataLoader
@@ -126,3 +204,56 @@ func (r *workoutRepository) GetWorkoutsByWorkoutGroupIDs(workoutGroupIDs []uint)
 
 	return result, nil
 }
+
+// GetWorkoutPagesByUserIDs はバッチでUserID別にWorkoutを新しい順でページ取得
+func (r *workoutRepository) GetWorkoutPagesByUserIDs(userIDs []uint, args base.PageArgs) (map[uint]*base.Page[entity.Workout], error) {
+	result := make(map[uint]*base.Page[entity.Workout])
+	if len(userIDs) == 0 {
+		return result, nil
+	}
+
+	// ユーザー別の総件数を取得
+	var counts []struct {
+		UserID uint
+		Count  int64
+	}
+	if err := r.db.Model(&entity.Workout{}).
+		Select("user_id, COUNT(*) AS count").
+		Where("user_id IN ?", userIDs).
+		Group("user_id").
+		Scan(&counts).Error; err != nil {
+		return nil, fmt.Errorf("failed to count workouts by user IDs: %w", err)
+	}
+	for _, count := range counts {
+		result[count.UserID] = &base.Page[entity.Workout]{
+			Items:      []*entity.Workout{},
+			TotalCount: count.Count,
+		}
+	}
+
+	// ユーザー別に first+1 件を取得（1件多く取得して次ページの有無を判定）
+	ranked := r.db.Model(&entity.Workout{}).
+		Select("workouts.*, ROW_NUMBER() OVER (PARTITION BY user_id ORDER BY id DESC) AS rn").
+		Where("user_id IN ?", userIDs)
+	if args.After != 0 {
+		ranked = ranked.Where("id < ?", args.After)
+	}
+
+	var workouts []entity.Workout
+	if err := r.db.Table("(?) AS ranked", ranked).
+		Where("rn <= ?", args.First+1).
+		Order("user_id, rn").
+		Find(&workouts).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch workout pages by user IDs: %w", err)
+	}
+
+	for i := range workouts {
+		page, exists := result[workouts[i].UserID]
+		if !exists {
+			continue
+		}
+		page.Append(&workouts[i], args.First)
+	}
+
+	return result, nil
+}
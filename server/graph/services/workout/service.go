@@ -2,8 +2,11 @@ package workout
 
 import (
 	"app/entity"
+	"app/graph/errcode"
 	"app/graph/model"
 	"app/graph/services/common"
+	"app/graph/services/event"
+	"app/graph/services/policy"
 	"context"
 	"fmt"
 	"strconv"
@@ -13,11 +16,16 @@ import (
 type WorkoutService interface {
 	GetWorkoutByID(ctx context.Context, workoutID string) (*model.Workout, error)
 	GetWorkoutsByUserID(ctx context.Context, userID string) ([]*model.Workout, error)
+	GetActiveWorkout(ctx context.Context) (*model.Workout, error)
 	StartWorkout(ctx context.Context, input model.StartWorkout) (*model.Workout, error)
+	FinishWorkout(ctx context.Context, input model.FinishWorkout) (*model.Workout, error)
+	ResumeWorkout(ctx context.Context, input model.ResumeWorkout) (*model.Workout, error)
+	UpdateWorkout(ctx context.Context, input model.UpdateWorkout) (*model.Workout, error)
 	DeleteWorkout(ctx context.Context, input model.DeleteWorkout) (bool, error)
 	// DataLoader使用メソッド
 	GetWorkoutByIDWithDataLoader(ctx context.Context, workoutID string) (*model.Workout, error)
 	GetWorkoutsByUserIDWithDataLoader(ctx context.Context, userID string) ([]*model.Workout, error)
+	GetWorkoutConnectionByUserIDWithDataLoader(ctx context.Context, userID string, first *int32, after *string) (*model.WorkoutConnection, error)
 	GetWorkoutsByWorkoutGroupIDWithDataLoader(ctx context.Context, workoutGroupID string) ([]*model.Workout, error)
 }
 
@@ -25,14 +33,18 @@ type workoutService struct {
 	repo       WorkoutRepository
 	converter  *WorkoutConverter
 	common     common.CommonRepository
+	ownership  *policy.OwnershipPolicy
+	events     *event.Bus
 	dataLoader *WorkoutDataLoader // DataLoaderを統合
 }
 
-func NewWorkoutService(repo WorkoutRepository, converter *WorkoutConverter, dataLoader *WorkoutDataLoader) WorkoutService {
+func NewWorkoutService(repo WorkoutRepository, converter *WorkoutConverter, dataLoader *WorkoutDataLoader, events *event.Bus) WorkoutService {
 	return &workoutService{
 		repo:       repo,
 		converter:  converter,
 		common:     common.NewCommonRepository(repo.(*workoutRepository).db),
+		ownership:  policy.NewOwnershipPolicy(policy.NewOwnershipRepository(repo.(*workoutRepository).db)),
+		events:     events,
 		dataLoader: dataLoader,
 	}
 }
@@ -53,6 +65,24 @@ func (s *workoutService) GetWorkoutsByUserID(ctx context.Context, userID string)
 	return s.converter.ToModelWorkoutsFromPointers(workouts), nil
 }
 
+// GetActiveWorkout は現在のユーザーの実施中のワークアウトを取得する
+func (s *workoutService) GetActiveWorkout(ctx context.Context) (*model.Workout, error) {
+	currentUser, err := s.common.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	workout, err := s.repo.GetInProgressWorkout(ctx, currentUser.ID)
+	if err != nil {
+		return nil, err
+	}
+	if workout == nil {
+		return nil, nil
+	}
+	return s.converter.ToModelWorkout(*workout), nil
+}
+
+// StartWorkout はワークアウトを作成して開始する（実施中のワークアウトがある場合は中断済みにする）
 func (s *workoutService) StartWorkout(ctx context.Context, input model.StartWorkout) (*model.Workout, error) {
 	currentUser, err := s.common.GetCurrentUser(ctx)
 	if err != nil {
@@ -68,6 +98,33 @@ func (s *workoutService) StartWorkout(ctx context.Context, input model.StartWork
 		}
 		workoutGroupIDValue := uint(workoutGroupIDUint64)
 		workoutGroupID = &workoutGroupIDValue
+
+		// グループに参加中のメンバーのみグループのワークアウトを開始できる
+		if _, err := s.ownership.AuthorizeWorkoutGroupMember(ctx, currentUser, workoutGroupIDValue); err != nil {
+			return nil, err
+		}
+	}
+
+	// プログラムの処理（オプショナル）
+	var programEnrollmentID *uint
+	if input.ProgramEnrollmentID != nil {
+		enrollmentID, err := strconv.ParseUint(*input.ProgramEnrollmentID, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid program enrollment ID: %s", *input.ProgramEnrollmentID)
+		}
+
+		// 自分が参加中のプログラムのみ紐付けられる
+		enrollment, err := s.repo.GetProgramEnrollmentByID(ctx, uint(enrollmentID))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get program enrollment: %w", err)
+		}
+		if enrollment == nil || enrollment.UserID != currentUser.ID {
+			return nil, errcode.NewForbidden(ctx, "not the owner of the program enrollment")
+		}
+		if !enrollment.IsActive() {
+			return nil, fmt.Errorf("program enrollment has already ended")
+		}
+		programEnrollmentID = &enrollment.ID
 	}
 
 	// 日付の処理（オプショナル）
@@ -80,32 +137,120 @@ func (s *workoutService) StartWorkout(ctx context.Context, input model.StartWork
 		date = &parsedDate
 	}
 
+	now := time.Now()
 	workout := entity.Workout{
-		UserID:         currentUser.ID,
-		WorkoutGroupID: workoutGroupID,
-		Date:           date,
+		UserID:              currentUser.ID,
+		WorkoutGroupID:      workoutGroupID,
+		ProgramEnrollmentID: programEnrollmentID,
+		Date:                date,
+		Status:              entity.WorkoutStatusInProgress,
+		StartedAt:           &now,
 	}
 
-	if err := s.repo.CreateWorkout(ctx, &workout); err != nil {
+	if err := s.repo.StartWorkout(ctx, &workout); err != nil {
 		return nil, fmt.Errorf("failed to create workout: %w", err)
 	}
 
 	return s.converter.ToModelWorkout(workout), nil
 }
 
+// FinishWorkout は実施中のワークアウトを終了し、グループのワークアウトの場合はメンバーに配信する
+func (s *workoutService) FinishWorkout(ctx context.Context, input model.FinishWorkout) (*model.Workout, error) {
+	workout, err := s.getMyWorkout(ctx, input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := workout.Finish(time.Now()); err != nil {
+		return nil, err
+	}
+	if input.Notes != nil {
+		workout.Notes = *input.Notes
+	}
+	if input.SessionRpe != nil {
+		rpe := int(*input.SessionRpe)
+		workout.SessionRPE = &rpe
+	}
+
+	if err := s.repo.UpdateWorkout(ctx, workout); err != nil {
+		return nil, fmt.Errorf("failed to finish workout: %w", err)
+	}
+
+	if workout.WorkoutGroupID != nil {
+		s.events.PublishWorkoutGroupActivity(ctx, event.WorkoutGroupActivity{
+			Type:           event.WorkoutGroupActivityWorkoutFinished,
+			WorkoutGroupID: *workout.WorkoutGroupID,
+			UserID:         workout.UserID,
+			WorkoutID:      &workout.ID,
+			OccurredAt:     *workout.FinishedAt,
+		})
+	}
+
+	return s.converter.ToModelWorkout(*workout), nil
+}
+
+// ResumeWorkout は終了したワークアウトを再開する（実施中の別のワークアウトは中断済みにする）
+func (s *workoutService) ResumeWorkout(ctx context.Context, input model.ResumeWorkout) (*model.Workout, error) {
+	workout, err := s.getMyWorkout(ctx, input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := workout.Resume(time.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.StartWorkout(ctx, workout); err != nil {
+		return nil, fmt.Errorf("failed to resume workout: %w", err)
+	}
+
+	return s.converter.ToModelWorkout(*workout), nil
+}
+
+// UpdateWorkout はワークアウトの日付・メモ・セッションRPEを更新する
+func (s *workoutService) UpdateWorkout(ctx context.Context, input model.UpdateWorkout) (*model.Workout, error) {
+	workout, err := s.getMyWorkout(ctx, input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Date != nil {
+		parsedDate, err := time.Parse(common.DateFormat, *input.Date)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date: %s", *input.Date)
+		}
+		workout.Date = &parsedDate
+	}
+	if input.Notes != nil {
+		workout.Notes = *input.Notes
+	}
+	if input.ClearSessionRpe != nil && *input.ClearSessionRpe {
+		workout.SessionRPE = nil
+	} else if input.SessionRpe != nil {
+		rpe := int(*input.SessionRpe)
+		workout.SessionRPE = &rpe
+	}
+
+	if err := s.repo.UpdateWorkout(ctx, workout); err != nil {
+		return nil, fmt.Errorf("failed to update workout: %w", err)
+	}
+
+	return s.converter.ToModelWorkout(*workout), nil
+}
+
 func (s *workoutService) DeleteWorkout(ctx context.Context, input model.DeleteWorkout) (bool, error) {
 	currentUser, err := s.common.GetCurrentUser(ctx)
 	if err != nil {
 		return false, fmt.Errorf("failed to get current user: %w", err)
 	}
 
-	workout, err := s.repo.GetWorkoutByID(ctx, input.ID)
+	workoutID, err := strconv.ParseUint(input.ID, 10, 32)
 	if err != nil {
-		return false, fmt.Errorf("failed to get workout: %w", err)
+		return false, fmt.Errorf("invalid workout ID: %s", input.ID)
 	}
 
-	if workout.UserID != currentUser.ID {
-		return false, fmt.Errorf("unauthorized")
+	if err := s.ownership.AuthorizeWorkout(ctx, currentUser, uint(workoutID)); err != nil {
+		return false, err
 	}
 
 	if err := s.repo.DeleteWorkout(ctx, input.ID); err != nil {
@@ -115,6 +260,25 @@ func (s *workoutService) DeleteWorkout(ctx context.Context, input model.DeleteWo
 	return true, nil
 }
 
+// getMyWorkout は現在のユーザーのワークアウトを取得する
+func (s *workoutService) getMyWorkout(ctx context.Context, id string) (*entity.Workout, error) {
+	currentUser, err := s.common.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	workoutID, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid workout ID: %s", id)
+	}
+
+	if err := s.ownership.AuthorizeWorkout(ctx, currentUser, uint(workoutID)); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetWorkoutByID(ctx, id)
+}
+
 // DataLoader使用メソッド
 func (s *workoutService) GetWorkoutByIDWithDataLoader(ctx context.Context, workoutID string) (*model.Workout, error) {
 	// 既存のDataLoaderを使用
@@ -137,6 +301,19 @@ func (s *workoutService) GetWorkoutsByUserIDWithDataLoader(ctx context.Context,
 	return s.converter.ToModelWorkoutsFromPointers(entityWorkouts), nil
 }
 
+func (s *workoutService) GetWorkoutConnectionByUserIDWithDataLoader(ctx context.Context, userID string, first *int32, after *string) (*model.WorkoutConnection, error) {
+	args, err := common.NewPageArgs(first, after)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := s.dataLoader.LoadPageByUserID(ctx, userID, args)
+	if err != nil {
+		return nil, err
+	}
+	return s.converter.ToModelWorkoutConnection(page, args), nil
+}
+
 func (s *workoutService) GetWorkoutsByWorkoutGroupIDWithDataLoader(ctx context.Context, workoutGroupID string) ([]*model.Workout, error) {
 	entityWorkouts, err := s.dataLoader.LoadByWorkoutGroupID(ctx, workoutGroupID)
 	if err != nil {
@@ -0,0 +1,146 @@
+package workout
+
+import (
+	"app/entity"
+	"app/graph/errcode"
+	"app/graph/model"
+	"app/graph/services/common"
+	"app/graph/services/policy"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// fakeCommonRepository はログイン中のユーザーを固定で返す
+type fakeCommonRepository struct {
+	common.CommonRepository
+	user *entity.User
+}
+
+func (r *fakeCommonRepository) GetCurrentUser(ctx context.Context) (*entity.User, error) {
+	return r.user, nil
+}
+
+// fakeOwnershipRepository はグループのメンバーのみを返す
+type fakeOwnershipRepository struct {
+	policy.OwnershipRepository
+	groupMembers map[uint][]entity.WorkoutGroupMember
+}
+
+func (r *fakeOwnershipRepository) GetWorkoutGroupMember(ctx context.Context, workoutGroupID, userID uint) (*entity.WorkoutGroupMember, error) {
+	for _, member := range r.groupMembers[workoutGroupID] {
+		if member.UserID == userID {
+			return &member, nil
+		}
+	}
+	return nil, nil
+}
+
+// fakeWorkoutRepository はプログラムへの参加を返し、開始したワークアウトを保持する
+type fakeWorkoutRepository struct {
+	WorkoutRepository
+	enrollments map[uint]*entity.ProgramEnrollment
+	started     []*entity.Workout
+}
+
+func (r *fakeWorkoutRepository) GetProgramEnrollmentByID(ctx context.Context, id uint) (*entity.ProgramEnrollment, error) {
+	return r.enrollments[id], nil
+}
+
+func (r *fakeWorkoutRepository) StartWorkout(ctx context.Context, workout *entity.Workout) error {
+	workout.ID = uint(len(r.started) + 1)
+	r.started = append(r.started, workout)
+	return nil
+}
+
+func TestWorkoutService_StartWorkout(t *testing.T) {
+	const groupID = "50"
+	members := map[uint][]entity.WorkoutGroupMember{
+		50: {
+			{WorkoutGroupID: 50, UserID: 1, Role: entity.WorkoutGroupMemberRoleOwner, Status: entity.WorkoutGroupMemberStatusJoined},
+			{WorkoutGroupID: 50, UserID: 2, Role: entity.WorkoutGroupMemberRoleMember, Status: entity.WorkoutGroupMemberStatusInvited},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		userID         uint
+		workoutGroupID *string
+		expectErr      bool
+	}{
+		{name: "Without group", userID: 3},
+		{name: "Joined member", userID: 1, workoutGroupID: ptr(groupID)},
+		{name: "Invited member is rejected", userID: 2, workoutGroupID: ptr(groupID), expectErr: true},
+		{name: "Non-member is rejected", userID: 3, workoutGroupID: ptr(groupID), expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeWorkoutRepository{}
+			service := &workoutService{
+				repo:      repo,
+				converter: NewWorkoutConverter(),
+				common:    &fakeCommonRepository{user: &entity.User{Model: gorm.Model{ID: tt.userID}}},
+				ownership: policy.NewOwnershipPolicy(&fakeOwnershipRepository{groupMembers: members}),
+			}
+
+			workout, err := service.StartWorkout(context.Background(), model.StartWorkout{WorkoutGroupID: tt.workoutGroupID})
+			if tt.expectErr {
+				assert.True(t, errcode.Is(err, errcode.Forbidden))
+				assert.Empty(t, repo.started)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, model.WorkoutStatusInProgress, workout.Status)
+			assert.Len(t, repo.started, 1)
+		})
+	}
+}
+
+func TestWorkoutService_StartWorkoutInProgram(t *testing.T) {
+	endedAt := time.Now()
+	enrollments := map[uint]*entity.ProgramEnrollment{
+		7: {Model: gorm.Model{ID: 7}, UserID: 1, ProgramID: 1},
+		8: {Model: gorm.Model{ID: 8}, UserID: 1, ProgramID: 1, EndedAt: &endedAt},
+		9: {Model: gorm.Model{ID: 9}, UserID: 2, ProgramID: 1},
+	}
+
+	tests := []struct {
+		name         string
+		enrollmentID string
+		expectErr    func(err error) bool
+	}{
+		{name: "Active enrollment is linked", enrollmentID: "7"},
+		{name: "Ended enrollment is rejected", enrollmentID: "8", expectErr: func(err error) bool { return err != nil }},
+		{name: "Other user's enrollment is rejected", enrollmentID: "9", expectErr: func(err error) bool { return errcode.Is(err, errcode.Forbidden) }},
+		{name: "Missing enrollment is rejected", enrollmentID: "10", expectErr: func(err error) bool { return errcode.Is(err, errcode.Forbidden) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeWorkoutRepository{enrollments: enrollments}
+			service := &workoutService{
+				repo:      repo,
+				converter: NewWorkoutConverter(),
+				common:    &fakeCommonRepository{user: &entity.User{Model: gorm.Model{ID: 1}}},
+				ownership: policy.NewOwnershipPolicy(&fakeOwnershipRepository{}),
+			}
+
+			workout, err := service.StartWorkout(context.Background(), model.StartWorkout{ProgramEnrollmentID: ptr(tt.enrollmentID)})
+			if tt.expectErr != nil {
+				assert.True(t, tt.expectErr(err), err)
+				assert.Empty(t, repo.started)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, ptr(tt.enrollmentID), workout.ProgramEnrollmentID)
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
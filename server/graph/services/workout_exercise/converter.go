@@ -13,7 +13,7 @@ func NewWorkoutExerciseConverter() *WorkoutExerciseConverter {
 }
 
 func (c *WorkoutExerciseConverter) ToModelWorkoutExercise(workoutExercise entity.WorkoutExercise) *model.WorkoutExercise {
-	return &model.WorkoutExercise{
+	modelWorkoutExercise := &model.WorkoutExercise{
 		ID: fmt.Sprintf("%d", workoutExercise.ID),
 		Workout: &model.Workout{
 			ID: fmt.Sprintf("%d", workoutExercise.WorkoutID),
@@ -21,7 +21,13 @@ func (c *WorkoutExerciseConverter) ToModelWorkoutExercise(workoutExercise entity
 		Exercise: &model.Exercise{
 			ID: fmt.Sprintf("%d", workoutExercise.ExerciseID),
 		},
+		Position: int32(workoutExercise.Position),
 	}
+	if workoutExercise.SupersetGroup != nil {
+		supersetGroup := int32(*workoutExercise.SupersetGroup)
+		modelWorkoutExercise.SupersetGroup = &supersetGroup
+	}
+	return modelWorkoutExercise
 }
 
 func (c *WorkoutExerciseConverter) ToModelWorkoutExercises(workoutExercises []entity.WorkoutExercise) []*model.WorkoutExercise {
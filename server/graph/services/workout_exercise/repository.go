@@ -2,6 +2,7 @@ package workout_exercise
 
 import (
 	"app/entity"
+	"app/graph/services/personal_record"
 	"context"
 	"fmt"
 
@@ -9,7 +10,11 @@ import (
 )
 
 type WorkoutExerciseRepository interface {
+	GetWorkoutExerciseByID(ctx context.Context, id uint) (*entity.WorkoutExercise, error)
 	CreateWorkoutExercise(ctx context.Context, workoutExercise *entity.WorkoutExercise) error
+	UpdateWorkoutExerciseOrder(ctx context.Context, workoutID uint, update func(order *entity.WorkoutExerciseOrder) error) ([]*entity.WorkoutExercise, error)
+	DeleteWorkoutExercise(ctx context.Context, id uint) error
+	ReplaceExercise(ctx context.Context, id, exerciseID uint) (*entity.WorkoutExercise, error)
 
 	// バッチ取得メソッド（DataLoader用）
 	GetWorkoutExercisesByWorkoutIDs(workoutIDs []uint) ([]*entity.WorkoutExercise, error)
@@ -24,9 +29,153 @@ func NewWorkoutExerciseRepository(db *gorm.DB) WorkoutExerciseRepository {
 	return &workoutExerciseRepository{db: db}
 }
 
+func (r *workoutExerciseRepository) GetWorkoutExerciseByID(ctx context.Context, id uint) (*entity.WorkoutExercise, error) {
+	var workoutExercise entity.WorkoutExercise
+	if err := r.db.Where("id = ?", id).First(&workoutExercise).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch workout exercise: %w", err)
+	}
+	return &workoutExercise, nil
+}
+
+// CreateWorkoutExercise はワークアウトの最後の種目として追加する
 func (r *workoutExerciseRepository) CreateWorkoutExercise(ctx context.Context, workoutExercise *entity.WorkoutExercise) error {
-	if err := r.db.Create(workoutExercise).Error; err != nil {
-		return fmt.Errorf("failed to create workout exercise: %w", err)
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		order, err := getWorkoutExerciseOrder(tx, workoutExercise.WorkoutID)
+		if err != nil {
+			return err
+		}
+
+		workoutExercise.Position = order.NextPosition()
+		if err := tx.Create(workoutExercise).Error; err != nil {
+			return fmt.Errorf("failed to create workout exercise: %w", err)
+		}
+		return nil
+	})
+}
+
+// UpdateWorkoutExerciseOrder はワークアウトの種目の並びを update で変更して保存する（1トランザクションで実行）
+func (r *workoutExerciseRepository) UpdateWorkoutExerciseOrder(ctx context.Context, workoutID uint, update func(order *entity.WorkoutExerciseOrder) error) ([]*entity.WorkoutExercise, error) {
+	var result []*entity.WorkoutExercise
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		order, err := getWorkoutExerciseOrder(tx, workoutID)
+		if err != nil {
+			return err
+		}
+		if err := update(&order); err != nil {
+			return err
+		}
+		if err := saveWorkoutExerciseOrder(tx, order); err != nil {
+			return err
+		}
+
+		result = order
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// DeleteWorkoutExercise は種目をセットごと削除し、残りの種目の順番を詰めて自己ベストを再計算する
+func (r *workoutExerciseRepository) DeleteWorkoutExercise(ctx context.Context, id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var workout entity.Workout
+		if err := tx.Joins("inner join workout_exercises on workout_exercises.workout_id = workouts.id").
+			Where("workout_exercises.id = ? AND workout_exercises.deleted_at IS NULL", id).
+			First(&workout).Error; err != nil {
+			return fmt.Errorf("failed to fetch workout: %w", err)
+		}
+
+		order, err := getWorkoutExerciseOrder(tx, workout.ID)
+		if err != nil {
+			return err
+		}
+		removed, err := order.Remove(id)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Unscoped().Delete(&entity.WorkoutExercise{}, removed.ID).Error; err != nil {
+			return fmt.Errorf("failed to delete workout exercise: %w", err)
+		}
+		if err := saveWorkoutExerciseOrder(tx, order); err != nil {
+			return err
+		}
+
+		return personal_record.NewPersonalRecordRepository(tx).RebuildPersonalRecords(ctx, workout.UserID, removed.ExerciseID)
+	})
+}
+
+// ReplaceExercise は記録したセットを残したまま種目を差し替え、新旧の種目の自己ベストを再計算する
+// セットを記録済みの場合は記録方法が同じ種目にのみ差し替えられる
+func (r *workoutExerciseRepository) ReplaceExercise(ctx context.Context, id, exerciseID uint) (*entity.WorkoutExercise, error) {
+	var workoutExercise entity.WorkoutExercise
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Preload("Workout").Preload("Exercise").Where("id = ?", id).First(&workoutExercise).Error; err != nil {
+			return fmt.Errorf("failed to fetch workout exercise: %w", err)
+		}
+		if workoutExercise.ExerciseID == exerciseID {
+			return nil
+		}
+
+		var exercise entity.Exercise
+		if err := tx.Where("id = ?", exerciseID).First(&exercise).Error; err != nil {
+			return fmt.Errorf("failed to fetch exercise: %w", err)
+		}
+
+		if exercise.TrackingType.ToGraphQL() != workoutExercise.Exercise.TrackingType.ToGraphQL() {
+			var count int64
+			if err := tx.Model(&entity.SetLog{}).Where("workout_exercise_id = ?", id).Count(&count).Error; err != nil {
+				return fmt.Errorf("failed to count set logs: %w", err)
+			}
+			if count > 0 {
+				return fmt.Errorf("cannot replace with an exercise of a different tracking type after sets are logged")
+			}
+		}
+
+		previousExerciseID := workoutExercise.ExerciseID
+		if err := tx.Model(&workoutExercise).Update("exercise_id", exerciseID).Error; err != nil {
+			return fmt.Errorf("failed to replace exercise: %w", err)
+		}
+		workoutExercise.ExerciseID = exerciseID
+		workoutExercise.Exercise = exercise
+
+		recordRepo := personal_record.NewPersonalRecordRepository(tx)
+		for _, recordExerciseID := range []uint{previousExerciseID, exerciseID} {
+			if err := recordRepo.RebuildPersonalRecords(ctx, workoutExercise.Workout.UserID, recordExerciseID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &workoutExercise, nil
+}
+
+// getWorkoutExerciseOrder はワークアウトの種目を現在の並びで取得する
+func getWorkoutExerciseOrder(tx *gorm.DB, workoutID uint) (entity.WorkoutExerciseOrder, error) {
+	var workoutExercises []*entity.WorkoutExercise
+	if err := tx.Where("workout_id = ?", workoutID).Find(&workoutExercises).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch workout exercises: %w", err)
+	}
+	return entity.NewWorkoutExerciseOrder(workoutExercises), nil
+}
+
+// saveWorkoutExerciseOrder は種目の順番とスーパーセットを保存する
+func saveWorkoutExerciseOrder(tx *gorm.DB, order entity.WorkoutExerciseOrder) error {
+	for _, workoutExercise := range order {
+		if err := tx.Model(workoutExercise).
+			Select("Position", "SupersetGroup").
+			Updates(workoutExercise).Error; err != nil {
+			return fmt.Errorf("failed to update workout exercise %d: %w", workoutExercise.ID, err)
+		}
 	}
 	return nil
 }
@@ -38,7 +187,7 @@ func (r *workoutExerciseRepository) GetWorkoutExercisesByWorkoutIDs(workoutIDs [
 	}
 
 	var workoutExercises []entity.WorkoutExercise
-	if err := r.db.Where("workout_id IN ?", workoutIDs).Find(&workoutExercises).Error; err != nil {
+	if err := r.db.Where("workout_id IN ?", workoutIDs).Order("position ASC, id ASC").Find(&workoutExercises).Error; err != nil {
 		return nil, fmt.Errorf("failed to fetch workout exercises by workout IDs: %w", err)
 	}
 
@@ -3,6 +3,8 @@ package workout_exercise
 import (
 	"app/entity"
 	"app/graph/model"
+	"app/graph/services/common"
+	"app/graph/services/policy"
 	"context"
 	"fmt"
 	"strconv"
@@ -11,6 +13,11 @@ import (
 type WorkoutExerciseService interface {
 	GetWorkoutExercisesByWorkoutID(ctx context.Context, workoutID string) ([]*model.WorkoutExercise, error)
 	CreateWorkoutExercise(ctx context.Context, input model.CreateWorkoutExercise) (*model.WorkoutExercise, error)
+	ReorderWorkoutExercises(ctx context.Context, input model.ReorderWorkoutExercises) ([]*model.WorkoutExercise, error)
+	GroupIntoSuperset(ctx context.Context, input model.GroupIntoSuperset) ([]*model.WorkoutExercise, error)
+	RemoveFromSuperset(ctx context.Context, input model.RemoveFromSuperset) ([]*model.WorkoutExercise, error)
+	DeleteWorkoutExercise(ctx context.Context, input model.DeleteWorkoutExercise) (bool, error)
+	ReplaceExercise(ctx context.Context, input model.ReplaceExercise) (*model.WorkoutExercise, error)
 	// DataLoader使用メソッド
 	GetWorkoutExercisesByWorkoutIDWithDataLoader(ctx context.Context, workoutID string) ([]*model.WorkoutExercise, error)
 }
@@ -18,6 +25,8 @@ type WorkoutExerciseService interface {
 type workoutExerciseService struct {
 	repo       WorkoutExerciseRepository
 	converter  *WorkoutExerciseConverter
+	common     common.CommonRepository
+	ownership  *policy.OwnershipPolicy
 	dataLoader *WorkoutExerciseDataLoader // DataLoaderを統合
 }
 
@@ -25,6 +34,8 @@ func NewWorkoutExerciseService(repo WorkoutExerciseRepository, converter *Workou
 	return &workoutExerciseService{
 		repo:       repo,
 		converter:  converter,
+		common:     common.NewCommonRepository(repo.(*workoutExerciseRepository).db),
+		ownership:  policy.NewOwnershipPolicy(policy.NewOwnershipRepository(repo.(*workoutExerciseRepository).db)),
 		dataLoader: dataLoader,
 	}
 }
@@ -38,17 +49,31 @@ func (s *workoutExerciseService) GetWorkoutExercisesByWorkoutID(ctx context.Cont
 	return s.converter.ToModelWorkoutExercisesFromPointers(workoutExercises), nil
 }
 
+// CreateWorkoutExercise はワークアウトに種目を追加する（ワークアウトの所有者のみ）
 func (s *workoutExerciseService) CreateWorkoutExercise(ctx context.Context, input model.CreateWorkoutExercise) (*model.WorkoutExercise, error) {
+	currentUser, err := s.common.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+
 	workoutID, err := strconv.ParseUint(input.WorkoutID, 10, 32)
 	if err != nil {
 		return nil, fmt.Errorf("invalid workout ID: %s", input.WorkoutID)
 	}
 
+	if err := s.ownership.AuthorizeWorkout(ctx, currentUser, uint(workoutID)); err != nil {
+		return nil, err
+	}
+
 	exerciseID, err := strconv.ParseUint(input.ExerciseID, 10, 32)
 	if err != nil {
 		return nil, fmt.Errorf("invalid exercise ID: %s", input.ExerciseID)
 	}
 
+	if err := s.ownership.AuthorizeExercises(ctx, currentUser.ID, []uint{uint(exerciseID)}); err != nil {
+		return nil, err
+	}
+
 	workoutExercise := &entity.WorkoutExercise{
 		WorkoutID:  uint(workoutID),
 		ExerciseID: uint(exerciseID),
@@ -61,6 +86,159 @@ func (s *workoutExerciseService) CreateWorkoutExercise(ctx context.Context, inpu
 	return s.converter.ToModelWorkoutExercise(*workoutExercise), nil
 }
 
+// ReorderWorkoutExercises はワークアウトの種目を orderedIDs の順に並べ替える
+func (s *workoutExerciseService) ReorderWorkoutExercises(ctx context.Context, input model.ReorderWorkoutExercises) ([]*model.WorkoutExercise, error) {
+	workoutID, err := s.authorizeWorkout(ctx, input.WorkoutID)
+	if err != nil {
+		return nil, err
+	}
+
+	orderedIDs, err := parseWorkoutExerciseIDs(input.OrderedIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	workoutExercises, err := s.repo.UpdateWorkoutExerciseOrder(ctx, workoutID, func(order *entity.WorkoutExerciseOrder) error {
+		return order.Reorder(orderedIDs)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to reorder workout exercises: %w", err)
+	}
+
+	return s.converter.ToModelWorkoutExercisesFromPointers(workoutExercises), nil
+}
+
+// GroupIntoSuperset は指定した種目をスーパーセット（3種目以上はサーキット）にまとめる
+func (s *workoutExerciseService) GroupIntoSuperset(ctx context.Context, input model.GroupIntoSuperset) ([]*model.WorkoutExercise, error) {
+	workoutID, err := s.authorizeWorkout(ctx, input.WorkoutID)
+	if err != nil {
+		return nil, err
+	}
+
+	memberIDs, err := parseWorkoutExerciseIDs(input.WorkoutExerciseIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	workoutExercises, err := s.repo.UpdateWorkoutExerciseOrder(ctx, workoutID, func(order *entity.WorkoutExerciseOrder) error {
+		return order.GroupIntoSuperset(memberIDs)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to group workout exercises into superset: %w", err)
+	}
+
+	return s.converter.ToModelWorkoutExercisesFromPointers(workoutExercises), nil
+}
+
+// RemoveFromSuperset は種目をスーパーセットから外す（1種目だけ残ったスーパーセットは解除する）
+func (s *workoutExerciseService) RemoveFromSuperset(ctx context.Context, input model.RemoveFromSuperset) ([]*model.WorkoutExercise, error) {
+	workoutExercise, err := s.getMyWorkoutExercise(ctx, input.WorkoutExerciseID)
+	if err != nil {
+		return nil, err
+	}
+
+	workoutExercises, err := s.repo.UpdateWorkoutExerciseOrder(ctx, workoutExercise.WorkoutID, func(order *entity.WorkoutExerciseOrder) error {
+		return order.RemoveFromSuperset(workoutExercise.ID)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to remove workout exercise from superset: %w", err)
+	}
+
+	return s.converter.ToModelWorkoutExercisesFromPointers(workoutExercises), nil
+}
+
+// DeleteWorkoutExercise はワークアウトから種目を記録したセットごと削除する
+func (s *workoutExerciseService) DeleteWorkoutExercise(ctx context.Context, input model.DeleteWorkoutExercise) (bool, error) {
+	workoutExercise, err := s.getMyWorkoutExercise(ctx, input.ID)
+	if err != nil {
+		return false, err
+	}
+
+	if err := s.repo.DeleteWorkoutExercise(ctx, workoutExercise.ID); err != nil {
+		return false, fmt.Errorf("failed to delete workout exercise: %w", err)
+	}
+
+	return true, nil
+}
+
+// ReplaceExercise は記録したセットを残したまま種目を差し替える
+func (s *workoutExerciseService) ReplaceExercise(ctx context.Context, input model.ReplaceExercise) (*model.WorkoutExercise, error) {
+	workoutExercise, err := s.getMyWorkoutExercise(ctx, input.WorkoutExerciseID)
+	if err != nil {
+		return nil, err
+	}
+
+	exerciseID, err := strconv.ParseUint(input.ExerciseID, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid exercise ID: %s", input.ExerciseID)
+	}
+
+	currentUser, err := s.common.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+	if err := s.ownership.AuthorizeExercises(ctx, currentUser.ID, []uint{uint(exerciseID)}); err != nil {
+		return nil, err
+	}
+
+	replaced, err := s.repo.ReplaceExercise(ctx, workoutExercise.ID, uint(exerciseID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to replace exercise: %w", err)
+	}
+
+	return s.converter.ToModelWorkoutExercise(*replaced), nil
+}
+
+// authorizeWorkout は現在のユーザーがワークアウトの所有者か確認し、ワークアウトIDを返す
+func (s *workoutExerciseService) authorizeWorkout(ctx context.Context, id string) (uint, error) {
+	currentUser, err := s.common.GetCurrentUser(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	workoutID, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid workout ID: %s", id)
+	}
+
+	if err := s.ownership.AuthorizeWorkout(ctx, currentUser, uint(workoutID)); err != nil {
+		return 0, err
+	}
+
+	return uint(workoutID), nil
+}
+
+// getMyWorkoutExercise は現在のユーザーのワークアウトの種目を取得する
+func (s *workoutExerciseService) getMyWorkoutExercise(ctx context.Context, id string) (*entity.WorkoutExercise, error) {
+	currentUser, err := s.common.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	workoutExerciseID, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid workout exercise ID: %s", id)
+	}
+
+	if err := s.ownership.AuthorizeWorkoutExercise(ctx, currentUser, uint(workoutExerciseID)); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetWorkoutExerciseByID(ctx, uint(workoutExerciseID))
+}
+
+func parseWorkoutExerciseIDs(ids []string) ([]uint, error) {
+	result := make([]uint, len(ids))
+	for i, id := range ids {
+		workoutExerciseID, err := strconv.ParseUint(id, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid workout exercise ID: %s", id)
+		}
+		result[i] = uint(workoutExerciseID)
+	}
+	return result, nil
+}
+
 // DataLoader使用メソッド
 func (s *workoutExerciseService) GetWorkoutExercisesByWorkoutIDWithDataLoader(ctx context.Context, workoutID string) ([]*model.WorkoutExercise, error) {
 	// 既存のDataLoaderを使用
@@ -0,0 +1,97 @@
+package workout_exercise
+
+import (
+	"app/entity"
+	"app/graph/errcode"
+	"app/graph/model"
+	"app/graph/services/common"
+	"app/graph/services/policy"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// fakeCommonRepository はログイン中のユーザーを固定で返す
+type fakeCommonRepository struct {
+	common.CommonRepository
+	user *entity.User
+}
+
+func (r *fakeCommonRepository) GetCurrentUser(ctx context.Context) (*entity.User, error) {
+	return r.user, nil
+}
+
+// fakeOwnershipRepository はユーザー1のワークアウトと、閲覧できる種目のみを返す
+type fakeOwnershipRepository struct {
+	policy.OwnershipRepository
+	visibleExerciseIDs []uint
+}
+
+func (r *fakeOwnershipRepository) GetWorkoutOwnerID(ctx context.Context, workoutID uint) (uint, error) {
+	return 1, nil
+}
+
+func (r *fakeOwnershipRepository) GetWorkoutExerciseOwnerID(ctx context.Context, workoutExerciseID uint) (uint, error) {
+	return 1, nil
+}
+
+func (r *fakeOwnershipRepository) GetVisibleExerciseIDs(ctx context.Context, userID uint, exerciseIDs []uint) ([]uint, error) {
+	return r.visibleExerciseIDs, nil
+}
+
+// fakeWorkoutExerciseRepository は追加・差し替えた種目を保持する
+type fakeWorkoutExerciseRepository struct {
+	WorkoutExerciseRepository
+	created  []*entity.WorkoutExercise
+	replaced []uint
+}
+
+func (r *fakeWorkoutExerciseRepository) GetWorkoutExerciseByID(ctx context.Context, id uint) (*entity.WorkoutExercise, error) {
+	return &entity.WorkoutExercise{Model: gorm.Model{ID: id}, WorkoutID: 10, ExerciseID: 1}, nil
+}
+
+func (r *fakeWorkoutExerciseRepository) CreateWorkoutExercise(ctx context.Context, workoutExercise *entity.WorkoutExercise) error {
+	r.created = append(r.created, workoutExercise)
+	return nil
+}
+
+func (r *fakeWorkoutExerciseRepository) ReplaceExercise(ctx context.Context, id, exerciseID uint) (*entity.WorkoutExercise, error) {
+	r.replaced = append(r.replaced, exerciseID)
+	return &entity.WorkoutExercise{Model: gorm.Model{ID: id}, WorkoutID: 10, ExerciseID: exerciseID}, nil
+}
+
+func TestWorkoutExerciseService_RejectsExercisesNotVisible(t *testing.T) {
+	newService := func(repo *fakeWorkoutExerciseRepository) *workoutExerciseService {
+		return &workoutExerciseService{
+			repo:      repo,
+			converter: NewWorkoutExerciseConverter(),
+			common:    &fakeCommonRepository{user: &entity.User{Model: gorm.Model{ID: 1}}},
+			// 種目2は他のユーザーの非公開の種目、またはアーカイブされた種目
+			ownership: policy.NewOwnershipPolicy(&fakeOwnershipRepository{visibleExerciseIDs: []uint{1}}),
+		}
+	}
+	ctx := context.Background()
+
+	t.Run("CreateWorkoutExercise", func(t *testing.T) {
+		repo := &fakeWorkoutExerciseRepository{}
+		service := newService(repo)
+
+		_, err := service.CreateWorkoutExercise(ctx, model.CreateWorkoutExercise{WorkoutID: "10", ExerciseID: "1"})
+		assert.NoError(t, err)
+
+		_, err = service.CreateWorkoutExercise(ctx, model.CreateWorkoutExercise{WorkoutID: "10", ExerciseID: "2"})
+		assert.True(t, errcode.Is(err, errcode.Forbidden))
+		assert.Len(t, repo.created, 1)
+	})
+
+	t.Run("ReplaceExercise", func(t *testing.T) {
+		repo := &fakeWorkoutExerciseRepository{}
+		service := newService(repo)
+
+		_, err := service.ReplaceExercise(ctx, model.ReplaceExercise{WorkoutExerciseID: "100", ExerciseID: "2"})
+		assert.True(t, errcode.Is(err, errcode.Forbidden))
+		assert.Empty(t, repo.replaced)
+	})
+}
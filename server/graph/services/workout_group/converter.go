@@ -4,6 +4,7 @@ import (
 	"app/entity"
 	"app/graph/model"
 	"app/graph/services/common"
+	"app/graph/services/event"
 	"fmt"
 	"time"
 )
@@ -43,6 +44,31 @@ func (c *WorkoutGroupConverter) ToModelWorkoutGroup(group entity.WorkoutGroup) *
 	}
 }
 
+var activityTypeToGraphQL = map[event.WorkoutGroupActivityType]model.WorkoutGroupActivityType{
+	event.WorkoutGroupActivitySetLogged:       model.WorkoutGroupActivityTypeSetLogged,
+	event.WorkoutGroupActivityMemberJoined:    model.WorkoutGroupActivityTypeMemberJoined,
+	event.WorkoutGroupActivityWorkoutFinished: model.WorkoutGroupActivityTypeWorkoutFinished,
+}
+
+func (c *WorkoutGroupConverter) ToModelWorkoutGroupActivity(activity event.WorkoutGroupActivity) *model.WorkoutGroupActivity {
+	formatID := func(id *uint) *string {
+		if id == nil {
+			return nil
+		}
+		formatted := fmt.Sprint(*id)
+		return &formatted
+	}
+
+	return &model.WorkoutGroupActivity{
+		Type:           activityTypeToGraphQL[activity.Type],
+		WorkoutGroupID: fmt.Sprint(activity.WorkoutGroupID),
+		UserID:         fmt.Sprint(activity.UserID),
+		WorkoutID:      formatID(activity.WorkoutID),
+		SetLogID:       formatID(activity.SetLogID),
+		OccurredAt:     activity.OccurredAt.Format(common.TimeFormat),
+	}
+}
+
 // User変換はCommonConverterを使用
 func (c *WorkoutGroupConverter) ToModelUsers(user []entity.User) []*model.User {
 	return c.common.ToModelUsers(user)
@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -12,7 +13,7 @@ import (
 type WorkoutGroupRepository interface {
 	GetWorkoutGroups(ctx context.Context, userID string) ([]entity.WorkoutGroup, error)
 	GetWorkoutGroupByID(ctx context.Context, id string, userID string) (*entity.WorkoutGroup, error)
-	CreateWorkoutGroup(ctx context.Context, workoutGroup *entity.WorkoutGroup) error
+	CreateWorkoutGroup(ctx context.Context, workoutGroup *entity.WorkoutGroup, ownerID uint) error
 	UpdateWorkoutGroup(ctx context.Context, workoutGroup *entity.WorkoutGroup) error
 	DeleteWorkoutGroup(ctx context.Context, id string) error
 
@@ -28,6 +29,7 @@ func NewWorkoutGroupRepository(db *gorm.DB) WorkoutGroupRepository {
 	return &workoutGroupRepository{db: db}
 }
 
+// GetWorkoutGroups は参加中のグループを取得
 func (r *workoutGroupRepository) GetWorkoutGroups(ctx context.Context, userID string) ([]entity.WorkoutGroup, error) {
 	userIDUint, err := strconv.ParseUint(userID, 10, 64)
 	if err != nil {
@@ -36,8 +38,9 @@ func (r *workoutGroupRepository) GetWorkoutGroups(ctx context.Context, userID st
 
 	var groups []entity.WorkoutGroup
 	err = r.db.WithContext(ctx).
-		Joins("inner join workouts on workout_groups.id = workouts.workout_group_id").
-		Where("workouts.user_id = ?", userIDUint).
+		Joins("inner join workout_group_members on workout_groups.id = workout_group_members.workout_group_id").
+		Where("workout_group_members.user_id = ?", userIDUint).
+		Where("workout_group_members.status = ?", entity.WorkoutGroupMemberStatusJoined).
 		Find(&groups).Error
 	if err != nil {
 		return nil, err
@@ -45,6 +48,7 @@ func (r *workoutGroupRepository) GetWorkoutGroups(ctx context.Context, userID st
 	return groups, nil
 }
 
+// GetWorkoutGroupByID は参加中または招待中のグループを取得
 func (r *workoutGroupRepository) GetWorkoutGroupByID(ctx context.Context, id string, userID string) (*entity.WorkoutGroup, error) {
 	userIDUint, err := strconv.ParseUint(userID, 10, 64)
 	if err != nil {
@@ -53,8 +57,12 @@ func (r *workoutGroupRepository) GetWorkoutGroupByID(ctx context.Context, id str
 
 	var group entity.WorkoutGroup
 	err = r.db.WithContext(ctx).
-		Joins("inner join workouts on workout_groups.id = workouts.workout_group_id").
-		Where("workouts.user_id = ?", userIDUint).
+		Joins("inner join workout_group_members on workout_groups.id = workout_group_members.workout_group_id").
+		Where("workout_group_members.user_id = ?", userIDUint).
+		Where("workout_group_members.status IN ?", []entity.WorkoutGroupMemberStatus{
+			entity.WorkoutGroupMemberStatusJoined,
+			entity.WorkoutGroupMemberStatusInvited,
+		}).
 		Where("workout_groups.id = ?", id).
 		First(&group).Error
 	if err != nil {
@@ -63,8 +71,33 @@ func (r *workoutGroupRepository) GetWorkoutGroupByID(ctx context.Context, id str
 	return &group, nil
 }
 
-func (r *workoutGroupRepository) CreateWorkoutGroup(ctx context.Context, workoutGroup *entity.WorkoutGroup) error {
-	return r.db.Create(workoutGroup).Error
+// CreateWorkoutGroup はグループを作成し、作成者をメンバーに追加してワークアウトを作成する
+func (r *workoutGroupRepository) CreateWorkoutGroup(ctx context.Context, workoutGroup *entity.WorkoutGroup, ownerID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(workoutGroup).Error; err != nil {
+			return err
+		}
+
+		now := time.Now()
+		owner := entity.WorkoutGroupMember{
+			WorkoutGroupID: workoutGroup.ID,
+			UserID:         ownerID,
+			Role:           entity.WorkoutGroupMemberRoleOwner,
+			Status:         entity.WorkoutGroupMemberStatusJoined,
+			JoinedAt:       &now,
+		}
+		if err := tx.Create(&owner).Error; err != nil {
+			return err
+		}
+
+		workout := entity.Workout{
+			UserID:         ownerID,
+			WorkoutGroupID: &workoutGroup.ID,
+			Date:           workoutGroup.Date,
+			Status:         entity.WorkoutStatusPlanned,
+		}
+		return tx.Create(&workout).Error
+	})
 }
 
 func (r *workoutGroupRepository) UpdateWorkoutGroup(ctx context.Context, workoutGroup *entity.WorkoutGroup) error {
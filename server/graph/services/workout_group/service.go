@@ -4,8 +4,8 @@ import (
 	"app/entity"
 	"app/graph/model"
 	"app/graph/services/common"
-	"app/graph/services/user"
-	"app/graph/services/workout"
+	"app/graph/services/event"
+	"app/graph/services/policy"
 	"context"
 	"fmt"
 	"strconv"
@@ -18,29 +18,29 @@ type WorkoutGroupService interface {
 	CreateWorkoutGroup(ctx context.Context, input model.CreateWorkoutGroup) (*model.WorkoutGroup, error)
 	UpdateWorkoutGroup(ctx context.Context, input model.UpdateWorkoutGroup) (*model.WorkoutGroup, error)
 	DeleteWorkoutGroup(ctx context.Context, input model.DeleteWorkoutGroup) (bool, error)
-	AddWorkoutGroupMember(ctx context.Context, input model.AddWorkoutGroupMember) (*model.WorkoutGroup, error)
+	SubscribeWorkoutGroupActivity(ctx context.Context, id string) (<-chan *model.WorkoutGroupActivity, error)
 
 	// DataLoader使用メソッド
 	GetWorkoutGroupWithDataLoader(ctx context.Context, id string) (*model.WorkoutGroup, error)
 }
 
 type workoutGroupService struct {
-	repo        WorkoutGroupRepository
-	workoutRepo workout.WorkoutRepository
-	userRepo    user.UserRepository
-	converter   *WorkoutGroupConverter
-	common      common.CommonRepository
-	dataLoader  *WorkoutGroupDataLoader // DataLoaderを統合
+	repo       WorkoutGroupRepository
+	converter  *WorkoutGroupConverter
+	common     common.CommonRepository
+	ownership  *policy.OwnershipPolicy
+	events     *event.Bus
+	dataLoader *WorkoutGroupDataLoader // DataLoaderを統合
 }
 
-func NewWorkoutGroupService(repo WorkoutGroupRepository, converter *WorkoutGroupConverter, loader *WorkoutGroupDataLoader) WorkoutGroupService {
+func NewWorkoutGroupService(repo WorkoutGroupRepository, converter *WorkoutGroupConverter, loader *WorkoutGroupDataLoader, events *event.Bus) WorkoutGroupService {
 	return &workoutGroupService{
-		repo:        repo,
-		workoutRepo: workout.NewWorkoutRepository(repo.(*workoutGroupRepository).db),
-		userRepo:    user.NewUserRepository(repo.(*workoutGroupRepository).db),
-		converter:   converter,
-		common:      common.NewCommonRepository(repo.(*workoutGroupRepository).db),
-		dataLoader:  loader,
+		repo:       repo,
+		converter:  converter,
+		common:     common.NewCommonRepository(repo.(*workoutGroupRepository).db),
+		ownership:  policy.NewOwnershipPolicy(policy.NewOwnershipRepository(repo.(*workoutGroupRepository).db)),
+		events:     events,
+		dataLoader: loader,
 	}
 }
 
@@ -75,7 +75,13 @@ func (s *workoutGroupService) GetWorkoutGroup(ctx context.Context, id string) (*
 	return s.converter.ToModelWorkoutGroup(*group), nil
 }
 
+// CreateWorkoutGroup はグループを作成する（作成者がOWNERとして参加する）
 func (s *workoutGroupService) CreateWorkoutGroup(ctx context.Context, input model.CreateWorkoutGroup) (*model.WorkoutGroup, error) {
+	currentUser, err := s.common.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+
 	var date *time.Time
 	if input.Date != nil {
 		parsedDate, err := time.Parse(common.DateFormat, *input.Date)
@@ -96,32 +102,29 @@ func (s *workoutGroupService) CreateWorkoutGroup(ctx context.Context, input mode
 		ImageURL: imageURL,
 	}
 
-	if err := s.repo.CreateWorkoutGroup(ctx, workoutGroup); err != nil {
+	if err := s.repo.CreateWorkoutGroup(ctx, workoutGroup, currentUser.ID); err != nil {
 		return nil, fmt.Errorf("failed to create workout group: %w", err)
 	}
 
-	// 作成者をメンバーに追加
-	currentUser, err := s.common.GetCurrentUser(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get current user: %w", err)
-	}
-	workout := entity.Workout{
-		UserID:         currentUser.ID,
-		WorkoutGroupID: &workoutGroup.ID,
-	}
-	if err := s.workoutRepo.CreateWorkout(ctx, &workout); err != nil {
-		return nil, fmt.Errorf("failed to create workout: %w", err)
-	}
-
 	return s.converter.ToModelWorkoutGroup(*workoutGroup), nil
 }
 
+// UpdateWorkoutGroup はグループを編集する（作成者・管理者のみ）
 func (s *workoutGroupService) UpdateWorkoutGroup(ctx context.Context, input model.UpdateWorkoutGroup) (*model.WorkoutGroup, error) {
 	currentUser, err := s.common.GetCurrentUser(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get current user: %w", err)
 	}
 
+	workoutGroupID, err := strconv.ParseUint(input.ID, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid workout group ID: %s", input.ID)
+	}
+
+	if _, err := s.ownership.AuthorizeManageWorkoutGroup(ctx, currentUser, uint(workoutGroupID)); err != nil {
+		return nil, err
+	}
+
 	workoutGroup, err := s.repo.GetWorkoutGroupByID(ctx, input.ID, strconv.FormatUint(uint64(currentUser.ID), 10))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get workout group: %w", err)
@@ -152,54 +155,80 @@ func (s *workoutGroupService) UpdateWorkoutGroup(ctx context.Context, input mode
 	return s.converter.ToModelWorkoutGroup(*workoutGroup), nil
 }
 
+// DeleteWorkoutGroup はグループを削除する（作成者、またはコンテンツを管理できるユーザーのみ）
 func (s *workoutGroupService) DeleteWorkoutGroup(ctx context.Context, input model.DeleteWorkoutGroup) (bool, error) {
 	currentUser, err := s.common.GetCurrentUser(ctx)
 	if err != nil {
 		return false, fmt.Errorf("failed to get current user: %w", err)
 	}
 
-	workoutGroup, err := s.repo.GetWorkoutGroupByID(ctx, input.ID, strconv.FormatUint(uint64(currentUser.ID), 10))
+	workoutGroupID, err := strconv.ParseUint(input.ID, 10, 32)
 	if err != nil {
-		return false, fmt.Errorf("failed to get workout group: %w", err)
+		return false, fmt.Errorf("invalid workout group ID: %s", input.ID)
 	}
 
-	if workoutGroup == nil {
+	groups, err := s.repo.GetWorkoutGroupsByIDs([]uint{uint(workoutGroupID)})
+	if err != nil {
+		return false, fmt.Errorf("failed to get workout group: %w", err)
+	}
+	if len(groups) == 0 {
 		return false, fmt.Errorf("workout group not found")
 	}
 
-	if err := s.repo.DeleteWorkoutGroup(ctx, strconv.FormatUint(uint64(workoutGroup.ID), 10)); err != nil {
+	if err := s.ownership.AuthorizeDeleteWorkoutGroup(ctx, currentUser, uint(workoutGroupID)); err != nil {
+		return false, err
+	}
+
+	if err := s.repo.DeleteWorkoutGroup(ctx, input.ID); err != nil {
 		return false, fmt.Errorf("failed to delete workout group: %w", err)
 	}
 
 	return true, nil
 }
 
-func (s *workoutGroupService) AddWorkoutGroupMember(ctx context.Context, input model.AddWorkoutGroupMember) (*model.WorkoutGroup, error) {
+// SubscribeWorkoutGroupActivity はグループの出来事を購読する（参加中のメンバーのみ）
+// 退出・退会させられた後は配信を止める。ブロックしている・されているユーザーの出来事は配信しない
+func (s *workoutGroupService) SubscribeWorkoutGroupActivity(ctx context.Context, id string) (<-chan *model.WorkoutGroupActivity, error) {
 	currentUser, err := s.common.GetCurrentUser(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get current user: %w", err)
 	}
 
-	workoutGroup, err := s.repo.GetWorkoutGroupByID(ctx, input.WorkoutGroupID, strconv.FormatUint(uint64(currentUser.ID), 10))
+	workoutGroupID, err := strconv.ParseUint(id, 10, 32)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get workout group: %w", err)
+		return nil, fmt.Errorf("invalid workout group ID: %s", id)
 	}
 
-	user, err := s.userRepo.GetUserByID(ctx, input.UserID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get user: %w", err)
+	if _, err := s.ownership.AuthorizeWorkoutGroupMember(ctx, currentUser, uint(workoutGroupID)); err != nil {
+		return nil, err
 	}
 
-	workout := entity.Workout{
-		UserID:         user.ID,
-		WorkoutGroupID: &workoutGroup.ID,
-		Date:           workoutGroup.Date,
-	}
-	if err := s.workoutRepo.CreateWorkout(ctx, &workout); err != nil {
-		return nil, fmt.Errorf("failed to create workout: %w", err)
+	events, err := s.events.SubscribeWorkoutGroupActivity(ctx, uint(workoutGroupID))
+	if err != nil {
+		return nil, err
 	}
 
-	return s.converter.ToModelWorkoutGroup(*workoutGroup), nil
+	activities := make(chan *model.WorkoutGroupActivity)
+	go func() {
+		defer close(activities)
+		for activity := range events {
+			if _, err := s.ownership.AuthorizeWorkoutGroupMember(ctx, currentUser, uint(workoutGroupID)); err != nil {
+				return
+			}
+			blocked, err := s.ownership.BlockedUserIDs(ctx, currentUser)
+			if err != nil || blocked[activity.UserID] {
+				continue
+			}
+
+			select {
+			case activities <- s.converter.ToModelWorkoutGroupActivity(activity):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return activities, nil
 }
 
 // DataLoader使用メソッド
@@ -0,0 +1,45 @@
+package workout_group_member
+
+import (
+	"app/entity"
+	"app/graph/model"
+	"app/graph/services/common"
+	"fmt"
+)
+
+type WorkoutGroupMemberConverter struct{}
+
+func NewWorkoutGroupMemberConverter() *WorkoutGroupMemberConverter {
+	return &WorkoutGroupMemberConverter{}
+}
+
+func (c *WorkoutGroupMemberConverter) ToModelWorkoutGroupMember(member entity.WorkoutGroupMember) *model.WorkoutGroupMember {
+	result := &model.WorkoutGroupMember{
+		ID:             fmt.Sprintf("%d", member.ID),
+		WorkoutGroupID: fmt.Sprintf("%d", member.WorkoutGroupID),
+		UserID:         fmt.Sprintf("%d", member.UserID),
+		Role:           member.Role.ToGraphQL(),
+		Status:         member.Status.ToGraphQL(),
+		CreatedAt:      member.CreatedAt.Format(common.TimeFormat),
+		UpdatedAt:      member.UpdatedAt.Format(common.TimeFormat),
+	}
+	if member.InvitedByID != nil {
+		invitedByID := fmt.Sprintf("%d", *member.InvitedByID)
+		result.InvitedByID = &invitedByID
+	}
+	if member.JoinedAt != nil {
+		joinedAt := member.JoinedAt.Format(common.TimeFormat)
+		result.JoinedAt = &joinedAt
+	}
+	return result
+}
+
+func (c *WorkoutGroupMemberConverter) ToModelWorkoutGroupMembersFromPointers(members []*entity.WorkoutGroupMember) []*model.WorkoutGroupMember {
+	result := make([]*model.WorkoutGroupMember, 0, len(members))
+	for _, member := range members {
+		if member != nil {
+			result = append(result, c.ToModelWorkoutGroupMember(*member))
+		}
+	}
+	return result
+}
@@ -0,0 +1,51 @@
+package workout_group_member
+
+import (
+	"app/entity"
+	"app/graph/services/common/base"
+	"context"
+)
+
+// WorkoutGroupMemberDataLoader は WorkoutGroupMember エンティティの遅延ローディングを担当
+type WorkoutGroupMemberDataLoader struct {
+	repository             WorkoutGroupMemberRepository
+	byWorkoutGroupIDLoader *base.BaseArrayLoader[entity.WorkoutGroupMember]
+}
+
+// NewWorkoutGroupMemberDataLoader は新しいDataLoaderを作成
+func NewWorkoutGroupMemberDataLoader(repository WorkoutGroupMemberRepository) *WorkoutGroupMemberDataLoader {
+	loader := &WorkoutGroupMemberDataLoader{
+		repository: repository,
+	}
+
+	// ByWorkoutGroupID用のローダー
+	loader.byWorkoutGroupIDLoader = base.NewBaseArrayLoader(
+		nil, // dbは不要（repositoryを使用）
+		loader.fetchByWorkoutGroupIDs,
+		loader.createWorkoutGroupIDMap,
+		base.ParseUintKey,
+	)
+
+	return loader
+}
+
+// LoadByWorkoutGroupID は指定されたWorkoutGroupIDのメンバーを取得
+func (l *WorkoutGroupMemberDataLoader) LoadByWorkoutGroupID(ctx context.Context, workoutGroupID string) ([]*entity.WorkoutGroupMember, error) {
+	return l.byWorkoutGroupIDLoader.Load(ctx, workoutGroupID)
+}
+
+// fetchByWorkoutGroupIDs はRepository経由でWorkoutGroupID別にデータを取得
+func (l *WorkoutGroupMemberDataLoader) fetchByWorkoutGroupIDs(workoutGroupIDs []uint) ([]*entity.WorkoutGroupMember, error) {
+	return l.repository.GetMembersByWorkoutGroupIDs(workoutGroupIDs)
+}
+
+// createWorkoutGroupIDMap はWorkoutGroupID別にデータをマップ化
+func (l *WorkoutGroupMemberDataLoader) createWorkoutGroupIDMap(members []*entity.WorkoutGroupMember) map[uint][]*entity.WorkoutGroupMember {
+	result := make(map[uint][]*entity.WorkoutGroupMember)
+	for _, member := range members {
+		if member != nil {
+			result[member.WorkoutGroupID] = append(result[member.WorkoutGroupID], member)
+		}
+	}
+	return result
+}
@@ -0,0 +1,115 @@
+package workout_group_member
+
+import (
+	"app/entity"
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+type WorkoutGroupMemberRepository interface {
+	GetMember(ctx context.Context, workoutGroupID, userID uint) (*entity.WorkoutGroupMember, error)
+	GetInvitations(ctx context.Context, userID uint) ([]*entity.WorkoutGroupMember, error)
+	SaveMember(ctx context.Context, member *entity.WorkoutGroupMember) error
+	JoinWorkoutGroup(ctx context.Context, member *entity.WorkoutGroupMember) error
+	TransferOwnership(ctx context.Context, owner, newOwner *entity.WorkoutGroupMember) error
+
+	// バッチ取得メソッド（DataLoader用）
+	GetMembersByWorkoutGroupIDs(workoutGroupIDs []uint) ([]*entity.WorkoutGroupMember, error)
+}
+
+type workoutGroupMemberRepository struct {
+	db *gorm.DB
+}
+
+func NewWorkoutGroupMemberRepository(db *gorm.DB) WorkoutGroupMemberRepository {
+	return &workoutGroupMemberRepository{db: db}
+}
+
+// GetMember はグループのメンバー情報を取得する（招待されたことがない場合はnil）
+func (r *workoutGroupMemberRepository) GetMember(ctx context.Context, workoutGroupID, userID uint) (*entity.WorkoutGroupMember, error) {
+	var members []entity.WorkoutGroupMember
+	if err := r.db.
+		Where("workout_group_id = ? AND user_id = ?", workoutGroupID, userID).
+		Limit(1).
+		Find(&members).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch workout group member: %w", err)
+	}
+	if len(members) == 0 {
+		return nil, nil
+	}
+	return &members[0], nil
+}
+
+// GetInvitations は招待中のグループを新しい順に取得
+func (r *workoutGroupMemberRepository) GetInvitations(ctx context.Context, userID uint) ([]*entity.WorkoutGroupMember, error) {
+	var members []*entity.WorkoutGroupMember
+	if err := r.db.
+		Where("user_id = ? AND status = ?", userID, entity.WorkoutGroupMemberStatusInvited).
+		Order("updated_at DESC").
+		Find(&members).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch workout group invitations: %w", err)
+	}
+	return members, nil
+}
+
+func (r *workoutGroupMemberRepository) SaveMember(ctx context.Context, member *entity.WorkoutGroupMember) error {
+	return r.db.Save(member).Error
+}
+
+// JoinWorkoutGroup はメンバーを参加中にし、グループのワークアウトがなければ作成する
+func (r *workoutGroupMemberRepository) JoinWorkoutGroup(ctx context.Context, member *entity.WorkoutGroupMember) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(member).Error; err != nil {
+			return err
+		}
+
+		var count int64
+		if err := tx.Model(&entity.Workout{}).
+			Where("workout_group_id = ? AND user_id = ?", member.WorkoutGroupID, member.UserID).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return nil
+		}
+
+		var group entity.WorkoutGroup
+		if err := tx.First(&group, member.WorkoutGroupID).Error; err != nil {
+			return err
+		}
+		workout := entity.Workout{
+			UserID:         member.UserID,
+			WorkoutGroupID: &group.ID,
+			Date:           group.Date,
+			Status:         entity.WorkoutStatusPlanned,
+		}
+		return tx.Create(&workout).Error
+	})
+}
+
+// TransferOwnership はグループの作成者を変更する（元の作成者は管理者になる）
+func (r *workoutGroupMemberRepository) TransferOwnership(ctx context.Context, owner, newOwner *entity.WorkoutGroupMember) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		owner.Role = entity.WorkoutGroupMemberRoleAdmin
+		if err := tx.Save(owner).Error; err != nil {
+			return err
+		}
+		newOwner.Role = entity.WorkoutGroupMemberRoleOwner
+		return tx.Save(newOwner).Error
+	})
+}
+
+// GetMembersByWorkoutGroupIDs はバッチでWorkoutGroupID別にメンバーを取得
+func (r *workoutGroupMemberRepository) GetMembersByWorkoutGroupIDs(workoutGroupIDs []uint) ([]*entity.WorkoutGroupMember, error) {
+	if len(workoutGroupIDs) == 0 {
+		return []*entity.WorkoutGroupMember{}, nil
+	}
+
+	var members []*entity.WorkoutGroupMember
+	if err := r.db.Where("workout_group_id IN ?", workoutGroupIDs).Order("id ASC").Find(&members).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch workout group members by workout group IDs: %w", err)
+	}
+	return members, nil
+}
@@ -0,0 +1,328 @@
+package workout_group_member
+
+import (
+	"app/entity"
+	"app/graph/errcode"
+	"app/graph/model"
+	"app/graph/services/common"
+	"app/graph/services/event"
+	"app/graph/services/notification"
+	"app/graph/services/policy"
+	"app/graph/services/user"
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+type WorkoutGroupMemberService interface {
+	GetInvitations(ctx context.Context) ([]*model.WorkoutGroupMember, error)
+	InviteMember(ctx context.Context, input model.InviteWorkoutGroupMember) (*model.WorkoutGroupMember, error)
+	AcceptInvitation(ctx context.Context, input model.AcceptWorkoutGroupInvitation) (*model.WorkoutGroupMember, error)
+	DeclineInvitation(ctx context.Context, input model.DeclineWorkoutGroupInvitation) (*model.WorkoutGroupMember, error)
+	LeaveWorkoutGroup(ctx context.Context, input model.LeaveWorkoutGroup) (bool, error)
+	KickMember(ctx context.Context, input model.KickWorkoutGroupMember) (bool, error)
+	UpdateMemberRole(ctx context.Context, input model.UpdateWorkoutGroupMemberRole) (*model.WorkoutGroupMember, error)
+	// DataLoader使用メソッド
+	GetMembersByWorkoutGroupIDWithDataLoader(ctx context.Context, workoutGroupID string, status *model.WorkoutGroupMemberStatus) ([]*model.WorkoutGroupMember, error)
+}
+
+type workoutGroupMemberService struct {
+	repo       WorkoutGroupMemberRepository
+	userRepo   user.UserRepository
+	converter  *WorkoutGroupMemberConverter
+	common     common.CommonRepository
+	ownership  *policy.OwnershipPolicy
+	events     *event.Bus
+	notifier   *notification.Notifier
+	dataLoader *WorkoutGroupMemberDataLoader // DataLoaderを統合
+}
+
+func NewWorkoutGroupMemberService(repo WorkoutGroupMemberRepository, converter *WorkoutGroupMemberConverter, dataLoader *WorkoutGroupMemberDataLoader, events *event.Bus) WorkoutGroupMemberService {
+	db := repo.(*workoutGroupMemberRepository).db
+	return &workoutGroupMemberService{
+		repo:       repo,
+		userRepo:   user.NewUserRepository(db),
+		converter:  converter,
+		common:     common.NewCommonRepository(db),
+		ownership:  policy.NewOwnershipPolicy(policy.NewOwnershipRepository(db)),
+		events:     events,
+		notifier:   notification.NewNotifier(db),
+		dataLoader: dataLoader,
+	}
+}
+
+// GetInvitations は現在のユーザーが招待されているグループを取得
+func (s *workoutGroupMemberService) GetInvitations(ctx context.Context) ([]*model.WorkoutGroupMember, error) {
+	currentUser, err := s.common.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	members, err := s.repo.GetInvitations(ctx, currentUser.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.converter.ToModelWorkoutGroupMembersFromPointers(members), nil
+}
+
+// InviteMember は友達をグループに招待する（作成者・管理者のみ）
+// 招待されたユーザーが承認するまではグループに参加しない
+func (s *workoutGroupMemberService) InviteMember(ctx context.Context, input model.InviteWorkoutGroupMember) (*model.WorkoutGroupMember, error) {
+	currentUser, err := s.common.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	workoutGroupID, err := parseID(input.WorkoutGroupID, "workout group")
+	if err != nil {
+		return nil, err
+	}
+
+	invitee, err := s.userRepo.GetUserByID(ctx, input.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if err := s.ownership.AuthorizeInviteWorkoutGroupMember(ctx, currentUser, workoutGroupID, invitee.ID); err != nil {
+		return nil, err
+	}
+
+	member, err := s.repo.GetMember(ctx, workoutGroupID, invitee.ID)
+	if err != nil {
+		return nil, err
+	}
+	if member == nil {
+		member = &entity.WorkoutGroupMember{
+			WorkoutGroupID: workoutGroupID,
+			UserID:         invitee.ID,
+			Role:           entity.WorkoutGroupMemberRoleMember,
+			Status:         entity.WorkoutGroupMemberStatusInvited,
+			InvitedByID:    &currentUser.ID,
+		}
+	} else if err := member.Invite(currentUser.ID); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.SaveMember(ctx, member); err != nil {
+		return nil, fmt.Errorf("failed to invite workout group member: %w", err)
+	}
+
+	s.notifier.Notify(ctx, entity.NewWorkoutGroupInvitedNotification(*member))
+
+	return s.converter.ToModelWorkoutGroupMember(*member), nil
+}
+
+// AcceptInvitation は招待を承認してグループに参加する
+func (s *workoutGroupMemberService) AcceptInvitation(ctx context.Context, input model.AcceptWorkoutGroupInvitation) (*model.WorkoutGroupMember, error) {
+	member, err := s.getMyMembership(ctx, input.WorkoutGroupID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := member.Accept(time.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.JoinWorkoutGroup(ctx, member); err != nil {
+		return nil, fmt.Errorf("failed to join workout group: %w", err)
+	}
+
+	s.events.PublishWorkoutGroupActivity(ctx, event.WorkoutGroupActivity{
+		Type:           event.WorkoutGroupActivityMemberJoined,
+		WorkoutGroupID: member.WorkoutGroupID,
+		UserID:         member.UserID,
+		OccurredAt:     *member.JoinedAt,
+	})
+
+	return s.converter.ToModelWorkoutGroupMember(*member), nil
+}
+
+// DeclineInvitation は招待を辞退する
+func (s *workoutGroupMemberService) DeclineInvitation(ctx context.Context, input model.DeclineWorkoutGroupInvitation) (*model.WorkoutGroupMember, error) {
+	member, err := s.getMyMembership(ctx, input.WorkoutGroupID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := member.Decline(); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.SaveMember(ctx, member); err != nil {
+		return nil, fmt.Errorf("failed to decline workout group invitation: %w", err)
+	}
+
+	return s.converter.ToModelWorkoutGroupMember(*member), nil
+}
+
+// LeaveWorkoutGroup はグループから退出する（記録したワークアウトは残る）
+func (s *workoutGroupMemberService) LeaveWorkoutGroup(ctx context.Context, input model.LeaveWorkoutGroup) (bool, error) {
+	member, err := s.getMyMembership(ctx, input.WorkoutGroupID)
+	if err != nil {
+		return false, err
+	}
+
+	if err := member.Leave(); err != nil {
+		return false, err
+	}
+
+	if err := s.repo.SaveMember(ctx, member); err != nil {
+		return false, fmt.Errorf("failed to leave workout group: %w", err)
+	}
+
+	return true, nil
+}
+
+// KickMember はメンバーをグループから削除する（招待中の場合は招待を取り消す）
+func (s *workoutGroupMemberService) KickMember(ctx context.Context, input model.KickWorkoutGroupMember) (bool, error) {
+	currentUser, err := s.common.GetCurrentUser(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	userID, err := parseID(input.UserID, "user")
+	if err != nil {
+		return false, err
+	}
+
+	target, err := s.getMember(ctx, input.WorkoutGroupID, userID)
+	if err != nil {
+		return false, err
+	}
+	if target.Status != entity.WorkoutGroupMemberStatusJoined && target.Status != entity.WorkoutGroupMemberStatusInvited {
+		return false, fmt.Errorf("user is not a member of the workout group")
+	}
+
+	if err := s.ownership.AuthorizeKickWorkoutGroupMember(ctx, currentUser, target); err != nil {
+		return false, err
+	}
+
+	target.Status = entity.WorkoutGroupMemberStatusLeft
+	target.Role = entity.WorkoutGroupMemberRoleMember
+	if err := s.repo.SaveMember(ctx, target); err != nil {
+		return false, fmt.Errorf("failed to remove workout group member: %w", err)
+	}
+
+	return true, nil
+}
+
+// UpdateMemberRole はメンバーのロールを変更する（作成者のみ）
+// OWNERを指定した場合は作成者を引き継ぎ、元の作成者は管理者になる
+func (s *workoutGroupMemberService) UpdateMemberRole(ctx context.Context, input model.UpdateWorkoutGroupMemberRole) (*model.WorkoutGroupMember, error) {
+	currentUser, err := s.common.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	workoutGroupID, err := parseID(input.WorkoutGroupID, "workout group")
+	if err != nil {
+		return nil, err
+	}
+
+	owner, err := s.ownership.AuthorizeWorkoutGroupMember(ctx, currentUser, workoutGroupID)
+	if err != nil {
+		return nil, err
+	}
+	if owner.Role != entity.WorkoutGroupMemberRoleOwner {
+		return nil, errcode.NewForbidden(ctx, "workout group owner role required")
+	}
+
+	userID, err := parseID(input.UserID, "user")
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := s.getMember(ctx, input.WorkoutGroupID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if target.UserID == owner.UserID {
+		return nil, fmt.Errorf("cannot change your own role")
+	}
+	if !target.IsJoined() {
+		return nil, fmt.Errorf("user is not a member of the workout group")
+	}
+
+	role := entity.WorkoutGroupMemberRoleFromGraphQL(input.Role)
+	if role == entity.WorkoutGroupMemberRoleOwner {
+		if err := s.repo.TransferOwnership(ctx, owner, target); err != nil {
+			return nil, fmt.Errorf("failed to transfer workout group ownership: %w", err)
+		}
+		return s.converter.ToModelWorkoutGroupMember(*target), nil
+	}
+
+	target.Role = role
+	if err := s.repo.SaveMember(ctx, target); err != nil {
+		return nil, fmt.Errorf("failed to update workout group member role: %w", err)
+	}
+
+	return s.converter.ToModelWorkoutGroupMember(*target), nil
+}
+
+// DataLoader使用メソッド
+// GetMembersByWorkoutGroupIDWithDataLoader はグループのメンバーを取得する（現在のユーザーとの間でブロックしているユーザーは除く）
+func (s *workoutGroupMemberService) GetMembersByWorkoutGroupIDWithDataLoader(ctx context.Context, workoutGroupID string, status *model.WorkoutGroupMemberStatus) ([]*model.WorkoutGroupMember, error) {
+	currentUser, err := s.common.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+	blocked, err := s.ownership.BlockedUserIDs(ctx, currentUser)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := s.dataLoader.LoadByWorkoutGroupID(ctx, workoutGroupID)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := make([]*entity.WorkoutGroupMember, 0, len(members))
+	for _, member := range members {
+		if blocked[member.UserID] {
+			continue
+		}
+		if status != nil && member.Status != entity.WorkoutGroupMemberStatusFromGraphQL(*status) {
+			continue
+		}
+		filtered = append(filtered, member)
+	}
+
+	return s.converter.ToModelWorkoutGroupMembersFromPointers(filtered), nil
+}
+
+// ヘルパー関数
+// getMyMembership は現在のユーザーのメンバー情報を取得する
+func (s *workoutGroupMemberService) getMyMembership(ctx context.Context, workoutGroupID string) (*entity.WorkoutGroupMember, error) {
+	currentUser, err := s.common.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+	return s.getMember(ctx, workoutGroupID, currentUser.ID)
+}
+
+// getMember はグループのメンバー情報を取得する（招待されたことがない場合はエラー）
+func (s *workoutGroupMemberService) getMember(ctx context.Context, workoutGroupID string, userID uint) (*entity.WorkoutGroupMember, error) {
+	groupID, err := parseID(workoutGroupID, "workout group")
+	if err != nil {
+		return nil, err
+	}
+
+	member, err := s.repo.GetMember(ctx, groupID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if member == nil {
+		return nil, fmt.Errorf("workout group member not found")
+	}
+	return member, nil
+}
+
+func parseID(id string, resource string) (uint, error) {
+	parsed, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s ID: %s", resource, id)
+	}
+	return uint(parsed), nil
+}
@@ -0,0 +1,71 @@
+package workout_template
+
+import (
+	"app/entity"
+	"app/graph/model"
+	"app/graph/services/common"
+	"app/graph/services/workout"
+	"fmt"
+)
+
+type WorkoutTemplateConverter struct {
+	workoutConverter *workout.WorkoutConverter
+}
+
+func NewWorkoutTemplateConverter() *WorkoutTemplateConverter {
+	return &WorkoutTemplateConverter{
+		workoutConverter: workout.NewWorkoutConverter(),
+	}
+}
+
+func (c *WorkoutTemplateConverter) ToModelWorkoutTemplate(template entity.WorkoutTemplate) *model.WorkoutTemplate {
+	var description *string
+	if template.Description != "" {
+		description = &template.Description
+	}
+
+	return &model.WorkoutTemplate{
+		ID:          fmt.Sprintf("%d", template.ID),
+		Name:        template.Name,
+		Description: description,
+		UserID:      fmt.Sprintf("%d", template.UserID),
+		CreatedAt:   template.CreatedAt.Format(common.TimeFormat),
+		UpdatedAt:   template.UpdatedAt.Format(common.TimeFormat),
+	}
+}
+
+func (c *WorkoutTemplateConverter) ToModelWorkoutTemplatesFromPointers(templates []*entity.WorkoutTemplate) []*model.WorkoutTemplate {
+	result := make([]*model.WorkoutTemplate, len(templates))
+	for i, template := range templates {
+		if template != nil {
+			result[i] = c.ToModelWorkoutTemplate(*template)
+		}
+	}
+	return result
+}
+
+func (c *WorkoutTemplateConverter) ToModelWorkoutTemplateExercise(exercise entity.WorkoutTemplateExercise) *model.WorkoutTemplateExercise {
+	return &model.WorkoutTemplateExercise{
+		ID:             fmt.Sprintf("%d", exercise.ID),
+		ExerciseID:     fmt.Sprintf("%d", exercise.ExerciseID),
+		Position:       int32(exercise.Position),
+		TargetSets:     int32(exercise.TargetSets),
+		TargetReps:     int32(exercise.TargetReps),
+		TargetWeightKg: exercise.TargetWeight,
+	}
+}
+
+func (c *WorkoutTemplateConverter) ToModelWorkoutTemplateExercisesFromPointers(exercises []*entity.WorkoutTemplateExercise) []*model.WorkoutTemplateExercise {
+	result := make([]*model.WorkoutTemplateExercise, len(exercises))
+	for i, exercise := range exercises {
+		if exercise != nil {
+			result[i] = c.ToModelWorkoutTemplateExercise(*exercise)
+		}
+	}
+	return result
+}
+
+// ToModelWorkout はテンプレートから作成したワークアウトを変換する
+func (c *WorkoutTemplateConverter) ToModelWorkout(workout entity.Workout) *model.Workout {
+	return c.workoutConverter.ToModelWorkout(workout)
+}
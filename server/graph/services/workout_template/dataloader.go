@@ -0,0 +1,51 @@
+package workout_template
+
+import (
+	"app/entity"
+	"app/graph/services/common/base"
+	"context"
+)
+
+// WorkoutTemplateDataLoader は WorkoutTemplate エンティティの遅延ローディングを担当
+type WorkoutTemplateDataLoader struct {
+	repository                  WorkoutTemplateRepository
+	exercisesByTemplateIDLoader *base.BaseArrayLoader[entity.WorkoutTemplateExercise]
+}
+
+// NewWorkoutTemplateDataLoader は新しいDataLoaderを作成
+func NewWorkoutTemplateDataLoader(repository WorkoutTemplateRepository) *WorkoutTemplateDataLoader {
+	loader := &WorkoutTemplateDataLoader{
+		repository: repository,
+	}
+
+	// ExercisesByTemplateID用のローダー
+	loader.exercisesByTemplateIDLoader = base.NewBaseArrayLoader(
+		nil, // dbは不要（repositoryを使用）
+		loader.fetchExercisesByTemplateIDs,
+		loader.createTemplateIDMap,
+		base.ParseUintKey,
+	)
+
+	return loader
+}
+
+// LoadExercisesByTemplateID は指定されたテンプレートの種目を取得
+func (l *WorkoutTemplateDataLoader) LoadExercisesByTemplateID(ctx context.Context, templateID string) ([]*entity.WorkoutTemplateExercise, error) {
+	return l.exercisesByTemplateIDLoader.Load(ctx, templateID)
+}
+
+// fetchExercisesByTemplateIDs はRepository経由でテンプレートID別にデータを取得
+func (l *WorkoutTemplateDataLoader) fetchExercisesByTemplateIDs(templateIDs []uint) ([]*entity.WorkoutTemplateExercise, error) {
+	return l.repository.GetWorkoutTemplateExercisesByTemplateIDs(templateIDs)
+}
+
+// createTemplateIDMap はテンプレートID別にデータをマップ化
+func (l *WorkoutTemplateDataLoader) createTemplateIDMap(exercises []*entity.WorkoutTemplateExercise) map[uint][]*entity.WorkoutTemplateExercise {
+	result := make(map[uint][]*entity.WorkoutTemplateExercise)
+	for _, exercise := range exercises {
+		if exercise != nil {
+			result[exercise.WorkoutTemplateID] = append(result[exercise.WorkoutTemplateID], exercise)
+		}
+	}
+	return result
+}
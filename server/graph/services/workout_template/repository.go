@@ -0,0 +1,148 @@
+package workout_template
+
+import (
+	"app/entity"
+	"context"
+	"fmt"
+	"strconv"
+
+	"gorm.io/gorm"
+)
+
+type WorkoutTemplateRepository interface {
+	GetWorkoutTemplateByID(ctx context.Context, id string) (*entity.WorkoutTemplate, error)
+	GetWorkoutTemplatesByUserID(ctx context.Context, userID uint) ([]*entity.WorkoutTemplate, error)
+	CreateWorkoutTemplate(ctx context.Context, template *entity.WorkoutTemplate) error
+	UpdateWorkoutTemplate(ctx context.Context, template *entity.WorkoutTemplate, exercises []entity.WorkoutTemplateExercise) error
+	DeleteWorkoutTemplate(ctx context.Context, id uint) error
+	StartWorkoutFromTemplate(ctx context.Context, template *entity.WorkoutTemplate, workout *entity.Workout) error
+	// Batch methods for DataLoader
+	GetWorkoutTemplateExercisesByTemplateIDs(templateIDs []uint) ([]*entity.WorkoutTemplateExercise, error)
+}
+
+type workoutTemplateRepository struct {
+	db *gorm.DB
+}
+
+func NewWorkoutTemplateRepository(db *gorm.DB) WorkoutTemplateRepository {
+	return &workoutTemplateRepository{db: db}
+}
+
+func (r *workoutTemplateRepository) GetWorkoutTemplateByID(ctx context.Context, id string) (*entity.WorkoutTemplate, error) {
+	templateID, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid workout template ID: %s", id)
+	}
+
+	var template entity.WorkoutTemplate
+	if err := r.db.
+		Preload("Exercises", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position ASC")
+		}).
+		Preload("Exercises.Exercise").
+		Where("id = ?", uint(templateID)).
+		First(&template).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch workout template: %w", err)
+	}
+
+	return &template, nil
+}
+
+func (r *workoutTemplateRepository) GetWorkoutTemplatesByUserID(ctx context.Context, userID uint) ([]*entity.WorkoutTemplate, error) {
+	var templates []*entity.WorkoutTemplate
+	if err := r.db.Where("user_id = ?", userID).Order("id ASC").Find(&templates).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch workout templates: %w", err)
+	}
+
+	return templates, nil
+}
+
+// CreateWorkoutTemplate はテンプレートと種目を作成する（GORMのアソシエーションで同一トランザクション）
+func (r *workoutTemplateRepository) CreateWorkoutTemplate(ctx context.Context, template *entity.WorkoutTemplate) error {
+	return r.db.Create(template).Error
+}
+
+// UpdateWorkoutTemplate はテンプレートを更新する（exercisesがnilでない場合は種目を置き換える）
+func (r *workoutTemplateRepository) UpdateWorkoutTemplate(ctx context.Context, template *entity.WorkoutTemplate, exercises []entity.WorkoutTemplateExercise) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Exercises").Save(template).Error; err != nil {
+			return err
+		}
+
+		if exercises == nil {
+			return nil
+		}
+
+		if err := tx.Unscoped().
+			Where("workout_template_id = ?", template.ID).
+			Delete(&entity.WorkoutTemplateExercise{}).Error; err != nil {
+			return fmt.Errorf("failed to delete workout template exercises: %w", err)
+		}
+
+		for i := range exercises {
+			exercises[i].WorkoutTemplateID = template.ID
+		}
+		if len(exercises) > 0 {
+			if err := tx.Create(&exercises).Error; err != nil {
+				return err
+			}
+		}
+		template.Exercises = exercises
+
+		return nil
+	})
+}
+
+func (r *workoutTemplateRepository) DeleteWorkoutTemplate(ctx context.Context, id uint) error {
+	return r.db.Unscoped().Delete(&entity.WorkoutTemplate{}, id).Error
+}
+
+// StartWorkoutFromTemplate はテンプレートからワークアウト・種目・プレースホルダーのセットを1トランザクションで作成する
+// 実施中のワークアウトは中断済みにする
+func (r *workoutTemplateRepository) StartWorkoutFromTemplate(ctx context.Context, template *entity.WorkoutTemplate, workout *entity.Workout) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := entity.AbandonInProgressWorkouts(tx, workout.UserID, 0); err != nil {
+			return fmt.Errorf("failed to abandon in-progress workouts: %w", err)
+		}
+		if err := tx.Create(workout).Error; err != nil {
+			return fmt.Errorf("failed to create workout: %w", err)
+		}
+
+		for i, templateExercise := range template.Exercises {
+			workoutExercise := entity.WorkoutExercise{
+				WorkoutID:  workout.ID,
+				ExerciseID: templateExercise.ExerciseID,
+				Position:   i + 1,
+			}
+			if err := tx.Create(&workoutExercise).Error; err != nil {
+				return fmt.Errorf("failed to create workout exercise: %w", err)
+			}
+
+			setLogs := templateExercise.PlaceholderSetLogs(workoutExercise.ID, templateExercise.Exercise.TrackingType)
+			if len(setLogs) == 0 {
+				continue
+			}
+			if err := tx.Create(&setLogs).Error; err != nil {
+				return fmt.Errorf("failed to create set logs: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// Batch methods for DataLoader
+func (r *workoutTemplateRepository) GetWorkoutTemplateExercisesByTemplateIDs(templateIDs []uint) ([]*entity.WorkoutTemplateExercise, error) {
+	if len(templateIDs) == 0 {
+		return []*entity.WorkoutTemplateExercise{}, nil
+	}
+
+	var exercises []*entity.WorkoutTemplateExercise
+	if err := r.db.Where("workout_template_id IN ?", templateIDs).
+		Order("workout_template_id ASC, position ASC").
+		Find(&exercises).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch workout template exercises by template IDs: %w", err)
+	}
+
+	return exercises, nil
+}
@@ -0,0 +1,280 @@
+package workout_template
+
+import (
+	"app/entity"
+	"app/graph/errcode"
+	"app/graph/model"
+	"app/graph/services/common"
+	"app/graph/services/policy"
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+type WorkoutTemplateService interface {
+	GetWorkoutTemplates(ctx context.Context) ([]*model.WorkoutTemplate, error)
+	GetWorkoutTemplate(ctx context.Context, id string) (*model.WorkoutTemplate, error)
+	CreateWorkoutTemplate(ctx context.Context, input model.CreateWorkoutTemplate) (*model.WorkoutTemplate, error)
+	UpdateWorkoutTemplate(ctx context.Context, input model.UpdateWorkoutTemplate) (*model.WorkoutTemplate, error)
+	DeleteWorkoutTemplate(ctx context.Context, input model.DeleteWorkoutTemplate) (bool, error)
+	StartWorkoutFromTemplate(ctx context.Context, input model.StartWorkoutFromTemplate) (*model.Workout, error)
+	ConvertTargetWeight(ctx context.Context, exercise *model.WorkoutTemplateExercise, unit *model.WeightUnit) (*float64, error)
+	// DataLoader使用メソッド
+	GetWorkoutTemplateExercisesWithDataLoader(ctx context.Context, templateID string) ([]*model.WorkoutTemplateExercise, error)
+}
+
+type workoutTemplateService struct {
+	repo       WorkoutTemplateRepository
+	converter  *WorkoutTemplateConverter
+	common     common.CommonRepository
+	ownership  *policy.OwnershipPolicy
+	dataLoader *WorkoutTemplateDataLoader // DataLoaderを統合
+}
+
+func NewWorkoutTemplateService(repo WorkoutTemplateRepository, converter *WorkoutTemplateConverter, dataLoader *WorkoutTemplateDataLoader) WorkoutTemplateService {
+	return &workoutTemplateService{
+		repo:       repo,
+		converter:  converter,
+		common:     common.NewCommonRepository(repo.(*workoutTemplateRepository).db),
+		ownership:  policy.NewOwnershipPolicy(policy.NewOwnershipRepository(repo.(*workoutTemplateRepository).db)),
+		dataLoader: dataLoader,
+	}
+}
+
+func (s *workoutTemplateService) GetWorkoutTemplates(ctx context.Context) ([]*model.WorkoutTemplate, error) {
+	currentUser, err := s.common.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	templates, err := s.repo.GetWorkoutTemplatesByUserID(ctx, currentUser.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workout templates: %w", err)
+	}
+
+	return s.converter.ToModelWorkoutTemplatesFromPointers(templates), nil
+}
+
+func (s *workoutTemplateService) GetWorkoutTemplate(ctx context.Context, id string) (*model.WorkoutTemplate, error) {
+	template, err := s.getOwnedTemplate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.converter.ToModelWorkoutTemplate(*template), nil
+}
+
+func (s *workoutTemplateService) CreateWorkoutTemplate(ctx context.Context, input model.CreateWorkoutTemplate) (*model.WorkoutTemplate, error) {
+	currentUser, err := s.common.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	exercises, err := s.toEntityExercises(ctx, currentUser.ID, input.Exercises, input.WeightUnit)
+	if err != nil {
+		return nil, err
+	}
+
+	template := entity.WorkoutTemplate{
+		UserID:    currentUser.ID,
+		Name:      input.Name,
+		Exercises: exercises,
+	}
+	if input.Description != nil {
+		template.Description = *input.Description
+	}
+
+	if err := s.repo.CreateWorkoutTemplate(ctx, &template); err != nil {
+		return nil, fmt.Errorf("failed to create workout template: %w", err)
+	}
+
+	return s.converter.ToModelWorkoutTemplate(template), nil
+}
+
+func (s *workoutTemplateService) UpdateWorkoutTemplate(ctx context.Context, input model.UpdateWorkoutTemplate) (*model.WorkoutTemplate, error) {
+	template, err := s.getOwnedTemplate(ctx, input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 更新可能なフィールドのみ更新
+	if input.Name != nil {
+		template.Name = *input.Name
+	}
+	if input.Description != nil {
+		template.Description = *input.Description
+	}
+
+	// 種目が指定された場合は順序ごと置き換える
+	var exercises []entity.WorkoutTemplateExercise
+	if input.Exercises != nil {
+		exercises, err = s.toEntityExercises(ctx, template.UserID, input.Exercises, input.WeightUnit)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.repo.UpdateWorkoutTemplate(ctx, template, exercises); err != nil {
+		return nil, fmt.Errorf("failed to update workout template: %w", err)
+	}
+
+	return s.converter.ToModelWorkoutTemplate(*template), nil
+}
+
+func (s *workoutTemplateService) DeleteWorkoutTemplate(ctx context.Context, input model.DeleteWorkoutTemplate) (bool, error) {
+	template, err := s.getOwnedTemplate(ctx, input.ID)
+	if err != nil {
+		return false, err
+	}
+
+	if err := s.repo.DeleteWorkoutTemplate(ctx, template.ID); err != nil {
+		return false, fmt.Errorf("failed to delete workout template: %w", err)
+	}
+
+	return true, nil
+}
+
+func (s *workoutTemplateService) StartWorkoutFromTemplate(ctx context.Context, input model.StartWorkoutFromTemplate) (*model.Workout, error) {
+	template, err := s.getOwnedTemplate(ctx, input.TemplateID)
+	if err != nil {
+		return nil, err
+	}
+
+	// ワークアウトグループIDの処理（オプショナル）
+	var workoutGroupID *uint
+	if input.WorkoutGroupID != nil {
+		workoutGroupIDUint64, err := strconv.ParseUint(*input.WorkoutGroupID, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid workout group ID: %s", *input.WorkoutGroupID)
+		}
+		workoutGroupIDValue := uint(workoutGroupIDUint64)
+		workoutGroupID = &workoutGroupIDValue
+
+		// グループに参加中のメンバーのみグループのワークアウトを開始できる
+		currentUser, err := s.common.GetCurrentUser(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get current user: %w", err)
+		}
+		if _, err := s.ownership.AuthorizeWorkoutGroupMember(ctx, currentUser, workoutGroupIDValue); err != nil {
+			return nil, err
+		}
+	}
+
+	// 日付の処理（オプショナル）
+	var date *time.Time
+	if input.Date != nil {
+		parsedDate, err := time.Parse(common.DateFormat, *input.Date)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date: %s", *input.Date)
+		}
+		date = &parsedDate
+	}
+
+	now := time.Now()
+	workout := entity.Workout{
+		UserID:         template.UserID,
+		WorkoutGroupID: workoutGroupID,
+		Date:           date,
+		Status:         entity.WorkoutStatusInProgress,
+		StartedAt:      &now,
+	}
+
+	if err := s.repo.StartWorkoutFromTemplate(ctx, template, &workout); err != nil {
+		return nil, fmt.Errorf("failed to start workout from template: %w", err)
+	}
+
+	return s.converter.ToModelWorkout(workout), nil
+}
+
+// ConvertTargetWeight はkgで保存された目標重量を指定単位に変換する（未指定の場合はプロフィールの単位）
+func (s *workoutTemplateService) ConvertTargetWeight(ctx context.Context, exercise *model.WorkoutTemplateExercise, unit *model.WeightUnit) (*float64, error) {
+	if exercise.TargetWeightKg == nil {
+		return nil, nil
+	}
+
+	weightUnit, err := s.weightUnit(ctx, unit)
+	if err != nil {
+		return nil, err
+	}
+
+	weight := entity.FromKilograms(*exercise.TargetWeightKg, weightUnit)
+	return &weight, nil
+}
+
+// ヘルパー関数
+// getOwnedTemplate は現在のユーザーが所有するテンプレートを取得する
+func (s *workoutTemplateService) getOwnedTemplate(ctx context.Context, id string) (*entity.WorkoutTemplate, error) {
+	currentUser, err := s.common.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	template, err := s.repo.GetWorkoutTemplateByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workout template: %w", err)
+	}
+
+	if template.UserID != currentUser.ID {
+		return nil, errcode.NewForbidden(ctx, "workout template is not owned by the current user")
+	}
+
+	return template, nil
+}
+
+// weightUnit は指定された重量単位を返す（未指定の場合はプロフィールの単位、読み書きで同じ単位を使う）
+func (s *workoutTemplateService) weightUnit(ctx context.Context, unit *model.WeightUnit) (entity.WeightUnit, error) {
+	if unit != nil {
+		return entity.WeightUnitFromGraphQL(*unit), nil
+	}
+
+	preferredUnit, err := s.common.GetViewerWeightUnit(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get preferred weight unit: %w", err)
+	}
+	return preferredUnit, nil
+}
+
+// toEntityExercises は入力の並び順を Position としてテンプレートの種目を作成する
+func (s *workoutTemplateService) toEntityExercises(ctx context.Context, userID uint, inputs []*model.WorkoutTemplateExerciseInput, unit *model.WeightUnit) ([]entity.WorkoutTemplateExercise, error) {
+	weightUnit, err := s.weightUnit(ctx, unit)
+	if err != nil {
+		return nil, err
+	}
+
+	exercises := make([]entity.WorkoutTemplateExercise, len(inputs))
+	exerciseIDs := make([]uint, len(inputs))
+	for i, input := range inputs {
+		exerciseID, err := strconv.ParseUint(input.ExerciseID, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exercise ID: %s", input.ExerciseID)
+		}
+		exerciseIDs[i] = uint(exerciseID)
+
+		exercises[i] = entity.WorkoutTemplateExercise{
+			ExerciseID: uint(exerciseID),
+			Position:   i + 1,
+			TargetSets: int(input.TargetSets),
+			TargetReps: int(input.TargetReps),
+		}
+		if input.TargetWeight != nil {
+			targetWeight := entity.ToKilograms(*input.TargetWeight, weightUnit)
+			exercises[i].TargetWeight = &targetWeight
+		}
+	}
+
+	if err := s.ownership.AuthorizeExercises(ctx, userID, exerciseIDs); err != nil {
+		return nil, err
+	}
+
+	return exercises, nil
+}
+
+// DataLoader使用メソッド
+func (s *workoutTemplateService) GetWorkoutTemplateExercisesWithDataLoader(ctx context.Context, templateID string) ([]*model.WorkoutTemplateExercise, error) {
+	exercises, err := s.dataLoader.LoadExercisesByTemplateID(ctx, templateID)
+	if err != nil {
+		return nil, err
+	}
+	return s.converter.ToModelWorkoutTemplateExercisesFromPointers(exercises), nil
+}
@@ -0,0 +1,187 @@
+package workout_template
+
+import (
+	"app/entity"
+	"app/graph/errcode"
+	"app/graph/model"
+	"app/graph/services/common"
+	"app/graph/services/policy"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// fakeCommonRepository はログイン中のユーザーとプロフィールの重量単位を固定で返す
+type fakeCommonRepository struct {
+	common.CommonRepository
+	user *entity.User
+	unit entity.WeightUnit
+}
+
+func (r *fakeCommonRepository) GetCurrentUser(ctx context.Context) (*entity.User, error) {
+	return r.user, nil
+}
+
+func (r *fakeCommonRepository) GetViewerWeightUnit(ctx context.Context) (entity.WeightUnit, error) {
+	return r.unit, nil
+}
+
+// fakeOwnershipRepository はグループのメンバーと閲覧できる種目のみを返す
+type fakeOwnershipRepository struct {
+	policy.OwnershipRepository
+	groupMembers       map[uint][]entity.WorkoutGroupMember
+	visibleExerciseIDs []uint
+}
+
+func (r *fakeOwnershipRepository) GetWorkoutGroupMember(ctx context.Context, workoutGroupID, userID uint) (*entity.WorkoutGroupMember, error) {
+	for _, member := range r.groupMembers[workoutGroupID] {
+		if member.UserID == userID {
+			return &member, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeOwnershipRepository) GetVisibleExerciseIDs(ctx context.Context, userID uint, exerciseIDs []uint) ([]uint, error) {
+	return r.visibleExerciseIDs, nil
+}
+
+// fakeWorkoutTemplateRepository は ownerID のテンプレートを返し、開始したワークアウトを保持する
+type fakeWorkoutTemplateRepository struct {
+	WorkoutTemplateRepository
+	ownerID uint
+	created []*entity.WorkoutTemplate
+	started []*entity.Workout
+}
+
+func (r *fakeWorkoutTemplateRepository) CreateWorkoutTemplate(ctx context.Context, template *entity.WorkoutTemplate) error {
+	r.created = append(r.created, template)
+	return nil
+}
+
+func (r *fakeWorkoutTemplateRepository) GetWorkoutTemplateByID(ctx context.Context, id string) (*entity.WorkoutTemplate, error) {
+	return &entity.WorkoutTemplate{Model: gorm.Model{ID: 1}, UserID: r.ownerID, Name: "Push"}, nil
+}
+
+func (r *fakeWorkoutTemplateRepository) StartWorkoutFromTemplate(ctx context.Context, template *entity.WorkoutTemplate, workout *entity.Workout) error {
+	workout.ID = uint(len(r.started) + 1)
+	r.started = append(r.started, workout)
+	return nil
+}
+
+func TestWorkoutTemplateService_StartWorkoutFromTemplate(t *testing.T) {
+	groupID := "50"
+	members := map[uint][]entity.WorkoutGroupMember{
+		50: {{WorkoutGroupID: 50, UserID: 1, Role: entity.WorkoutGroupMemberRoleOwner, Status: entity.WorkoutGroupMemberStatusJoined}},
+	}
+
+	tests := []struct {
+		name           string
+		userID         uint
+		ownerID        uint // テンプレートの作成者（0の場合は userID）
+		workoutGroupID *string
+		expectErr      bool
+	}{
+		{name: "Without group", userID: 3},
+		{name: "Joined member", userID: 1, workoutGroupID: &groupID},
+		{name: "Non-member is rejected", userID: 3, workoutGroupID: &groupID, expectErr: true},
+		{name: "Template of another user is rejected", userID: 3, ownerID: 1, expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ownerID := tt.ownerID
+			if ownerID == 0 {
+				ownerID = tt.userID
+			}
+			repo := &fakeWorkoutTemplateRepository{ownerID: ownerID}
+			service := &workoutTemplateService{
+				repo:      repo,
+				converter: NewWorkoutTemplateConverter(),
+				common:    &fakeCommonRepository{user: &entity.User{Model: gorm.Model{ID: tt.userID}}},
+				ownership: policy.NewOwnershipPolicy(&fakeOwnershipRepository{groupMembers: members}),
+			}
+
+			_, err := service.StartWorkoutFromTemplate(context.Background(), model.StartWorkoutFromTemplate{TemplateID: "1", WorkoutGroupID: tt.workoutGroupID})
+			if tt.expectErr {
+				assert.True(t, errcode.Is(err, errcode.Forbidden))
+				assert.Empty(t, repo.started)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, repo.started, 1)
+		})
+	}
+}
+
+func TestWorkoutTemplateService_CreateWorkoutTemplate(t *testing.T) {
+	kg := model.WeightUnitKg
+	tests := []struct {
+		name        string
+		exerciseIDs []string
+		expectErr   bool
+	}{
+		{name: "Visible exercises", exerciseIDs: []string{"1", "2"}},
+		{name: "Exercise not visible or archived is rejected", exerciseIDs: []string{"1", "3"}, expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeWorkoutTemplateRepository{}
+			service := &workoutTemplateService{
+				repo:      repo,
+				converter: NewWorkoutTemplateConverter(),
+				common:    &fakeCommonRepository{user: &entity.User{Model: gorm.Model{ID: 1}}},
+				ownership: policy.NewOwnershipPolicy(&fakeOwnershipRepository{visibleExerciseIDs: []uint{1, 2}}),
+			}
+
+			input := model.CreateWorkoutTemplate{Name: "Push", WeightUnit: &kg}
+			for _, id := range tt.exerciseIDs {
+				input.Exercises = append(input.Exercises, &model.WorkoutTemplateExerciseInput{ExerciseID: id, TargetSets: 3, TargetReps: 10})
+			}
+
+			_, err := service.CreateWorkoutTemplate(context.Background(), input)
+			if tt.expectErr {
+				assert.True(t, errcode.Is(err, errcode.Forbidden))
+				assert.Empty(t, repo.created)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, repo.created, 1)
+		})
+	}
+}
+
+func TestWorkoutTemplateService_TargetWeightUsesPreferredUnit(t *testing.T) {
+	repo := &fakeWorkoutTemplateRepository{}
+	service := &workoutTemplateService{
+		repo:      repo,
+		converter: NewWorkoutTemplateConverter(),
+		common:    &fakeCommonRepository{user: &entity.User{Model: gorm.Model{ID: 1}}, unit: entity.WeightUnitLb},
+		ownership: policy.NewOwnershipPolicy(&fakeOwnershipRepository{visibleExerciseIDs: []uint{1}}),
+	}
+	ctx := context.Background()
+
+	// 単位を指定せずに保存した目標重量は、単位を指定せずに取得すると同じ値になる
+	targetWeight := 135.0
+	_, err := service.CreateWorkoutTemplate(ctx, model.CreateWorkoutTemplate{
+		Name:      "Push",
+		Exercises: []*model.WorkoutTemplateExerciseInput{{ExerciseID: "1", TargetSets: 3, TargetReps: 5, TargetWeight: &targetWeight}},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, repo.created, 1)
+
+	stored := repo.created[0].Exercises[0].TargetWeight
+	assert.InDelta(t, entity.ToKilograms(135, entity.WeightUnitLb), *stored, 0.001)
+
+	weight, err := service.ConvertTargetWeight(ctx, &model.WorkoutTemplateExercise{TargetWeightKg: stored}, nil)
+	assert.NoError(t, err)
+	assert.InDelta(t, 135, *weight, 0.001)
+
+	kg := model.WeightUnitKg
+	weight, err = service.ConvertTargetWeight(ctx, &model.WorkoutTemplateExercise{TargetWeightKg: stored}, &kg)
+	assert.NoError(t, err)
+	assert.Equal(t, 61.24, *weight)
+}
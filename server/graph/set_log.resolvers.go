@@ -1,22 +1,59 @@
 package graph
 
 import (
+	"app/entity"
 	"app/graph/model"
 	"app/graph/services"
+	"app/graph/services/common"
 	"context"
 )
 
+// ================================
+// Model
+// ================================
+
+// SetLog returns SetLogResolver implementation.
+func (r *Resolver) SetLog() SetLogResolver { return &setLogResolver{r} }
+
+type setLogResolver struct{ *Resolver }
+
+// Weight はkgで保存された重量を指定単位に変換する（未指定の場合はプロフィールの単位、匿名の場合はkg）
+func (r *setLogResolver) Weight(ctx context.Context, obj *model.SetLog, unit *model.WeightUnit) (float64, error) {
+	if unit != nil {
+		return entity.FromKilograms(obj.WeightKg, entity.WeightUnitFromGraphQL(*unit)), nil
+	}
+
+	// 一覧の各セットから参照されるため、プロフィールの単位はリクエスト内で1度だけ取得する
+	preferredUnit, err := common.NewCommonRepository(r.DB).GetViewerWeightUnit(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return entity.FromKilograms(obj.WeightKg, preferredUnit), nil
+}
+
 // ================================
 // Mutation
 // ================================
 
 // AddSetLog is the resolver for the addSetLog field.
 func (r *mutationResolver) CreateSetLog(ctx context.Context, input model.CreateSetLog) (*model.SetLog, error) {
-	setLogService := services.NewSetLogServiceWithSeparation(r.DB)
+	setLogService := services.NewSetLogServiceWithSeparation(r.DB, r.PubSub)
 	return setLogService.CreateSetLog(ctx, input)
 }
 
+// UpdateSetLog is the resolver for the updateSetLog field.
+func (r *mutationResolver) UpdateSetLog(ctx context.Context, input model.UpdateSetLog) (*model.SetLog, error) {
+	setLogService := services.NewSetLogServiceWithSeparation(r.DB, r.PubSub)
+	return setLogService.UpdateSetLog(ctx, input)
+}
+
+// ReorderSetLogs is the resolver for the reorderSetLogs field.
+func (r *mutationResolver) ReorderSetLogs(ctx context.Context, input model.ReorderSetLogs) ([]*model.SetLog, error) {
+	setLogService := services.NewSetLogServiceWithSeparation(r.DB, r.PubSub)
+	return setLogService.ReorderSetLogs(ctx, input)
+}
+
 func (r *mutationResolver) DeleteSetLog(ctx context.Context, input model.DeleteSetLog) (bool, error) {
-	setLogService := services.NewSetLogServiceWithSeparation(r.DB)
+	setLogService := services.NewSetLogServiceWithSeparation(r.DB, r.PubSub)
 	return setLogService.DeleteSetLog(ctx, input)
 }
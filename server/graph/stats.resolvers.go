@@ -0,0 +1,32 @@
+package graph
+
+import (
+	"app/graph/model"
+	"app/graph/services"
+	"context"
+)
+
+// ================================
+// Model
+// ================================
+
+// OneRepMaxTrend returns OneRepMaxTrendResolver implementation.
+func (r *Resolver) OneRepMaxTrend() OneRepMaxTrendResolver { return &oneRepMaxTrendResolver{r} }
+
+type oneRepMaxTrendResolver struct{ *Resolver }
+
+// Exercise is the resolver for the exercise field.
+func (r *oneRepMaxTrendResolver) Exercise(ctx context.Context, obj *model.OneRepMaxTrend) (*model.Exercise, error) {
+	exerciseService := services.NewExerciseServiceWithSeparation(r.DB)
+	return exerciseService.GetExerciseWithDataLoader(ctx, obj.ExerciseID)
+}
+
+// ================================
+// Query
+// ================================
+
+// Stats is the resolver for the stats field.
+func (r *queryResolver) Stats(ctx context.Context, from string, to string, formula *model.OneRepMaxFormula, exerciseIDs []string) (*model.Stats, error) {
+	analyticsService := services.NewAnalyticsServiceWithSeparation(r.DB)
+	return analyticsService.GetStats(ctx, from, to, formula, exerciseIDs)
+}
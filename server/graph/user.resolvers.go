@@ -4,7 +4,6 @@ import (
 	"app/graph/model"
 	"app/graph/services"
 	"context"
-	"fmt"
 )
 
 // ================================
@@ -16,34 +15,46 @@ func (r *Resolver) User() UserResolver { return &userResolver{r} }
 
 type userResolver struct{ *Resolver }
 
+// Roles is the resolver for the roles field.
+func (r *userResolver) Roles(ctx context.Context, obj *model.User) ([]model.Role, error) {
+	roleService := services.NewRoleServiceWithSeparation(r.DB, r.FirebaseAuth)
+	return roleService.GetRolesByUserIDWithDataLoader(ctx, obj.ID)
+}
+
 // Profile is the resolver for the profile field.
 func (r *userResolver) Profile(ctx context.Context, obj *model.User) (*model.Profile, error) {
 	profileService := services.NewProfileServiceWithSeparation(r.DB)
 	return profileService.GetProfileByUserID(ctx, obj.ID)
 }
 
-// WorkoutLogs is the resolver for the workoutLogs field.
-func (r *userResolver) Workouts(ctx context.Context, obj *model.User) ([]*model.Workout, error) {
-	workoutService := services.NewWorkoutServiceWithSeparation(r.DB)
-	return workoutService.GetWorkoutsByUserIDWithDataLoader(ctx, obj.ID)
+// Workouts is the resolver for the workouts field.
+func (r *userResolver) Workouts(ctx context.Context, obj *model.User, first *int32, after *string) (*model.WorkoutConnection, error) {
+	workoutService := services.NewWorkoutServiceWithSeparation(r.DB, r.PubSub)
+	return workoutService.GetWorkoutConnectionByUserIDWithDataLoader(ctx, obj.ID, first, after)
 }
 
 // Friends is the resolver for the friends field.
-func (r *userResolver) Friends(ctx context.Context, obj *model.User) ([]*model.User, error) {
-	friendshipService := services.NewFriendshipServiceWithSeparation(r.DB)
-	return friendshipService.GetFriendsWithDataLoader(ctx, obj.ID)
+func (r *userResolver) Friends(ctx context.Context, obj *model.User, first *int32, after *string) (*model.UserConnection, error) {
+	friendshipService := services.NewFriendshipServiceWithSeparation(r.DB, r.PubSub, r.FriendQR)
+	return friendshipService.GetFriendsWithDataLoader(ctx, obj.ID, first, after)
 }
 
 // FriendshipRequests is the resolver for the friendshipRequests field.
 func (r *userResolver) FriendshipRequests(ctx context.Context, obj *model.User) ([]*model.Friendship, error) {
-	friendshipService := services.NewFriendshipServiceWithSeparation(r.DB)
+	friendshipService := services.NewFriendshipServiceWithSeparation(r.DB, r.PubSub, r.FriendQR)
 	return friendshipService.GetFriendshipRequestsWithDataLoader(ctx, obj.ID)
 }
 
 // RecommendedUsers is the resolver for the recommendedUsers field.
-func (r *userResolver) RecommendedUsers(ctx context.Context, obj *model.User) ([]*model.User, error) {
-	friendshipService := services.NewFriendshipServiceWithSeparation(r.DB)
-	return friendshipService.GetRecommendedUsersWithDataLoader(ctx, obj.ID)
+func (r *userResolver) RecommendedUsers(ctx context.Context, obj *model.User, first *int32, after *string) (*model.UserConnection, error) {
+	friendshipService := services.NewFriendshipServiceWithSeparation(r.DB, r.PubSub, r.FriendQR)
+	return friendshipService.GetRecommendedUsersWithDataLoader(ctx, obj.ID, first, after)
+}
+
+// PersonalRecords is the resolver for the personalRecords field.
+func (r *userResolver) PersonalRecords(ctx context.Context, obj *model.User, history *bool) ([]*model.PersonalRecord, error) {
+	personalRecordService := services.NewPersonalRecordServiceWithSeparation(r.DB)
+	return personalRecordService.GetPersonalRecordsByUserIDWithDataLoader(ctx, obj.ID, history != nil && *history)
 }
 
 // ================================
@@ -52,15 +63,8 @@ func (r *userResolver) RecommendedUsers(ctx context.Context, obj *model.User) ([
 
 // Users is the resolver for the users field.
 func (r *queryResolver) Users(ctx context.Context) ([]*model.User, error) {
+	// モデレーター・管理者のみ（サービス層のポリシーで確認）
 	userService := services.NewUserServiceWithSeparation(r.DB)
-	currentUser, err := userService.GetCurrentUser(ctx)
-	if err != nil {
-		return nil, err
-	}
-	if !currentUser.IsAdmin() {
-		return nil, fmt.Errorf("unauthorized")
-	}
-
 	return userService.GetUsers(ctx)
 }
 
@@ -79,3 +83,15 @@ func (r *mutationResolver) DeleteUser(ctx context.Context, input model.DeleteUse
 	userService := services.NewUserServiceWithSeparation(r.DB)
 	return userService.DeleteUser(ctx, input)
 }
+
+// GrantRole is the resolver for the grantRole field.
+func (r *mutationResolver) GrantRole(ctx context.Context, input model.GrantRole) (*model.User, error) {
+	roleService := services.NewRoleServiceWithSeparation(r.DB, r.FirebaseAuth)
+	return roleService.GrantRole(ctx, input)
+}
+
+// RevokeRole is the resolver for the revokeRole field.
+func (r *mutationResolver) RevokeRole(ctx context.Context, input model.RevokeRole) (*model.User, error) {
+	roleService := services.NewRoleServiceWithSeparation(r.DB, r.FirebaseAuth)
+	return roleService.RevokeRole(ctx, input)
+}
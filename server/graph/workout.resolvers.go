@@ -27,7 +27,7 @@ func (r *workoutResolver) WorkoutGroup(ctx context.Context, obj *model.Workout)
 		return nil, nil
 	}
 
-	workoutGroupService := services.NewWorkoutGroupServiceWithSeparation(r.DB)
+	workoutGroupService := services.NewWorkoutGroupServiceWithSeparation(r.DB, r.PubSub)
 	return workoutGroupService.GetWorkoutGroupWithDataLoader(ctx, *obj.WorkoutGroupID)
 }
 
@@ -36,17 +36,45 @@ func (r *workoutResolver) User(ctx context.Context, obj *model.Workout) (*model.
 	return userService.GetUserByIDWithDataLoader(ctx, obj.UserID)
 }
 
+// ================================
+// Query
+// ================================
+
+// ActiveWorkout is the resolver for the activeWorkout field.
+func (r *queryResolver) ActiveWorkout(ctx context.Context) (*model.Workout, error) {
+	workoutService := services.NewWorkoutServiceWithSeparation(r.DB, r.PubSub)
+	return workoutService.GetActiveWorkout(ctx)
+}
+
 // ================================
 // Mutation
 // ================================
 
 // StartWorkout is the resolver for the startWorkout field.
 func (r *mutationResolver) StartWorkout(ctx context.Context, input *model.StartWorkout) (*model.Workout, error) {
-	workoutService := services.NewWorkoutServiceWithSeparation(r.DB)
+	workoutService := services.NewWorkoutServiceWithSeparation(r.DB, r.PubSub)
 	return workoutService.StartWorkout(ctx, *input)
 }
 
+// FinishWorkout is the resolver for the finishWorkout field.
+func (r *mutationResolver) FinishWorkout(ctx context.Context, input model.FinishWorkout) (*model.Workout, error) {
+	workoutService := services.NewWorkoutServiceWithSeparation(r.DB, r.PubSub)
+	return workoutService.FinishWorkout(ctx, input)
+}
+
+// ResumeWorkout is the resolver for the resumeWorkout field.
+func (r *mutationResolver) ResumeWorkout(ctx context.Context, input model.ResumeWorkout) (*model.Workout, error) {
+	workoutService := services.NewWorkoutServiceWithSeparation(r.DB, r.PubSub)
+	return workoutService.ResumeWorkout(ctx, input)
+}
+
+// UpdateWorkout is the resolver for the updateWorkout field.
+func (r *mutationResolver) UpdateWorkout(ctx context.Context, input model.UpdateWorkout) (*model.Workout, error) {
+	workoutService := services.NewWorkoutServiceWithSeparation(r.DB, r.PubSub)
+	return workoutService.UpdateWorkout(ctx, input)
+}
+
 func (r *mutationResolver) DeleteWorkout(ctx context.Context, input model.DeleteWorkout) (bool, error) {
-	workoutService := services.NewWorkoutServiceWithSeparation(r.DB)
+	workoutService := services.NewWorkoutServiceWithSeparation(r.DB, r.PubSub)
 	return workoutService.DeleteWorkout(ctx, input)
 }
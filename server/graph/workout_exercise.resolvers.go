@@ -21,12 +21,12 @@ func (r *workoutExerciseResolver) Exercise(ctx context.Context, obj *model.Worko
 }
 
 func (r *workoutExerciseResolver) Workout(ctx context.Context, obj *model.WorkoutExercise) (*model.Workout, error) {
-	workoutService := services.NewWorkoutServiceWithSeparation(r.DB)
+	workoutService := services.NewWorkoutServiceWithSeparation(r.DB, r.PubSub)
 	return workoutService.GetWorkoutByIDWithDataLoader(ctx, obj.Workout.ID)
 }
 
 func (r *workoutExerciseResolver) SetLogs(ctx context.Context, obj *model.WorkoutExercise) ([]*model.SetLog, error) {
-	setLogService := services.NewSetLogServiceWithSeparation(r.DB)
+	setLogService := services.NewSetLogServiceWithSeparation(r.DB, r.PubSub)
 	return setLogService.GetSetLogsByWorkoutExerciseIDWithDataLoader(ctx, obj.ID)
 }
 
@@ -39,3 +39,33 @@ func (r *mutationResolver) CreateWorkoutExercise(ctx context.Context, input mode
 	workoutExerciseService := services.NewWorkoutExerciseServiceWithSeparation(r.DB)
 	return workoutExerciseService.CreateWorkoutExercise(ctx, input)
 }
+
+// ReorderWorkoutExercises is the resolver for the reorderWorkoutExercises field.
+func (r *mutationResolver) ReorderWorkoutExercises(ctx context.Context, input model.ReorderWorkoutExercises) ([]*model.WorkoutExercise, error) {
+	workoutExerciseService := services.NewWorkoutExerciseServiceWithSeparation(r.DB)
+	return workoutExerciseService.ReorderWorkoutExercises(ctx, input)
+}
+
+// GroupIntoSuperset is the resolver for the groupIntoSuperset field.
+func (r *mutationResolver) GroupIntoSuperset(ctx context.Context, input model.GroupIntoSuperset) ([]*model.WorkoutExercise, error) {
+	workoutExerciseService := services.NewWorkoutExerciseServiceWithSeparation(r.DB)
+	return workoutExerciseService.GroupIntoSuperset(ctx, input)
+}
+
+// RemoveFromSuperset is the resolver for the removeFromSuperset field.
+func (r *mutationResolver) RemoveFromSuperset(ctx context.Context, input model.RemoveFromSuperset) ([]*model.WorkoutExercise, error) {
+	workoutExerciseService := services.NewWorkoutExerciseServiceWithSeparation(r.DB)
+	return workoutExerciseService.RemoveFromSuperset(ctx, input)
+}
+
+// DeleteWorkoutExercise is the resolver for the deleteWorkoutExercise field.
+func (r *mutationResolver) DeleteWorkoutExercise(ctx context.Context, input model.DeleteWorkoutExercise) (bool, error) {
+	workoutExerciseService := services.NewWorkoutExerciseServiceWithSeparation(r.DB)
+	return workoutExerciseService.DeleteWorkoutExercise(ctx, input)
+}
+
+// ReplaceExercise is the resolver for the replaceExercise field.
+func (r *mutationResolver) ReplaceExercise(ctx context.Context, input model.ReplaceExercise) (*model.WorkoutExercise, error) {
+	workoutExerciseService := services.NewWorkoutExerciseServiceWithSeparation(r.DB)
+	return workoutExerciseService.ReplaceExercise(ctx, input)
+}
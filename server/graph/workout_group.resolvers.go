@@ -15,49 +15,172 @@ func (r *Resolver) WorkoutGroup() WorkoutGroupResolver { return &workoutGroupRes
 type workoutGroupResolver struct{ *Resolver }
 
 func (r *workoutGroupResolver) Workouts(ctx context.Context, obj *model.WorkoutGroup) ([]*model.Workout, error) {
-	workoutService := services.NewWorkoutServiceWithSeparation(r.DB)
+	workoutService := services.NewWorkoutServiceWithSeparation(r.DB, r.PubSub)
 	return workoutService.GetWorkoutsByWorkoutGroupIDWithDataLoader(ctx, obj.ID)
 }
 
+func (r *workoutGroupResolver) Members(ctx context.Context, obj *model.WorkoutGroup, status *model.WorkoutGroupMemberStatus) ([]*model.WorkoutGroupMember, error) {
+	workoutGroupMemberService := services.NewWorkoutGroupMemberServiceWithSeparation(r.DB, r.PubSub)
+	return workoutGroupMemberService.GetMembersByWorkoutGroupIDWithDataLoader(ctx, obj.ID, status)
+}
+
+func (r *Resolver) WorkoutGroupMember() WorkoutGroupMemberResolver {
+	return &workoutGroupMemberResolver{r}
+}
+
+type workoutGroupMemberResolver struct{ *Resolver }
+
+func (r *workoutGroupMemberResolver) WorkoutGroup(ctx context.Context, obj *model.WorkoutGroupMember) (*model.WorkoutGroup, error) {
+	workoutGroupService := services.NewWorkoutGroupServiceWithSeparation(r.DB, r.PubSub)
+	return workoutGroupService.GetWorkoutGroupWithDataLoader(ctx, obj.WorkoutGroupID)
+}
+
+func (r *workoutGroupMemberResolver) User(ctx context.Context, obj *model.WorkoutGroupMember) (*model.User, error) {
+	userService := services.NewUserServiceWithSeparation(r.DB)
+	return userService.GetUserByIDWithDataLoader(ctx, obj.UserID)
+}
+
+func (r *workoutGroupMemberResolver) InvitedBy(ctx context.Context, obj *model.WorkoutGroupMember) (*model.User, error) {
+	if obj.InvitedByID == nil {
+		return nil, nil
+	}
+	userService := services.NewUserServiceWithSeparation(r.DB)
+	return userService.GetUserByIDWithDataLoader(ctx, *obj.InvitedByID)
+}
+
+func (r *Resolver) WorkoutGroupActivity() WorkoutGroupActivityResolver {
+	return &workoutGroupActivityResolver{r}
+}
+
+type workoutGroupActivityResolver struct{ *Resolver }
+
+func (r *workoutGroupActivityResolver) WorkoutGroup(ctx context.Context, obj *model.WorkoutGroupActivity) (*model.WorkoutGroup, error) {
+	workoutGroupService := services.NewWorkoutGroupServiceWithSeparation(r.DB, r.PubSub)
+	return workoutGroupService.GetWorkoutGroupWithDataLoader(ctx, obj.WorkoutGroupID)
+}
+
+func (r *workoutGroupActivityResolver) User(ctx context.Context, obj *model.WorkoutGroupActivity) (*model.User, error) {
+	userService := services.NewUserServiceWithSeparation(r.DB)
+	return userService.GetUserByIDWithDataLoader(ctx, obj.UserID)
+}
+
+func (r *workoutGroupActivityResolver) Workout(ctx context.Context, obj *model.WorkoutGroupActivity) (*model.Workout, error) {
+	if obj.WorkoutID == nil {
+		return nil, nil
+	}
+	workoutService := services.NewWorkoutServiceWithSeparation(r.DB, r.PubSub)
+	return workoutService.GetWorkoutByIDWithDataLoader(ctx, *obj.WorkoutID)
+}
+
+func (r *workoutGroupActivityResolver) SetLog(ctx context.Context, obj *model.WorkoutGroupActivity) (*model.SetLog, error) {
+	if obj.SetLogID == nil {
+		return nil, nil
+	}
+	setLogService := services.NewSetLogServiceWithSeparation(r.DB, r.PubSub)
+	return setLogService.GetSetLogByID(ctx, *obj.SetLogID)
+}
+
 // ================================
 // Query
 // ================================
 
 // WorkoutGroups is the resolver for the workoutGroups query.
 func (r *queryResolver) WorkoutGroups(ctx context.Context) ([]*model.WorkoutGroup, error) {
-	workoutGroupService := services.NewWorkoutGroupServiceWithSeparation(r.DB)
+	workoutGroupService := services.NewWorkoutGroupServiceWithSeparation(r.DB, r.PubSub)
 	return workoutGroupService.GetWorkoutGroups(ctx)
 }
 
 // WorkoutGroup is the resolver for the workoutGroup query.
 func (r *queryResolver) WorkoutGroup(ctx context.Context, id string) (*model.WorkoutGroup, error) {
-	workoutGroupService := services.NewWorkoutGroupServiceWithSeparation(r.DB)
+	workoutGroupService := services.NewWorkoutGroupServiceWithSeparation(r.DB, r.PubSub)
 	return workoutGroupService.GetWorkoutGroup(ctx, id)
 }
 
+// WorkoutGroupInvitations is the resolver for the workoutGroupInvitations query.
+func (r *queryResolver) WorkoutGroupInvitations(ctx context.Context) ([]*model.WorkoutGroupMember, error) {
+	workoutGroupMemberService := services.NewWorkoutGroupMemberServiceWithSeparation(r.DB, r.PubSub)
+	return workoutGroupMemberService.GetInvitations(ctx)
+}
+
 // ================================
 // Mutation
 // ================================
 
 // CreateWorkoutGroup is the resolver for the createWorkoutGroup field.
 func (r *mutationResolver) CreateWorkoutGroup(ctx context.Context, input model.CreateWorkoutGroup) (*model.WorkoutGroup, error) {
-	workoutGroupService := services.NewWorkoutGroupServiceWithSeparation(r.DB)
+	workoutGroupService := services.NewWorkoutGroupServiceWithSeparation(r.DB, r.PubSub)
 	return workoutGroupService.CreateWorkoutGroup(ctx, input)
 }
 
 // UpdateWorkoutGroup is the resolver for the updateWorkoutGroup field.
 func (r *mutationResolver) UpdateWorkoutGroup(ctx context.Context, input model.UpdateWorkoutGroup) (*model.WorkoutGroup, error) {
-	workoutGroupService := services.NewWorkoutGroupServiceWithSeparation(r.DB)
+	workoutGroupService := services.NewWorkoutGroupServiceWithSeparation(r.DB, r.PubSub)
 	return workoutGroupService.UpdateWorkoutGroup(ctx, input)
 }
 
 // DeleteWorkoutGroup is the resolver for the deleteWorkoutGroup field.
 func (r *mutationResolver) DeleteWorkoutGroup(ctx context.Context, input model.DeleteWorkoutGroup) (bool, error) {
-	workoutGroupService := services.NewWorkoutGroupServiceWithSeparation(r.DB)
+	workoutGroupService := services.NewWorkoutGroupServiceWithSeparation(r.DB, r.PubSub)
 	return workoutGroupService.DeleteWorkoutGroup(ctx, input)
 }
 
+// AddWorkoutGroupMember is the resolver for the addWorkoutGroupMember field.
+// 非推奨: ユーザーを直接追加せず招待する（inviteWorkoutGroupMemberと同じ）
 func (r *mutationResolver) AddWorkoutGroupMember(ctx context.Context, input model.AddWorkoutGroupMember) (*model.WorkoutGroup, error) {
-	workoutGroupService := services.NewWorkoutGroupServiceWithSeparation(r.DB)
-	return workoutGroupService.AddWorkoutGroupMember(ctx, input)
+	workoutGroupMemberService := services.NewWorkoutGroupMemberServiceWithSeparation(r.DB, r.PubSub)
+	if _, err := workoutGroupMemberService.InviteMember(ctx, model.InviteWorkoutGroupMember{
+		WorkoutGroupID: input.WorkoutGroupID,
+		UserID:         input.UserID,
+	}); err != nil {
+		return nil, err
+	}
+
+	workoutGroupService := services.NewWorkoutGroupServiceWithSeparation(r.DB, r.PubSub)
+	return workoutGroupService.GetWorkoutGroup(ctx, input.WorkoutGroupID)
+}
+
+// InviteWorkoutGroupMember is the resolver for the inviteWorkoutGroupMember field.
+func (r *mutationResolver) InviteWorkoutGroupMember(ctx context.Context, input model.InviteWorkoutGroupMember) (*model.WorkoutGroupMember, error) {
+	workoutGroupMemberService := services.NewWorkoutGroupMemberServiceWithSeparation(r.DB, r.PubSub)
+	return workoutGroupMemberService.InviteMember(ctx, input)
+}
+
+// AcceptWorkoutGroupInvitation is the resolver for the acceptWorkoutGroupInvitation field.
+func (r *mutationResolver) AcceptWorkoutGroupInvitation(ctx context.Context, input model.AcceptWorkoutGroupInvitation) (*model.WorkoutGroupMember, error) {
+	workoutGroupMemberService := services.NewWorkoutGroupMemberServiceWithSeparation(r.DB, r.PubSub)
+	return workoutGroupMemberService.AcceptInvitation(ctx, input)
+}
+
+// DeclineWorkoutGroupInvitation is the resolver for the declineWorkoutGroupInvitation field.
+func (r *mutationResolver) DeclineWorkoutGroupInvitation(ctx context.Context, input model.DeclineWorkoutGroupInvitation) (*model.WorkoutGroupMember, error) {
+	workoutGroupMemberService := services.NewWorkoutGroupMemberServiceWithSeparation(r.DB, r.PubSub)
+	return workoutGroupMemberService.DeclineInvitation(ctx, input)
+}
+
+// LeaveWorkoutGroup is the resolver for the leaveWorkoutGroup field.
+func (r *mutationResolver) LeaveWorkoutGroup(ctx context.Context, input model.LeaveWorkoutGroup) (bool, error) {
+	workoutGroupMemberService := services.NewWorkoutGroupMemberServiceWithSeparation(r.DB, r.PubSub)
+	return workoutGroupMemberService.LeaveWorkoutGroup(ctx, input)
+}
+
+// KickWorkoutGroupMember is the resolver for the kickWorkoutGroupMember field.
+func (r *mutationResolver) KickWorkoutGroupMember(ctx context.Context, input model.KickWorkoutGroupMember) (bool, error) {
+	workoutGroupMemberService := services.NewWorkoutGroupMemberServiceWithSeparation(r.DB, r.PubSub)
+	return workoutGroupMemberService.KickMember(ctx, input)
+}
+
+// UpdateWorkoutGroupMemberRole is the resolver for the updateWorkoutGroupMemberRole field.
+func (r *mutationResolver) UpdateWorkoutGroupMemberRole(ctx context.Context, input model.UpdateWorkoutGroupMemberRole) (*model.WorkoutGroupMember, error) {
+	workoutGroupMemberService := services.NewWorkoutGroupMemberServiceWithSeparation(r.DB, r.PubSub)
+	return workoutGroupMemberService.UpdateMemberRole(ctx, input)
+}
+
+// ================================
+// Subscription
+// ================================
+
+// WorkoutGroupActivity is the resolver for the workoutGroupActivity field.
+func (r *subscriptionResolver) WorkoutGroupActivity(ctx context.Context, groupID string) (<-chan *model.WorkoutGroupActivity, error) {
+	workoutGroupService := services.NewWorkoutGroupServiceWithSeparation(r.DB, r.PubSub)
+	return workoutGroupService.SubscribeWorkoutGroupActivity(ctx, groupID)
 }
@@ -0,0 +1,87 @@
+package graph
+
+import (
+	"app/graph/model"
+	"app/graph/services"
+	"context"
+)
+
+// ================================
+// Model
+// ================================
+
+// WorkoutTemplate returns WorkoutTemplateResolver implementation.
+func (r *Resolver) WorkoutTemplate() WorkoutTemplateResolver { return &workoutTemplateResolver{r} }
+
+type workoutTemplateResolver struct{ *Resolver }
+
+func (r *workoutTemplateResolver) User(ctx context.Context, obj *model.WorkoutTemplate) (*model.User, error) {
+	userService := services.NewUserServiceWithSeparation(r.DB)
+	return userService.GetUserByIDWithDataLoader(ctx, obj.UserID)
+}
+
+func (r *workoutTemplateResolver) Exercises(ctx context.Context, obj *model.WorkoutTemplate) ([]*model.WorkoutTemplateExercise, error) {
+	workoutTemplateService := services.NewWorkoutTemplateServiceWithSeparation(r.DB)
+	return workoutTemplateService.GetWorkoutTemplateExercisesWithDataLoader(ctx, obj.ID)
+}
+
+// WorkoutTemplateExercise returns WorkoutTemplateExerciseResolver implementation.
+func (r *Resolver) WorkoutTemplateExercise() WorkoutTemplateExerciseResolver {
+	return &workoutTemplateExerciseResolver{r}
+}
+
+type workoutTemplateExerciseResolver struct{ *Resolver }
+
+func (r *workoutTemplateExerciseResolver) Exercise(ctx context.Context, obj *model.WorkoutTemplateExercise) (*model.Exercise, error) {
+	exerciseService := services.NewExerciseServiceWithSeparation(r.DB)
+	return exerciseService.GetExerciseWithDataLoader(ctx, obj.ExerciseID)
+}
+
+func (r *workoutTemplateExerciseResolver) TargetWeight(ctx context.Context, obj *model.WorkoutTemplateExercise, unit *model.WeightUnit) (*float64, error) {
+	workoutTemplateService := services.NewWorkoutTemplateServiceWithSeparation(r.DB)
+	return workoutTemplateService.ConvertTargetWeight(ctx, obj, unit)
+}
+
+// ================================
+// Query
+// ================================
+
+// WorkoutTemplates is the resolver for the workoutTemplates field.
+func (r *queryResolver) WorkoutTemplates(ctx context.Context) ([]*model.WorkoutTemplate, error) {
+	workoutTemplateService := services.NewWorkoutTemplateServiceWithSeparation(r.DB)
+	return workoutTemplateService.GetWorkoutTemplates(ctx)
+}
+
+// WorkoutTemplate is the resolver for the workoutTemplate field.
+func (r *queryResolver) WorkoutTemplate(ctx context.Context, id string) (*model.WorkoutTemplate, error) {
+	workoutTemplateService := services.NewWorkoutTemplateServiceWithSeparation(r.DB)
+	return workoutTemplateService.GetWorkoutTemplate(ctx, id)
+}
+
+// ================================
+// Mutation
+// ================================
+
+// CreateWorkoutTemplate is the resolver for the createWorkoutTemplate field.
+func (r *mutationResolver) CreateWorkoutTemplate(ctx context.Context, input model.CreateWorkoutTemplate) (*model.WorkoutTemplate, error) {
+	workoutTemplateService := services.NewWorkoutTemplateServiceWithSeparation(r.DB)
+	return workoutTemplateService.CreateWorkoutTemplate(ctx, input)
+}
+
+// UpdateWorkoutTemplate is the resolver for the updateWorkoutTemplate field.
+func (r *mutationResolver) UpdateWorkoutTemplate(ctx context.Context, input model.UpdateWorkoutTemplate) (*model.WorkoutTemplate, error) {
+	workoutTemplateService := services.NewWorkoutTemplateServiceWithSeparation(r.DB)
+	return workoutTemplateService.UpdateWorkoutTemplate(ctx, input)
+}
+
+// DeleteWorkoutTemplate is the resolver for the deleteWorkoutTemplate field.
+func (r *mutationResolver) DeleteWorkoutTemplate(ctx context.Context, input model.DeleteWorkoutTemplate) (bool, error) {
+	workoutTemplateService := services.NewWorkoutTemplateServiceWithSeparation(r.DB)
+	return workoutTemplateService.DeleteWorkoutTemplate(ctx, input)
+}
+
+// StartWorkoutFromTemplate is the resolver for the startWorkoutFromTemplate field.
+func (r *mutationResolver) StartWorkoutFromTemplate(ctx context.Context, input model.StartWorkoutFromTemplate) (*model.Workout, error) {
+	workoutTemplateService := services.NewWorkoutTemplateServiceWithSeparation(r.DB)
+	return workoutTemplateService.StartWorkoutFromTemplate(ctx, input)
+}
@@ -2,22 +2,28 @@ package middleware
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"os"
 
 	"app/auth"
+	"app/graph/errcode"
 
 	firebaseAuth "firebase.google.com/go/v4/auth"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
 )
 
 type AuthMiddleware struct {
 	firebaseAuth *auth.FirebaseAuth
+	strict       bool // trueの場合、無効・期限切れのJWTを401で拒否する
 }
 
+// NewAuthMiddleware は認証ミドルウェアを作成する（AUTH_STRICT_MODE=true で厳格モード）
 func NewAuthMiddleware(firebaseAuth *auth.FirebaseAuth) *AuthMiddleware {
 	return &AuthMiddleware{
 		firebaseAuth: firebaseAuth,
+		strict:       os.Getenv("AUTH_STRICT_MODE") == "true",
 	}
 }
 
@@ -37,34 +43,69 @@ func (am *AuthMiddleware) AuthMiddleware(next http.Handler) http.Handler {
 			return
 		}
 
-		if os.Getenv("ENABLE_MOCK_AUTH") == "true" && r.Header.Get("Authorization") == os.Getenv("MOCK_ADMIN_UID") {
-			ctx := context.WithValue(r.Context(), UserContextKey, &firebaseAuth.Token{
-				UID: os.Getenv("MOCK_ADMIN_UID"),
-			})
-			next.ServeHTTP(w, r.WithContext(ctx))
+		// Authorization headerからJWTを取得して検証
+		ctx, err := am.authenticate(r.Context(), r.Header.Get("Authorization"))
+		if err != nil {
+			// 厳格モードでは無効なJWTを401で拒否する
+			writeUnauthenticated(w, err.Error())
 			return
 		}
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
 
-		// Authorization headerからJWTを取得
-		authHeader := r.Header.Get("Authorization")
-		if authHeader == "" {
-			// JWTがない場合は匿名ユーザーとして処理
-			next.ServeHTTP(w, r)
-			return
-		}
+// WebsocketInit はWebSocketの接続開始時（connection_init）のペイロードのAuthorizationを検証する
+// ブラウザのWebSocketはヘッダーを設定できないため、サブスクリプションはペイロードでJWTを受け取る
+func (am *AuthMiddleware) WebsocketInit(ctx context.Context, initPayload transport.InitPayload) (context.Context, *transport.InitPayload, error) {
+	ctx, err := am.authenticate(ctx, initPayload.Authorization())
+	if err != nil {
+		return nil, nil, err
+	}
+	return ctx, nil, nil
+}
 
-		// JWTを検証
-		token, err := am.firebaseAuth.VerifyIDToken(r.Context(), authHeader)
-		if err != nil {
-			// JWTが無効な場合は匿名ユーザーとして処理
-			// 本番環境では適切なエラーレスポンスを返す
-			next.ServeHTTP(w, r)
-			return
+// authenticate はJWTを検証し、ユーザー情報を保存したコンテキストを返す
+// JWTがない・無効な場合は匿名ユーザーとして元のコンテキストを返す（厳格モードでは無効なJWTをエラーにする）
+func (am *AuthMiddleware) authenticate(ctx context.Context, authHeader string) (context.Context, error) {
+	if os.Getenv("ENABLE_MOCK_AUTH") == "true" && authHeader == os.Getenv("MOCK_ADMIN_UID") {
+		return context.WithValue(ctx, UserContextKey, &firebaseAuth.Token{
+			UID: os.Getenv("MOCK_ADMIN_UID"),
+		}), nil
+	}
+
+	if authHeader == "" {
+		// JWTがない場合は匿名ユーザーとして処理
+		return ctx, nil
+	}
+
+	// JWTを検証
+	token, err := am.firebaseAuth.VerifyIDToken(ctx, authHeader)
+	if err != nil {
+		if am.strict {
+			return nil, fmt.Errorf("invalid or expired token")
 		}
+		// JWTが無効な場合は匿名ユーザーとして処理
+		return ctx, nil
+	}
 
-		// コンテキストにユーザー情報を保存
-		ctx := context.WithValue(r.Context(), UserContextKey, token)
-		next.ServeHTTP(w, r.WithContext(ctx))
+	// コンテキストにユーザー情報を保存
+	return context.WithValue(ctx, UserContextKey, token), nil
+}
+
+// writeUnauthenticated はGraphQLのエラー形式で401を返す
+func writeUnauthenticated(w http.ResponseWriter, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", "Bearer")
+	w.WriteHeader(http.StatusUnauthorized)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"errors": []map[string]interface{}{
+			{
+				"message": message,
+				"extensions": map[string]interface{}{
+					"code": string(errcode.Unauthenticated),
+				},
+			},
+		},
 	})
 }
 
@@ -0,0 +1,90 @@
+package pubsub
+
+import (
+	"context"
+	"log"
+	"sync"
+)
+
+// subscriberBufferSize は購読者ごとのバッファ数（溢れたメッセージは破棄する）
+const subscriberBufferSize = 16
+
+// MemoryPubSub はプロセス内で完結するPubSub
+type MemoryPubSub struct {
+	mu          sync.RWMutex
+	subscribers map[string]map[chan []byte]struct{}
+	closed      bool
+}
+
+func NewMemoryPubSub() *MemoryPubSub {
+	return &MemoryPubSub{
+		subscribers: make(map[string]map[chan []byte]struct{}),
+	}
+}
+
+func (p *MemoryPubSub) Publish(ctx context.Context, topic string, payload []byte) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	for ch := range p.subscribers[topic] {
+		// 受信が遅い購読者のために配信全体を止めない
+		select {
+		case ch <- payload:
+		default:
+			log.Printf("pubsub: dropped message for slow subscriber on %s", topic)
+		}
+	}
+	return nil
+}
+
+func (p *MemoryPubSub) Subscribe(ctx context.Context, topic string) (<-chan []byte, error) {
+	ch := make(chan []byte, subscriberBufferSize)
+
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		close(ch)
+		return ch, nil
+	}
+	if p.subscribers[topic] == nil {
+		p.subscribers[topic] = make(map[chan []byte]struct{})
+	}
+	p.subscribers[topic][ch] = struct{}{}
+	p.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		p.unsubscribe(topic, ch)
+	}()
+
+	return ch, nil
+}
+
+func (p *MemoryPubSub) unsubscribe(topic string, ch chan []byte) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.subscribers[topic][ch]; !ok {
+		return
+	}
+	delete(p.subscribers[topic], ch)
+	if len(p.subscribers[topic]) == 0 {
+		delete(p.subscribers, topic)
+	}
+	close(ch)
+}
+
+// Close は全ての購読者のチャネルを閉じる
+func (p *MemoryPubSub) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for topic, subscribers := range p.subscribers {
+		for ch := range subscribers {
+			close(ch)
+		}
+		delete(p.subscribers, topic)
+	}
+	p.closed = true
+	return nil
+}
@@ -0,0 +1,78 @@
+package pubsub
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryPubSub_PublishToSubscribersOfTopic(t *testing.T) {
+	ps := NewMemoryPubSub()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	first, err := ps.Subscribe(ctx, "workout_group:1")
+	require.NoError(t, err)
+	second, err := ps.Subscribe(ctx, "workout_group:1")
+	require.NoError(t, err)
+	other, err := ps.Subscribe(ctx, "workout_group:2")
+	require.NoError(t, err)
+
+	require.NoError(t, ps.Publish(ctx, "workout_group:1", []byte("hello")))
+
+	assert.Equal(t, []byte("hello"), receive(t, first))
+	assert.Equal(t, []byte("hello"), receive(t, second))
+	select {
+	case payload := <-other:
+		t.Fatalf("unexpected message on other topic: %s", payload)
+	default:
+	}
+}
+
+func TestMemoryPubSub_UnsubscribeOnContextCancel(t *testing.T) {
+	ps := NewMemoryPubSub()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	ch, err := ps.Subscribe(ctx, "topic")
+	require.NoError(t, err)
+
+	cancel()
+
+	// キャンセル後はチャネルが閉じられる
+	select {
+	case _, ok := <-ch:
+		assert.False(t, ok)
+	case <-time.After(time.Second):
+		t.Fatal("channel was not closed after cancel")
+	}
+	assert.NoError(t, ps.Publish(context.Background(), "topic", []byte("after cancel")))
+}
+
+func TestMemoryPubSub_DropsMessagesForSlowSubscriber(t *testing.T) {
+	ps := NewMemoryPubSub()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch, err := ps.Subscribe(ctx, "topic")
+	require.NoError(t, err)
+
+	// バッファを超えても配信はブロックしない
+	for i := 0; i < subscriberBufferSize+5; i++ {
+		require.NoError(t, ps.Publish(ctx, "topic", []byte{byte(i)}))
+	}
+	assert.Len(t, ch, subscriberBufferSize)
+}
+
+func receive(t *testing.T, ch <-chan []byte) []byte {
+	t.Helper()
+	select {
+	case payload := <-ch:
+		return payload
+	case <-time.After(time.Second):
+		t.Fatal("no message received")
+		return nil
+	}
+}
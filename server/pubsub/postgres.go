@@ -0,0 +1,128 @@
+package pubsub
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+const (
+	// postgresChannel は全てのトピックで共有するNOTIFYチャネル
+	postgresChannel = "app_pubsub"
+	// maxNotifyPayloadSize はNOTIFYのペイロードの上限（Postgresの既定は8000バイト未満）
+	maxNotifyPayloadSize = 7999
+	// maxReconnectInterval はLISTEN用の接続が切れたときの再接続間隔の上限
+	maxReconnectInterval = 30 * time.Second
+)
+
+// postgresEnvelope はNOTIFYで送るメッセージ（トピックはペイロードに含める）
+type postgresEnvelope struct {
+	Topic   string `json:"topic"`
+	Payload []byte `json:"payload"`
+}
+
+// PostgresPubSub はPostgresのLISTEN/NOTIFYで複数インスタンスに配信するPubSub
+// NOTIFYはDBの接続プールから送信し、受信は専用の接続で1本のLISTENを張ってプロセス内の購読者に配る
+type PostgresPubSub struct {
+	db     *gorm.DB
+	dsn    string
+	local  *MemoryPubSub
+	cancel context.CancelFunc
+}
+
+func NewPostgresPubSub(ctx context.Context, db *gorm.DB) (*PostgresPubSub, error) {
+	dialector, ok := db.Dialector.(*postgres.Dialector)
+	if !ok || dialector.Config.DSN == "" {
+		return nil, fmt.Errorf("postgres pubsub requires a postgres connection")
+	}
+	if _, err := pgx.ParseConfig(dialector.Config.DSN); err != nil {
+		return nil, fmt.Errorf("invalid postgres DSN: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	p := &PostgresPubSub{
+		db:     db,
+		dsn:    dialector.Config.DSN,
+		local:  NewMemoryPubSub(),
+		cancel: cancel,
+	}
+	go p.listen(ctx)
+
+	return p, nil
+}
+
+func (p *PostgresPubSub) Publish(ctx context.Context, topic string, payload []byte) error {
+	message, err := json.Marshal(postgresEnvelope{Topic: topic, Payload: payload})
+	if err != nil {
+		return fmt.Errorf("failed to encode message: %w", err)
+	}
+	if len(message) > maxNotifyPayloadSize {
+		return fmt.Errorf("message for %s is too large to publish (%d bytes)", topic, len(message))
+	}
+
+	return p.db.WithContext(ctx).Exec("SELECT pg_notify(?, ?)", postgresChannel, string(message)).Error
+}
+
+func (p *PostgresPubSub) Subscribe(ctx context.Context, topic string) (<-chan []byte, error) {
+	return p.local.Subscribe(ctx, topic)
+}
+
+func (p *PostgresPubSub) Close() error {
+	p.cancel()
+	return p.local.Close()
+}
+
+// listen は接続が切れても再接続しながらNOTIFYを受信し続ける
+func (p *PostgresPubSub) listen(ctx context.Context) {
+	interval := time.Second
+	for {
+		connected, err := p.listenOnce(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if connected {
+			interval = time.Second
+		}
+		log.Printf("pubsub: postgres listener disconnected, reconnecting in %s: %v", interval, err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+		interval = min(interval*2, maxReconnectInterval)
+	}
+}
+
+// listenOnce は1本の接続でLISTENし、切断されるまでプロセス内の購読者に配信する
+func (p *PostgresPubSub) listenOnce(ctx context.Context) (connected bool, err error) {
+	conn, err := pgx.Connect(ctx, p.dsn)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{postgresChannel}.Sanitize()); err != nil {
+		return false, err
+	}
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return true, err
+		}
+
+		var envelope postgresEnvelope
+		if err := json.Unmarshal([]byte(notification.Payload), &envelope); err != nil {
+			log.Printf("pubsub: ignored malformed notification: %v", err)
+			continue
+		}
+		p.local.Publish(ctx, envelope.Topic, envelope.Payload)
+	}
+}
@@ -0,0 +1,32 @@
+package pubsub
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"gorm.io/gorm"
+)
+
+// PubSub はトピック単位でメッセージを配信する（GraphQLのサブスクリプションで使用）
+type PubSub interface {
+	// Publish はトピックを購読している全ての購読者にメッセージを配信する
+	Publish(ctx context.Context, topic string, payload []byte) error
+	// Subscribe はトピックを購読する（ctxがキャンセルされると購読を解除してチャネルを閉じる）
+	Subscribe(ctx context.Context, topic string) (<-chan []byte, error)
+	Close() error
+}
+
+// New は環境変数PUBSUB_DRIVERに応じたPubSubを作成する
+// memory（デフォルト）: 単一インスタンス向け。同じプロセス内の購読者にのみ配信する
+// postgres: 複数インスタンス向け。PostgresのLISTEN/NOTIFYでインスタンス間に配信する
+func New(ctx context.Context, db *gorm.DB) (PubSub, error) {
+	switch driver := os.Getenv("PUBSUB_DRIVER"); driver {
+	case "", "memory":
+		return NewMemoryPubSub(), nil
+	case "postgres":
+		return NewPostgresPubSub(ctx, db)
+	default:
+		return nil, fmt.Errorf("unknown pubsub driver: %s", driver)
+	}
+}
@@ -0,0 +1,57 @@
+package push
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+)
+
+// SentMessage はFakeSenderが受け取ったメッセージ
+type SentMessage struct {
+	Tokens  []string
+	Message Message
+}
+
+// FakeSender は実際には送信せず、送ったメッセージを記録してログに出力する
+// InvalidTokensに含まれるトークンは無効なトークンとして失敗させる
+type FakeSender struct {
+	mu            sync.Mutex
+	sent          []SentMessage
+	InvalidTokens map[string]bool
+	Err           error // 設定した場合はSendが失敗する
+}
+
+func NewFakeSender() *FakeSender {
+	return &FakeSender{InvalidTokens: map[string]bool{}}
+}
+
+func (s *FakeSender) Send(ctx context.Context, tokens []string, message Message) ([]Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.Err != nil {
+		return nil, s.Err
+	}
+
+	s.sent = append(s.sent, SentMessage{Tokens: append([]string(nil), tokens...), Message: message})
+	log.Printf("push (fake): %d device(s): %s - %s %v", len(tokens), message.Title, message.Body, message.Data)
+
+	results := make([]Result, 0, len(tokens))
+	for _, token := range tokens {
+		result := Result{Token: token}
+		if s.InvalidTokens[token] {
+			result.Err = fmt.Errorf("registration token is not registered")
+			result.InvalidToken = true
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// Sent はこれまでに送ったメッセージを返す
+func (s *FakeSender) Sent() []SentMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]SentMessage(nil), s.sent...)
+}
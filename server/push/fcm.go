@@ -0,0 +1,63 @@
+package push
+
+import (
+	"context"
+	"fmt"
+
+	"app/config"
+
+	"firebase.google.com/go/v4/messaging"
+)
+
+// fcmMulticastLimit は1回のマルチキャストで送れるトークンの最大数
+const fcmMulticastLimit = 500
+
+// FCMSender はFirebase Cloud Messagingでプッシュ通知を送る
+type FCMSender struct {
+	client *messaging.Client
+}
+
+func NewFCMSender(ctx context.Context) (*FCMSender, error) {
+	app, err := config.NewFirebaseApp(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize firebase app: %w", err)
+	}
+
+	client, err := app.Messaging(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get firebase messaging client: %w", err)
+	}
+
+	return &FCMSender{client: client}, nil
+}
+
+func (s *FCMSender) Send(ctx context.Context, tokens []string, message Message) ([]Result, error) {
+	results := make([]Result, 0, len(tokens))
+	for start := 0; start < len(tokens); start += fcmMulticastLimit {
+		end := min(start+fcmMulticastLimit, len(tokens))
+		batch := tokens[start:end]
+
+		response, err := s.client.SendEachForMulticast(ctx, &messaging.MulticastMessage{
+			Tokens: batch,
+			Data:   message.Data,
+			Notification: &messaging.Notification{
+				Title: message.Title,
+				Body:  message.Body,
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to send fcm message: %w", err)
+		}
+
+		// Responsesはトークンと同じ順番で返される
+		for i, sendResponse := range response.Responses {
+			result := Result{Token: batch[i]}
+			if !sendResponse.Success {
+				result.Err = sendResponse.Error
+				result.InvalidToken = messaging.IsUnregistered(sendResponse.Error) || messaging.IsSenderIDMismatch(sendResponse.Error)
+			}
+			results = append(results, result)
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,48 @@
+package push
+
+import (
+	"app/entity"
+	"fmt"
+)
+
+// buildMessage は通知の種類に応じたプッシュ通知の文面を作成する
+// actorNameが空の場合（プロフィール未作成）は名前を含めない
+func buildMessage(notification entity.Notification, actorName string) Message {
+	data := map[string]string{
+		"notificationId": fmt.Sprint(notification.ID),
+		"type":           notification.TypeToGraphQL().String(),
+	}
+	if notification.FriendshipID != nil {
+		data["friendshipId"] = fmt.Sprint(*notification.FriendshipID)
+	}
+	if notification.WorkoutGroupID != nil {
+		data["workoutGroupId"] = fmt.Sprint(*notification.WorkoutGroupID)
+	}
+
+	message := Message{Data: data}
+	switch notification.Type {
+	case entity.NotificationFriendRequestReceived:
+		message.Title = "友達リクエスト"
+		message.Body = "友達リクエストが届きました"
+		if actorName != "" {
+			message.Body = fmt.Sprintf("%sさんから友達リクエストが届きました", actorName)
+		}
+	case entity.NotificationFriendRequestAccepted:
+		message.Title = "友達リクエストが承認されました"
+		message.Body = "友達リクエストが承認されました"
+		if actorName != "" {
+			message.Body = fmt.Sprintf("%sさんと友達になりました", actorName)
+		}
+	case entity.NotificationWorkoutGroupInvited:
+		title := ""
+		if notification.WorkoutGroup != nil {
+			title = notification.WorkoutGroup.Title
+		}
+		message.Title = "グループへの招待"
+		message.Body = fmt.Sprintf("グループ「%s」に招待されました", title)
+		if actorName != "" {
+			message.Body = fmt.Sprintf("%sさんがグループ「%s」に招待しました", actorName, title)
+		}
+	}
+	return message
+}
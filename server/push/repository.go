@@ -0,0 +1,121 @@
+package push
+
+import (
+	"app/entity"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// DeliveryRepository はワーカーが使う送信待ちの通知・端末・設定の取得と更新
+type DeliveryRepository interface {
+	// ClaimDueDeliveries は送信時刻を過ぎた送信待ちを取得し、leaseUntilまで他のワーカーが取得しないようにする
+	ClaimDueDeliveries(ctx context.Context, now time.Time, limit int, leaseUntil time.Time) ([]*entity.PushDelivery, error)
+	SaveDelivery(ctx context.Context, delivery *entity.PushDelivery) error
+	GetPreference(ctx context.Context, userID uint) (*entity.NotificationPreference, error)
+	GetDeviceTokens(ctx context.Context, userID uint) ([]string, error)
+	DeleteDeviceTokens(ctx context.Context, tokens []string) error
+	// GetUserName はプロフィールの名前を取得する（プロフィールがない場合は空文字）
+	GetUserName(ctx context.Context, userID uint) (string, error)
+}
+
+type deliveryRepository struct {
+	db *gorm.DB
+}
+
+func NewDeliveryRepository(db *gorm.DB) DeliveryRepository {
+	return &deliveryRepository{db: db}
+}
+
+func (r *deliveryRepository) ClaimDueDeliveries(ctx context.Context, now time.Time, limit int, leaseUntil time.Time) ([]*entity.PushDelivery, error) {
+	// 複数のインスタンスで同じ通知を送らないように、ロック中の行は飛ばして送信時刻を先に進める
+	var ids []uint
+	if err := r.db.WithContext(ctx).Raw(`
+		UPDATE push_deliveries SET next_attempt_at = ?, updated_at = ?
+		WHERE id IN (
+			SELECT id FROM push_deliveries
+			WHERE status = ? AND next_attempt_at <= ? AND deleted_at IS NULL
+			ORDER BY next_attempt_at
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id`,
+		leaseUntil, now, string(entity.PushDeliveryStatusPending), now, limit,
+	).Scan(&ids).Error; err != nil {
+		return nil, fmt.Errorf("failed to claim push deliveries: %w", err)
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	var deliveries []*entity.PushDelivery
+	if err := r.db.WithContext(ctx).
+		Preload("Notification.WorkoutGroup").
+		Where("id IN ?", ids).
+		Order("next_attempt_at, id").
+		Find(&deliveries).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch push deliveries: %w", err)
+	}
+	return deliveries, nil
+}
+
+func (r *deliveryRepository) SaveDelivery(ctx context.Context, delivery *entity.PushDelivery) error {
+	// 関連する通知は更新しない
+	if err := r.db.WithContext(ctx).Model(delivery).
+		Select("Status", "Attempts", "NextAttemptAt", "SentAt", "LastError").
+		Updates(delivery).Error; err != nil {
+		return fmt.Errorf("failed to save push delivery: %w", err)
+	}
+	return nil
+}
+
+func (r *deliveryRepository) GetPreference(ctx context.Context, userID uint) (*entity.NotificationPreference, error) {
+	var preference entity.NotificationPreference
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).First(&preference).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		preference = entity.DefaultNotificationPreference(userID)
+		return &preference, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch notification preference: %w", err)
+	}
+	return &preference, nil
+}
+
+func (r *deliveryRepository) GetDeviceTokens(ctx context.Context, userID uint) ([]string, error) {
+	var tokens []string
+	if err := r.db.WithContext(ctx).Model(&entity.DeviceToken{}).
+		Where("user_id = ?", userID).
+		Order("last_registered_at DESC").
+		Pluck("token", &tokens).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch device tokens: %w", err)
+	}
+	return tokens, nil
+}
+
+func (r *deliveryRepository) DeleteDeviceTokens(ctx context.Context, tokens []string) error {
+	if len(tokens) == 0 {
+		return nil
+	}
+	if err := r.db.WithContext(ctx).Unscoped().Where("token IN ?", tokens).Delete(&entity.DeviceToken{}).Error; err != nil {
+		return fmt.Errorf("failed to delete device tokens: %w", err)
+	}
+	return nil
+}
+
+func (r *deliveryRepository) GetUserName(ctx context.Context, userID uint) (string, error) {
+	var names []string
+	if err := r.db.WithContext(ctx).Model(&entity.Profile{}).
+		Where("user_id = ?", userID).
+		Limit(1).
+		Pluck("name", &names).Error; err != nil {
+		return "", fmt.Errorf("failed to fetch profile name: %w", err)
+	}
+	if len(names) == 0 {
+		return "", nil
+	}
+	return names[0], nil
+}
@@ -0,0 +1,42 @@
+package push
+
+import (
+	"context"
+	"fmt"
+	"os"
+)
+
+// Message は端末に送るプッシュ通知
+type Message struct {
+	Title string
+	Body  string
+	Data  map[string]string // アプリが通知をタップした時の遷移先に使う
+}
+
+// Result はトークンごとの送信結果
+type Result struct {
+	Token        string
+	Err          error
+	InvalidToken bool // トークンが無効になっている（アプリの削除など）ため登録を削除すべき
+}
+
+// Sender はプッシュ通知を端末に送る
+type Sender interface {
+	// Send は全てのトークンにメッセージを送り、トークンごとの結果を返す
+	// 送信自体ができなかった場合（認証エラーなど）はerrorを返す
+	Send(ctx context.Context, tokens []string, message Message) ([]Result, error)
+}
+
+// NewSender は環境変数PUSH_SENDERに応じたSenderを作成する
+// fake（デフォルト）: 送信せずにログに出力する。ローカル開発・テスト向け
+// fcm: Firebase Cloud Messagingで送信する
+func NewSender(ctx context.Context) (Sender, error) {
+	switch sender := os.Getenv("PUSH_SENDER"); sender {
+	case "", "fake":
+		return NewFakeSender(), nil
+	case "fcm":
+		return NewFCMSender(ctx)
+	default:
+		return nil, fmt.Errorf("unknown push sender: %s", sender)
+	}
+}
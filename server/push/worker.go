@@ -0,0 +1,155 @@
+package push
+
+import (
+	"app/entity"
+	"context"
+	"errors"
+	"log"
+	"time"
+)
+
+const (
+	defaultPollInterval = 5 * time.Second
+	defaultBatchSize    = 50
+	// claimLease は取得した送信待ちを他のワーカーが取得しない時間（送信中に停止した場合はこの後に再送される）
+	claimLease = 5 * time.Minute
+)
+
+// Worker は送信待ちの通知を定期的に取得してプッシュ通知を送る
+type Worker struct {
+	repo         DeliveryRepository
+	sender       Sender
+	pollInterval time.Duration
+	batchSize    int
+	now          func() time.Time
+}
+
+func NewWorker(repo DeliveryRepository, sender Sender) *Worker {
+	return &Worker{
+		repo:         repo,
+		sender:       sender,
+		pollInterval: defaultPollInterval,
+		batchSize:    defaultBatchSize,
+		now:          time.Now,
+	}
+}
+
+// Run はctxがキャンセルされるまで送信待ちの通知を送り続ける
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		// 取得した件数が上限に達した場合は待たずに続きを送る
+		for {
+			processed, err := w.ProcessDue(ctx)
+			if err != nil {
+				log.Printf("failed to process push deliveries: %v", err)
+				break
+			}
+			if processed < w.batchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ProcessDue は送信時刻を過ぎた送信待ちを1回分処理し、処理した件数を返す
+func (w *Worker) ProcessDue(ctx context.Context) (int, error) {
+	now := w.now()
+	deliveries, err := w.repo.ClaimDueDeliveries(ctx, now, w.batchSize, now.Add(claimLease))
+	if err != nil {
+		return 0, err
+	}
+
+	for _, delivery := range deliveries {
+		w.deliver(ctx, delivery)
+		if err := w.repo.SaveDelivery(ctx, delivery); err != nil {
+			log.Printf("failed to save push delivery %d: %v", delivery.ID, err)
+		}
+	}
+	return len(deliveries), nil
+}
+
+// deliver はユーザーの設定に従って通知を送り、結果をdeliveryに記録する
+func (w *Worker) deliver(ctx context.Context, delivery *entity.PushDelivery) {
+	now := w.now()
+	notification := delivery.Notification
+
+	// おやすみ時間の間に既読になった通知は送らない
+	if notification.IsRead() {
+		delivery.Skip("notification already read")
+		return
+	}
+
+	preference, err := w.repo.GetPreference(ctx, delivery.UserID)
+	if err != nil {
+		delivery.Fail(err, now)
+		return
+	}
+	if !preference.AllowsPush(notification.Type) {
+		delivery.Skip("disabled by notification preference")
+		return
+	}
+	if until, ok := preference.QuietHoursEndAfter(now); ok {
+		delivery.Postpone(until)
+		return
+	}
+
+	tokens, err := w.repo.GetDeviceTokens(ctx, delivery.UserID)
+	if err != nil {
+		delivery.Fail(err, now)
+		return
+	}
+	if len(tokens) == 0 {
+		delivery.Skip("no registered devices")
+		return
+	}
+
+	var actorName string
+	if notification.ActorID != nil {
+		if actorName, err = w.repo.GetUserName(ctx, *notification.ActorID); err != nil {
+			delivery.Fail(err, now)
+			return
+		}
+	}
+
+	results, err := w.sender.Send(ctx, tokens, buildMessage(notification, actorName))
+	if err != nil {
+		delivery.Fail(err, now)
+		return
+	}
+
+	var invalidTokens []string
+	var sendErrs []error
+	succeeded := false
+	for _, result := range results {
+		switch {
+		case result.Err == nil:
+			succeeded = true
+		case result.InvalidToken:
+			invalidTokens = append(invalidTokens, result.Token)
+		default:
+			sendErrs = append(sendErrs, result.Err)
+		}
+	}
+
+	if err := w.repo.DeleteDeviceTokens(ctx, invalidTokens); err != nil {
+		log.Printf("failed to delete invalid device tokens: %v", err)
+	}
+
+	switch {
+	case succeeded:
+		delivery.MarkSent(now)
+	case len(sendErrs) > 0:
+		delivery.Fail(errors.Join(sendErrs...), now)
+	default:
+		delivery.Skip("no valid devices")
+	}
+}
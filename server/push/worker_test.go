@@ -0,0 +1,212 @@
+package push
+
+import (
+	"app/entity"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// fakeDeliveryRepository はメモリ上で送信待ち・端末・設定を管理する
+type fakeDeliveryRepository struct {
+	deliveries  []*entity.PushDelivery
+	preferences map[uint]*entity.NotificationPreference
+	tokens      map[uint][]string
+	names       map[uint]string
+}
+
+func newFakeDeliveryRepository() *fakeDeliveryRepository {
+	return &fakeDeliveryRepository{
+		preferences: map[uint]*entity.NotificationPreference{},
+		tokens:      map[uint][]string{},
+		names:       map[uint]string{},
+	}
+}
+
+func (r *fakeDeliveryRepository) ClaimDueDeliveries(ctx context.Context, now time.Time, limit int, leaseUntil time.Time) ([]*entity.PushDelivery, error) {
+	var claimed []*entity.PushDelivery
+	for _, delivery := range r.deliveries {
+		if len(claimed) == limit {
+			break
+		}
+		if delivery.Status == entity.PushDeliveryStatusPending && !delivery.NextAttemptAt.After(now) {
+			delivery.NextAttemptAt = leaseUntil
+			claimed = append(claimed, delivery)
+		}
+	}
+	return claimed, nil
+}
+
+func (r *fakeDeliveryRepository) SaveDelivery(ctx context.Context, delivery *entity.PushDelivery) error {
+	return nil
+}
+
+func (r *fakeDeliveryRepository) GetPreference(ctx context.Context, userID uint) (*entity.NotificationPreference, error) {
+	if preference, ok := r.preferences[userID]; ok {
+		return preference, nil
+	}
+	preference := entity.DefaultNotificationPreference(userID)
+	return &preference, nil
+}
+
+func (r *fakeDeliveryRepository) GetDeviceTokens(ctx context.Context, userID uint) ([]string, error) {
+	return r.tokens[userID], nil
+}
+
+func (r *fakeDeliveryRepository) DeleteDeviceTokens(ctx context.Context, tokens []string) error {
+	for userID, userTokens := range r.tokens {
+		remaining := userTokens[:0]
+		for _, token := range userTokens {
+			if !contains(tokens, token) {
+				remaining = append(remaining, token)
+			}
+		}
+		r.tokens[userID] = remaining
+	}
+	return nil
+}
+
+func (r *fakeDeliveryRepository) GetUserName(ctx context.Context, userID uint) (string, error) {
+	return r.names[userID], nil
+}
+
+func (r *fakeDeliveryRepository) enqueue(notification entity.Notification, now time.Time) *entity.PushDelivery {
+	delivery := &entity.NewPushDeliveries([]entity.Notification{notification}, now)[0]
+	delivery.ID = uint(len(r.deliveries) + 1)
+	delivery.Notification = notification
+	r.deliveries = append(r.deliveries, delivery)
+	return delivery
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func friendRequestNotification() entity.Notification {
+	friendshipID, actorID := uint(10), uint(2)
+	return entity.Notification{
+		Model:        gorm.Model{ID: 1},
+		UserID:       1,
+		ActorID:      &actorID,
+		Type:         entity.NotificationFriendRequestReceived,
+		FriendshipID: &friendshipID,
+	}
+}
+
+func newTestWorker(repo *fakeDeliveryRepository, sender Sender, now time.Time) *Worker {
+	worker := NewWorker(repo, sender)
+	worker.now = func() time.Time { return now }
+	return worker
+}
+
+func TestWorker_SendsFriendRequest(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	repo := newFakeDeliveryRepository()
+	repo.tokens[1] = []string{"token-a", "token-b"}
+	repo.names[2] = "田中"
+	delivery := repo.enqueue(friendRequestNotification(), now)
+	sender := NewFakeSender()
+
+	processed, err := newTestWorker(repo, sender, now).ProcessDue(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, processed)
+
+	assert.Equal(t, entity.PushDeliveryStatusSent, delivery.Status)
+	require.Len(t, sender.Sent(), 1)
+	sent := sender.Sent()[0]
+	assert.Equal(t, []string{"token-a", "token-b"}, sent.Tokens)
+	assert.Equal(t, "田中さんから友達リクエストが届きました", sent.Message.Body)
+	assert.Equal(t, "FRIEND_REQUEST_RECEIVED", sent.Message.Data["type"])
+	assert.Equal(t, "10", sent.Message.Data["friendshipId"])
+}
+
+func TestWorker_SkipsWhenDisabledByPreference(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	repo := newFakeDeliveryRepository()
+	repo.tokens[1] = []string{"token-a"}
+	preference := entity.DefaultNotificationPreference(1)
+	preference.FriendRequestReceived = false
+	repo.preferences[1] = &preference
+	delivery := repo.enqueue(friendRequestNotification(), now)
+	sender := NewFakeSender()
+
+	_, err := newTestWorker(repo, sender, now).ProcessDue(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, entity.PushDeliveryStatusSkipped, delivery.Status)
+	assert.Empty(t, sender.Sent())
+}
+
+func TestWorker_PostponesDuringQuietHours(t *testing.T) {
+	// 東京の23:00（UTC 14:00）、おやすみ時間は22:00〜07:00
+	now := time.Date(2026, 10, 18, 14, 0, 0, 0, time.UTC)
+	repo := newFakeDeliveryRepository()
+	repo.tokens[1] = []string{"token-a"}
+	preference := entity.DefaultNotificationPreference(1)
+	start, end := 22*60, 7*60
+	preference.QuietHoursStart, preference.QuietHoursEnd = &start, &end
+	repo.preferences[1] = &preference
+	delivery := repo.enqueue(friendRequestNotification(), now)
+	sender := NewFakeSender()
+
+	_, err := newTestWorker(repo, sender, now).ProcessDue(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, entity.PushDeliveryStatusPending, delivery.Status)
+	assert.Equal(t, time.Date(2026, 10, 18, 22, 0, 0, 0, time.UTC), delivery.NextAttemptAt.UTC())
+	assert.Empty(t, sender.Sent())
+
+	// おやすみ時間が終わった後に送る
+	_, err = newTestWorker(repo, sender, delivery.NextAttemptAt).ProcessDue(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, entity.PushDeliveryStatusSent, delivery.Status)
+	assert.Len(t, sender.Sent(), 1)
+}
+
+func TestWorker_RemovesInvalidTokens(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	repo := newFakeDeliveryRepository()
+	repo.tokens[1] = []string{"token-a", "token-expired"}
+	delivery := repo.enqueue(friendRequestNotification(), now)
+	sender := NewFakeSender()
+	sender.InvalidTokens["token-expired"] = true
+
+	_, err := newTestWorker(repo, sender, now).ProcessDue(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, entity.PushDeliveryStatusSent, delivery.Status)
+	assert.Equal(t, []string{"token-a"}, repo.tokens[1])
+}
+
+func TestWorker_RetriesWithBackoffOnFailure(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	repo := newFakeDeliveryRepository()
+	repo.tokens[1] = []string{"token-a"}
+	delivery := repo.enqueue(friendRequestNotification(), now)
+	sender := NewFakeSender()
+	sender.Err = errors.New("unavailable")
+
+	for attempt := 1; attempt < entity.MaxPushDeliveryAttempts; attempt++ {
+		_, err := newTestWorker(repo, sender, now).ProcessDue(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, entity.PushDeliveryStatusPending, delivery.Status)
+		assert.Equal(t, attempt, delivery.Attempts)
+		assert.Equal(t, now.Add(time.Minute<<(attempt-1)), delivery.NextAttemptAt)
+		now = delivery.NextAttemptAt
+	}
+
+	_, err := newTestWorker(repo, sender, now).ProcessDue(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, entity.PushDeliveryStatusFailed, delivery.Status)
+	assert.Equal(t, "unavailable", delivery.LastError)
+}
@@ -3,18 +3,24 @@ package main
 import (
 	"app/auth"
 	"app/db"
+	"app/friendqr"
 	"app/graph"
+	"app/graph/services/common"
 	"app/middleware"
+	"app/pubsub"
+	"app/push"
 	"context"
 	"log"
 	"net/http"
 	"os"
+	"time"
 
 	"github.com/99designs/gqlgen/graphql/handler"
 	"github.com/99designs/gqlgen/graphql/handler/extension"
 	"github.com/99designs/gqlgen/graphql/handler/lru"
 	"github.com/99designs/gqlgen/graphql/handler/transport"
 	"github.com/99designs/gqlgen/graphql/playground"
+	"github.com/gorilla/websocket"
 	"github.com/rs/cors"
 	"github.com/vektah/gqlparser/v2/ast"
 )
@@ -34,13 +40,47 @@ func main() {
 	// 認証ミドルウェアの初期化
 	authMiddleware := middleware.NewAuthMiddleware(firebaseAuth)
 
-	srv := handler.New(graph.NewExecutableSchema(graph.Config{Resolvers: &graph.Resolver{
-		DB:             db.DB,
-		FirebaseAuth:   firebaseAuth,
-		AuthMiddleware: authMiddleware,
-		DataLoaders:    graph.NewDataLoaders(db.DB),
-	}}))
+	// サブスクリプションの配信基盤の初期化（PUBSUB_DRIVER=postgres で複数インスタンス間に配信）
+	ps, err := pubsub.New(context.Background(), db.DB)
+	if err != nil {
+		log.Fatalf("Failed to initialize pubsub: %v", err)
+	}
+
+	// プッシュ通知の送信ワーカー（PUSH_SENDER=fcm でFCMに送信、デフォルトはログ出力のみ）
+	sender, err := push.NewSender(context.Background())
+	if err != nil {
+		log.Fatalf("Failed to initialize push sender: %v", err)
+	}
+	go push.NewWorker(push.NewDeliveryRepository(db.DB), sender).Run(context.Background())
 
+	// 友達追加用のQRコードのトークンの署名（FRIEND_QR_SECRETを全インスタンスで共有する）
+	qrSigner, err := friendqr.NewSignerFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to initialize QR code signer: %v", err)
+	}
+
+	srv := handler.New(graph.NewExecutableSchema(graph.Config{
+		Resolvers: &graph.Resolver{
+			DB:             db.DB,
+			FirebaseAuth:   firebaseAuth,
+			AuthMiddleware: authMiddleware,
+			DataLoaders:    graph.NewDataLoaders(db.DB),
+			PubSub:         ps,
+			FriendQR:       qrSigner,
+		},
+		Directives: graph.NewDirectives(db.DB),
+	}))
+
+	// サブスクリプション用のWebSocket（graphql-transport-ws / graphql-ws）
+	// JWTはconnection_initのペイロードのAuthorizationで受け取る
+	srv.AddTransport(transport.Websocket{
+		KeepAlivePingInterval: 10 * time.Second,
+		Upgrader: websocket.Upgrader{
+			// CORSと同様に全てのオリジンを許可する
+			CheckOrigin: func(r *http.Request) bool { return true },
+		},
+		InitFunc: authMiddleware.WebsocketInit,
+	})
 	srv.AddTransport(transport.Options{})
 	srv.AddTransport(transport.GET{})
 	srv.AddTransport(transport.POST{})
@@ -66,11 +106,16 @@ func main() {
 	withDataloaderHandler := func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			ctx := graph.WithDataLoaders(r.Context(), db.DB)
+			ctx = common.WithViewerCache(ctx)
 			r = r.WithContext(ctx)
 			next.ServeHTTP(w, r)
 		})
 	}
 
+	// 友達追加用のQRコード画像（トークンの署名を検証するため認証は不要）
+	http.Handle(friendqr.PNGPath, c.Handler(friendqr.NewPNGHandler(qrSigner)))
+	http.Handle(friendqr.SVGPath, c.Handler(friendqr.NewSVGHandler(qrSigner)))
+
 	// Add delay middleware for testing loading states
 	http.Handle("/query", c.Handler(middleware.DelayMiddleware(authMiddleware.AuthMiddleware(withDataloaderHandler(srv)))))
 